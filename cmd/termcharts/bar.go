@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/neilpeterson/termcharts/pkg/termcharts"
@@ -15,14 +16,28 @@ var (
 	barColor      bool
 	barASCII      bool
 	barNoColor    bool
+	barLabelColor bool
 	barVertical   bool
 	barShowValues bool
+	barMinBar     bool
+	barPercent    bool
+	barTop        int
+	barPage       int
+	barPageSize   int
+	barGroupBy    string
+	barLabelWrap  int
 	barTitle      string
 	barLabels     string
 	barGrouped    bool
 	barStacked    bool
 	barShowLegend bool
+	barStats      string
+	barAlign      string
 	barSeries     string
+	barWatch      watchFlags
+	barFill       string
+	barDescribe   string
+	barUnit       string
 )
 
 var barCmd = &cobra.Command{
@@ -37,6 +52,7 @@ Data can be provided as:
   - Command-line arguments: termcharts bar 10 20 30 25
   - File path: termcharts bar data.txt
   - Stdin: cat data.txt | termcharts bar
+  - CSV: termcharts bar sales.csv --value-col sales --label-col region
 
 Data format:
   - One number per line, or
@@ -77,40 +93,85 @@ Examples:
   termcharts bar --series '[{"label":"Product A","data":[10,20,30]},{"label":"Product B","data":[5,10,15]}]' --stacked --labels "Q1,Q2,Q3"
 
   # Vertical grouped bar chart with legend
-  termcharts bar --series '[{"label":"2023","data":[10,20,30]},{"label":"2024","data":[15,25,35]}]' --grouped --vertical --legend`,
+  termcharts bar --series '[{"label":"2023","data":[10,20,30]},{"label":"2024","data":[15,25,35]}]' --grouped --vertical --legend
+
+  # Redraw every 2 seconds with the CPU usage of each pod
+  termcharts bar --watch --exec "kubectl top pods --no-headers | awk '{ print \$2+0 }'"`,
 	RunE: runBar,
 }
 
 func init() {
 	rootCmd.AddCommand(barCmd)
 
-	barCmd.Flags().IntVarP(&barWidth, "width", "w", 80, "chart width in characters")
-	barCmd.Flags().IntVar(&barHeight, "height", 15, "chart height in rows (vertical mode)")
+	barCmd.Flags().IntVarP(&barWidth, "width", "w", 80, "chart width in characters (0 = terminal width)")
+	barCmd.Flags().IntVar(&barHeight, "height", 15, "chart height in rows (vertical mode, 0 = terminal height)")
 	barCmd.Flags().BoolVarP(&barColor, "color", "c", false, "enable colored output")
 	barCmd.Flags().BoolVar(&barASCII, "ascii", false, "use ASCII characters only")
 	barCmd.Flags().BoolVar(&barNoColor, "no-color", false, "disable colored output")
+	barCmd.Flags().BoolVar(&barLabelColor, "color-by-label", false, "color each series by its label, so it keeps its color across charts")
 	barCmd.Flags().BoolVarP(&barVertical, "vertical", "v", false, "render vertical bar chart")
 	barCmd.Flags().BoolVar(&barShowValues, "show-values", false, "display numeric values on bars")
+	barCmd.Flags().BoolVar(&barMinBar, "min-bar", false, "draw values too small for a cell as a thin marker, so they do not vanish")
+	barCmd.Flags().BoolVar(&barPercent, "percent", false, "scale bars to 100% and show values as percentages (values within [-1, 1] are fractions)")
 	barCmd.Flags().StringVarP(&barTitle, "title", "t", "", "chart title")
 	barCmd.Flags().StringVarP(&barLabels, "labels", "l", "", "comma-separated labels for each bar")
 	barCmd.Flags().BoolVarP(&barGrouped, "grouped", "g", false, "display multiple series as grouped bars")
 	barCmd.Flags().BoolVarP(&barStacked, "stacked", "s", false, "display multiple series as stacked bars")
 	barCmd.Flags().BoolVar(&barShowLegend, "legend", false, "show legend for multi-series charts")
+	barCmd.Flags().StringVar(&barStats, "legend-stats", "", "show per-series statistics in the legend, e.g. cur,min,max,avg,sum")
+	barCmd.Flags().StringVar(&barAlign, "align", "", "line up series of different lengths by their first (left) or last (right) values")
+	barCmd.Flags().IntVar(&barTop, "top", 0, "show only the N largest categories, largest first (0 = all)")
+	barCmd.Flags().IntVar(&barPage, "page", 0, "show one page of categories, counted from 1 (0 = all)")
+	barCmd.Flags().IntVar(&barPageSize, "page-size", 20, "categories per page with --page")
+	barCmd.Flags().StringVar(&barGroupBy, "group-by", "", "group categories by the label text before this delimiter, e.g. / for us/east")
+	barCmd.Flags().IntVar(&barLabelWrap, "label-wrap", 0, "wrap labels wider than this many columns over two lines (0 = no wrapping)")
+	barCmd.Flags().StringVar(&barFill, "fill", "", "character to fill bars with, e.g. ▓ or =")
 	barCmd.Flags().StringVar(&barSeries, "series", "", "JSON array of series: [{\"label\":\"name\",\"data\":[1,2,3]}]")
+	addDescribeFlag(barCmd, &barDescribe)
+	addWatchFlags(barCmd, &barWatch)
+	addUnitFlag(barCmd, &barUnit)
 }
 
 func runBar(cmd *cobra.Command, args []string) error {
+	// Summarizing needs a CSV column
+	summary, err := newColumnSummary(args, barTitle)
+	if err != nil {
+		return err
+	}
+	if summary != nil && barSeries != "" {
+		return fmt.Errorf("--auto-summary summarizes a CSV column; remove --series")
+	}
+
+	// Watching a file or command re-reads it
+	if err := barWatch.check(); err != nil {
+		return err
+	}
+	var source termcharts.DataSource
+	if barWatch.watch {
+		if barSeries != "" {
+			return fmt.Errorf("--watch charts a single series; remove --series")
+		}
+		if barWatch.stdin(args) {
+			return fmt.Errorf("bar --watch re-reads a file or re-runs an --exec command; it cannot follow stdin")
+		}
+		var err error
+		if source, err = barWatch.source(args); err != nil {
+			return err
+		}
+	}
+
 	// Build options
-	var opts []termcharts.Option
+	var opts []termcharts.BarOption
+	var csvLabels []string
 
 	// Check if multi-series data is provided
 	if barSeries != "" {
 		series, err := parseSeriesJSON(barSeries)
 		if err != nil {
-			return fmt.Errorf("failed to parse series JSON: %w", err)
+			return withExit(exitParse, fmt.Errorf("failed to parse series JSON: %w", err))
 		}
 		if len(series) == 0 {
-			return fmt.Errorf("no series data provided")
+			return fmt.Errorf("%w: --series is empty", errNoData)
 		}
 		opts = append(opts, termcharts.WithSeries(series))
 
@@ -125,29 +186,47 @@ func runBar(cmd *cobra.Command, args []string) error {
 		if barShowLegend {
 			opts = append(opts, termcharts.WithShowLegend(true))
 		}
-	} else {
+		if barStats != "" {
+			values, err := parseLegendStats(barStats)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, termcharts.WithLegendStats(values))
+		}
+		switch barAlign {
+		case "":
+		case "left":
+			opts = append(opts, termcharts.WithAlign(termcharts.AlignLeft))
+		case "right":
+			opts = append(opts, termcharts.WithAlign(termcharts.AlignRight))
+		default:
+			return fmt.Errorf("invalid --align %q: want left or right", barAlign)
+		}
+	} else if source == nil {
 		// Parse single-series data from various sources
-		data, err := parseBarData(args)
+		data, labels, err := parseBarData(args)
 		if err != nil {
-			return fmt.Errorf("failed to parse data: %w", err)
+			return parseFailed(err)
 		}
 
 		if len(data) == 0 {
-			return fmt.Errorf("no data provided")
+			return errNoData
 		}
 
 		opts = append(opts, termcharts.WithData(data))
+		csvLabels = labels
+		summary.set(data)
 	}
 
 	// Apply width
-	if barWidth > 0 {
+	if barWidth >= 0 {
 		opts = append(opts, termcharts.WithWidth(barWidth))
 	}
 
 	// Apply height if vertical mode
 	if barVertical {
 		opts = append(opts, termcharts.WithDirection(termcharts.Vertical))
-		if barHeight > 0 {
+		if barHeight >= 0 {
 			opts = append(opts, termcharts.WithHeight(barHeight))
 		}
 	}
@@ -156,22 +235,64 @@ func runBar(cmd *cobra.Command, args []string) error {
 	if barTitle != "" {
 		opts = append(opts, termcharts.WithTitle(barTitle))
 	}
+	if summary != nil {
+		opts = append(opts, summary.option(true))
+	}
 
 	// Apply labels if specified
 	if barLabels != "" {
 		labels := parseLabels(barLabels)
 		opts = append(opts, termcharts.WithLabels(labels))
+	} else if csvLabels != nil {
+		opts = append(opts, termcharts.WithLabels(csvLabels))
 	}
 
 	// Apply show values
 	if barShowValues {
 		opts = append(opts, termcharts.WithShowValues(true))
 	}
+	if barMinBar {
+		opts = append(opts, termcharts.WithMinBar(true))
+	}
+	if barPercent {
+		opts = append(opts, termcharts.WithPercentAxis(true))
+	}
+
+	// Apply paging
+	if barTop < 0 || barPage < 0 || barPageSize <= 0 {
+		return fmt.Errorf("--top and --page must not be negative, and --page-size must be positive")
+	}
+	if barTop > 0 {
+		opts = append(opts, termcharts.WithTopN(barTop))
+	}
+	if barPage > 0 {
+		opts = append(opts, termcharts.WithPage(barPage, barPageSize))
+	}
+
+	// Apply grouping
+	if barGroupBy != "" {
+		opts = append(opts, termcharts.WithGroupDelimiter(barGroupBy))
+	}
+
+	// Apply label wrapping
+	if barLabelWrap < 0 {
+		return fmt.Errorf("--label-wrap must not be negative, got %d", barLabelWrap)
+	}
+	if barLabelWrap > 0 {
+		opts = append(opts, termcharts.WithLabelWrap(barLabelWrap))
+	}
 
 	// Apply style
 	if barASCII {
 		opts = append(opts, termcharts.WithStyle(termcharts.StyleASCII))
 	}
+	if barFill != "" {
+		fill := []rune(barFill)
+		if len(fill) != 1 {
+			return fmt.Errorf("--fill must be a single character, got %q", barFill)
+		}
+		opts = append(opts, termcharts.WithFillChar(fill[0]))
+	}
 
 	// Apply color settings
 	if barNoColor {
@@ -181,30 +302,67 @@ func runBar(cmd *cobra.Command, args []string) error {
 		colorEnabled := true
 		opts = append(opts, termcharts.WithColor(colorEnabled))
 	}
+	if barLabelColor {
+		opts = append(opts, termcharts.WithColorByLabel(true))
+	}
 
-	// Create and render bar chart
-	bar := termcharts.NewBarChart(opts...)
-	fmt.Print(bar.Render())
+	// Apply text summary
+	describe, err := describeOption(barDescribe)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, describe)
 
-	return nil
+	// Apply number locale and unit
+	locale, err := localeOption()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, locale, termcharts.WithYUnit(barUnit))
+
+	// Apply input limits
+	limits, err := limitsOption(barWidth, barHeight)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, limits)
+
+	// Create and render bar chart, redrawing it when watching
+	bar := termcharts.NewBarChart(opts...)
+	if source != nil {
+		if summary != nil {
+			source = summary.watch(bar, source, true)
+		}
+		return watchChart(bar, source, &barWatch)
+	}
+	return bar.RenderTo(os.Stdout)
 }
 
 // parseBarData parses data from command-line args, files, or stdin.
-func parseBarData(args []string) ([]float64, error) {
+func parseBarData(args []string) ([]float64, []string, error) {
+	if csv, err := csvInput(args); err != nil {
+		return nil, nil, err
+	} else if csv {
+		return parseCSVInput(args)
+	}
+
 	// If no args, read from stdin
 	if len(args) == 0 {
-		return readDataFromStdin()
+		data, err := readDataFromStdin()
+		return data, nil, err
 	}
 
 	// If single arg and it's a file, read from file
 	if len(args) == 1 {
 		if fileExists(args[0]) {
-			return readDataFromFile(args[0])
+			data, err := readDataFromFile(args[0])
+			return data, nil, err
 		}
 	}
 
 	// Otherwise, parse args as numbers
-	return parseNumbers(args)
+	data, err := parseNumbers(args)
+	return data, nil, err
 }
 
 // parseLabels parses comma-separated labels.
@@ -222,9 +380,10 @@ func parseLabels(labelsStr string) []string {
 
 // seriesJSON is used for JSON parsing of series data.
 type seriesJSON struct {
-	Label string    `json:"label"`
-	Data  []float64 `json:"data"`
-	Color string    `json:"color,omitempty"`
+	Label  string    `json:"label"`
+	Data   []float64 `json:"data"`
+	Color  string    `json:"color,omitempty"`
+	Hidden bool      `json:"hidden,omitempty"`
 }
 
 // parseSeriesJSON parses JSON array of series data.
@@ -237,10 +396,34 @@ func parseSeriesJSON(jsonStr string) ([]termcharts.Series, error) {
 	result := make([]termcharts.Series, len(seriesData))
 	for i, s := range seriesData {
 		result[i] = termcharts.Series{
-			Label: s.Label,
-			Data:  s.Data,
-			Color: s.Color,
+			Label:  s.Label,
+			Data:   s.Data,
+			Color:  s.Color,
+			Hidden: s.Hidden,
 		}
 	}
 	return result, nil
 }
+
+// parseLegendStats parses a comma-separated list of legend statistics, such
+// as "cur,min,max,avg".
+func parseLegendStats(s string) (termcharts.LegendValues, error) {
+	var values termcharts.LegendValues
+	for _, name := range strings.Split(s, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "cur", "last":
+			values |= termcharts.LegendCurrent
+		case "min":
+			values |= termcharts.LegendMin
+		case "max":
+			values |= termcharts.LegendMax
+		case "sum":
+			values |= termcharts.LegendSum
+		case "avg", "mean":
+			values |= termcharts.LegendMean
+		default:
+			return 0, fmt.Errorf("invalid legend statistic %q: want cur, min, max, avg, or sum", name)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+	"github.com/spf13/cobra"
+)
+
+var (
+	boxWidth      int
+	boxHeight     int
+	boxColor      bool
+	boxASCII      bool
+	boxNoColor    bool
+	boxVertical   bool
+	boxShowValues bool
+	boxTitle      string
+	boxSeries     string
+	boxTheme      string
+	boxLabelColor bool
+	boxUnit       string
+)
+
+var boxCmd = &cobra.Command{
+	Use:     "box [samples...]",
+	Aliases: []string{"boxplot"},
+	Short:   "Create a box plot of raw samples",
+	Long: `Create a box-and-whisker plot to compare distributions of raw samples.
+
+Each box spans the first to the third quartile of its samples and is split
+at the median. The whiskers reach the furthest samples within 1.5
+interquartile ranges of the box; samples beyond them are drawn as outliers.
+
+Data can be provided as:
+  - Command-line arguments: termcharts box 12 15 11 19 14
+  - File path: termcharts box latencies.txt
+  - Stdin: cat latencies.txt | termcharts box
+  - CSV: termcharts box requests.csv --value-col latency_ms
+
+To compare categories, pass one series of samples per box with --series:
+  --series '[{"label":"checkout","data":[120,130,180]},{"label":"search","data":[40,45,90]}]'
+
+Examples:
+  # Distribution of request latencies
+  termcharts box latencies.txt --show-values
+
+  # Latencies of two services, drawn as columns
+  termcharts box --series '[{"label":"checkout","data":[120,130,125,400]},{"label":"search","data":[40,45,50,90]}]' --vertical`,
+	RunE: runBox,
+}
+
+func init() {
+	rootCmd.AddCommand(boxCmd)
+
+	boxCmd.Flags().IntVarP(&boxWidth, "width", "w", 80, "chart width in characters (0 = terminal width)")
+	boxCmd.Flags().IntVar(&boxHeight, "height", 15, "chart height in rows (vertical mode, 0 = terminal height)")
+	boxCmd.Flags().BoolVarP(&boxColor, "color", "c", false, "enable colored output")
+	boxCmd.Flags().BoolVar(&boxASCII, "ascii", false, "use ASCII characters only")
+	boxCmd.Flags().BoolVar(&boxNoColor, "no-color", false, "disable colored output")
+	boxCmd.Flags().BoolVarP(&boxVertical, "vertical", "v", false, "render vertical boxes")
+	boxCmd.Flags().BoolVar(&boxShowValues, "show-values", false, "display the median of each box")
+	boxCmd.Flags().StringVarP(&boxTitle, "title", "t", "", "chart title")
+	boxCmd.Flags().StringVar(&boxSeries, "series", "", "JSON array of series, one box each: [{\"label\":\"name\",\"data\":[1,2,3]}]")
+	boxCmd.Flags().StringVar(&boxTheme, "theme", "default", "color theme (default, dark, light, mono)")
+	boxCmd.Flags().BoolVar(&boxLabelColor, "color-by-label", false, "color each box by its label, so it keeps its color across charts")
+	addUnitFlag(boxCmd, &boxUnit)
+}
+
+func runBox(cmd *cobra.Command, args []string) error {
+	var opts []termcharts.BarOption
+	if boxSeries != "" {
+		series, err := parseSeriesJSON(boxSeries)
+		if err != nil {
+			return withExit(exitParse, fmt.Errorf("failed to parse series JSON: %w", err))
+		}
+		if len(series) == 0 {
+			return errNoData
+		}
+		opts = append(opts, termcharts.WithSeries(series))
+	} else {
+		samples, _, err := parseBarData(args)
+		if err != nil {
+			return parseFailed(err)
+		}
+		if len(samples) == 0 {
+			return errNoData
+		}
+		opts = append(opts, termcharts.WithData(samples))
+	}
+
+	// Apply dimensions
+	if boxWidth >= 0 {
+		opts = append(opts, termcharts.WithWidth(boxWidth))
+	}
+	if boxVertical {
+		opts = append(opts, termcharts.WithDirection(termcharts.Vertical))
+		if boxHeight >= 0 {
+			opts = append(opts, termcharts.WithHeight(boxHeight))
+		}
+	}
+
+	if boxTitle != "" {
+		opts = append(opts, termcharts.WithTitle(boxTitle))
+	}
+	if boxShowValues {
+		opts = append(opts, termcharts.WithShowValues(true))
+	}
+
+	// Apply style
+	opts = append(opts, termcharts.WithTheme(getTheme(boxTheme)))
+	if boxLabelColor {
+		opts = append(opts, termcharts.WithColorByLabel(true))
+	}
+	if boxASCII {
+		opts = append(opts, termcharts.WithStyle(termcharts.StyleASCII))
+	}
+
+	// Apply color settings
+	if boxNoColor {
+		opts = append(opts, termcharts.WithColor(false))
+	} else if boxColor {
+		opts = append(opts, termcharts.WithColor(true))
+	}
+
+	// Apply number locale and unit
+	locale, err := localeOption()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, locale, termcharts.WithYUnit(boxUnit))
+
+	// Apply input limits
+	limits, err := limitsOption(boxWidth, boxHeight)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, limits)
+
+	box := termcharts.NewBoxPlot(opts...)
+	return box.RenderTo(os.Stdout)
+}
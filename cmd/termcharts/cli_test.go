@@ -2,10 +2,19 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+	"github.com/spf13/cobra"
 )
 
 // TestCLI_Bar tests the bar command.
@@ -44,6 +53,52 @@ func TestCLI_Bar(t *testing.T) {
 			wantErr: false,
 			contains: []string{"#"},
 		},
+		{
+			name:     "tiny values keep a marker",
+			args:     []string{"bar", "1000", "1", "--min-bar", "--ascii", "--no-color", "--width", "20"},
+			wantErr:  false,
+			contains: []string{"\n |\n"},
+		},
+		{
+			name:     "custom fill character",
+			args:     []string{"bar", "10", "20", "--fill", "=", "--no-color"},
+			wantErr:  false,
+			contains: []string{"=========="},
+		},
+		{
+			name:     "percent bar values",
+			args:     []string{"bar", "0.25", "0.5", "--percent", "--show-values", "--no-color"},
+			wantErr:  false,
+			contains: []string{" 25%", " 50%"},
+		},
+		{
+			name:     "top categories",
+			args:     []string{"bar", "5", "40", "12", "--labels", "a,b,c", "--top", "2", "--no-color"},
+			wantErr:  false,
+			contains: []string{"b ", "c ", "top 2 of 3"},
+		},
+		{
+			name:     "paged categories",
+			args:     []string{"bar", "5", "40", "12", "--labels", "a,b,c", "--page", "2", "--page-size", "2", "--no-color"},
+			wantErr:  false,
+			contains: []string{"c ", "page 2 of 2 (3 of 3)"},
+		},
+		{
+			name:     "grouped categories",
+			args:     []string{"bar", "5", "40", "12", "--labels", "us/east,eu/west,us/west", "--group-by", "/", "--no-color"},
+			wantErr:  false,
+			contains: []string{"us (17.0)", "  east ", "eu (40.0)"},
+		},
+		{
+			name:    "page size must be positive",
+			args:    []string{"bar", "5", "40", "--page", "1", "--page-size", "0"},
+			wantErr: true,
+		},
+		{
+			name:    "fill must be one character",
+			args:    []string{"bar", "10", "20", "--fill", "=="},
+			wantErr: true,
+		},
 		{
 			name:    "no data error",
 			args:    []string{"bar"},
@@ -126,6 +181,75 @@ func TestCLI_BarGrouped(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "stacked totals",
+			args: []string{
+				"bar",
+				"--series", `[{"label":"A","data":[10,20]},{"label":"B","data":[5,25]}]`,
+				"--stacked",
+				"--show-values",
+				"--no-color",
+			},
+			wantErr:  false,
+			contains: []string{" 15.0\n", " 45.0\n"},
+		},
+		{
+			name: "hidden series",
+			args: []string{
+				"bar",
+				"--series", `[{"label":"2023","data":[10,20]},{"label":"2024","data":[15,25],"hidden":true}]`,
+				"--grouped",
+				"--legend",
+				"--ascii",
+				"--no-color",
+			},
+			wantErr:  false,
+			contains: []string{"# 2023    2024"},
+		},
+		{
+			name: "legend statistics",
+			args: []string{
+				"bar",
+				"--series", `[{"label":"A","data":[10,20]},{"label":"B","data":[5,25]}]`,
+				"--legend-stats", "max,avg",
+				"--ascii",
+				"--no-color",
+			},
+			wantErr:  false,
+			contains: []string{"# A  max 20.0  avg 15.0", "# B  max 25.0  avg 15.0"},
+		},
+		{
+			name: "invalid legend statistic",
+			args: []string{
+				"bar",
+				"--series", `[{"label":"A","data":[10,20]}]`,
+				"--legend-stats", "p95",
+			},
+			wantErr: true,
+		},
+		{
+			name: "right-aligned series",
+			args: []string{
+				"bar",
+				"--series", `[{"label":"A","data":[1,2,3]},{"label":"B","data":[4]}]`,
+				"--align", "right",
+				"--labels", "a,b,c",
+				"--width", "30",
+				"--ascii",
+				"--no-color",
+			},
+			wantErr:  false,
+			contains: []string{"a  ###\nb  ######\nc  ######################\n"},
+		},
+		{
+			name: "invalid alignment",
+			args: []string{
+				"bar",
+				"--series", `[{"label":"A","data":[10,20]}]`,
+				"--align", "up",
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid JSON series",
 			args: []string{
@@ -249,6 +373,7 @@ func TestCLI_Spark(t *testing.T) {
 		name     string
 		args     []string
 		wantErr  bool
+		contains []string
 	}{
 		{
 			name:    "simple sparkline",
@@ -265,6 +390,85 @@ func TestCLI_Spark(t *testing.T) {
 			args:    []string{"spark", "1", "2", "3", "4", "5", "6", "7", "8", "--width", "4"},
 			wantErr: false,
 		},
+		{
+			name:    "sparkline with custom characters",
+			args:    []string{"spark", "1", "2", "3", "--chars", " ░▒▓█"},
+			wantErr: false,
+		},
+		{
+			name:    "sparkline characters need a ramp",
+			args:    []string{"spark", "1", "2", "3", "--chars", "x"},
+			wantErr: true,
+		},
+		{
+			name:    "sparkline with percent stats",
+			args:    []string{"spark", "20", "45", "--percent", "--stats"},
+			wantErr: false,
+		},
+		{
+			name:    "sparkline with stats",
+			args:    []string{"spark", "1", "2", "3", "4", "--stats", "--trend"},
+			wantErr: false,
+		},
+		{
+			name:    "sparkline from zero",
+			args:    []string{"spark", "98", "99", "97", "100", "--zero"},
+			wantErr: false,
+		},
+		{
+			name:    "sparkline with extremes",
+			args:    []string{"spark", "3", "9", "1", "5", "--color", "--extremes"},
+			wantErr: false,
+		},
+		{
+			name:    "sparkline with thresholds",
+			args:    []string{"spark", "10", "75", "95", "--color", "--thresholds", "0=green,70=yellow,90=red"},
+			wantErr: false,
+		},
+		{
+			name:     "sparkline with a fixed range",
+			args:     []string{"spark", "10", "20", "--min", "0", "--max", "100", "--ascii", "--no-color"},
+			wantErr:  false,
+			contains: []string{"_."},
+		},
+		{
+			name:    "sparkline range needs min and max",
+			args:    []string{"spark", "10", "20", "--min", "0"},
+			wantErr: true,
+		},
+		{
+			name:    "sparkline range must not be empty",
+			args:    []string{"spark", "10", "20", "--min", "5", "--max", "5"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid thresholds",
+			args:    []string{"spark", "10", "75", "--thresholds", "warn"},
+			wantErr: true,
+		},
+		{
+			name:     "sparkline stats with a unit",
+			args:     []string{"spark", "1", "5", "3", "--stats", "--unit", "ms", "--no-color"},
+			wantErr:  false,
+			contains: []string{"min 1.0 ms  max 5.0 ms  last 3.0 ms"},
+		},
+		{
+			name:     "stacked series",
+			args:     []string{"spark", "--series", `[{"label":"p50","data":[12,14,13]},{"label":"p99","data":[40,95,52]}]`, "--ascii", "--no-color"},
+			wantErr:  false,
+			contains: []string{"p50 _@=\n", "p99 _@.\n"},
+		},
+		{
+			name:     "overlaid series",
+			args:     []string{"spark", "--series", `[{"label":"p50","data":[12,14,13]},{"label":"p99","data":[40,95,52]}]`, "--overlay", "--no-color"},
+			wantErr:  false,
+			contains: []string{"⣌⡄\n", "● p99"},
+		},
+		{
+			name:    "overlay needs series",
+			args:    []string{"spark", "1", "2", "--overlay"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -290,6 +494,11 @@ func TestCLI_Spark(t *testing.T) {
 			if output == "" {
 				t.Error("expected non-empty output")
 			}
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
 		})
 	}
 }
@@ -322,6 +531,169 @@ func TestCLI_Pie(t *testing.T) {
 			wantErr:  false,
 			contains: []string{"Pie Chart"},
 		},
+		{
+			name:     "pie chart with an emphasized slice",
+			args:     []string{"pie", "30", "70", "--labels", "A,B", "--emphasis", "2", "--color"},
+			wantErr:  false,
+			contains: []string{"\033[1mB"},
+		},
+		{
+			name:     "pie chart colored by label",
+			args:     []string{"pie", "3", "4", "--labels", "cpu,memory", "--color", "--color-by-label"},
+			wantErr:  false,
+			contains: []string{"\033[34m*\033[0m cpu", "\033[31m*\033[0m memory"},
+		},
+		{
+			name:    "emphasis out of range",
+			args:    []string{"pie", "30", "70", "--emphasis", "3"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, tt.args...)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v\nstderr: %s", err, stderr.String())
+				return
+			}
+
+			output := stdout.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("output should contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+// TestCLI_Histogram tests the histogram command.
+func TestCLI_Histogram(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		contains []string
+	}{
+		{
+			name:     "fixed bins",
+			args:     []string{"histogram", "1", "2", "2", "3", "3", "3", "4", "9", "--bins", "2", "--show-values", "--ascii", "--no-color"},
+			wantErr:  false,
+			contains: []string{"[1, 5)", "[5, 9]", " 7\n", " 1\n"},
+		},
+		{
+			name:     "alias and Freedman-Diaconis rule",
+			args:     []string{"hist", "1", "2", "2", "3", "3", "3", "4", "9", "--rule", "fd", "--no-color"},
+			wantErr:  false,
+			contains: []string{"[1.0, "},
+		},
+		{
+			name:     "cumulative",
+			args:     []string{"histogram", "1", "2", "2", "3", "3", "3", "4", "9", "--bins", "2", "--cumulative", "--show-values", "--no-color"},
+			wantErr:  false,
+			contains: []string{" 7\n", " 8\n"},
+		},
+		{
+			name:     "cumulative percent",
+			args:     []string{"histogram", "1", "2", "2", "3", "3", "3", "4", "9", "--bins", "2", "--cumulative", "--percent", "--show-values", "--no-color"},
+			wantErr:  false,
+			contains: []string{" 87.5%\n", " 100%\n"},
+		},
+		{
+			name:     "density curve",
+			args:     []string{"histogram", "12", "15", "11", "19", "14", "22", "17", "13", "16", "--density", "--no-color"},
+			wantErr:  false,
+			contains: []string{"● samples  ● density"},
+		},
+		{
+			name:    "density with values",
+			args:    []string{"histogram", "1", "2", "3", "--density", "--show-values"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid rule",
+			args:    []string{"histogram", "1", "2", "--rule", "scott"},
+			wantErr: true,
+		},
+		{
+			name:    "negative bins",
+			args:    []string{"histogram", "1", "2", "--bins", "-3"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, tt.args...)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v\nstderr: %s", err, stderr.String())
+				return
+			}
+
+			output := stdout.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("output should contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+// TestCLI_Box tests the box command.
+func TestCLI_Box(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		contains []string
+	}{
+		{
+			name:     "samples with median",
+			args:     []string{"box", "2", "3", "4", "5", "6", "20", "--width", "30", "--show-values", "--ascii", "--no-color"},
+			wantErr:  false,
+			contains: []string{"|#", "o 4.5\n"},
+		},
+		{
+			name:     "series drawn vertically",
+			args:     []string{"boxplot", "--series", `[{"label":"api","data":[2,3,4,5,6]},{"label":"db","data":[8,9,10,11,12]}]`, "--vertical", "--height", "10", "--no-color"},
+			wantErr:  false,
+			contains: []string{"api", "db", "━"},
+		},
+		{
+			name:    "invalid series",
+			args:    []string{"box", "--series", "not json"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -380,11 +752,47 @@ func TestCLI_Line(t *testing.T) {
 			args:    []string{"line", "10", "20", "30", "--braille"},
 			wantErr: false,
 		},
+		{
+			name:     "quadrant block line chart",
+			args:     []string{"line", "0", "10", "--blocks", "--no-color"},
+			wantErr:  false,
+			contains: []string{"▗▄▄▞▀"},
+		},
+		{
+			name:     "sextant line chart",
+			args:     []string{"line", "0", "10", "--sextants", "--no-color"},
+			wantErr:  false,
+			contains: []string{"\U0001FB1E"},
+		},
 		{
 			name:    "ascii line chart",
 			args:    []string{"line", "10", "20", "30", "--ascii"},
 			wantErr: false,
 		},
+		{
+			name:     "line chart with y ticks",
+			args:     []string{"line", "3", "40", "97", "--y-ticks", "5", "--no-color"},
+			wantErr:  false,
+			contains: []string{"    100 ", "     75 ", "      0 "},
+		},
+		{
+			name:     "line chart with percent axis",
+			args:     []string{"line", "0.1", "0.4", "0.9", "--percent", "--y-ticks", "3", "--no-color"},
+			wantErr:  false,
+			contains: []string{"100% ", " 50% ", "  0% "},
+		},
+		{
+			name:     "line chart with fill",
+			args:     []string{"line", "1", "3", "2", "4", "--fill", "--no-color"},
+			wantErr:  false,
+			contains: []string{"░░░░"},
+		},
+		{
+			name:     "ASCII line chart with fill",
+			args:     []string{"line", "1", "3", "2", "4", "--fill", "--ascii", "--no-color"},
+			wantErr:  false,
+			contains: []string{"...."},
+		},
 	}
 
 	for _, tt := range tests {
@@ -534,7 +942,867 @@ func TestCLI_Stdin(t *testing.T) {
 	}
 }
 
-// buildBinary builds the CLI binary for testing.
+// TestCLI_Follow tests redrawing charts as data arrives on a long-lived pipe.
+func TestCLI_Follow(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	t.Run("pipe momentarily empty", func(t *testing.T) {
+		cmd := exec.Command(binary, "spark", "--follow", "--no-color")
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		// The pipe stays open but empty between writes
+		fmt.Fprintln(stdin, "1 5")
+		time.Sleep(3 * time.Second / defaultFollowFPS)
+		fmt.Fprintln(stdin, "2 8")
+		stdin.Close()
+
+		if err := cmd.Wait(); err != nil {
+			t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+		}
+		out := stdout.String()
+		if !strings.HasPrefix(out, "▁█\n") {
+			t.Errorf("first frame should be drawn before the pipe closes, got %q", out)
+		}
+		if !strings.Contains(out, "\033[1A") {
+			t.Errorf("later values should redraw the frame in place, got %q", out)
+		}
+	})
+
+	t.Run("bursts are coalesced", func(t *testing.T) {
+		// Thousands of values at once are drawn in a few frames, not one per value
+		var input strings.Builder
+		for i := 0; i < 20000; i++ {
+			fmt.Fprintln(&input, i%7)
+		}
+		fmt.Fprintln(&input, "0 100")
+		cmd := exec.Command(binary, "spark", "--follow", "--no-color", "--width", "2", "--fps", "5")
+		cmd.Stdin = strings.NewReader(input.String())
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+		}
+		out := stdout.String()
+		if redraws := strings.Count(out, "\033[1A"); redraws > 10 {
+			t.Errorf("burst should be coalesced into a few frames, got %d redraws", redraws)
+		}
+		if !strings.Contains(out, "▁█") {
+			t.Errorf("last frame should show the last values, got %q", out)
+		}
+	})
+
+	t.Run("invalid fps", func(t *testing.T) {
+		cmd := exec.Command(binary, "spark", "--follow", "--fps", "0")
+		cmd.Stdin = strings.NewReader("1 2 3\n")
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err == nil || !strings.Contains(stderr.String(), "--fps must be positive") {
+			t.Errorf("expected --fps error, got %v: %s", err, stderr.String())
+		}
+	})
+
+	t.Run("values are not followed", func(t *testing.T) {
+		cmd := exec.Command(binary, "line", "1", "2", "--follow")
+		if err := cmd.Run(); err == nil {
+			t.Error("expected error for --follow with values, got nil")
+		}
+	})
+}
+
+func TestCLI_Watch(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	// watch runs the binary until it has drawn for a while, then interrupts it
+	watch := func(t *testing.T, during func(), args ...string) string {
+		t.Helper()
+		cmd := exec.Command(binary, args...)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(300 * time.Millisecond)
+		during()
+		time.Sleep(300 * time.Millisecond)
+		if err := cmd.Process.Signal(os.Interrupt); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.Wait(); err != nil {
+			t.Fatalf("interrupt should exit cleanly, got %v\nstderr: %s", err, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	t.Run("file is re-read", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "data.txt")
+		if err := os.WriteFile(file, []byte("1 5\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		out := watch(t, func() {
+			if err := os.WriteFile(file, []byte("5 1\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}, "spark", file, "--watch", "--interval", "50ms", "--no-color")
+
+		if !strings.Contains(out, "▁█") {
+			t.Errorf("first frame should be drawn, got %q", out)
+		}
+		if !strings.Contains(out, "\033[1A") || !strings.Contains(out, "█▁") {
+			t.Errorf("changed file should redraw the frame in place, got %q", out)
+		}
+	})
+
+	t.Run("changes are highlighted", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "data.txt")
+		if err := os.WriteFile(file, []byte("1 5\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		out := watch(t, func() {
+			if err := os.WriteFile(file, []byte("5 1\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}, "spark", file, "--watch", "--highlight", "--interval", "50ms", "--no-color")
+
+		if !strings.Contains(out, "\033[7m█") {
+			t.Errorf("changed values should be drawn in reverse video, got %q", out)
+		}
+		if last := out[strings.LastIndex(out, "\033[7m"):]; !strings.Contains(last, "\033[1A") {
+			t.Errorf("the highlight should be cleared on the next redraw, got %q", out)
+		}
+	})
+
+	t.Run("stdin changes are highlighted", func(t *testing.T) {
+		cmd := exec.Command(binary, "line", "--watch", "--highlight", "--no-color", "--width", "20", "--height", "4")
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		fmt.Fprintln(stdin, "1 5")
+		time.Sleep(3 * time.Second / defaultFollowFPS)
+		fmt.Fprintln(stdin, "5 1")
+		stdin.Close()
+
+		if err := cmd.Wait(); err != nil {
+			t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+		}
+		if out := stdout.String(); !strings.Contains(out, "\033[7m") {
+			t.Errorf("values followed on stdin should be highlighted when they change, got %q", out)
+		}
+	})
+
+	t.Run("command is re-run", func(t *testing.T) {
+		out := watch(t, func() {}, "bar", "--watch", "--interval", "50ms", "--exec", "echo 1 2", "--no-color", "--width", "20")
+		if strings.Count(out, "\n") != 2 {
+			t.Errorf("unchanged output should be drawn once, got %q", out)
+		}
+	})
+
+	errTests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"exec without watch", []string{"line", "--exec", "echo 1"}, "--exec needs --watch"},
+		{"highlight without watch", []string{"spark", "1", "2", "--highlight"}, "--highlight needs --watch"},
+		{"watch values", []string{"line", "1", "2", "--watch"}, "not values"},
+		{"watch and follow", []string{"spark", "--watch", "--follow"}, "cannot be combined"},
+		{"bar stdin", []string{"bar", "--watch"}, "cannot follow stdin"},
+		{"interval", []string{"bar", "--watch", "--exec", "echo 1", "--interval", "0s"}, "--interval must be positive"},
+		{"failing command", []string{"spark", "--watch", "--exec", "echo oops >&2; exit 3"}, "oops"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, tt.args...)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if err := cmd.Run(); err == nil || !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v: %s", tt.wantErr, err, stderr.String())
+			}
+		})
+	}
+}
+
+func TestCLI_ExitCodes(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(empty, []byte("# no values\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantKind string
+	}{
+		{"usage", []string{"bar", "1", "2", "--fill", "ab"}, exitUsage, "usage"},
+		{"unknown flag", []string{"bar", "1", "--bogus"}, exitUsage, "usage"},
+		{"no data", []string{"pie", empty}, exitNoData, "no_data"},
+		{"parse", []string{"line", "1", "abc"}, exitParse, "parse"},
+		{"render", []string{"bar", "1", "+Inf"}, exitRender, "render"},
+		{"limit", []string{"spark", "1", "2", "3", "--width", "2000"}, exitLimit, "limit"},
+		{"failed command", []string{"spark", "--watch", "--exec", "exit 3"}, exitError, "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := func(extra ...string) (int, string) {
+				// Flags after an unknown flag are not parsed, so they go first
+				cmd := exec.Command(binary, append(extra, tt.args...)...)
+				var stderr bytes.Buffer
+				cmd.Stderr = &stderr
+				err := cmd.Run()
+				exitErr, ok := err.(*exec.ExitError)
+				if !ok {
+					t.Fatalf("expected exit error, got %v", err)
+				}
+				return exitErr.ExitCode(), stderr.String()
+			}
+
+			code, stderr := run()
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (stderr: %s)", code, tt.wantCode, stderr)
+			}
+			if !strings.HasPrefix(stderr, "Error: ") {
+				t.Errorf("stderr should start with the error, got %q", stderr)
+			}
+
+			code, stderr = run("--json-errors", "--quiet")
+			if code != tt.wantCode {
+				t.Errorf("--json-errors exit code = %d, want %d", code, tt.wantCode)
+			}
+			var got struct {
+				Code    int    `json:"code"`
+				Kind    string `json:"kind"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal([]byte(stderr), &got); err != nil {
+				t.Fatalf("stderr should be one JSON object, got %q: %v", stderr, err)
+			}
+			if got.Code != tt.wantCode || got.Kind != tt.wantKind || got.Message == "" {
+				t.Errorf("JSON error = %+v, want code %d and kind %q with a message", got, tt.wantCode, tt.wantKind)
+			}
+
+			if _, stderr = run("--quiet"); stderr != "" {
+				t.Errorf("--quiet should print nothing, got %q", stderr)
+			}
+		})
+	}
+}
+
+// TestCLI_RegisteredChart tests that registered chart types become commands.
+func TestCLI_Plugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin test uses a shell script")
+	}
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	// A plugin that echoes its arguments and input, and fails on request
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"gauge $*\"\ncat\n[ \"$1\" = fail ] && exit 7\nexit 0\n"
+	for _, name := range []string{"termcharts-cli-gauge", "termcharts-bar"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	env := append(os.Environ(), "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     string
+		wantCode int
+	}{
+		{name: "runs the plugin", args: []string{"cli-gauge", "42", "--max", "100"}, want: "gauge 42 --max 100\nstdin\n"},
+		{name: "exit code of the plugin", args: []string{"cli-gauge", "fail"}, want: "gauge fail\nstdin\n", wantCode: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, tt.args...)
+			cmd.Env = env
+			cmd.Stdin = strings.NewReader("stdin\n")
+			out, err := cmd.Output()
+			code := 0
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				code = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if string(out) != tt.want {
+				t.Errorf("output = %q, want %q", out, tt.want)
+			}
+		})
+	}
+
+	// Commands take precedence over plugins of the same name
+	cmd := exec.Command(binary, "bar", "1", "--ascii", "--no-color")
+	cmd.Env = env
+	out, err := cmd.Output()
+	if err != nil || strings.Contains(string(out), "gauge") || !strings.Contains(string(out), "#") {
+		t.Errorf("bar output = %q, error = %v, want the bar command's chart", out, err)
+	}
+
+	cmd = exec.Command(binary, "cli-no-such-plugin")
+	cmd.Env = env
+	if err := cmd.Run(); err == nil {
+		t.Error("expected an unknown command error without a plugin, got nil")
+	}
+}
+
+func TestCLI_RegisteredChart(t *testing.T) {
+	termcharts.RegisterChart("cli-test-chart", func(opts ...termcharts.Option) termcharts.Chart {
+		return termcharts.NewSparkline(termcharts.Combine(opts...))
+	})
+
+	root := &cobra.Command{Use: "termcharts"}
+	root.AddCommand(&cobra.Command{Use: "bar"})
+	addRegisteredCharts(root)
+
+	names := make(map[string]int)
+	for _, cmd := range root.Commands() {
+		names[cmd.Name()]++
+	}
+	if names["bar"] != 1 {
+		t.Errorf("bar command added %d times, want 1", names["bar"])
+	}
+	if names["cli-test-chart"] != 1 {
+		t.Fatalf("registered chart command missing, commands: %v", names)
+	}
+	for _, cmd := range root.Commands() {
+		if strings.Contains(cmd.Long, "extension") {
+			t.Errorf("%s help should not claim the chart comes from an extension:\n%s", cmd.Name(), cmd.Long)
+		}
+	}
+
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetArgs([]string{"cli-test-chart", "1", "2", "3", "--ascii", "--no-color"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stdout.String(); got != "_=@\n" {
+		t.Errorf("output = %q, want %q", got, "_=@\n")
+	}
+
+	root.SetArgs([]string{"cli-test-chart", "1", "abc"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error for invalid data, got nil")
+	}
+}
+
+// TestCLI_Describe tests the --describe text summary flag.
+func TestCLI_Describe(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		contains string
+		exact    string
+	}{
+		{
+			name:     "bar summary appended",
+			args:     []string{"bar", "10", "30", "20", "--labels", "A,B,C", "--describe"},
+			contains: "Summary: 3 values, min 10.0 (A), max 30.0 (B), last 20.0, trend rising; top B 30.0, C 20.0, A 10.0",
+		},
+		{
+			name:  "summary only",
+			args:  []string{"spark", "3", "2", "1", "--describe=only"},
+			exact: "Summary: 3 values, min 1.0, max 3.0, last 1.0, trend falling\n",
+		},
+		{
+			name:     "line summary",
+			args:     []string{"line", "1", "1", "1", "--describe"},
+			contains: "trend flat",
+		},
+		{
+			name:    "invalid mode",
+			args:    []string{"pie", "1", "2", "--describe=verbose"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, tt.args...)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+			}
+
+			output := stdout.String()
+			if tt.contains != "" && !strings.Contains(output, tt.contains) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.contains, output)
+			}
+			if tt.exact != "" && output != tt.exact {
+				t.Errorf("output = %q, want %q", output, tt.exact)
+			}
+		})
+	}
+}
+
+func TestCLI_Locale(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		contains string
+	}{
+		{
+			name:     "bar values",
+			args:     []string{"bar", "1250.5", "980", "--show-values", "--no-color", "--locale", "de-DE"},
+			contains: "1.250,5",
+		},
+		{
+			name:     "summary",
+			args:     []string{"spark", "1250.5", "980", "--describe=only", "--locale", "fr-FR"},
+			contains: "max 1\u202f250,5",
+		},
+		{
+			name:     "trend",
+			args:     []string{"spark", "80", "90", "--trend", "--no-color", "--locale", "de-DE"},
+			contains: "+12,5%",
+		},
+		{
+			name:    "unsupported locale",
+			args:    []string{"line", "1", "2", "--locale", "xx-YY"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, tt.args...)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+			}
+
+			if output := stdout.String(); !strings.Contains(output, tt.contains) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.contains, output)
+			}
+		})
+	}
+}
+
+// buildBinary builds the CLI binary for testing.
+func TestCLI_LargeFile(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	// 200k rows with a single spike
+	var data strings.Builder
+	for i := 0; i < 200000; i++ {
+		v := i % 50
+		if i == 123456 {
+			v = 999
+		}
+		fmt.Fprintf(&data, "%d\n", v)
+	}
+	file := filepath.Join(t.TempDir(), "large.txt")
+	if err := os.WriteFile(file, []byte(data.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		contains string
+	}{
+		{
+			name:     "line file",
+			args:     []string{"line", file, "--width", "40", "--height", "6", "--no-color"},
+			contains: "999.0",
+		},
+		{
+			name:     "spark file",
+			args:     []string{"spark", file, "--width", "20", "--no-color"},
+			contains: "▁",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, tt.args...)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.contains) {
+				t.Errorf("output should contain %q, got:\n%s", tt.contains, stdout.String())
+			}
+		})
+	}
+}
+
+func TestCLI_Limits(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	file := filepath.Join(t.TempDir(), "data.txt")
+	if err := os.WriteFile(file, []byte("1\n2\n3\n4\n5\n6\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "too many points",
+			args:    []string{"bar", file, "--max-points", "5"},
+			wantErr: "input has more than 5 numbers",
+		},
+		{
+			name: "raised points limit",
+			args: []string{"bar", file, "--max-points", "6"},
+		},
+		{
+			name: "reduced input is not limited",
+			args: []string{"spark", file, "--width", "2", "--max-points", "5"},
+		},
+		{
+			name:    "width beyond the limit",
+			args:    []string{"line", file, "--width", "100000"},
+			wantErr: "--width 100000 exceeds the limit of 1000 columns",
+		},
+		{
+			name:    "height beyond a lowered limit",
+			args:    []string{"line", file, "--height", "20", "--max-height", "10"},
+			wantErr: "--height 20 exceeds the limit of 10 rows",
+		},
+		{
+			name: "no width limit",
+			args: []string{"spark", file, "--width", "2000", "--max-width", "-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, tt.args...)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got output:\n%s", tt.wantErr, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("stderr should contain %q, got: %s", tt.wantErr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestCLI_CSV(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	dir := t.TempDir()
+	sales := filepath.Join(dir, "sales.csv")
+	if err := os.WriteFile(sales, []byte("region,sales,units\nNorth,120,4\nSouth,80,3\n# closed\nEast,150,9\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tsv := filepath.Join(dir, "sales.tsv")
+	if err := os.WriteFile(tsv, []byte("North\t120\nSouth\t80\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "columns by name",
+			args: []string{"bar", sales, "--value-col", "sales", "--label-col", "region", "--show-values"},
+			want: []string{"North", "East", "150"},
+		},
+		{
+			name: "value column by number",
+			args: []string{"bar", sales, "--value-col", "3", "--show-values"},
+			want: []string{"South", "9"},
+		},
+		{
+			name: "detected columns",
+			args: []string{"pie", sales},
+			want: []string{"North", "South", "East"},
+		},
+		{
+			name: "tsv file",
+			args: []string{"bar", tsv},
+			want: []string{"North", "South"},
+		},
+		{
+			name:  "stdin with a delimiter",
+			args:  []string{"bar", "--format", "csv", "--delimiter", ";", "--show-values"},
+			stdin: "city;temp\nOslo;4\nRome;18\n",
+			want:  []string{"Oslo", "Rome", "18"},
+		},
+		{
+			name:  "no header",
+			args:  []string{"bar", "--format", "csv", "--no-header", "--label-col", "2", "--value-col", "1"},
+			stdin: "5,first\n7,second\n",
+			want:  []string{"first", "second"},
+		},
+		{
+			name: "labels flag wins",
+			args: []string{"bar", sales, "--labels", "N,S,E"},
+			want: []string{"N ", "S ", "E "},
+		},
+		{
+			name: "summary in the title",
+			args: []string{"bar", sales, "--value-col", "sales", "--title", "Regional Sales", "--auto-summary"},
+			want: []string{"Regional Sales (sales: min 80, max 150, mean 116.67)"},
+		},
+		{
+			name: "summary in the footer",
+			args: []string{"spark", sales, "--value-col", "3", "--auto-summary=footer"},
+			want: []string{"units: min 3, max 9, mean 5.33"},
+		},
+		{
+			name:    "summary without CSV",
+			args:    []string{"bar", "1", "2", "--auto-summary"},
+			wantErr: "--auto-summary summarizes a CSV column",
+		},
+		{
+			name:    "unknown column",
+			args:    []string{"bar", sales, "--value-col", "profit"},
+			wantErr: `no such column (columns: region, sales, units)`,
+		},
+		{
+			name:    "invalid number",
+			args:    []string{"bar", "--format", "csv", "--value-col", "b"},
+			stdin:   "a,b\nx,1\ny,n/a\n",
+			wantErr: `row 2, column "b": invalid number "n/a"`,
+		},
+		{
+			name:    "numbers format",
+			args:    []string{"bar", sales, "--format", "numbers"},
+			wantErr: "failed to parse data",
+		},
+		{
+			name:    "follow",
+			args:    []string{"spark", "--follow", sales},
+			wantErr: "not CSV",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, append(tt.args, "--no-color")...)
+			cmd.Stdin = strings.NewReader(tt.stdin)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got output:\n%s", tt.wantErr, stdout.String())
+				}
+				if !strings.Contains(stderr.String(), tt.wantErr) {
+					t.Errorf("stderr should contain %q, got: %s", tt.wantErr, stderr.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output should contain %q, got:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCLI_Render(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	spec := filepath.Join(t.TempDir(), "sales.json")
+	if err := os.WriteFile(spec, []byte(`{"type": "bar", "title": "Regional Sales", "data": [120, 98, 145],
+		"labels": ["North", "South", "East"], "showValues": true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		want     []string
+		wantCode int
+	}{
+		{
+			name: "spec file",
+			args: []string{"render", spec, "--no-color"},
+			want: []string{"Regional Sales", "North", "145"},
+		},
+		{
+			name:  "spec on stdin",
+			args:  []string{"render", "--no-color", "--ascii"},
+			stdin: `{"type": "pie", "data": [3, 2, 1], "labels": ["alpha", "beta", "gamma"]}`,
+			want:  []string{"alpha", "gamma"},
+		},
+		{
+			name:     "unknown chart type",
+			args:     []string{"render"},
+			stdin:    `{"type": "radar", "data": [1]}`,
+			wantCode: exitParse,
+		},
+		{
+			name:     "invalid JSON",
+			args:     []string{"render"},
+			stdin:    `{"type": "bar", "data": [1,`,
+			wantCode: exitParse,
+		},
+		{
+			name:     "no data",
+			args:     []string{"render"},
+			stdin:    `{"type": "line"}`,
+			wantCode: exitNoData,
+		},
+		{
+			name:     "too many points",
+			args:     []string{"render", spec, "--max-points", "2"},
+			wantCode: exitLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binary, tt.args...)
+			cmd.Stdin = strings.NewReader(tt.stdin)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+			if tt.wantCode != 0 {
+				exitErr, ok := err.(*exec.ExitError)
+				if !ok || exitErr.ExitCode() != tt.wantCode {
+					t.Fatalf("error = %v, want exit code %d (stderr: %s)", err, tt.wantCode, stderr.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output should contain %q, got:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCLI_AutoSize(t *testing.T) {
+	binary := buildBinary(t)
+	defer os.Remove(binary)
+
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command(binary, args...)
+		cmd.Env = append(os.Environ(), "COLUMNS=30", "LINES=10")
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("unexpected error: %v\nstderr: %s", err, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	// A width of 0 is the terminal's
+	out := run("bar", "1", "2", "3", "--width", "0", "--no-color")
+	widest := 0
+	for _, line := range strings.Split(out, "\n") {
+		if n := utf8.RuneCountInString(line); n > widest {
+			widest = n
+		}
+	}
+	if widest > 30 || widest < 20 {
+		t.Errorf("bar chart is %d columns wide, want the terminal's 30:\n%s", widest, out)
+	}
+
+	// Sparklines draw every point by default
+	args := []string{"spark", "--no-color"}
+	for i := 0; i < 100; i++ {
+		args = append(args, fmt.Sprint(i))
+	}
+	if n := utf8.RuneCountInString(strings.TrimSpace(run(args...))); n != 100 {
+		t.Errorf("sparkline has %d characters, want 100", n)
+	}
+}
+
 func buildBinary(t *testing.T) string {
 	t.Helper()
 
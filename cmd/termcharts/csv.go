@@ -0,0 +1,364 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+)
+
+// CSV input flags shared by all chart commands.
+var (
+	inputFormat string
+	valueColumn string
+	labelColumn string
+	delimiter   string
+	noHeader    bool
+	autoSummary string
+)
+
+// csvValueName names the value column of the CSV input read last, for
+// --auto-summary: its header name, or its number.
+var csvValueName string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&inputFormat, "format", "auto", "input format: auto, numbers, or csv (auto reads .csv and .tsv files, or input with --value-col or --label-col, as CSV)")
+	rootCmd.PersistentFlags().StringVar(&valueColumn, "value-col", "", "CSV column to chart, by header name or number counted from 1 (default: the first numeric column)")
+	rootCmd.PersistentFlags().StringVar(&labelColumn, "label-col", "", "CSV column to label values with, by header name or number counted from 1 (default: the first column, if it is text)")
+	rootCmd.PersistentFlags().StringVar(&delimiter, "delimiter", "", "CSV field delimiter, e.g. ; or tab (default: tab for .tsv files, otherwise a comma)")
+	rootCmd.PersistentFlags().BoolVar(&noHeader, "no-header", false, "the first CSV row is data, not column names (default: detected)")
+	rootCmd.PersistentFlags().StringVar(&autoSummary, "auto-summary", "", "add the min, max, and mean of the CSV value column to the title or footer (title, footer)")
+	rootCmd.PersistentFlags().Lookup("auto-summary").NoOptDefVal = "title"
+}
+
+// csvInput reports whether the input in args is read as CSV: with --format
+// csv, or, with --format auto, when a CSV column is selected or args is a
+// single .csv or .tsv file.
+func csvInput(args []string) (bool, error) {
+	switch strings.ToLower(inputFormat) {
+	case "csv":
+		return true, nil
+	case "numbers":
+		return false, nil
+	case "auto", "":
+	default:
+		return false, withExit(exitUsage, fmt.Errorf("invalid --format %q: want auto, numbers, or csv", inputFormat))
+	}
+	if valueColumn != "" || labelColumn != "" {
+		return true, nil
+	}
+	if len(args) == 1 && fileExists(args[0]) {
+		switch strings.ToLower(filepath.Ext(args[0])) {
+		case ".csv", ".tsv":
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseCSVInput reads the values of the CSV file in args, or of stdin, and
+// their labels. Labels are nil when there is no label column.
+func parseCSVInput(args []string) ([]float64, []string, error) {
+	switch len(args) {
+	case 0:
+		stat, err := os.Stdin.Stat()
+		if err != nil {
+			return nil, nil, err
+		}
+		if (stat.Mode() & os.ModeCharDevice) != 0 {
+			return nil, nil, fmt.Errorf("%w via stdin", errNoData)
+		}
+		return readCSV(os.Stdin, csvDelimiter(""))
+	case 1:
+		file, err := os.Open(args[0]) // #nosec G304 - filename is provided by user via CLI
+		if err != nil {
+			return nil, nil, err
+		}
+		defer file.Close()
+		return readCSV(file, csvDelimiter(args[0]))
+	default:
+		return nil, nil, withExit(exitUsage, fmt.Errorf("CSV input is read from one file or stdin, not values"))
+	}
+}
+
+// csvDelimiter returns the delimiter of the CSV file filename: --delimiter,
+// or a tab for .tsv files, or a comma.
+func csvDelimiter(filename string) string {
+	if delimiter != "" {
+		return delimiter
+	}
+	if strings.EqualFold(filepath.Ext(filename), ".tsv") {
+		return "\t"
+	}
+	return ","
+}
+
+// readCSV reads the value column, and the label column if there is one, of
+// CSV records from r. Unless --no-header is set, the first record is a
+// header of column names when a column is selected by name or none of its
+// cells is a number. Empty lines and lines starting with "#" are skipped.
+func readCSV(r io.Reader, delim string) ([]float64, []string, error) {
+	comma, err := parseDelimiter(delim)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	first, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errNoData
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A header names the columns for --value-col and --label-col
+	var header []string
+	if !noHeader && (!isColumnNumber(valueColumn) || !isColumnNumber(labelColumn) || !hasNumber(first)) {
+		header = first
+		if first, err = reader.Read(); errors.Is(err, io.EOF) {
+			return nil, nil, errNoData
+		} else if err != nil {
+			return nil, nil, err
+		}
+	}
+	value, err := findColumn(valueColumn, "--value-col", header, first)
+	if err != nil {
+		return nil, nil, err
+	}
+	csvValueName = fmt.Sprintf("column %d", value+1)
+	if value < len(header) && strings.TrimSpace(header[value]) != "" {
+		csvValueName = strings.TrimSpace(header[value])
+	}
+	label := -1
+	if labelColumn != "" {
+		if label, err = findColumn(labelColumn, "--label-col", header, first); err != nil {
+			return nil, nil, err
+		}
+	} else if value > 0 {
+		// The first column labels the values when it is text
+		if !isNumber(first[0]) {
+			label = 0
+		}
+	}
+
+	var values []float64
+	var labels []string
+	max := maxInputPoints()
+	for record, row := first, 1; ; row++ {
+		if max > 0 && len(values) == max {
+			return nil, nil, fmt.Errorf("%w: input has more than %d rows (raise the limit with --max-points)", termcharts.ErrLimitExceeded, max)
+		}
+		if value >= len(record) {
+			return nil, nil, fmt.Errorf("row %d has no column %s", row, columnName(header, value))
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(record[value]), 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d, column %s: invalid number %q", row, columnName(header, value), record[value])
+		}
+		values = append(values, v)
+		if label >= 0 {
+			text := ""
+			if label < len(record) {
+				text = strings.TrimSpace(record[label])
+			}
+			labels = append(labels, text)
+		}
+
+		if record, err = reader.Read(); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+	}
+	return values, labels, nil
+}
+
+// parseDelimiter returns the rune of a --delimiter value: a single
+// character, or "tab".
+func parseDelimiter(delim string) (rune, error) {
+	if strings.EqualFold(delim, "tab") || delim == `\t` {
+		return '\t', nil
+	}
+	runes := []rune(delim)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\n' || runes[0] == '\r' {
+		return 0, withExit(exitUsage, fmt.Errorf("invalid --delimiter %q: want a single character other than a quote or newline", delim))
+	}
+	return runes[0], nil
+}
+
+// findColumn returns the index of the column spec names: a header name or a
+// number counted from 1. An empty spec selects the first column whose cell
+// in the first data row is a number.
+func findColumn(spec, flag string, header, first []string) (int, error) {
+	if spec == "" {
+		for i, cell := range first {
+			if isNumber(cell) {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("no numeric column found; select one with --value-col")
+	}
+	if n, err := strconv.Atoi(spec); err == nil {
+		if n < 1 || n > len(first) {
+			return 0, withExit(exitUsage, fmt.Errorf("invalid %s %d: want a column from 1 to %d", flag, n, len(first)))
+		}
+		return n - 1, nil
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), spec) {
+			return i, nil
+		}
+	}
+	if header == nil {
+		return 0, withExit(exitUsage, fmt.Errorf("invalid %s %q: the input has no header row; select the column by number", flag, spec))
+	}
+	return 0, withExit(exitUsage, fmt.Errorf("invalid %s %q: no such column (columns: %s)", flag, spec, strings.Join(header, ", ")))
+}
+
+// isColumnNumber reports whether a column flag is empty or selects a column
+// by number.
+func isColumnNumber(spec string) bool {
+	_, err := strconv.Atoi(spec)
+	return spec == "" || err == nil
+}
+
+// hasNumber reports whether any cell of record is a number, as some cells
+// of a data row are and a header's usually are not.
+func hasNumber(record []string) bool {
+	for _, cell := range record {
+		if isNumber(cell) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNumber reports whether a CSV cell is a number.
+func isNumber(cell string) bool {
+	_, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+	return err == nil
+}
+
+// columnName returns the name of column i for messages: its header name,
+// or its number.
+func columnName(header []string, i int) string {
+	if i < len(header) {
+		return strconv.Quote(header[i])
+	}
+	return strconv.Itoa(i + 1)
+}
+
+// columnSummary adds --auto-summary, the min, max, and mean of the charted
+// CSV column, to a chart's title or footer.
+type columnSummary struct {
+	footer bool
+	title  string // the --title the summary follows
+	text   string
+}
+
+// newColumnSummary returns the --auto-summary of a chart titled title that
+// reads the input in args, or nil without --auto-summary.
+func newColumnSummary(args []string, title string) (*columnSummary, error) {
+	switch autoSummary {
+	case "":
+		return nil, nil
+	case "title", "footer":
+	default:
+		return nil, withExit(exitUsage, fmt.Errorf("invalid --auto-summary value %q (use title or footer)", autoSummary))
+	}
+	csv, err := csvInput(args)
+	if err != nil {
+		return nil, err
+	}
+	if !csv {
+		return nil, withExit(exitUsage, fmt.Errorf("--auto-summary summarizes a CSV column; select one with --value-col or read CSV with --format csv"))
+	}
+	return &columnSummary{footer: autoSummary == "footer", title: title}, nil
+}
+
+// set summarizes data, the values of the CSV value column. It does nothing
+// on a nil summary.
+func (s *columnSummary) set(data []float64) {
+	if s == nil {
+		return
+	}
+	if len(data) == 0 {
+		s.text = ""
+		return
+	}
+	min, max, sum := data[0], data[0], 0.0
+	for _, v := range data {
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+		sum += v
+	}
+	s.text = fmt.Sprintf("%s: min %s, max %s, mean %s", csvValueName,
+		summaryNumber(min), summaryNumber(max), summaryNumber(sum/float64(len(data))))
+}
+
+// titleText returns the title with the summary.
+func (s *columnSummary) titleText() string {
+	switch {
+	case s.text == "":
+		return s.title
+	case s.title == "":
+		return s.text
+	default:
+		return s.title + " (" + s.text + ")"
+	}
+}
+
+// option returns the option adding the summary to a chart. Charts without
+// a title, such as sparklines, get the title summary on a line above.
+func (s *columnSummary) option(titled bool) termcharts.Option {
+	if titled && !s.footer {
+		return termcharts.WithTitle(s.titleText())
+	}
+	return termcharts.WithPostProcessor(func(lines []string) []string {
+		if s.text == "" {
+			return lines
+		}
+		if s.footer {
+			return append(lines, s.text)
+		}
+		return append([]string{s.text}, lines...)
+	})
+}
+
+// watch returns source, summarizing each read into the title or footer of
+// chart, which option was added to with titled.
+func (s *columnSummary) watch(chart termcharts.Updatable, source termcharts.DataSource, titled bool) termcharts.DataSource {
+	return func() ([]float64, error) {
+		data, err := source()
+		if err != nil {
+			return nil, err
+		}
+		s.set(data)
+		if titled && !s.footer {
+			chart.Apply(termcharts.WithTitle(s.titleText()))
+		}
+		return data, nil
+	}
+}
+
+// summaryNumber formats a number of the summary with at most two decimals.
+func summaryNumber(v float64) string {
+	text := strconv.FormatFloat(v, 'f', 2, 64)
+	text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	if text == "-0" {
+		return "0"
+	}
+	return text
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+)
+
+// Exit codes of the CLI. They are stable, so scripts can branch on them.
+const (
+	exitError  = 1 // Any other failure, such as an --exec command that failed
+	exitUsage  = 2 // Invalid flags or arguments
+	exitNoData = 3 // No data to chart
+	exitParse  = 4 // Data or a chart spec that could not be read or parsed
+	exitRender = 5 // Data the chart cannot draw, such as infinite values
+	exitLimit  = 6 // Input beyond --max-points, --max-width, or --max-height
+)
+
+// errorKinds names the exit codes in --json-errors output.
+var errorKinds = map[int]string{
+	exitError:  "error",
+	exitUsage:  "usage",
+	exitNoData: "no_data",
+	exitParse:  "parse",
+	exitRender: "render",
+	exitLimit:  "limit",
+}
+
+// errNoData is returned when there is no data to chart.
+var errNoData = errors.New("no data provided")
+
+// Error reporting flags shared by all commands.
+var (
+	quietErrors bool
+	jsonErrors  bool
+)
+
+func init() {
+	rootCmd.PersistentFlags().BoolVarP(&quietErrors, "quiet", "q", false, "print no error messages; the exit code reports failures")
+	rootCmd.PersistentFlags().BoolVar(&jsonErrors, "json-errors", false, "print errors to stderr as JSON objects with code, kind, and message")
+}
+
+// cliError is an error with the exit code it causes.
+type cliError struct {
+	code int
+	err  error
+}
+
+func (e *cliError) Error() string { return e.err.Error() }
+func (e *cliError) Unwrap() error { return e.err }
+
+// withExit returns err with the exit code it causes.
+func withExit(code int, err error) error {
+	return &cliError{code: code, err: err}
+}
+
+// parseFailed returns the error for data that could not be read or parsed.
+func parseFailed(err error) error {
+	return withExit(exitParse, fmt.Errorf("failed to parse data: %w", err))
+}
+
+// exitCodeOf returns the exit code err causes. Errors beyond a limit, or
+// with no data, take precedence over the code they are wrapped with, and the
+// innermost code over outer ones, since it is the most specific. Library
+// errors are render errors, and other errors are usage errors, since most
+// are flags or arguments the CLI rejected.
+func exitCodeOf(err error) int {
+	code := 0
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if ce, ok := e.(*cliError); ok {
+			code = ce.code
+		}
+	}
+	switch {
+	case errors.Is(err, termcharts.ErrLimitExceeded):
+		return exitLimit
+	case errors.Is(err, errNoData), errors.Is(err, termcharts.ErrEmptyData):
+		return exitNoData
+	case code != 0:
+		return code
+	case errors.Is(err, termcharts.ErrInvalidSpec):
+		return exitParse
+	case errors.Is(err, termcharts.ErrInvalidData), errors.Is(err, termcharts.ErrInvalidDimensions),
+		errors.Is(err, termcharts.ErrInvalidOption), errors.Is(err, termcharts.ErrLabelMismatch),
+		errors.Is(err, termcharts.ErrConflictingOptions):
+		return exitRender
+	default:
+		return exitUsage
+	}
+}
+
+// reportError writes err to w: as a JSON object with --json-errors, not at
+// all with --quiet, and as a message otherwise.
+func reportError(w io.Writer, err error) {
+	code := exitCodeOf(err)
+	switch {
+	case jsonErrors:
+		out, _ := json.Marshal(struct {
+			Code    int    `json:"code"`
+			Kind    string `json:"kind"`
+			Message string `json:"message"`
+		}{code, errorKinds[code], err.Error()})
+		fmt.Fprintf(w, "%s\n", out)
+	case quietErrors:
+	default:
+		fmt.Fprintf(w, "Error: %v\n", err)
+		if code == exitUsage {
+			fmt.Fprintf(w, "Run 'termcharts --help' for usage.\n")
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+)
+
+// defaultFollowFPS is the default of the --fps flag: the most redraws per
+// second in --follow mode, so input arriving faster than a terminal can show
+// it is drawn in batches.
+const defaultFollowFPS = 10
+
+// followWindow is the number of values charted in --follow mode when the
+// chart has no width to size the window by.
+const followWindow = 80
+
+// followChart reads numbers from stdin, or from the file or named pipe in
+// args, as they arrive and redraws chart in place with the latest window
+// values, at most fps times a second. The first values after a pause are
+// drawn right away; values arriving faster than that are coalesced into the
+// next frame. A pipe that is open but momentarily empty is waited on rather
+// than treated as having no data. A named pipe is reopened when its writer
+// closes it, so a series of writers can feed one chart; stdin and regular
+// files are followed until they end. With highlight the cells that changed
+// are shown in reverse video until the next redraw.
+func followChart(chart termcharts.Updatable, args []string, window, fps int, highlight bool) error {
+	if fps <= 0 {
+		return fmt.Errorf("--fps must be positive, got %d", fps)
+	}
+	if csv, err := csvInput(args); err != nil {
+		return err
+	} else if csv {
+		return fmt.Errorf("following input reads numbers, not CSV; chart CSV without --follow, or with --watch on a file")
+	}
+	interval := time.Second / time.Duration(fps)
+
+	buf := newFollowBuffer(window)
+	errc := make(chan error, 1)
+	go func() {
+		if err := followSource(args, buf.add); err != nil {
+			errc <- parseFailed(err)
+			return
+		}
+		errc <- nil
+	}()
+
+	live := termcharts.NewLiveRenderer(os.Stdout)
+	live.Highlight = highlight
+	draw := func() error {
+		data, ok := buf.take()
+		if !ok {
+			return nil
+		}
+		chart.Apply(termcharts.WithData(data))
+		return live.Draw(chart.Render())
+	}
+	// finish draws the values read since the last frame once the input ends
+	finish := func(err error) error {
+		if drawErr := draw(); err == nil {
+			err = drawErr
+		}
+		return err
+	}
+	for {
+		select {
+		case <-buf.ready:
+		case err := <-errc:
+			return finish(err)
+		}
+		if err := draw(); err != nil {
+			return err
+		}
+
+		// Values arriving before the next frame is due wait for it
+		pause := time.NewTimer(interval)
+		select {
+		case <-pause.C:
+		case err := <-errc:
+			pause.Stop()
+			return finish(err)
+		}
+	}
+}
+
+// followBuffer holds the latest values read in --follow mode until they
+// are drawn. Adding values is cheap, so a fast producer is never held up by
+// the terminal.
+type followBuffer struct {
+	mu     sync.Mutex
+	window int
+	data   []float64
+	dirty  bool
+	ready  chan struct{} // Signaled when values arrive after a take
+}
+
+// newFollowBuffer creates a buffer of the latest window values.
+func newFollowBuffer(window int) *followBuffer {
+	return &followBuffer{
+		window: window,
+		data:   make([]float64, 0, 2*window),
+		ready:  make(chan struct{}, 1),
+	}
+}
+
+// add appends v, dropping the oldest values beyond the window.
+func (b *followBuffer) add(v float64) {
+	b.mu.Lock()
+	// Values beyond the window are dropped in batches, so most adds only append
+	if len(b.data) == cap(b.data) {
+		b.data = append(b.data[:0], b.data[len(b.data)-b.window+1:]...)
+	}
+	b.data = append(b.data, v)
+	b.dirty = true
+	b.mu.Unlock()
+
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+}
+
+// take returns a copy of the latest window values, and false if none
+// arrived since the last take.
+func (b *followBuffer) take() ([]float64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.dirty {
+		return nil, false
+	}
+	b.dirty = false
+	start := len(b.data) - b.window
+	if start < 0 {
+		start = 0
+	}
+	return append([]float64(nil), b.data[start:]...), true
+}
+
+// followSource passes each number read from the --follow input to add until
+// the input ends.
+func followSource(args []string, add func(float64)) error {
+	switch {
+	case len(args) == 0:
+		return streamDataFromStdin(0, add)
+	case len(args) == 1 && fileExists(args[0]):
+	default:
+		return fmt.Errorf("--follow reads from stdin or a single file, not values")
+	}
+
+	for {
+		info, err := os.Stat(args[0])
+		if err != nil {
+			return err
+		}
+		if err := streamDataFromFile(args[0], 0, add); err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeNamedPipe == 0 {
+			return nil
+		}
+	}
+}
+
+// followWindowFor returns the number of values to chart in --follow mode
+// for a chart width columns wide.
+func followWindowFor(width int) int {
+	if width > 0 {
+		return width
+	}
+	return followWindow
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+	"github.com/spf13/cobra"
+)
+
+var (
+	histWidth      int
+	histHeight     int
+	histColor      bool
+	histASCII      bool
+	histNoColor    bool
+	histVertical   bool
+	histShowValues bool
+	histMinBar     bool
+	histCumulative bool
+	histPercent    bool
+	histDensity    bool
+	histBins       int
+	histRule       string
+	histTitle      string
+	histFill       string
+	histDescribe   string
+	histUnit       string
+)
+
+var histCmd = &cobra.Command{
+	Use:     "histogram [samples...]",
+	Aliases: []string{"hist"},
+	Short:   "Create a histogram of raw samples",
+	Long: `Create a histogram to show how raw samples are distributed.
+
+The samples are sorted into bins of equal width, and each bar shows the
+number of samples in its bin, labeled with the bin's range. The number of
+bins is set with --bins, or chosen by --rule: sturges (the default) or fd
+(Freedman-Diaconis, for large or skewed samples).
+
+Data can be provided as:
+  - Command-line arguments: termcharts histogram 12 15 11 19 14
+  - File path: termcharts histogram latencies.txt
+  - Stdin: cat latencies.txt | termcharts histogram
+  - CSV: termcharts histogram requests.csv --value-col latency_ms
+
+Examples:
+  # Distribution of request latencies
+  termcharts histogram latencies.txt --show-values
+
+  # Ten bins, drawn vertically
+  termcharts histogram latencies.txt --bins 10 --vertical
+
+  # Bins sized for skewed data
+  termcharts histogram latencies.txt --rule fd
+
+  # Share of requests at or below each latency
+  termcharts histogram latencies.txt --cumulative --percent
+
+  # Smoothed distribution curve over the bars
+  termcharts histogram latencies.txt --density`,
+	RunE: runHistogram,
+}
+
+func init() {
+	rootCmd.AddCommand(histCmd)
+
+	histCmd.Flags().IntVarP(&histWidth, "width", "w", 80, "chart width in characters (0 = terminal width)")
+	histCmd.Flags().IntVar(&histHeight, "height", 15, "chart height in rows (vertical mode, 0 = terminal height)")
+	histCmd.Flags().BoolVarP(&histColor, "color", "c", false, "enable colored output")
+	histCmd.Flags().BoolVar(&histASCII, "ascii", false, "use ASCII characters only")
+	histCmd.Flags().BoolVar(&histNoColor, "no-color", false, "disable colored output")
+	histCmd.Flags().BoolVarP(&histVertical, "vertical", "v", false, "render vertical bars")
+	histCmd.Flags().BoolVar(&histShowValues, "show-values", false, "display the count of each bin")
+	histCmd.Flags().BoolVar(&histMinBar, "min-bar", false, "draw bins with too few samples for a cell as a thin marker")
+	histCmd.Flags().BoolVar(&histCumulative, "cumulative", false, "show the running total of each bin and the bins before it")
+	histCmd.Flags().BoolVar(&histPercent, "percent", false, "show counts as percentages of all samples")
+	histCmd.Flags().BoolVar(&histDensity, "density", false, "overlay a smoothed density curve on vertical bars")
+	histCmd.Flags().IntVar(&histBins, "bins", 0, "number of bins (0 = chosen by --rule)")
+	histCmd.Flags().StringVar(&histRule, "rule", "sturges", "rule choosing the number of bins: sturges or fd (Freedman-Diaconis)")
+	histCmd.Flags().StringVarP(&histTitle, "title", "t", "", "chart title")
+	histCmd.Flags().StringVar(&histFill, "fill", "", "character to fill bars with, e.g. ▓ or =")
+	addDescribeFlag(histCmd, &histDescribe)
+	addUnitFlag(histCmd, &histUnit)
+}
+
+func runHistogram(cmd *cobra.Command, args []string) error {
+	summary, err := newColumnSummary(args, histTitle)
+	if err != nil {
+		return err
+	}
+	samples, _, err := parseBarData(args)
+	if err != nil {
+		return parseFailed(err)
+	}
+	if len(samples) == 0 {
+		return errNoData
+	}
+	summary.set(samples)
+
+	opts := []termcharts.BarOption{
+		termcharts.WithData(samples),
+	}
+
+	// Apply binning
+	if histBins < 0 {
+		return fmt.Errorf("--bins must not be negative")
+	}
+	if histBins > 0 {
+		opts = append(opts, termcharts.WithBins(histBins))
+	}
+	switch strings.ToLower(histRule) {
+	case "sturges":
+		opts = append(opts, termcharts.WithBinRule(termcharts.BinSturges))
+	case "fd", "freedman-diaconis":
+		opts = append(opts, termcharts.WithBinRule(termcharts.BinFreedmanDiaconis))
+	default:
+		return fmt.Errorf("invalid --rule %q: want sturges or fd", histRule)
+	}
+
+	// Apply dimensions
+	if histWidth >= 0 {
+		opts = append(opts, termcharts.WithWidth(histWidth))
+	}
+	if histVertical {
+		opts = append(opts, termcharts.WithDirection(termcharts.Vertical))
+		if histHeight >= 0 {
+			opts = append(opts, termcharts.WithHeight(histHeight))
+		}
+	}
+
+	if histTitle != "" {
+		opts = append(opts, termcharts.WithTitle(histTitle))
+	}
+	if summary != nil {
+		opts = append(opts, summary.option(true))
+	}
+	if histShowValues {
+		opts = append(opts, termcharts.WithShowValues(true))
+	}
+	if histMinBar {
+		opts = append(opts, termcharts.WithMinBar(true))
+	}
+	if histCumulative {
+		opts = append(opts, termcharts.WithCumulative(true))
+	}
+	if histPercent {
+		opts = append(opts, termcharts.WithPercentAxis(true))
+	}
+	if histDensity {
+		if histShowValues || histMinBar {
+			return fmt.Errorf("--density cannot be combined with --show-values or --min-bar")
+		}
+		opts = append(opts, termcharts.WithDensity(true))
+		if histHeight >= 0 && !histVertical {
+			opts = append(opts, termcharts.WithHeight(histHeight))
+		}
+	}
+
+	// Apply style
+	if histASCII {
+		opts = append(opts, termcharts.WithStyle(termcharts.StyleASCII))
+	}
+	if histFill != "" {
+		fill := []rune(histFill)
+		if len(fill) != 1 {
+			return fmt.Errorf("--fill must be a single character, got %q", histFill)
+		}
+		opts = append(opts, termcharts.WithFillChar(fill[0]))
+	}
+
+	// Apply color settings
+	if histNoColor {
+		opts = append(opts, termcharts.WithColor(false))
+	} else if histColor {
+		opts = append(opts, termcharts.WithColor(true))
+	}
+
+	// Apply text summary
+	describe, err := describeOption(histDescribe)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, describe)
+
+	// Apply number locale and unit
+	locale, err := localeOption()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, locale, termcharts.WithYUnit(histUnit))
+
+	// Apply input limits
+	limits, err := limitsOption(histWidth, histHeight)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, limits)
+
+	hist := termcharts.NewHistogram(opts...)
+	return hist.RenderTo(os.Stdout)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/neilpeterson/termcharts/pkg/termcharts"
 	"github.com/spf13/cobra"
@@ -13,11 +14,21 @@ var (
 	lineColor     bool
 	lineASCII     bool
 	lineBraille   bool
+	lineBlocks    bool
+	lineSextants  bool
 	lineNoColor   bool
 	lineShowAxes  bool
 	lineTitle     string
 	lineLabels    string
 	lineThemeName string
+	lineYTicks    int
+	linePercent   bool
+	lineFill      bool
+	lineFollow    bool
+	lineFPS       int
+	lineWatch     watchFlags
+	lineDescribe  string
+	lineUnit      string
 )
 
 var lineCmd = &cobra.Command{
@@ -27,12 +38,14 @@ var lineCmd = &cobra.Command{
 
 Line charts can be rendered using ASCII box-drawing characters,
 Unicode characters, or high-resolution Braille patterns for
-maximum detail.
+maximum detail. Quadrant blocks and sextants sit in between and
+read better than Braille in some fonts.
 
 Data can be provided as:
   - Command-line arguments: termcharts line 10 20 30 25
   - File path: termcharts line data.txt
   - Stdin: cat data.txt | termcharts line
+  - CSV: termcharts line sales.csv --value-col sales --label-col region
 
 Data format:
   - One number per line, or
@@ -46,9 +59,18 @@ Examples:
   # High-resolution Braille rendering
   termcharts line 1 5 2 8 3 7 4 6 --braille
 
+  # Double resolution with solid quadrant blocks
+  termcharts line 1 5 2 8 3 7 4 6 --blocks
+
   # With title and axes
   termcharts line 10 25 15 30 20 --title "Sales Trend" --axes
 
+  # Area chart, shaded under the line
+  termcharts line 12 18 15 24 30 27 --fill
+
+  # Five round-numbered Y-axis labels on a tall chart
+  termcharts line 3 40 97 55 --height 30 --y-ticks 5
+
   # With X-axis labels
   termcharts line 10 25 15 30 --labels "Jan,Feb,Mar,Apr"
 
@@ -59,46 +81,90 @@ Examples:
   termcharts line 10 20 30 --ascii
 
   # With color
-  termcharts line 10 20 30 --color`,
+  termcharts line 10 20 30 --color
+
+  # Redraw as values arrive on a named pipe, across writers
+  mkfifo /tmp/load && termcharts line /tmp/load --follow
+
+  # Redraw every second with the latest contents of a file
+  termcharts line latency.txt --watch --interval 1s`,
 	RunE: runLine,
 }
 
 func init() {
 	rootCmd.AddCommand(lineCmd)
 
-	lineCmd.Flags().IntVarP(&lineWidth, "width", "w", 60, "chart width in characters")
-	lineCmd.Flags().IntVar(&lineHeight, "height", 12, "chart height in rows")
+	lineCmd.Flags().IntVarP(&lineWidth, "width", "w", 60, "chart width in characters (0 = terminal width)")
+	lineCmd.Flags().IntVar(&lineHeight, "height", 12, "chart height in rows (0 = terminal height)")
 	lineCmd.Flags().BoolVarP(&lineColor, "color", "c", false, "enable colored output")
 	lineCmd.Flags().BoolVar(&lineASCII, "ascii", false, "use ASCII characters only")
 	lineCmd.Flags().BoolVarP(&lineBraille, "braille", "b", false, "use high-resolution Braille patterns")
+	lineCmd.Flags().BoolVar(&lineBlocks, "blocks", false, "use 2x2 quadrant blocks for double resolution")
+	lineCmd.Flags().BoolVar(&lineSextants, "sextants", false, "use 2x3 sextant blocks (needs a Unicode 13 font)")
 	lineCmd.Flags().BoolVar(&lineNoColor, "no-color", false, "disable colored output")
 	lineCmd.Flags().BoolVar(&lineShowAxes, "axes", true, "show axes and labels")
 	lineCmd.Flags().StringVarP(&lineTitle, "title", "t", "", "chart title")
 	lineCmd.Flags().StringVarP(&lineLabels, "labels", "l", "", "comma-separated X-axis labels")
 	lineCmd.Flags().StringVar(&lineThemeName, "theme", "default", "color theme (default, dark, light, mono)")
+	lineCmd.Flags().IntVar(&lineYTicks, "y-ticks", 0, "number of round-numbered Y-axis labels (0 = one per row)")
+	lineCmd.Flags().BoolVar(&linePercent, "percent", false, "label the Y axis 0-100% (values within [-1, 1] are fractions)")
+	lineCmd.Flags().BoolVar(&lineFill, "fill", false, "shade the area under the line")
+	lineCmd.Flags().BoolVar(&lineFollow, "follow", false, "keep reading stdin or a named pipe and redraw as values arrive")
+	lineCmd.Flags().IntVar(&lineFPS, "fps", defaultFollowFPS, "most redraws per second with --follow")
+	addWatchFlags(lineCmd, &lineWatch)
+	addDescribeFlag(lineCmd, &lineDescribe)
+	addUnitFlag(lineCmd, &lineUnit)
 }
 
 func runLine(cmd *cobra.Command, args []string) error {
-	// Parse data from various sources
-	data, err := parseLineData(args)
+	// Summarizing needs a CSV column
+	summary, err := newColumnSummary(args, lineTitle)
 	if err != nil {
-		return fmt.Errorf("failed to parse data: %w", err)
+		return err
+	}
+
+	// Watching stdin follows it; watching a file or command re-reads it
+	if err := lineWatch.check(); err != nil {
+		return err
 	}
+	if lineFollow && lineWatch.watch {
+		return fmt.Errorf("--follow and --watch cannot be combined")
+	}
+	follow := lineFollow || lineWatch.stdin(args)
+	var source termcharts.DataSource
+	if lineWatch.watch && !follow {
+		var err error
+		if source, err = lineWatch.source(args); err != nil {
+			return err
+		}
+	}
+
+	// Parse data from various sources
+	var data []float64
+	var csvLabels []string
+	if !follow && source == nil {
+		var err error
+		data, csvLabels, err = parseReducedData(args, lineMaxPoints())
+		if err != nil {
+			return parseFailed(err)
+		}
 
-	if len(data) == 0 {
-		return fmt.Errorf("no data provided")
+		if len(data) == 0 {
+			return errNoData
+		}
+		summary.set(data)
 	}
 
 	// Build options
-	opts := []termcharts.Option{
+	opts := []termcharts.LineOption{
 		termcharts.WithData(data),
 	}
 
 	// Apply dimensions
-	if lineWidth > 0 {
+	if lineWidth >= 0 {
 		opts = append(opts, termcharts.WithWidth(lineWidth))
 	}
-	if lineHeight > 0 {
+	if lineHeight >= 0 {
 		opts = append(opts, termcharts.WithHeight(lineHeight))
 	}
 
@@ -106,19 +172,37 @@ func runLine(cmd *cobra.Command, args []string) error {
 	if lineTitle != "" {
 		opts = append(opts, termcharts.WithTitle(lineTitle))
 	}
+	if summary != nil {
+		opts = append(opts, summary.option(true))
+	}
 
 	// Apply labels if specified
 	if lineLabels != "" {
 		labels := parseLabels(lineLabels)
 		opts = append(opts, termcharts.WithLabels(labels))
+	} else if csvLabels != nil {
+		opts = append(opts, termcharts.WithLabels(csvLabels))
 	}
 
 	// Apply axes setting
 	opts = append(opts, termcharts.WithShowAxes(lineShowAxes))
+	if lineYTicks > 0 {
+		opts = append(opts, termcharts.WithYTicks(lineYTicks))
+	}
+	if linePercent {
+		opts = append(opts, termcharts.WithPercentAxis(true))
+	}
+	if lineFill {
+		opts = append(opts, termcharts.WithFill(true))
+	}
 
 	// Apply style
 	if lineBraille {
-		opts = append(opts, termcharts.WithStyle(termcharts.StyleBraille))
+		opts = append(opts, termcharts.WithBraille())
+	} else if lineSextants {
+		opts = append(opts, termcharts.WithStyle(termcharts.StyleSextant))
+	} else if lineBlocks {
+		opts = append(opts, termcharts.WithStyle(termcharts.StyleBlock2x2))
 	} else if lineASCII {
 		opts = append(opts, termcharts.WithStyle(termcharts.StyleASCII))
 	}
@@ -138,29 +222,59 @@ func runLine(cmd *cobra.Command, args []string) error {
 		opts = append(opts, termcharts.WithTheme(theme))
 	}
 
-	// Create and render line chart
-	line := termcharts.NewLineChart(opts...)
-	fmt.Print(line.Render())
+	// Apply text summary
+	describe, err := describeOption(lineDescribe)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, describe)
 
-	return nil
-}
+	// Apply number locale and unit
+	locale, err := localeOption()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, locale, termcharts.WithYUnit(lineUnit))
 
-// parseLineData parses data from command-line args, files, or stdin.
-func parseLineData(args []string) ([]float64, error) {
-	// If no args, read from stdin
-	if len(args) == 0 {
-		return readDataFromStdin()
+	// Apply input limits
+	limits, err := limitsOption(lineWidth, lineHeight)
+	if err != nil {
+		return err
 	}
+	opts = append(opts, limits)
 
-	// If single arg and it's a file, read from file
-	if len(args) == 1 {
-		if fileExists(args[0]) {
-			return readDataFromFile(args[0])
+	// Create and render line chart, redrawing it as data arrives when following
+	// or watching
+	line := termcharts.NewLineChart(opts...)
+	if follow {
+		return followChart(line, args, followWindowFor(lineWidth), lineFPS, lineWatch.highlight)
+	}
+	if source != nil {
+		if summary != nil {
+			source = summary.watch(line, source, true)
 		}
+		return watchChart(line, source, &lineWatch)
 	}
+	return line.RenderTo(os.Stdout)
+}
 
-	// Otherwise, parse args as numbers
-	return parseNumbers(args)
+// streamPointsPerColumn is the number of points kept per chart column when
+// data is reduced while reading. It leaves the chart's own downsampling
+// enough points to find the minimum and maximum of each column.
+const streamPointsPerColumn = 8
+
+// lineMaxPoints returns the number of points to keep from data read from a
+// file or stdin, scaled by the number of columns (dot columns in Braille
+// mode). X-axis labels and text summaries need every value, so nothing is
+// reduced when they are requested.
+func lineMaxPoints() int {
+	if lineWidth <= 0 || lineLabels != "" || lineDescribe != "" {
+		return 0
+	}
+	if lineBraille {
+		return 2 * streamPointsPerColumn * lineWidth
+	}
+	return streamPointsPerColumn * lineWidth
 }
 
 // getTheme returns a theme by name.
@@ -5,7 +5,19 @@ import (
 )
 
 func main() {
+	addRegisteredCharts(rootCmd)
+
+	// Commands the CLI does not have run the plugin of that name
+	if path := findPlugin(rootCmd, os.Args[1:]); path != "" {
+		code, err := runPlugin(path, os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+		if err != nil {
+			reportError(os.Stderr, err)
+		}
+		os.Exit(code)
+	}
+
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		reportError(os.Stderr, err)
+		os.Exit(exitCodeOf(err))
 	}
 }
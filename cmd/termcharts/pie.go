@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/neilpeterson/termcharts/pkg/termcharts"
@@ -13,10 +14,13 @@ var (
 	pieColor      bool
 	pieASCII      bool
 	pieNoColor    bool
+	pieLabelColor bool
 	pieShowValues bool
+	pieEmphasis   int
 	pieTitle      string
 	pieLabels     string
 	pieTheme      string
+	pieDescribe   string
 )
 
 var pieCmd = &cobra.Command{
@@ -31,6 +35,7 @@ Data can be provided as:
   - Command-line arguments: termcharts pie 30 25 20 15 10
   - File path: termcharts pie data.txt
   - Stdin: cat data.txt | termcharts pie
+  - CSV: termcharts pie sales.csv --value-col sales --label-col region
 
 Data format:
   - One number per line, or
@@ -49,6 +54,9 @@ Examples:
   # With title and values
   termcharts pie 30 25 20 --title "Market Share" --show-values
 
+  # Call attention to the third slice
+  termcharts pie 30 25 20 15 10 --emphasis 3 --color
+
   # From file with color
   termcharts pie data.txt --color
 
@@ -60,34 +68,44 @@ Examples:
 func init() {
 	rootCmd.AddCommand(pieCmd)
 
-	pieCmd.Flags().IntVarP(&pieWidth, "width", "w", 80, "chart width in characters")
+	pieCmd.Flags().IntVarP(&pieWidth, "width", "w", 80, "chart width in characters (0 = terminal width)")
 	pieCmd.Flags().BoolVarP(&pieColor, "color", "c", false, "enable colored output")
 	pieCmd.Flags().BoolVar(&pieASCII, "ascii", false, "use ASCII characters only")
 	pieCmd.Flags().BoolVar(&pieNoColor, "no-color", false, "disable colored output")
+	pieCmd.Flags().BoolVar(&pieLabelColor, "color-by-label", false, "color each slice by its label, so it keeps its color across charts")
 	pieCmd.Flags().BoolVar(&pieShowValues, "show-values", false, "display numeric values")
+	pieCmd.Flags().IntVar(&pieEmphasis, "emphasis", 0, "offset and highlight one slice, counted from 1 (0 = none)")
 	pieCmd.Flags().StringVarP(&pieTitle, "title", "t", "", "chart title")
 	pieCmd.Flags().StringVarP(&pieLabels, "labels", "l", "", "comma-separated labels for each slice")
 	pieCmd.Flags().StringVar(&pieTheme, "theme", "", "color theme: default, dark, light, mono")
+	addDescribeFlag(pieCmd, &pieDescribe)
 }
 
 func runPie(cmd *cobra.Command, args []string) error {
+	// Summarizing needs a CSV column
+	summary, err := newColumnSummary(args, pieTitle)
+	if err != nil {
+		return err
+	}
+
 	// Parse data from various sources
-	data, err := parsePieData(args)
+	data, csvLabels, err := parsePieData(args)
 	if err != nil {
-		return fmt.Errorf("failed to parse data: %w", err)
+		return parseFailed(err)
 	}
 
 	if len(data) == 0 {
-		return fmt.Errorf("no data provided")
+		return errNoData
 	}
+	summary.set(data)
 
 	// Build options
-	opts := []termcharts.Option{
+	opts := []termcharts.PieOption{
 		termcharts.WithData(data),
 	}
 
 	// Apply width
-	if pieWidth > 0 {
+	if pieWidth >= 0 {
 		opts = append(opts, termcharts.WithWidth(pieWidth))
 	}
 
@@ -95,11 +113,16 @@ func runPie(cmd *cobra.Command, args []string) error {
 	if pieTitle != "" {
 		opts = append(opts, termcharts.WithTitle(pieTitle))
 	}
+	if summary != nil {
+		opts = append(opts, summary.option(true))
+	}
 
 	// Apply labels if specified
 	if pieLabels != "" {
 		labels := parsePieLabels(pieLabels)
 		opts = append(opts, termcharts.WithLabels(labels))
+	} else if csvLabels != nil {
+		opts = append(opts, termcharts.WithLabels(csvLabels))
 	}
 
 	// Apply show values
@@ -107,6 +130,14 @@ func runPie(cmd *cobra.Command, args []string) error {
 		opts = append(opts, termcharts.WithShowValues(true))
 	}
 
+	// Apply emphasis
+	if pieEmphasis < 0 || pieEmphasis > len(data) {
+		return fmt.Errorf("--emphasis must be between 1 and %d, the number of slices", len(data))
+	}
+	if pieEmphasis > 0 {
+		opts = append(opts, termcharts.WithEmphasis(pieEmphasis-1))
+	}
+
 	// Apply style
 	if pieASCII {
 		opts = append(opts, termcharts.WithStyle(termcharts.StyleASCII))
@@ -136,30 +167,61 @@ func runPie(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unknown theme: %s (use: default, dark, light, mono)", pieTheme)
 		}
 	}
+	if pieLabelColor {
+		opts = append(opts, termcharts.WithColorByLabel(true))
+	}
+
+	// Apply text summary
+	describe, err := describeOption(pieDescribe)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, describe)
+
+	// Apply number locale
+	locale, err := localeOption()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, locale)
+
+	// Apply input limits
+	limits, err := limitsOption(pieWidth, 0)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, limits)
 
 	// Create and render pie chart
 	pie := termcharts.NewPieChart(opts...)
-	fmt.Print(pie.Render())
-
-	return nil
+	return pie.RenderTo(os.Stdout)
 }
 
 // parsePieData parses data from command-line args, files, or stdin.
-func parsePieData(args []string) ([]float64, error) {
+func parsePieData(args []string) ([]float64, []string, error) {
+	if csv, err := csvInput(args); err != nil {
+		return nil, nil, err
+	} else if csv {
+		return parseCSVInput(args)
+	}
+
 	// If no args, read from stdin
 	if len(args) == 0 {
-		return readDataFromStdin()
+		data, err := readDataFromStdin()
+		return data, nil, err
 	}
 
 	// If single arg and it's a file, read from file
 	if len(args) == 1 {
 		if fileExists(args[0]) {
-			return readDataFromFile(args[0])
+			data, err := readDataFromFile(args[0])
+			return data, nil, err
 		}
 	}
 
 	// Otherwise, parse args as numbers
-	return parseNumbers(args)
+	data, err := parseNumbers(args)
+	return data, nil, err
 }
 
 // parsePieLabels parses comma-separated labels.
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix starts the name of a plugin: an executable on PATH that adds
+// a chart type to the CLI, so "termcharts gauge 42" runs "termcharts-gauge 42".
+const pluginPrefix = "termcharts-"
+
+// findPlugin returns the path of the plugin for the command named by the
+// first of args, or "" when it names a flag, a command of root, or no
+// installed plugin. Commands of root, including charts registered with
+// termcharts.RegisterChart, take precedence over plugins.
+func findPlugin(root *cobra.Command, args []string) string {
+	if len(args) == 0 || args[0] == "" || strings.HasPrefix(args[0], "-") || strings.ContainsAny(args[0], `/\`) {
+		return ""
+	}
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+	if cmd, _, err := root.Find(args[:1]); err == nil && cmd != root {
+		return ""
+	}
+	path, err := exec.LookPath(pluginPrefix + args[0])
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// runPlugin runs the plugin at path with args and the CLI's standard
+// streams, and returns the exit code it exited with, or exitError when it was
+// killed. A plugin that cannot be started is an error.
+func runPlugin(path string, args []string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if code := exitErr.ExitCode(); code >= 0 {
+			return code, nil
+		}
+		return exitError, nil
+	}
+	if err != nil {
+		return exitError, withExit(exitError, fmt.Errorf("failed to run plugin %s: %w", path, err))
+	}
+	return 0, nil
+}
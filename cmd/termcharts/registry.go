@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+	"github.com/spf13/cobra"
+)
+
+// addRegisteredCharts adds a command for every chart type registered with
+// termcharts.RegisterChart that does not already have a dedicated command.
+// This lets a fork that links in more chart types expose them without
+// patching the commands; a separate program adds one as a plugin instead.
+func addRegisteredCharts(root *cobra.Command) {
+	existing := make(map[string]bool)
+	for _, cmd := range root.Commands() {
+		existing[cmd.Name()] = true
+		for _, alias := range cmd.Aliases {
+			existing[alias] = true
+		}
+	}
+
+	for _, name := range termcharts.RegisteredCharts() {
+		if existing[name] {
+			continue
+		}
+		factory, _ := termcharts.LookupChart(name)
+		root.AddCommand(newChartCommand(name, factory))
+	}
+}
+
+// newChartCommand builds a generic command for a registered chart type.
+// It supports the options shared by all charts.
+func newChartCommand(name string, factory termcharts.ChartFactory) *cobra.Command {
+	var (
+		width     int
+		height    int
+		color     bool
+		ascii     bool
+		noColor   bool
+		title     string
+		labels    string
+		themeName string
+		describe  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   name + " [values...]",
+		Short: fmt.Sprintf("Create a %s chart", name),
+		Long: fmt.Sprintf(`Create a %s chart.
+
+This command builds the chart registered with termcharts under this name,
+with the options shared by all charts.
+
+Data can be provided as:
+  - Command-line arguments: termcharts %[1]s 10 20 30 25
+  - File path: termcharts %[1]s data.txt
+  - Stdin: cat data.txt | termcharts %[1]s`, name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			summary, err := newColumnSummary(args, title)
+			if err != nil {
+				return err
+			}
+			data, csvLabels, err := parseSparklineData(args)
+			if err != nil {
+				return parseFailed(err)
+			}
+
+			if len(data) == 0 {
+				return errNoData
+			}
+			summary.set(data)
+
+			opts := []termcharts.Option{
+				termcharts.WithData(data),
+				termcharts.WithTheme(getTheme(themeName)),
+			}
+			if width > 0 {
+				opts = append(opts, termcharts.WithWidth(width))
+			}
+			if height > 0 {
+				opts = append(opts, termcharts.WithHeight(height))
+			}
+			if title != "" {
+				opts = append(opts, termcharts.WithTitle(title))
+			}
+			if summary != nil {
+				opts = append(opts, summary.option(true))
+			}
+			if labels != "" {
+				opts = append(opts, termcharts.WithLabels(parseLabels(labels)))
+			} else if csvLabels != nil {
+				opts = append(opts, termcharts.WithLabels(csvLabels))
+			}
+			if ascii {
+				opts = append(opts, termcharts.WithStyle(termcharts.StyleASCII))
+			}
+			if noColor {
+				opts = append(opts, termcharts.WithColor(false))
+			} else if color {
+				opts = append(opts, termcharts.WithColor(true))
+			}
+			describeOpt, err := describeOption(describe)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, describeOpt)
+			localeOpt, err := localeOption()
+			if err != nil {
+				return err
+			}
+			opts = append(opts, localeOpt)
+			limitsOpt, err := limitsOption(width, height)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, limitsOpt)
+
+			return renderChart(cmd, factory(opts...))
+		},
+	}
+
+	cmd.Flags().IntVarP(&width, "width", "w", 0, "chart width in characters (0 = default)")
+	cmd.Flags().IntVar(&height, "height", 0, "chart height in rows (0 = default)")
+	cmd.Flags().BoolVarP(&color, "color", "c", false, "enable colored output")
+	cmd.Flags().BoolVar(&ascii, "ascii", false, "use ASCII characters only")
+	cmd.Flags().BoolVar(&noColor, "no-color", false, "disable colored output")
+	cmd.Flags().StringVarP(&title, "title", "t", "", "chart title")
+	cmd.Flags().StringVarP(&labels, "labels", "l", "", "comma-separated labels")
+	cmd.Flags().StringVar(&themeName, "theme", "default", "color theme (default, dark, light, mono)")
+	addDescribeFlag(cmd, &describe)
+
+	return cmd
+}
+
+// renderChart writes a chart to the command's output, reporting render
+// errors for charts that implement termcharts.ChartE.
+func renderChart(cmd *cobra.Command, chart termcharts.Chart) error {
+	var out string
+	if ce, ok := chart.(termcharts.ChartE); ok {
+		var err error
+		if out, err = ce.RenderE(); err != nil {
+			return err
+		}
+	} else {
+		out = chart.Render()
+	}
+
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	fmt.Fprint(cmd.OutOrStdout(), out)
+	return nil
+}
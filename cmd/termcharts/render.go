@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renderWidth   int
+	renderHeight  int
+	renderColor   bool
+	renderNoColor bool
+	renderASCII   bool
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render [spec.json]",
+	Short: "Render a chart from a JSON chart spec",
+	Long: `Render a chart described by a JSON chart spec.
+
+A spec names the chart type and holds its data, labels, and styling, so
+other tools can generate charts without building argument lists:
+
+  {
+    "type": "bar",
+    "title": "Regional Sales",
+    "data": [120, 98, 145],
+    "labels": ["North", "South", "East"],
+    "showValues": true,
+    "theme": "dark"
+  }
+
+Fields: type, title, data, series (label, data, color, hidden, upper,
+lower), labels, width, height, style, theme, color, colorByLabel,
+showValues, locale, direction, barMode, and showLegend. The type is any chart the CLI knows:
+bar, line, pie, histogram, box, spark, kpi, or a registered extension.
+
+The spec is read from a file, or from stdin. Flags override the spec.
+
+Examples:
+  # Render a spec file
+  termcharts render sales.json
+
+  # Render a spec generated by another tool
+  report --json | termcharts render --width 60`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRender,
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.Flags().IntVarP(&renderWidth, "width", "w", 0, "chart width in characters, in place of the spec's (0 = the spec's)")
+	renderCmd.Flags().IntVar(&renderHeight, "height", 0, "chart height in rows, in place of the spec's (0 = the spec's)")
+	renderCmd.Flags().BoolVarP(&renderColor, "color", "c", false, "enable colored output")
+	renderCmd.Flags().BoolVar(&renderNoColor, "no-color", false, "disable colored output")
+	renderCmd.Flags().BoolVar(&renderASCII, "ascii", false, "use ASCII characters only")
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	spec, err := readSpec(args)
+	if err != nil {
+		return err
+	}
+
+	// Flags override the spec
+	var opts []termcharts.Option
+	if renderWidth > 0 {
+		opts = append(opts, termcharts.WithWidth(renderWidth))
+	}
+	if renderHeight > 0 {
+		opts = append(opts, termcharts.WithHeight(renderHeight))
+	}
+	if renderASCII {
+		opts = append(opts, termcharts.WithStyle(termcharts.StyleASCII))
+	}
+	if renderNoColor {
+		opts = append(opts, termcharts.WithColor(false))
+	} else if renderColor {
+		opts = append(opts, termcharts.WithColor(true))
+	}
+	if numberLocale != "" {
+		locale, err := localeOption()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, locale)
+	}
+
+	// The chart limits the points of the spec, which the CLI does not read
+	// one by one
+	if _, err := limitsOption(renderWidth, renderHeight); err != nil {
+		return err
+	}
+	opts = append(opts, termcharts.WithLimits(inputLimits))
+
+	chart, err := termcharts.FromSpec(spec, termcharts.Combine(opts...))
+	if err != nil {
+		return err
+	}
+	return termcharts.RenderTo(os.Stdout, chart)
+}
+
+// readSpec reads the chart spec from the file in args, or from stdin.
+func readSpec(args []string) ([]byte, error) {
+	if len(args) == 1 {
+		spec, err := os.ReadFile(args[0])
+		if err != nil {
+			return nil, withExit(exitParse, fmt.Errorf("failed to read spec: %w", err))
+		}
+		return spec, nil
+	}
+
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if (stat.Mode() & os.ModeCharDevice) != 0 {
+		return nil, fmt.Errorf("%w: pass a spec file or pipe a spec to stdin", errNoData)
+	}
+	spec, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, withExit(exitParse, fmt.Errorf("failed to read spec: %w", err))
+	}
+	return spec, nil
+}
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
 	"github.com/spf13/cobra"
 )
 
@@ -24,11 +28,97 @@ Examples:
   cat data.txt | termcharts spark
 
   # Create a bar chart
-  termcharts bar 10 20 30 25 --labels "Q1,Q2,Q3,Q4"`,
+  termcharts bar 10 20 30 25 --labels "Q1,Q2,Q3,Q4"
+
+Plugins add chart types: "termcharts NAME args..." runs the executable
+termcharts-NAME on PATH with the arguments when there is no command NAME.`,
 	Version: "0.1.0",
+	// Errors are reported by main, per --quiet and --json-errors
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
+// numberLocale is the --locale flag shared by all chart commands.
+var numberLocale string
+
+// inputLimits are the --max-points, --max-width, and --max-height flags
+// shared by all chart commands, which guard against input too large to chart.
+var inputLimits termcharts.Limits
+
 func init() {
-	// Global flags can be added here
-	// rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&numberLocale, "locale", "", "number format for values and axis labels, e.g. de-DE or fr-FR")
+	rootCmd.PersistentFlags().IntVar(&inputLimits.MaxPoints, "max-points", termcharts.DefaultMaxPoints, "most numbers to read from a file or stdin (negative = no limit)")
+	rootCmd.PersistentFlags().IntVar(&inputLimits.MaxWidth, "max-width", termcharts.DefaultMaxWidth, "widest --width allowed, in columns (negative = no limit)")
+	rootCmd.PersistentFlags().IntVar(&inputLimits.MaxHeight, "max-height", termcharts.DefaultMaxHeight, "tallest --height allowed, in rows (negative = no limit)")
+}
+
+// addDescribeFlag registers the --describe flag on cmd. Given alone it
+// appends a text summary; --describe=only prints the summary instead of
+// the chart.
+func addDescribeFlag(cmd *cobra.Command, mode *string) {
+	cmd.Flags().StringVar(mode, "describe", "", "add a text summary of the data for screen readers and logs (append, only)")
+	cmd.Flags().Lookup("describe").NoOptDefVal = "append"
+}
+
+// addUnitFlag registers the --unit flag on cmd, for a unit appended to
+// values and axis labels.
+func addUnitFlag(cmd *cobra.Command, unit *string) {
+	cmd.Flags().StringVar(unit, "unit", "", "unit appended to values and axis labels, e.g. ms or req/s")
+}
+
+// localeOption returns the option for the --locale flag.
+func localeOption() (termcharts.Option, error) {
+	if numberLocale != "" && !termcharts.LocaleSupported(numberLocale) {
+		return nil, fmt.Errorf("unsupported --locale value %q (supported: %s)", numberLocale, strings.Join(termcharts.Locales(), ", "))
+	}
+	return termcharts.WithLocale(numberLocale), nil
+}
+
+// limitsOption returns the option for the size limit flags, after checking
+// the --width and --height flags against them. --max-points limits the
+// numbers read rather than the points charted, since line charts and
+// sparklines reduce their input while reading.
+func limitsOption(width, height int) (termcharts.Option, error) {
+	if max := effectiveLimit(inputLimits.MaxWidth, termcharts.DefaultMaxWidth); max > 0 && width > max {
+		return nil, withExit(exitLimit, fmt.Errorf("--width %d exceeds the limit of %d columns (raise it with --max-width)", width, max))
+	}
+	if max := effectiveLimit(inputLimits.MaxHeight, termcharts.DefaultMaxHeight); max > 0 && height > max {
+		return nil, withExit(exitLimit, fmt.Errorf("--height %d exceeds the limit of %d rows (raise it with --max-height)", height, max))
+	}
+	limits := inputLimits
+	limits.MaxPoints = -1
+	return termcharts.WithLimits(limits), nil
+}
+
+// maxInputPoints returns the most numbers to read from a file or stdin
+// (0 = no limit).
+func maxInputPoints() int {
+	return effectiveLimit(inputLimits.MaxPoints, termcharts.DefaultMaxPoints)
+}
+
+// effectiveLimit returns the limit a flag sets, like termcharts.Limits: 0 is
+// the default, and a negative value is no limit, returned as 0.
+func effectiveLimit(value, def int) int {
+	switch {
+	case value > 0:
+		return value
+	case value < 0:
+		return 0
+	default:
+		return def
+	}
+}
+
+// describeOption returns the option for a --describe flag value.
+func describeOption(mode string) (termcharts.Option, error) {
+	switch mode {
+	case "":
+		return termcharts.WithTextSummaryMode(termcharts.TextSummaryOff), nil
+	case "append":
+		return termcharts.WithTextSummaryMode(termcharts.TextSummaryAppend), nil
+	case "only":
+		return termcharts.WithTextSummaryMode(termcharts.TextSummaryOnly), nil
+	default:
+		return nil, fmt.Errorf("invalid --describe value %q (use append or only)", mode)
+	}
 }
@@ -3,6 +3,8 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -12,10 +14,26 @@ import (
 )
 
 var (
-	sparkWidth   int
-	sparkColor   bool
-	sparkASCII   bool
-	sparkNoColor bool
+	sparkWidth      int
+	sparkColor      bool
+	sparkASCII      bool
+	sparkNoColor    bool
+	sparkStats      bool
+	sparkTrend      bool
+	sparkZero       bool
+	sparkPercent    bool
+	sparkMin        float64
+	sparkMax        float64
+	sparkExtremes   bool
+	sparkThresholds string
+	sparkChars      string
+	sparkFollow     bool
+	sparkFPS        int
+	sparkWatch      watchFlags
+	sparkDescribe   string
+	sparkSeries     string
+	sparkOverlay    bool
+	sparkUnit       string
 )
 
 var sparkCmd = &cobra.Command{
@@ -30,6 +48,7 @@ Data can be provided as:
   - Command-line arguments: termcharts spark 10 20 30 25 15
   - File path: termcharts spark data.txt
   - Stdin: cat data.txt | termcharts spark
+  - CSV: termcharts spark sales.csv --value-col sales
 
 Data format:
   - One number per line, or
@@ -53,7 +72,32 @@ Examples:
   termcharts spark 10 20 30 --ascii
 
   # With color
-  termcharts spark 10 20 30 --color`,
+  termcharts spark 10 20 30 --color
+
+  # Color values by ok/warn/crit thresholds
+  termcharts spark 10 75 95 50 --color --thresholds 0=green,70=yellow,90=red
+
+  # Highlight the highest and lowest points
+  termcharts spark 3 9 1 5 --color --extremes
+
+  # Scaled from zero, so small fluctuations stay small
+  termcharts spark 98 99 97 100 --zero
+
+  # Scaled from 0 to 100, so sparklines from different hosts compare
+  termcharts spark 42 57 61 --min 0 --max 100
+
+  # With min, max, and last values and the last change, e.g. ▼ -58.2%
+  termcharts spark 1.2 5 9.8 4.1 --stats --trend
+
+  # One labeled sparkline per series, or all of them in one row of Braille
+  termcharts spark --series '[{"label":"p50","data":[12,14,13]},{"label":"p99","data":[40,95,52]}]'
+  termcharts spark --series '[{"label":"p50","data":[12,14,13]},{"label":"p99","data":[40,95,52]}]' --overlay --color
+
+  # Redraw the last 40 values as they arrive on a pipe
+  vmstat 1 | awk '{ print $15; fflush() }' | termcharts spark --follow --width 40
+
+  # Redraw every 5 seconds with the output of a command
+  termcharts spark --watch --interval 5s --exec "cut -d' ' -f1 /proc/loadavg"`,
 	RunE: runSparkline,
 }
 
@@ -64,26 +108,96 @@ func init() {
 	sparkCmd.Flags().BoolVarP(&sparkColor, "color", "c", false, "enable colored output")
 	sparkCmd.Flags().BoolVar(&sparkASCII, "ascii", false, "use ASCII characters only")
 	sparkCmd.Flags().BoolVar(&sparkNoColor, "no-color", false, "disable colored output")
+	sparkCmd.Flags().BoolVar(&sparkStats, "stats", false, "show the min, max, and last values after the sparkline")
+	sparkCmd.Flags().BoolVar(&sparkTrend, "trend", false, "show an arrow and the percent change for the last change")
+	sparkCmd.Flags().BoolVar(&sparkZero, "zero", false, "scale from zero instead of the data minimum")
+	sparkCmd.Flags().Float64Var(&sparkMin, "min", 0, "value drawn as the lowest character, for a scale shared across sparklines (with --max)")
+	sparkCmd.Flags().Float64Var(&sparkMax, "max", 0, "value drawn as the highest character, for a scale shared across sparklines (with --min)")
+	sparkCmd.Flags().BoolVar(&sparkPercent, "percent", false, "scale from 0 to 100% and show stats as percentages (values within [-1, 1] are fractions)")
+	sparkCmd.Flags().BoolVar(&sparkExtremes, "extremes", false, "color the highest point red and the lowest blue (with --color)")
+	sparkCmd.Flags().StringVar(&sparkThresholds, "thresholds", "", "color values at or above each threshold, e.g. 0=green,70=yellow,90=red (with --color)")
+	sparkCmd.Flags().StringVar(&sparkChars, "chars", "", "characters to draw with, lowest first, e.g. \" ░▒▓█\"")
+	sparkCmd.Flags().BoolVar(&sparkFollow, "follow", false, "keep reading stdin or a named pipe and redraw as values arrive")
+	sparkCmd.Flags().IntVar(&sparkFPS, "fps", defaultFollowFPS, "most redraws per second with --follow")
+	sparkCmd.Flags().StringVar(&sparkSeries, "series", "", "JSON array of series, one sparkline each: [{\"label\":\"name\",\"data\":[1,2,3]}]")
+	sparkCmd.Flags().BoolVar(&sparkOverlay, "overlay", false, "draw the series over each other in one row of Braille dots (with --series)")
+	addWatchFlags(sparkCmd, &sparkWatch)
+	addDescribeFlag(sparkCmd, &sparkDescribe)
+	addUnitFlag(sparkCmd, &sparkUnit)
 }
 
 func runSparkline(cmd *cobra.Command, args []string) error {
-	// Parse data from various sources
-	data, err := parseSparklineData(args)
+	// Summarizing needs a CSV column
+	summary, err := newColumnSummary(args, "")
 	if err != nil {
-		return fmt.Errorf("failed to parse data: %w", err)
+		return err
 	}
 
-	if len(data) == 0 {
-		return fmt.Errorf("no data provided")
+	// Watching stdin follows it; watching a file or command re-reads it
+	if err := sparkWatch.check(); err != nil {
+		return err
+	}
+	if sparkFollow && sparkWatch.watch {
+		return fmt.Errorf("--follow and --watch cannot be combined")
+	}
+	if sparkSeries != "" && (sparkFollow || sparkWatch.watch) {
+		return fmt.Errorf("--series cannot be combined with --follow or --watch")
+	}
+	if sparkOverlay && sparkSeries == "" {
+		return fmt.Errorf("--overlay needs --series")
+	}
+	follow := sparkFollow || sparkWatch.stdin(args)
+	var source termcharts.DataSource
+	if sparkWatch.watch && !follow {
+		var err error
+		if source, err = sparkWatch.source(args); err != nil {
+			return err
+		}
+	}
+
+	// Parse data from various sources
+	// Reduce the input of a width-limited sparkline while reading;
+	// summaries need every value
+	maxPoints := 0
+	if sparkWidth > 0 && sparkDescribe == "" {
+		maxPoints = streamPointsPerColumn * sparkWidth
+	}
+	var data []float64
+	var series []termcharts.Series
+	if sparkSeries != "" {
+		var err error
+		if series, err = parseSeriesJSON(sparkSeries); err != nil {
+			return withExit(exitParse, fmt.Errorf("failed to parse series JSON: %w", err))
+		}
+		if len(series) == 0 {
+			return errNoData
+		}
+	} else if !follow && source == nil {
+		var err error
+		data, _, err = parseReducedData(args, maxPoints)
+		if err != nil {
+			return parseFailed(err)
+		}
+
+		if len(data) == 0 {
+			return errNoData
+		}
+		summary.set(data)
 	}
 
 	// Build options
-	opts := []termcharts.Option{
+	opts := []termcharts.SparklineOption{
 		termcharts.WithData(data),
 	}
+	if series != nil {
+		opts = []termcharts.SparklineOption{
+			termcharts.WithSeries(series),
+			termcharts.WithSparkOverlay(sparkOverlay),
+		}
+	}
 
-	// Apply width if specified
-	if sparkWidth > 0 {
+	// Apply width; 0 draws every point
+	if sparkWidth >= 0 {
 		opts = append(opts, termcharts.WithWidth(sparkWidth))
 	}
 
@@ -91,6 +205,13 @@ func runSparkline(cmd *cobra.Command, args []string) error {
 	if sparkASCII {
 		opts = append(opts, termcharts.WithStyle(termcharts.StyleASCII))
 	}
+	if sparkChars != "" {
+		chars := []rune(sparkChars)
+		if len(chars) < 2 {
+			return fmt.Errorf("--chars needs at least two characters, got %q", sparkChars)
+		}
+		opts = append(opts, termcharts.WithSparkChars(chars))
+	}
 
 	// Apply color settings
 	if sparkNoColor {
@@ -101,72 +222,203 @@ func runSparkline(cmd *cobra.Command, args []string) error {
 		opts = append(opts, termcharts.WithColor(colorEnabled))
 	}
 
-	// Create and render sparkline
+	// Apply baseline
+	if sparkZero {
+		opts = append(opts, termcharts.WithBaseline(termcharts.BaselineZero))
+	}
+	if sparkPercent {
+		opts = append(opts, termcharts.WithPercentAxis(true))
+	}
+
+	// Apply a fixed range; values outside it are clamped
+	if cmd.Flags().Changed("min") || cmd.Flags().Changed("max") {
+		if !cmd.Flags().Changed("min") || !cmd.Flags().Changed("max") {
+			return fmt.Errorf("--min and --max must be set together")
+		}
+		if sparkMax <= sparkMin {
+			return fmt.Errorf("--max (%g) must be greater than --min (%g)", sparkMax, sparkMin)
+		}
+		opts = append(opts, termcharts.WithYAxis(termcharts.AxisConfig{Min: sparkMin, Max: sparkMax}))
+	}
+
+	// Apply thresholds
+	if sparkThresholds != "" {
+		thresholds, err := parseThresholds(sparkThresholds)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, termcharts.WithThresholds(thresholds))
+	}
+
+	// Apply extreme highlights
+	if sparkExtremes {
+		opts = append(opts, termcharts.WithSparkExtremes("red", "blue"))
+	}
+
+	// Apply stats
+	opts = append(opts, termcharts.WithSparkStats(sparkStats), termcharts.WithSparkTrend(sparkTrend))
+
+	// Apply text summary
+	describe, err := describeOption(sparkDescribe)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, describe)
+
+	// Apply number locale and unit
+	locale, err := localeOption()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, locale, termcharts.WithYUnit(sparkUnit))
+
+	// Apply input limits
+	limits, err := limitsOption(sparkWidth, 0)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, limits)
+	if summary != nil {
+		opts = append(opts, summary.option(false))
+	}
+
+	// Create and render sparkline, redrawing it as data arrives when following
+	// or watching
 	spark := termcharts.NewSparkline(opts...)
-	fmt.Println(spark.Render())
+	if follow {
+		return followChart(spark, args, followWindowFor(sparkWidth), sparkFPS, sparkWatch.highlight)
+	}
+	if source != nil {
+		if summary != nil {
+			source = summary.watch(spark, source, false)
+		}
+		return watchChart(spark, source, &sparkWatch)
+	}
+	if err := spark.RenderTo(os.Stdout); err != nil {
+		return err
+	}
+	// Stacked and overlaid series end their own lines
+	if series == nil {
+		fmt.Println()
+	}
 
 	return nil
 }
 
+// parseThresholds parses comma-separated value=color pairs, such as
+// "0=green,70=yellow,90=red".
+func parseThresholds(s string) (map[float64]string, error) {
+	thresholds := make(map[float64]string)
+	for _, pair := range strings.Split(s, ",") {
+		value, color, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || color == "" {
+			return nil, fmt.Errorf("invalid threshold %q: want value=color", pair)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(v) {
+			return nil, fmt.Errorf("invalid threshold value: %s", value)
+		}
+		thresholds[v] = strings.TrimSpace(color)
+	}
+	return thresholds, nil
+}
+
 // parseSparklineData parses data from command-line args, files, or stdin.
-func parseSparklineData(args []string) ([]float64, error) {
+func parseSparklineData(args []string) ([]float64, []string, error) {
+	if csv, err := csvInput(args); err != nil {
+		return nil, nil, err
+	} else if csv {
+		return parseCSVInput(args)
+	}
+
 	// If no args, read from stdin
 	if len(args) == 0 {
-		return readDataFromStdin()
+		data, err := readDataFromStdin()
+		return data, nil, err
 	}
 
 	// If single arg and it's a file, read from file
 	if len(args) == 1 {
 		if fileExists(args[0]) {
-			return readDataFromFile(args[0])
+			data, err := readDataFromFile(args[0])
+			return data, nil, err
 		}
 	}
 
 	// Otherwise, parse args as numbers
-	return parseNumbers(args)
+	data, err := parseNumbers(args)
+	return data, nil, err
 }
 
-// readDataFromStdin reads numeric data from stdin.
-func readDataFromStdin() ([]float64, error) {
-	stat, err := os.Stdin.Stat()
+// parseReducedData parses data like parseSparklineData, but reduces numbers
+// read from a file or stdin to at most maxPoints points while reading, so
+// charting a file with millions of rows stays fast and memory-bounded, and
+// is not subject to --max-points. maxPoints <= 0 keeps every number, as does
+// CSV input, whose labels would no longer match the points.
+func parseReducedData(args []string, maxPoints int) ([]float64, []string, error) {
+	csv, err := csvInput(args)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	streamed := len(args) == 0 || (len(args) == 1 && fileExists(args[0]))
+	if maxPoints <= 0 || !streamed || csv {
+		return parseSparklineData(args)
 	}
 
-	// Check if stdin has data
-	if (stat.Mode() & os.ModeCharDevice) != 0 {
-		return nil, fmt.Errorf("no data provided via stdin")
+	reducer := termcharts.NewReducer(maxPoints)
+	if len(args) == 0 {
+		err = streamDataFromStdin(0, reducer.Add)
+	} else {
+		err = streamDataFromFile(args[0], 0, reducer.Add)
 	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return reducer.Points(), nil, nil
+}
 
+// readDataFromStdin reads numeric data from stdin.
+func readDataFromStdin() ([]float64, error) {
 	var data []float64
-	scanner := bufio.NewScanner(os.Stdin)
+	err := streamDataFromStdin(maxInputPoints(), func(v float64) {
+		data = append(data, v)
+	})
+	return data, err
+}
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
+// readDataFromFile reads numeric data from a file.
+func readDataFromFile(filename string) ([]float64, error) {
+	var data []float64
+	err := streamDataFromFile(filename, maxInputPoints(), func(v float64) {
+		data = append(data, v)
+	})
+	return data, err
+}
 
-		// Try to parse as space-separated or comma-separated numbers
-		nums, err := parseNumberLine(line)
-		if err != nil {
-			return nil, fmt.Errorf("invalid data on line: %s", line)
-		}
-		data = append(data, nums...)
+// streamDataFromStdin passes each number read from stdin to add, one line
+// at a time, without holding the whole input in memory. It fails after max
+// numbers, unless max is 0.
+func streamDataFromStdin(max int, add func(float64)) error {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return err
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	// Check if stdin has data
+	if (stat.Mode() & os.ModeCharDevice) != 0 {
+		return fmt.Errorf("%w via stdin", errNoData)
 	}
 
-	return data, nil
+	return scanNumbers(os.Stdin, "", max, add)
 }
 
-// readDataFromFile reads numeric data from a file.
-func readDataFromFile(filename string) ([]float64, error) {
+// streamDataFromFile passes each number read from a file to add, one line
+// at a time, without holding the whole file in memory. It fails after max
+// numbers, unless max is 0.
+func streamDataFromFile(filename string, max int, add func(float64)) error {
 	file, err := os.Open(filename) // #nosec G304 - filename is provided by user via CLI
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer func() {
 		if closeErr := file.Close(); closeErr != nil {
@@ -175,28 +427,44 @@ func readDataFromFile(filename string) ([]float64, error) {
 		}
 	}()
 
-	var data []float64
-	scanner := bufio.NewScanner(file)
+	return scanNumbers(file, filename, max, add)
+}
+
+// scanNumbers reads lines of numbers from r and passes each number to add.
+// Files, named by filename, may contain comment lines starting with "#";
+// stdin is read with an empty filename. Input with more than max numbers
+// fails as soon as the limit is passed, unless max is 0.
+func scanNumbers(r io.Reader, filename string, max int, add func(float64)) error {
+	scanner := bufio.NewScanner(r)
+	count := 0
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue // Skip empty lines and comments
+		if line == "" {
+			continue
+		}
+		if filename != "" && strings.HasPrefix(line, "#") {
+			continue // Skip comments in files
 		}
 
 		// Try to parse as space-separated or comma-separated numbers
 		nums, err := parseNumberLine(line)
 		if err != nil {
-			return nil, fmt.Errorf("invalid data in file %s: %s", filename, line)
+			if filename == "" {
+				return fmt.Errorf("invalid data on line: %s", line)
+			}
+			return fmt.Errorf("invalid data in file %s: %s", filename, line)
+		}
+		count += len(nums)
+		if max > 0 && count > max {
+			return fmt.Errorf("%w: input has more than %d numbers (raise the limit with --max-points)", termcharts.ErrLimitExceeded, max)
+		}
+		for _, n := range nums {
+			add(n)
 		}
-		data = append(data, nums...)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
 	}
 
-	return data, nil
+	return scanner.Err()
 }
 
 // parseNumberLine parses a line containing space-separated or comma-separated numbers.
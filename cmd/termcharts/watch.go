@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+	"github.com/spf13/cobra"
+)
+
+// defaultWatchInterval is the default of the --interval flag.
+const defaultWatchInterval = 2 * time.Second
+
+// watchFlags are the --watch, --interval, --exec, and --highlight flags of a
+// chart command.
+type watchFlags struct {
+	watch     bool
+	interval  time.Duration
+	command   string
+	highlight bool
+}
+
+// addWatchFlags registers the watch flags on cmd.
+func addWatchFlags(cmd *cobra.Command, w *watchFlags) {
+	cmd.Flags().BoolVar(&w.watch, "watch", false, "redraw the chart in place every --interval, re-reading the file or re-running --exec")
+	cmd.Flags().DurationVar(&w.interval, "interval", defaultWatchInterval, "time between redraws with --watch, e.g. 500ms or 5s")
+	cmd.Flags().StringVar(&w.command, "exec", "", "shell command whose output is charted, re-run every --interval (with --watch)")
+	cmd.Flags().BoolVar(&w.highlight, "highlight", false, "show what changed since the last redraw in reverse video (with --watch)")
+}
+
+// stdin reports whether the chart watches stdin: --watch with no file,
+// values, or command, which follows stdin like --follow.
+func (w *watchFlags) stdin(args []string) bool {
+	return w.watch && w.command == "" && len(args) == 0
+}
+
+// source returns the data source of a watched chart: the --exec command, or
+// the file in args.
+func (w *watchFlags) source(args []string) (termcharts.DataSource, error) {
+	if w.interval <= 0 {
+		return nil, fmt.Errorf("--interval must be positive, got %v", w.interval)
+	}
+	switch {
+	case w.command != "" && len(args) > 0:
+		return nil, fmt.Errorf("--exec charts the output of its command; remove the other values or files")
+	case w.command != "":
+		command := w.command
+		return func() ([]float64, error) { return runCommandData(command) }, nil
+	case len(args) == 1 && fileExists(args[0]):
+		file := args[0]
+		csv, err := csvInput(args)
+		if err != nil {
+			return nil, err
+		}
+		return func() ([]float64, error) {
+			var data []float64
+			var err error
+			if csv {
+				data, _, err = parseCSVInput([]string{file})
+			} else {
+				data, err = readDataFromFile(file)
+			}
+			if err != nil {
+				return nil, parseFailed(err)
+			}
+			return data, nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("--watch re-reads a single file, re-runs an --exec command, or follows stdin, not values")
+	}
+}
+
+// check returns an error for watch flags given without --watch.
+func (w *watchFlags) check() error {
+	if !w.watch && w.command != "" {
+		return fmt.Errorf("--exec needs --watch")
+	}
+	if !w.watch && w.highlight {
+		return fmt.Errorf("--highlight needs --watch")
+	}
+	return nil
+}
+
+// runCommandData runs command with the shell and parses the numbers, or with
+// CSV input the CSV, it writes to stdout.
+func runCommandData(command string) ([]float64, error) {
+	out, err := exec.Command("sh", "-c", command).Output() // #nosec G204 - command is provided by user via CLI
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, withExit(exitError, fmt.Errorf("--exec command failed: %v: %s", err, strings.TrimSpace(string(exitErr.Stderr))))
+		}
+		return nil, withExit(exitError, fmt.Errorf("--exec command failed: %w", err))
+	}
+
+	var data []float64
+	if csv, csvErr := csvInput(nil); csvErr != nil {
+		return nil, csvErr
+	} else if csv {
+		data, _, err = readCSV(bytes.NewReader(out), csvDelimiter(""))
+	} else {
+		err = scanNumbers(bytes.NewReader(out), "", maxInputPoints(), func(v float64) {
+			data = append(data, v)
+		})
+	}
+	if err != nil {
+		return nil, parseFailed(err)
+	}
+	return data, nil
+}
+
+// watchChart redraws chart in place with data from source every --interval,
+// starting right away, until interrupted. Unchanged data is not drawn
+// again. With --highlight the cells that changed are shown in reverse video
+// until the next redraw.
+func watchChart(chart termcharts.Updatable, source termcharts.DataSource, w *watchFlags) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	live := termcharts.NewLiveRenderer(os.Stdout)
+	live.Cache = termcharts.NewRenderCache(1)
+	live.Highlight = w.highlight
+	return live.Run(ctx, chart, source, w.interval)
+}
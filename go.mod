@@ -3,6 +3,8 @@ module github.com/neilpeterson/termcharts
 go 1.19
 
 require (
+	github.com/mattn/go-runewidth v0.0.15
+	github.com/rivo/uniseg v0.2.0
 	github.com/spf13/cobra v1.10.2
 	golang.org/x/term v0.15.0
 )
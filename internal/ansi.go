@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// Cell is a single visible character of rendered output together with the
+// SGR escape sequence(s) in effect when it is drawn.
+type Cell struct {
+	Rune  rune
+	Style string
+}
+
+// ParseCells splits a single line of ANSI-colored text into cells.
+// SGR sequences are accumulated until a reset, so each cell carries the full
+// styling needed to redraw it in isolation. Other escape sequences are dropped.
+func ParseCells(line string) []Cell {
+	cells := make([]Cell, 0, len(line))
+	style := ""
+	for i := 0; i < len(line); {
+		if seq, n := escapeAt(line, i); n > 0 {
+			if strings.HasSuffix(seq, "m") {
+				if isReset(seq) {
+					style = ""
+				} else {
+					style += seq
+				}
+			}
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		cells = append(cells, Cell{Rune: r, Style: style})
+		i += size
+	}
+	return cells
+}
+
+// StripANSI removes all escape sequences from s.
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\033") {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		if _, n := escapeAt(s, i); n > 0 {
+			i += n
+			continue
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
+
+// TruncateANSI shortens line to at most width columns without splitting an
+// escape sequence or a grapheme cluster. Escape sequences before the cut are
+// kept, and a line cut while styled ends with a reset so the style does not
+// leak into what follows.
+func TruncateANSI(line string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return cutANSI(line, width, true)[0]
+}
+
+// WrapANSI splits line into lines of at most width columns without splitting
+// an escape sequence or a grapheme cluster. Each line that ends styled is
+// closed with a reset, and the next one starts with the SGR sequences in
+// effect, so every line can be drawn on its own. A character wider than width
+// gets a line of its own. A width below 1 leaves line whole.
+func WrapANSI(line string, width int) []string {
+	if width <= 0 {
+		return []string{line}
+	}
+	return cutANSI(line, width, false)
+}
+
+// cutANSI breaks line before every character that would end past width
+// columns, stopping after the first line if truncate is set.
+func cutANSI(line string, width int, truncate bool) []string {
+	var lines []string
+	var b strings.Builder
+	b.Grow(len(line))
+	style := ""
+	col := 0
+	for i := 0; i < len(line); {
+		if seq, n := escapeAt(line, i); n > 0 {
+			b.WriteString(seq)
+			if strings.HasSuffix(seq, "m") {
+				if isReset(seq) {
+					style = ""
+				} else {
+					style += seq
+				}
+			}
+			i += n
+			continue
+		}
+
+		// Plain text runs to the next escape sequence; a lone escape byte
+		// is treated as text
+		end := len(line)
+		if j := strings.IndexByte(line[i+1:], '\033'); j >= 0 {
+			end = i + 1 + j
+		}
+		g := uniseg.NewGraphemes(line[i:end])
+		for g.Next() {
+			cluster := g.Str()
+			w := widthCondition.StringWidth(cluster)
+			if col+w > width && (col > 0 || truncate) {
+				if style != "" {
+					b.WriteString("\033[0m")
+				}
+				lines = append(lines, b.String())
+				if truncate {
+					return lines
+				}
+				b.Reset()
+				b.WriteString(style)
+				col = 0
+			}
+			b.WriteString(cluster)
+			col += w
+		}
+		i = end
+	}
+	return append(lines, b.String())
+}
+
+// escapeAt returns the CSI escape sequence starting at s[i] and its length,
+// or ("", 0) if there is none.
+func escapeAt(s string, i int) (string, int) {
+	if s[i] != '\033' || i+1 >= len(s) || s[i+1] != '[' {
+		return "", 0
+	}
+	for j := i + 2; j < len(s); j++ {
+		// Final byte of a CSI sequence is in the range 0x40-0x7E
+		if s[j] >= 0x40 && s[j] <= 0x7E {
+			return s[i : j+1], j + 1 - i
+		}
+	}
+	return "", 0
+}
+
+// isReset reports whether an SGR sequence resets all attributes.
+func isReset(seq string) bool {
+	return seq == "\033[0m" || seq == "\033[m"
+}
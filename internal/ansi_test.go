@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected []Cell
+	}{
+		{
+			name:     "plain text",
+			line:     "ab",
+			expected: []Cell{{Rune: 'a'}, {Rune: 'b'}},
+		},
+		{
+			name: "colored run",
+			line: "\033[31m██\033[0m.",
+			expected: []Cell{
+				{Rune: '█', Style: "\033[31m"},
+				{Rune: '█', Style: "\033[31m"},
+				{Rune: '.'},
+			},
+		},
+		{
+			name: "accumulated attributes",
+			line: "\033[1m\033[34mx\033[0m",
+			expected: []Cell{
+				{Rune: 'x', Style: "\033[1m\033[34m"},
+			},
+		},
+		{
+			name:     "empty line",
+			line:     "",
+			expected: []Cell{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseCells(tt.line)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("ParseCells(%q) returned %d cells, want %d", tt.line, len(result), len(tt.expected))
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("cell %d = %+v, want %+v", i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "no escapes", input: "hello", expected: "hello"},
+		{name: "color codes", input: "\033[31mred\033[0m", expected: "red"},
+		{name: "cursor movement", input: "\033[2Aup\033[K", expected: "up"},
+		{name: "unicode", input: "\033[34m▁▂▃\033[0m", expected: "▁▂▃"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := StripANSI(tt.input); result != tt.expected {
+				t.Errorf("StripANSI(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncateANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		width    int
+		expected string
+	}{
+		{name: "fits", line: "\033[31mab\033[0m", width: 2, expected: "\033[31mab\033[0m"},
+		{name: "cut while styled", line: "\033[31mabc\033[0m", width: 2, expected: "\033[31mab\033[0m"},
+		{name: "cut after reset", line: "\033[31ma\033[0mbc", width: 2, expected: "\033[31ma\033[0mb"},
+		{name: "wide character", line: "a世界", width: 4, expected: "a世"},
+		{name: "grapheme cluster", line: "e\u0301e\u0301", width: 1, expected: "e\u0301"},
+		{name: "zero width", line: "abc", width: 0, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateANSI(tt.line, tt.width); got != tt.expected {
+				t.Errorf("TruncateANSI(%q, %d) = %q, want %q", tt.line, tt.width, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWrapANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		width    int
+		expected []string
+	}{
+		{name: "plain", line: "abcde", width: 2, expected: []string{"ab", "cd", "e"}},
+		{
+			name:     "style carried over",
+			line:     "\033[1m\033[34mabc\033[0md",
+			width:    2,
+			expected: []string{"\033[1m\033[34mab\033[0m", "\033[1m\033[34mc\033[0md"},
+		},
+		{name: "wide character wider than width", line: "世a", width: 1, expected: []string{"世", "a"}},
+		{name: "empty", line: "", width: 3, expected: []string{""}},
+		{name: "no width", line: "abc", width: 0, expected: []string{"abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WrapANSI(tt.line, tt.width); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("WrapANSI(%q, %d) = %q, want %q", tt.line, tt.width, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package internal
+
+// DownsampleIndices returns the indices of at most max points of data that
+// preserve its visual shape. The first and last points are always kept; the
+// points between them are split into equal buckets and the minimum and
+// maximum of each bucket are kept, in their original order, so peaks and
+// dips survive. When data has at most max points, every index is returned.
+// max values below 2 are treated as 2.
+func DownsampleIndices(data []float64, max int) []int {
+	n := len(data)
+	if max < 2 {
+		max = 2
+	}
+	if n <= max {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	indices := make([]int, 0, max)
+	indices = append(indices, 0)
+
+	// Interior points, leaving room for the first and last
+	interior := n - 2
+	buckets := (max - 2) / 2
+	for b := 0; b < buckets; b++ {
+		start := 1 + b*interior/buckets
+		end := 1 + (b+1)*interior/buckets
+		lo, hi := start, start
+		for i := start + 1; i < end; i++ {
+			if data[i] < data[lo] {
+				lo = i
+			}
+			if data[i] > data[hi] {
+				hi = i
+			}
+		}
+		first, second := Min(lo, hi), Max(lo, hi)
+		indices = append(indices, first)
+		if second != first {
+			indices = append(indices, second)
+		}
+	}
+
+	return append(indices, n-1)
+}
+
+// Downsample returns at most max points of data chosen by DownsampleIndices.
+// data itself is returned when it has at most max points.
+func Downsample(data []float64, max int) []float64 {
+	if max < 2 {
+		max = 2
+	}
+	if len(data) <= max {
+		return data
+	}
+	indices := DownsampleIndices(data, max)
+	result := make([]float64, len(indices))
+	for i, idx := range indices {
+		result[i] = data[idx]
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDownsampleIndices(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		max      int
+		expected []int
+	}{
+		{
+			name:     "short data kept",
+			data:     []float64{1, 2, 3},
+			max:      5,
+			expected: []int{0, 1, 2},
+		},
+		{
+			name:     "min and max per bucket",
+			data:     []float64{0, 5, 1, 2, 9, 3, 4, 0},
+			max:      6,
+			expected: []int{0, 1, 2, 4, 5, 7},
+		},
+		{
+			name:     "spike survives",
+			data:     []float64{1, 1, 1, 1, 100, 1, 1, 1, 1, 1},
+			max:      4,
+			expected: []int{0, 1, 4, 9},
+		},
+		{
+			name:     "flat bucket keeps one point",
+			data:     []float64{2, 2, 2, 2, 2, 2},
+			max:      4,
+			expected: []int{0, 1, 5},
+		},
+		{
+			name:     "max below two",
+			data:     []float64{1, 2, 3, 4},
+			max:      0,
+			expected: []int{0, 3},
+		},
+		{
+			name:     "empty",
+			data:     nil,
+			max:      4,
+			expected: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DownsampleIndices(tt.data, tt.max)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("DownsampleIndices(%v, %d) = %v, want %v", tt.data, tt.max, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	data := make([]float64, 100000)
+	for i := range data {
+		data[i] = float64(i % 100)
+	}
+	data[54321] = 1000
+
+	result := Downsample(data, 200)
+	if len(result) > 200 {
+		t.Fatalf("Downsample returned %d points, want at most 200", len(result))
+	}
+	if result[0] != data[0] || result[len(result)-1] != data[len(data)-1] {
+		t.Error("Downsample should keep the first and last points")
+	}
+	min, max := MinMax(result)
+	if min != 0 || max != 1000 {
+		t.Errorf("Downsample range = [%v, %v], want [0, 1000]", min, max)
+	}
+
+	short := []float64{1, 2, 3}
+	if got := Downsample(short, 10); &got[0] != &short[0] {
+		t.Error("Downsample should return short data unchanged")
+	}
+}
@@ -0,0 +1,158 @@
+package internal
+
+// Reducer downsamples a stream of values of unknown length as they arrive,
+// keeping the same shape as Downsample: the first and last values, and the
+// minimum and maximum of each bucket in between, in their original order.
+// Buckets start one value wide and double in width whenever there are too
+// many of them, so memory stays bounded by the number of points kept no
+// matter how long the stream is.
+type Reducer struct {
+	max   int
+	limit int
+	count int
+
+	// raw holds every value until the stream outgrows max.
+	raw []float64
+
+	first, last float64
+	span        int
+	buckets     []bucket
+	cur         bucket
+}
+
+// bucket is the minimum and maximum of a run of consecutive values.
+type bucket struct {
+	n      int
+	lo, hi int
+	loV    float64
+	hiV    float64
+}
+
+// NewReducer returns a Reducer that keeps at most max points.
+// max values below 2 are treated as 2.
+func NewReducer(max int) *Reducer {
+	if max < 2 {
+		max = 2
+	}
+	return &Reducer{
+		max:   max,
+		limit: (max - 2) / 2,
+		raw:   make([]float64, 0, max),
+		span:  1,
+	}
+}
+
+// Add appends v to the stream.
+func (r *Reducer) Add(v float64) {
+	idx := r.count
+	r.count++
+	if r.raw != nil {
+		if len(r.raw) < r.max {
+			r.raw = append(r.raw, v)
+		} else {
+			r.raw = nil
+		}
+	}
+
+	if idx == 0 {
+		r.first = v
+		return
+	}
+	r.last = v
+	if r.limit == 0 {
+		return
+	}
+
+	// Interior values are bucketed; the final value is also the last point,
+	// and appears in a bucket only until the stream grows past it
+	r.cur.add(idx, v)
+	if r.cur.n < r.span {
+		return
+	}
+	r.buckets = append(r.buckets, r.cur)
+	r.cur = bucket{}
+	if len(r.buckets) > r.limit {
+		r.merge()
+	}
+}
+
+// merge halves the number of buckets by combining neighbours and doubling
+// the bucket width. An unpaired last bucket becomes the one being filled.
+func (r *Reducer) merge() {
+	n := len(r.buckets) / 2
+	for i := 0; i < n; i++ {
+		r.buckets[i] = r.buckets[2*i].merge(r.buckets[2*i+1])
+	}
+	if len(r.buckets)%2 == 1 {
+		r.cur = r.buckets[len(r.buckets)-1]
+	}
+	r.buckets = r.buckets[:n]
+	r.span *= 2
+}
+
+// Count returns the number of values added.
+func (r *Reducer) Count() int {
+	return r.count
+}
+
+// Points returns the reduced stream: every value when there are at most max
+// of them, otherwise at most max points chosen as described on Reducer.
+func (r *Reducer) Points() []float64 {
+	if r.raw != nil {
+		points := make([]float64, len(r.raw))
+		copy(points, r.raw)
+		return points
+	}
+
+	points := make([]float64, 0, r.max)
+	points = append(points, r.first)
+	buckets := r.buckets
+	if r.cur.n > 0 {
+		buckets = append(buckets[:len(buckets):len(buckets)], r.cur)
+	}
+	lastIdx := r.count - 1
+	for _, b := range buckets {
+		first, second := b.lo, b.hi
+		firstV, secondV := b.loV, b.hiV
+		if second < first {
+			first, second = second, first
+			firstV, secondV = secondV, firstV
+		}
+		if first != lastIdx {
+			points = append(points, firstV)
+		}
+		if second != first && second != lastIdx {
+			points = append(points, secondV)
+		}
+	}
+	points = append(points, r.last)
+
+	// The unfinished bucket can push the count one bucket over the limit
+	if len(points) > r.max {
+		return Downsample(points, r.max)
+	}
+	return points
+}
+
+// add includes value v at index idx in b.
+func (b *bucket) add(idx int, v float64) {
+	if b.n == 0 || v < b.loV {
+		b.lo, b.loV = idx, v
+	}
+	if b.n == 0 || v > b.hiV {
+		b.hi, b.hiV = idx, v
+	}
+	b.n++
+}
+
+// merge returns a bucket covering the values of b and the following bucket c.
+func (b bucket) merge(c bucket) bucket {
+	if c.loV < b.loV {
+		b.lo, b.loV = c.lo, c.loV
+	}
+	if c.hiV > b.hiV {
+		b.hi, b.hiV = c.hi, c.hiV
+	}
+	b.n += c.n
+	return b
+}
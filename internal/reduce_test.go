@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReducer_Short(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		max      int
+		expected []float64
+	}{
+		{name: "empty", data: nil, max: 4, expected: []float64{}},
+		{name: "kept as-is", data: []float64{3, 1, 2}, max: 4, expected: []float64{3, 1, 2}},
+		{name: "exactly max", data: []float64{3, 1, 2, 5}, max: 4, expected: []float64{3, 1, 2, 5}},
+		{name: "first and last only", data: []float64{3, 1, 2, 5}, max: 0, expected: []float64{3, 5}},
+		{name: "spike survives", data: []float64{1, 1, 1, 1, 100, 1, 1, 1, 1, 1}, max: 4, expected: []float64{1, 1, 100, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReducer(tt.max)
+			for _, v := range tt.data {
+				r.Add(v)
+			}
+			if got := r.Points(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Points() = %v, want %v", got, tt.expected)
+			}
+			if r.Count() != len(tt.data) {
+				t.Errorf("Count() = %d, want %d", r.Count(), len(tt.data))
+			}
+		})
+	}
+}
+
+func TestReducer_Long(t *testing.T) {
+	const n = 1000003
+	r := NewReducer(200)
+	for i := 0; i < n; i++ {
+		v := float64(i % 100)
+		switch i {
+		case 654321:
+			v = 1000
+		case 123456:
+			v = -50
+		}
+		r.Add(v)
+	}
+
+	points := r.Points()
+	if len(points) > 200 || len(points) < 100 {
+		t.Fatalf("Points() returned %d points, want between 100 and 200", len(points))
+	}
+	if points[0] != 0 || points[len(points)-1] != float64((n-1)%100) {
+		t.Errorf("Points() should keep the first and last values, got %v and %v", points[0], points[len(points)-1])
+	}
+	min, max := MinMax(points)
+	if min != -50 || max != 1000 {
+		t.Errorf("Points() range = [%v, %v], want [-50, 1000]", min, max)
+	}
+
+	// The spikes stay in stream order
+	lo, hi := -1, -1
+	for i, v := range points {
+		if v == -50 {
+			lo = i
+		}
+		if v == 1000 {
+			hi = i
+		}
+	}
+	if lo > hi {
+		t.Errorf("minimum at %d should come before maximum at %d", lo, hi)
+	}
+
+	// Memory stays bounded by the number of points kept
+	if len(r.buckets) > r.limit || r.raw != nil {
+		t.Errorf("reducer holds %d buckets (limit %d), raw = %v", len(r.buckets), r.limit, r.raw != nil)
+	}
+}
+
+func TestReducer_MatchesDownsampleShape(t *testing.T) {
+	// A monotonic ramp reduces to an increasing series spanning the range
+	r := NewReducer(50)
+	for i := 0; i < 10000; i++ {
+		r.Add(float64(i))
+	}
+	points := r.Points()
+	for i := 1; i < len(points); i++ {
+		if points[i] <= points[i-1] {
+			t.Fatalf("points not increasing at %d: %v", i, points)
+		}
+	}
+	if points[0] != 0 || points[len(points)-1] != 9999 {
+		t.Errorf("ramp endpoints = %v, %v, want 0, 9999", points[0], points[len(points)-1])
+	}
+}
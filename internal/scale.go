@@ -38,25 +38,23 @@ func Scale(value, dataMin, dataMax, targetMin, targetMax float64) float64 {
 	return targetMin + normalized*(targetMax-targetMin)
 }
 
-// MinMax returns the minimum and maximum values in the data.
-// Returns (0, 0) for empty data.
+// MinMax returns the minimum and maximum values in the data, skipping NaN
+// values, which mark missing points. Returns (0, 0) for empty data or data
+// of only NaN values.
 func MinMax(data []float64) (min, max float64) {
-	if len(data) == 0 {
-		return 0, 0
-	}
-
-	min = data[0]
-	max = data[0]
-
-	for _, v := range data[1:] {
-		if v < min {
+	found := false
+	for _, v := range data {
+		if math.IsNaN(v) {
+			continue
+		}
+		if !found || v < min {
 			min = v
 		}
-		if v > max {
+		if !found || v > max {
 			max = v
 		}
+		found = true
 	}
-
 	return min, max
 }
 
@@ -172,6 +172,12 @@ func TestMinMax(t *testing.T) {
 			expectedMin: 1,
 			expectedMax: 9,
 		},
+		{
+			name:        "NaN values skipped",
+			data:        []float64{math.NaN(), 4, math.NaN(), -1},
+			expectedMin: -1,
+			expectedMax: 4,
+		},
 		{
 			name:        "negative values",
 			data:        []float64{-5, -2, -10, -1},
@@ -6,10 +6,46 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/term"
 )
 
+// Capability detection reads environment variables and makes system calls.
+// Its results are cached for the life of the process, so dashboards that
+// render many small charts do not repeat it for each one. The terminal size
+// is not cached, since it changes when the terminal is resized.
+type detection struct {
+	colorOnce   sync.Once
+	depthOnce   sync.Once
+	unicodeOnce sync.Once
+
+	color   bool
+	depth   int
+	unicode bool
+}
+
+var (
+	detectionMu sync.Mutex
+	detected    = new(detection)
+)
+
+// currentDetection returns the cache of detected capabilities.
+func currentDetection() *detection {
+	detectionMu.Lock()
+	defer detectionMu.Unlock()
+	return detected
+}
+
+// ResetDetection discards cached terminal capabilities, so the next call to
+// SupportsColor, ColorDepth, or SupportsUnicode detects them again.
+// Tests that change the environment call it.
+func ResetDetection() {
+	detectionMu.Lock()
+	defer detectionMu.Unlock()
+	detected = new(detection)
+}
+
 // TerminalSize represents the dimensions of the terminal.
 type TerminalSize struct {
 	Width  int
@@ -22,8 +58,9 @@ var DefaultSize = TerminalSize{
 	Height: 24,
 }
 
-// GetTerminalSize returns the current terminal dimensions.
-// If detection fails, returns DefaultSize.
+// GetTerminalSize returns the terminal dimensions.
+// If detection fails, returns DefaultSize. The terminal is queried on every
+// call, so the size follows resizes.
 func GetTerminalSize() TerminalSize {
 	// Try to get terminal size from file descriptor
 	fd := int(os.Stdout.Fd())
@@ -56,8 +93,18 @@ func getSizeFromEnv() (width, height int) {
 }
 
 // SupportsColor detects whether the terminal supports ANSI colors.
-// Checks environment variables and terminal capabilities.
+// Checks environment variables and terminal capabilities. The result is
+// detected once and cached until ResetDetection.
 func SupportsColor() bool {
+	d := currentDetection()
+	d.colorOnce.Do(func() {
+		d.color = detectColor()
+	})
+	return d.color
+}
+
+// detectColor checks whether the terminal supports ANSI colors.
+func detectColor() bool {
 	// Check if colors are explicitly disabled
 	if os.Getenv("NO_COLOR") != "" {
 		return false
@@ -93,9 +140,55 @@ func SupportsColor() bool {
 	return term.IsTerminal(int(os.Stdout.Fd()))
 }
 
+// Color depths reported by ColorDepth, in bits per color.
+const (
+	Depth16   = 4
+	Depth256  = 8
+	DepthTrue = 24
+)
+
+// ColorDepth detects how many colors the terminal can show: Depth16,
+// Depth256, or DepthTrue. The result is detected once and cached until
+// ResetDetection.
+func ColorDepth() int {
+	d := currentDetection()
+	d.depthOnce.Do(func() {
+		d.depth = detectColorDepth()
+	})
+	return d.depth
+}
+
+// detectColorDepth checks COLORTERM and TERM for 24-bit and 256-color support.
+func detectColorDepth() int {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return DepthTrue
+	}
+
+	// Windows Terminal supports 24-bit color
+	if runtime.GOOS == "windows" && os.Getenv("WT_SESSION") != "" {
+		return DepthTrue
+	}
+
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Depth256
+	}
+	return Depth16
+}
+
 // SupportsUnicode detects whether the terminal supports Unicode characters.
-// Checks locale and environment variables.
+// Checks locale and environment variables. The result is detected once and
+// cached until ResetDetection.
 func SupportsUnicode() bool {
+	d := currentDetection()
+	d.unicodeOnce.Do(func() {
+		d.unicode = detectUnicode()
+	})
+	return d.unicode
+}
+
+// detectUnicode checks whether the terminal supports Unicode characters.
+func detectUnicode() bool {
 	// Check if ASCII is forced
 	if os.Getenv("LANG") == "C" || os.Getenv("LC_ALL") == "C" {
 		return false
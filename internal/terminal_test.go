@@ -185,6 +185,8 @@ func TestSupportsColor(t *testing.T) {
 				os.Setenv(key, val)
 			}
 
+			ResetDetection()
+			defer ResetDetection()
 			result := SupportsColor()
 			if result != tt.expected {
 				t.Errorf("SupportsColor() = %v, want %v", result, tt.expected)
@@ -193,6 +195,53 @@ func TestSupportsColor(t *testing.T) {
 	}
 }
 
+func TestColorDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		envVars  map[string]string
+		expected int
+	}{
+		{
+			name:     "COLORTERM truecolor",
+			envVars:  map[string]string{"COLORTERM": "truecolor", "TERM": "xterm-256color"},
+			expected: DepthTrue,
+		},
+		{
+			name:     "COLORTERM 24bit",
+			envVars:  map[string]string{"COLORTERM": "24bit"},
+			expected: DepthTrue,
+		},
+		{
+			name:     "256-color TERM",
+			envVars:  map[string]string{"TERM": "screen-256color"},
+			expected: Depth256,
+		},
+		{
+			name:     "plain TERM",
+			envVars:  map[string]string{"TERM": "xterm"},
+			expected: Depth16,
+		},
+		{
+			name:     "no TERM",
+			expected: Depth16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"COLORTERM", "TERM", "WT_SESSION"} {
+				t.Setenv(key, tt.envVars[key])
+			}
+
+			ResetDetection()
+			defer ResetDetection()
+			if got := ColorDepth(); got != tt.expected {
+				t.Errorf("ColorDepth() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestSupportsUnicode(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -267,6 +316,8 @@ func TestSupportsUnicode(t *testing.T) {
 				os.Setenv(key, val)
 			}
 
+			ResetDetection()
+			defer ResetDetection()
 			result := SupportsUnicode()
 			if result != tt.expected {
 				t.Errorf("SupportsUnicode() = %v, want %v (env: %v)", result, tt.expected, tt.envVars)
@@ -297,3 +348,40 @@ func TestTerminalSize_Struct(t *testing.T) {
 		t.Errorf("TerminalSize.Height = %d, want 50", size.Height)
 	}
 }
+
+func TestDetectionCached(t *testing.T) {
+	ResetDetection()
+	defer ResetDetection()
+
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("COLUMNS", "123")
+	t.Setenv("LINES", "45")
+	if SupportsColor() {
+		t.Fatal("SupportsColor() = true with NO_COLOR set")
+	}
+	size := GetTerminalSize()
+
+	// Later environment changes are not seen until the cache is reset
+	os.Unsetenv("NO_COLOR")
+	t.Setenv("FORCE_COLOR", "1")
+	t.Setenv("COLUMNS", "99")
+	if SupportsColor() {
+		t.Error("SupportsColor() should return the cached result")
+	}
+	// The size is not cached, so it follows resizes
+	if got := GetTerminalSize(); size.Width == 123 && got.Width != 99 {
+		t.Errorf("GetTerminalSize() = %v, want the new width 99", got)
+	}
+
+	ResetDetection()
+	if !SupportsColor() {
+		t.Error("SupportsColor() should detect again after ResetDetection")
+	}
+}
+
+func BenchmarkSupportsColor(b *testing.B) {
+	ResetDetection()
+	for i := 0; i < b.N; i++ {
+		SupportsColor()
+	}
+}
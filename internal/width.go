@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// widthCondition measures text the way chart glyphs are drawn: East Asian
+// ambiguous characters such as "●" and box-drawing lines occupy one column,
+// regardless of the user's locale.
+var widthCondition = &runewidth.Condition{
+	EastAsianWidth:     false,
+	StrictEmojiNeutral: true,
+}
+
+// StringWidth returns the number of terminal columns s occupies.
+// Wide characters (CJK, most emoji) count as two columns, combining marks
+// and zero-width joiners as none. Escape sequences are not counted.
+func StringWidth(s string) int {
+	if w, ok := asciiWidth(s); ok {
+		return w
+	}
+	return widthCondition.StringWidth(StripANSI(s))
+}
+
+// RuneWidth returns the number of terminal columns r occupies on its own.
+func RuneWidth(r rune) int {
+	return widthCondition.RuneWidth(r)
+}
+
+// asciiWidth returns the width of s if it is plain ASCII without escape
+// sequences, the common case for labels, without segmenting it into
+// grapheme clusters.
+func asciiWidth(s string) (int, bool) {
+	w := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf || c == '\033' {
+			return 0, false
+		}
+		// Control characters occupy no columns
+		if c >= ' ' && c != 0x7f {
+			w++
+		}
+	}
+	return w, true
+}
+
+// Truncate shortens s to at most width columns without splitting a grapheme
+// cluster. The result may be one column narrower than width when a wide
+// character would straddle the limit.
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return widthCondition.Truncate(s, width, "")
+}
+
+// Abbreviate shortens s to at most width columns like Truncate, but ends a
+// shortened string with tail, such as "…", so the cut is visible.
+func Abbreviate(s string, width int, tail string) string {
+	if width <= 0 {
+		return ""
+	}
+	if StringWidth(s) <= width {
+		return s
+	}
+	if StringWidth(tail) >= width {
+		return Truncate(s, width)
+	}
+	return widthCondition.Truncate(s, width, tail)
+}
+
+// Center pads s with spaces on both sides to at least width columns, putting
+// the odd space on the right. It never truncates.
+func Center(s string, width int) string {
+	gap := width - StringWidth(s)
+	if gap <= 0 {
+		return s
+	}
+	return strings.Repeat(" ", gap/2) + s + strings.Repeat(" ", gap-gap/2)
+}
+
+// PadRight truncates s to width columns and pads it with trailing spaces to
+// exactly width columns.
+func PadRight(s string, width int) string {
+	s = Truncate(s, width)
+	return s + strings.Repeat(" ", width-StringWidth(s))
+}
+
+// PadLeft pads s with leading spaces to at least width columns. Unlike
+// PadRight it never truncates, matching fmt's "%*s" verb.
+func PadLeft(s string, width int) string {
+	if w := StringWidth(s); w < width {
+		return strings.Repeat(" ", width-w) + s
+	}
+	return s
+}
+
+// FillRight pads s with trailing spaces to at least width columns without
+// truncating, matching fmt's "%-*s" verb.
+func FillRight(s string, width int) string {
+	if w := StringWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// Graphemes splits s into user-perceived characters (grapheme clusters),
+// so an emoji sequence or a letter with combining marks stays together.
+func Graphemes(s string) []string {
+	var clusters []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		clusters = append(clusters, g.Str())
+	}
+	return clusters
+}
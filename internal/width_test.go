@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected int
+	}{
+		{name: "ascii", s: "Q1", expected: 2},
+		{name: "cjk", s: "東京", expected: 4},
+		{name: "emoji", s: "🚀", expected: 2},
+		{name: "combining mark", s: "é", expected: 1},
+		{name: "zwj sequence", s: "👩‍💻", expected: 2},
+		{name: "ambiguous chart glyph", s: "●─", expected: 2},
+		{name: "ansi escapes", s: "\033[31m東\033[0m", expected: 2},
+		{name: "empty", s: "", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StringWidth(tt.s); got != tt.expected {
+				t.Errorf("StringWidth(%q) = %d, want %d", tt.s, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStringWidth_ASCIIFastPath(t *testing.T) {
+	// The ASCII fast path must agree with full grapheme segmentation
+	for c := 0; c < utf8.RuneSelf; c++ {
+		if c == '\033' {
+			continue
+		}
+		s := "a" + string(rune(c)) + "b"
+		if got, want := StringWidth(s), widthCondition.StringWidth(s); got != want {
+			t.Errorf("StringWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		width    int
+		expected string
+	}{
+		{name: "fits", s: "abc", width: 5, expected: "abc"},
+		{name: "ascii", s: "abcdef", width: 3, expected: "abc"},
+		{name: "cjk boundary", s: "東京都", width: 4, expected: "東京"},
+		{name: "wide character straddles limit", s: "東京都", width: 3, expected: "東"},
+		{name: "keeps combining mark", s: "éé", width: 1, expected: "é"},
+		{name: "zero width", s: "abc", width: 0, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.s, tt.width); got != tt.expected {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAbbreviate(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		width    int
+		expected string
+	}{
+		{name: "fits", s: "abc", width: 3, expected: "abc"},
+		{name: "shortened", s: "abcdef", width: 4, expected: "abc…"},
+		{name: "cjk", s: "東京都", width: 4, expected: "東…"},
+		{name: "no room for tail", s: "abc", width: 1, expected: "a"},
+		{name: "zero width", s: "abc", width: 0, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Abbreviate(tt.s, tt.width, "…"); got != tt.expected {
+				t.Errorf("Abbreviate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPadding(t *testing.T) {
+	tests := []struct {
+		name     string
+		pad      func(string, int) string
+		s        string
+		width    int
+		expected string
+	}{
+		{name: "PadRight ascii", pad: PadRight, s: "ab", width: 4, expected: "ab  "},
+		{name: "PadRight cjk", pad: PadRight, s: "東", width: 4, expected: "東  "},
+		{name: "PadRight truncates", pad: PadRight, s: "東京都", width: 5, expected: "東京 "},
+		{name: "PadLeft cjk", pad: PadLeft, s: "東", width: 4, expected: "  東"},
+		{name: "PadLeft keeps long", pad: PadLeft, s: "東京都", width: 4, expected: "東京都"},
+		{name: "FillRight emoji", pad: FillRight, s: "🚀", width: 3, expected: "🚀 "},
+		{name: "FillRight keeps long", pad: FillRight, s: "東京都", width: 4, expected: "東京都"},
+		{name: "Center odd gap", pad: Center, s: "ab", width: 5, expected: " ab  "},
+		{name: "Center cjk", pad: Center, s: "東", width: 6, expected: "  東  "},
+		{name: "Center keeps long", pad: Center, s: "abc", width: 2, expected: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pad(tt.s, tt.width); got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	got := Graphemes("a👩‍💻é")
+	expected := []string{"a", "👩‍💻", "é"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Graphemes = %q, want %q", got, expected)
+	}
+}
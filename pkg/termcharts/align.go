@@ -0,0 +1,130 @@
+package termcharts
+
+import (
+	"math"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// SeriesAlign specifies how the series of a multi-series chart line up when
+// they have different lengths.
+type SeriesAlign int
+
+const (
+	// AlignStretch spreads each series of a line chart across the full
+	// width, whatever its length, and starts each series of a bar chart at
+	// the first category. It is the default.
+	AlignStretch SeriesAlign = iota
+	// AlignLeft lines up the first points of the series; shorter series
+	// end early.
+	AlignLeft
+	// AlignRight lines up the last points of the series, such as the latest
+	// samples of metrics collected for different lengths of time; shorter
+	// series start late.
+	AlignRight
+)
+
+// String returns the string representation of the SeriesAlign.
+func (a SeriesAlign) String() string {
+	switch a {
+	case AlignStretch:
+		return "stretch"
+	case AlignLeft:
+		return "left"
+	case AlignRight:
+		return "right"
+	default:
+		return unknownString
+	}
+}
+
+// AlignOption configures a chart that lines up series of different lengths
+// (bar or line charts).
+type AlignOption interface {
+	BarOption
+	LineOption
+}
+
+// alignOption is an option that applies to bar and line charts.
+type alignOption func(*Options)
+
+func (f alignOption) applyBar(o *Options)  { f(o) }
+func (f alignOption) applyLine(o *Options) { f(o) }
+
+// WithAlign lines up series of different lengths by their first or last
+// points, so the points of each series at one index are drawn together.
+// Points a shorter series does not have are gaps: lines are not drawn there,
+// and bars are left out.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithSeries([]termcharts.Series{
+//	        {Label: "old host", Data: lastWeek},
+//	        {Label: "new host", Data: sinceTuesday},
+//	    }),
+//	    termcharts.WithAlign(termcharts.AlignRight),
+//	)
+func WithAlign(align SeriesAlign) AlignOption {
+	return alignOption(func(o *Options) {
+		o.Align = align
+	})
+}
+
+// alignSeries returns the series padded to the length of the longest, at
+// the end for AlignLeft and at the start for AlignRight, with missing
+// values: NaN for line charts, which leave gaps there, or 0 for bar charts.
+// Bounds are padded like the data. Empty series are left empty, and series
+// are returned as they are for AlignStretch.
+func alignSeries(allSeries []Series, align SeriesAlign, missing float64) []Series {
+	if align != AlignLeft && align != AlignRight {
+		return allSeries
+	}
+	n := 0
+	for _, series := range allSeries {
+		n = internal.Max(n, len(series.Data))
+	}
+
+	pad := func(values []float64) []float64 {
+		if len(values) == 0 || len(values) >= n {
+			return values
+		}
+		padded := make([]float64, n)
+		offset := 0
+		if align == AlignRight {
+			offset = n - len(values)
+		}
+		for i := range padded {
+			padded[i] = missing
+		}
+		copy(padded[offset:], values)
+		return padded
+	}
+	aligned := make([]Series, len(allSeries))
+	for i, series := range allSeries {
+		series.Data = pad(series.Data)
+		series.Upper = pad(series.Upper)
+		series.Lower = pad(series.Lower)
+		aligned[i] = series
+	}
+	return aligned
+}
+
+// aligned returns the options with the series of a bar chart lined up at
+// their last points, padded at the start with zeros, which draw no bar. It
+// returns o itself when there is nothing to line up: left-aligned series
+// already start at the first category, and end where their data does.
+func (o *Options) aligned() *Options {
+	if o.Align != AlignRight || len(o.Series) < 2 {
+		return o
+	}
+	aligned := *o
+	aligned.Series = alignSeries(o.Series, AlignRight, 0)
+	aligned.Align = AlignLeft
+	return &aligned
+}
+
+// missingPoint reports whether v marks a point a series does not have.
+func missingPoint(v float64) bool {
+	return math.IsNaN(v)
+}
@@ -0,0 +1,176 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSeriesAlign_String(t *testing.T) {
+	tests := map[SeriesAlign]string{
+		AlignStretch:    "stretch",
+		AlignLeft:       "left",
+		AlignRight:      "right",
+		SeriesAlign(99): "unknown",
+	}
+	for align, expected := range tests {
+		if got := align.String(); got != expected {
+			t.Errorf("SeriesAlign(%d).String() = %q, want %q", align, got, expected)
+		}
+	}
+}
+
+func TestAlignSeries(t *testing.T) {
+	nan := math.NaN()
+	series := []Series{
+		{Label: "a", Data: []float64{1, 2, 3, 4}},
+		{Label: "b", Data: []float64{5, 6}, Upper: []float64{7, 8}},
+		{Label: "c"},
+	}
+	tests := []struct {
+		name  string
+		align SeriesAlign
+		want  []Series
+	}{
+		{
+			name:  "stretch",
+			align: AlignStretch,
+			want:  series,
+		},
+		{
+			name:  "left",
+			align: AlignLeft,
+			want: []Series{
+				{Label: "a", Data: []float64{1, 2, 3, 4}},
+				{Label: "b", Data: []float64{5, 6, nan, nan}, Upper: []float64{7, 8, nan, nan}},
+				{Label: "c"},
+			},
+		},
+		{
+			name:  "right",
+			align: AlignRight,
+			want: []Series{
+				{Label: "a", Data: []float64{1, 2, 3, 4}},
+				{Label: "b", Data: []float64{nan, nan, 5, 6}, Upper: []float64{nan, nan, 7, 8}},
+				{Label: "c"},
+			},
+		},
+	}
+
+	// NaN never equals itself, so compare the series as text
+	format := func(s []Series) string { return fmt.Sprint(s) }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := alignSeries(series, tt.align, nan)
+			if format(got) != format(tt.want) {
+				t.Errorf("alignSeries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStackSeries_Missing(t *testing.T) {
+	nan := math.NaN()
+	got := stackSeries([]Series{
+		{Label: "a", Data: []float64{nan, 2, 3}},
+		{Label: "b", Data: []float64{10, nan, 30}},
+		{Label: "c", Data: []float64{100, 100, 100}},
+	})
+	if !math.IsNaN(got[1].Data[1]) {
+		t.Errorf("missing point stacked to %v, want NaN", got[1].Data[1])
+	}
+
+	// Missing points add nothing to the series above them
+	want := []float64{110, 102, 133}
+	if !reflect.DeepEqual(got[2].Data, want) {
+		t.Errorf("top series = %v, want %v", got[2].Data, want)
+	}
+}
+
+func TestLineChart_Render_Aligned(t *testing.T) {
+	series := []Series{
+		{Label: "long", Data: []float64{5, 5, 5, 5, 5, 5}},
+		{Label: "short", Data: []float64{1, 1, 1}},
+	}
+	render := func(align SeriesAlign, style RenderStyle) []string {
+		out := NewLineChart(
+			WithSeries(series), WithAlign(align), WithStyle(style),
+			WithColor(false), WithShowAxes(false), WithWidth(30), WithHeight(5),
+		).Render()
+		return strings.Split(out, "\n")
+	}
+
+	tests := []struct {
+		name  string
+		align SeriesAlign
+		// left and right report whether the short series is drawn in each half
+		left, right bool
+	}{
+		{name: "stretch", align: AlignStretch, left: true, right: true},
+		{name: "left", align: AlignLeft, left: true, right: false},
+		{name: "right", align: AlignRight, left: false, right: true},
+	}
+	for _, style := range []RenderStyle{StyleASCII, StyleBraille} {
+		for _, tt := range tests {
+			t.Run(style.String()+"/"+tt.name, func(t *testing.T) {
+				// The short series is drawn along the bottom row of the plot,
+				// which the legend follows after a blank line
+				lines := render(tt.align, style)
+				bottom := []rune(lines[4])
+				drawn := func(cells []rune) bool {
+					return strings.TrimLeft(strings.TrimSpace(string(cells)), "\u2800") != ""
+				}
+				half := len(bottom) / 2
+				if got := drawn(bottom[:half-1]); got != tt.left {
+					t.Errorf("left half drawn = %v, want %v:\n%s", got, tt.left, strings.Join(lines, "\n"))
+				}
+				if got := drawn(bottom[half+1:]); got != tt.right {
+					t.Errorf("right half drawn = %v, want %v:\n%s", got, tt.right, strings.Join(lines, "\n"))
+				}
+			})
+		}
+	}
+}
+
+func TestLineChart_Render_LonePoint(t *testing.T) {
+	// A point between gaps has no segment, but is still drawn
+	out := NewLineChart(
+		WithSeries([]Series{
+			{Label: "long", Data: []float64{0, 0, 0, 0, 0}},
+			{Label: "lone", Data: []float64{9}},
+		}),
+		WithAlign(AlignLeft), WithBraille(), WithColor(false), WithShowAxes(false), WithWidth(20), WithHeight(4),
+	).Render()
+	top := strings.Split(out, "\n")[0]
+	if strings.Trim(top, "\u2800 ") == "" {
+		t.Errorf("lone point not drawn:\n%s", out)
+	}
+}
+
+func TestBarChart_Render_AlignRight(t *testing.T) {
+	for _, dir := range []Direction{Horizontal, Vertical} {
+		t.Run(dir.String(), func(t *testing.T) {
+			render := func(opts ...BarOption) string {
+				return NewBarChart(append([]BarOption{
+					WithLabels([]string{"mon", "tue", "wed", "thu"}),
+					WithDirection(dir), WithStyle(StyleASCII), WithColor(false), WithWidth(40), WithHeight(10),
+				}, opts...)...).Render()
+			}
+
+			// Right-aligned series start late, as if their first values were zero
+			got := render(WithSeries([]Series{
+				{Label: "a", Data: []float64{4, 3, 2, 1}},
+				{Label: "b", Data: []float64{5, 6}},
+			}), WithAlign(AlignRight))
+			want := render(WithSeries([]Series{
+				{Label: "a", Data: []float64{4, 3, 2, 1}},
+				{Label: "b", Data: []float64{0, 0, 5, 6}},
+			}))
+			if got != want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package termcharts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+)
+
+// FrameFunc renders one frame of an animation. progress runs from 0 at the
+// first frame to 1 at the last.
+type FrameFunc func(progress float64) string
+
+// DefaultFPS is the frame rate used when an Animation does not set one.
+const DefaultFPS = 30
+
+// Animation plays a sequence of chart frames in place, for demos and
+// presentations. Frames are generated on demand by Frame, drawn at FPS
+// frames per second over Duration, and updated with a LiveRenderer so
+// only changed cells are redrawn.
+//
+// Example:
+//
+//	data := []float64{12, 30, 18, 25}
+//	anim := termcharts.Animation{
+//	    Frame: func(p float64) string {
+//	        return termcharts.NewBarChart(
+//	            termcharts.WithData(termcharts.Interpolate(nil, data, p)),
+//	            termcharts.WithYAxis(termcharts.AxisConfig{Max: 30}),
+//	        ).Render()
+//	    },
+//	    Duration: time.Second,
+//	}
+//	anim.Play(context.Background(), os.Stdout)
+type Animation struct {
+	// Frame renders the frame at the given progress.
+	Frame FrameFunc
+	// FPS is the number of frames per second (0 = DefaultFPS).
+	FPS int
+	// Duration is how long the animation runs. With a zero duration only
+	// the final frame is drawn.
+	Duration time.Duration
+}
+
+// Play draws the animation to w, ending on the frame at progress 1.
+// The cursor is hidden while playing and restored afterwards.
+// Play returns ctx.Err() if ctx is cancelled before the last frame.
+func (a Animation) Play(ctx context.Context, w io.Writer) error {
+	if a.Frame == nil {
+		return fmt.Errorf("%w: animation has no frame function", ErrInvalidOption)
+	}
+	if a.FPS < 0 {
+		return fmt.Errorf("%w: animation FPS %d is negative", ErrInvalidOption, a.FPS)
+	}
+	if a.Duration < 0 {
+		return fmt.Errorf("%w: animation duration %v is negative", ErrInvalidOption, a.Duration)
+	}
+
+	fps := a.FPS
+	if fps == 0 {
+		fps = DefaultFPS
+	}
+	frames := a.frameCount(fps)
+
+	live := NewLiveRenderer(w)
+	return live.withHiddenCursor(func() error {
+		if frames == 0 {
+			return live.Draw(a.Frame(1))
+		}
+
+		ticker := time.NewTicker(time.Second / time.Duration(fps))
+		defer ticker.Stop()
+
+		for i := 0; i <= frames; i++ {
+			if err := live.Draw(a.Frame(float64(i) / float64(frames))); err != nil {
+				return err
+			}
+			if i == frames {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		return nil
+	})
+}
+
+// frameCount returns the number of frame intervals in the animation.
+func (a Animation) frameCount(fps int) int {
+	return int(math.Round(a.Duration.Seconds() * float64(fps)))
+}
+
+// Animate plays frame on stdout at fps frames per second for duration.
+// See Animation.Play.
+func Animate(ctx context.Context, frame FrameFunc, fps int, duration time.Duration) error {
+	anim := Animation{Frame: frame, FPS: fps, Duration: duration}
+	return anim.Play(ctx, os.Stdout)
+}
+
+// Interpolate returns the values between from and to at progress t (0 to 1),
+// for transitions such as bars growing. Missing values in from are treated
+// as zero, so a nil from grows the data up from nothing.
+func Interpolate(from, to []float64, t float64) []float64 {
+	t = clampProgress(t)
+	result := make([]float64, len(to))
+	for i, v := range to {
+		start := 0.0
+		if i < len(from) {
+			start = from[i]
+		}
+		result[i] = start + (v-start)*t
+	}
+	return result
+}
+
+// Sweep returns the leading portion of data shown at progress t (0 to 1),
+// for lines that draw in from left to right. At least one value is
+// returned for non-empty data so each frame can be rendered.
+func Sweep(data []float64, t float64) []float64 {
+	if len(data) == 0 {
+		return data
+	}
+	n := int(math.Round(clampProgress(t) * float64(len(data))))
+	if n < 1 {
+		n = 1
+	}
+	return data[:n]
+}
+
+// clampProgress limits t to the range 0 to 1.
+func clampProgress(t float64) float64 {
+	return math.Max(0, math.Min(1, t))
+}
@@ -0,0 +1,136 @@
+package termcharts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnimation_Play(t *testing.T) {
+	var progress []float64
+	anim := Animation{
+		Frame: func(p float64) string {
+			progress = append(progress, p)
+			return "frame"
+		},
+		FPS:      1000,
+		Duration: 4 * time.Millisecond,
+	}
+
+	var buf bytes.Buffer
+	if err := anim.Play(context.Background(), &buf); err != nil {
+		t.Fatalf("Play returned error: %v", err)
+	}
+
+	expected := []float64{0, 0.25, 0.5, 0.75, 1}
+	if !reflect.DeepEqual(progress, expected) {
+		t.Errorf("frame progress = %v, want %v", progress, expected)
+	}
+	if got, want := buf.String(), cursorHide+"frame\n"+colorReset+cursorShow; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestAnimation_ZeroDuration(t *testing.T) {
+	var progress []float64
+	anim := Animation{Frame: func(p float64) string {
+		progress = append(progress, p)
+		return ""
+	}}
+
+	if err := anim.Play(context.Background(), &bytes.Buffer{}); err != nil {
+		t.Fatalf("Play returned error: %v", err)
+	}
+	if !reflect.DeepEqual(progress, []float64{1}) {
+		t.Errorf("frame progress = %v, want only the final frame", progress)
+	}
+}
+
+func TestAnimation_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	anim := Animation{
+		Frame: func(p float64) string {
+			cancel()
+			return "frame"
+		},
+		Duration: time.Hour,
+	}
+
+	var buf bytes.Buffer
+	if err := anim.Play(ctx, &buf); !errors.Is(err, context.Canceled) {
+		t.Errorf("Play() error = %v, want %v", err, context.Canceled)
+	}
+	if !strings.HasSuffix(buf.String(), cursorShow) {
+		t.Errorf("cursor not restored after cancel, got %q", buf.String())
+	}
+}
+
+func TestAnimation_Invalid(t *testing.T) {
+	frame := func(float64) string { return "" }
+	tests := []struct {
+		name string
+		anim Animation
+	}{
+		{name: "no frame function", anim: Animation{}},
+		{name: "negative FPS", anim: Animation{Frame: frame, FPS: -1}},
+		{name: "negative duration", anim: Animation{Frame: frame, Duration: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.anim.Play(context.Background(), &bytes.Buffer{}); !errors.Is(err, ErrInvalidOption) {
+				t.Errorf("Play() error = %v, want %v", err, ErrInvalidOption)
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     []float64
+		to       []float64
+		t        float64
+		expected []float64
+	}{
+		{name: "grow from nothing", from: nil, to: []float64{10, 20}, t: 0.5, expected: []float64{5, 10}},
+		{name: "between data sets", from: []float64{10, 20}, to: []float64{20, 0}, t: 0.25, expected: []float64{12.5, 15}},
+		{name: "start", from: []float64{1}, to: []float64{9}, t: 0, expected: []float64{1}},
+		{name: "progress clamped", from: nil, to: []float64{4}, t: 2, expected: []float64{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Interpolate(tt.from, tt.to, tt.t); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Interpolate() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	data := []float64{1, 2, 3, 4}
+	tests := []struct {
+		t        float64
+		expected []float64
+	}{
+		{t: 0, expected: []float64{1}},
+		{t: 0.5, expected: []float64{1, 2}},
+		{t: 1, expected: data},
+		{t: -1, expected: []float64{1}},
+	}
+
+	for _, tt := range tests {
+		if got := Sweep(data, tt.t); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Sweep(%v) = %v, want %v", tt.t, got, tt.expected)
+		}
+	}
+
+	if got := Sweep(nil, 1); len(got) != 0 {
+		t.Errorf("Sweep(nil) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,128 @@
+package termcharts
+
+import (
+	"math"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// Shading of the areas filled under lines, one per series in turn, so
+// stacked areas stay apart without color.
+var (
+	areaShades      = []rune{'░', '▒', '▓'}
+	asciiAreaShades = []rune{'.', ':', '='}
+)
+
+// WithFill fills the region under each line of a line chart, drawing an area
+// chart. Areas reach down to zero, or to the bottom of the value axis when
+// zero is off it. With WithStacking, each series fills the region between its
+// line and the line below, so the areas add up to the total. Each series is
+// shaded in its own color and with its own shade.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithSeries([]termcharts.Series{api, web, batch}),
+//	    termcharts.WithStacking(true),
+//	    termcharts.WithFill(true),
+//	)
+func WithFill(fill bool) LineOption {
+	return lineOption(func(o *Options) {
+		o.Fill = fill
+	})
+}
+
+// isShade reports whether r shades a confidence band or a filled area, which
+// lines draw over.
+func isShade(r rune) bool {
+	if r == bandShade || r == asciiBandShade {
+		return true
+	}
+	for i := range areaShades {
+		if r == areaShades[i] || r == asciiAreaShades[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// areaCell is a character cell of a filled area: the shade it is drawn with
+// and its color. The zero value is a cell no area covers.
+type areaCell struct {
+	shade rune
+	color string
+	top   float64 // the value of the line above the cell
+}
+
+// areaCells returns the cells the areas under the projected series cover,
+// on a plot of charWidth by charHeight characters with dotRows rows of dots
+// to a character, or nil without WithFill. colors holds the color of each
+// series. Where areas overlap, a cell is shaded like the area of the nearest
+// line above it, so lower areas are not hidden behind higher ones.
+func (l *LineChart) areaCells(series []Series, colors []string, charWidth, charHeight, dotRows int, minVal, maxVal float64, useUnicode bool) [][]areaCell {
+	if !l.opts.Fill || len(series) == 0 {
+		return nil
+	}
+	shades := areaShades
+	if !useUnicode {
+		shades = asciiAreaShades
+	}
+	base := l.areaBase(minVal, maxVal)
+
+	cells := make([][]areaCell, charHeight)
+	for y := range cells {
+		cells[y] = make([]areaCell, charWidth)
+	}
+	for i, s := range series {
+		// The area spans the line and the floor below it, as a band does
+		area := Series{Data: s.Data, Upper: s.Data, Lower: l.areaFloor(series[:i], len(s.Data), base)}
+		lower, upper := seriesBand(area, charWidth)
+		for x := 0; x < charWidth; x++ {
+			if math.IsNaN(lower[x]) || math.IsNaN(upper[x]) {
+				continue
+			}
+			// Rounding the top, where lines are placed by truncation, keeps the
+			// shade from showing above a sloping line
+			rows := float64(charHeight*dotRows - 1)
+			top := internal.ClampInt(int(math.Round((maxVal-upper[x])/(maxVal-minVal)*rows)), 0, charHeight*dotRows-1) / dotRows
+			bottom := bandRow(lower[x], charHeight*dotRows, minVal, maxVal) / dotRows
+			fill := areaCell{shade: shades[i%len(shades)], color: colors[i], top: math.Max(lower[x], upper[x])}
+			for y := top; y <= bottom; y++ {
+				if cells[y][x].shade == 0 || fill.top < cells[y][x].top {
+					cells[y][x] = fill
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// areaBase returns the value areas are filled down to: zero, within the axis
+// range, or the bottom of a log or per-series axis.
+func (l *LineChart) areaBase(minVal, maxVal float64) float64 {
+	if l.opts.YAxis.Scale == ScaleLog || l.opts.SeparateScales {
+		return minVal
+	}
+	return math.Max(minVal, math.Min(0, maxVal))
+}
+
+// areaFloor returns the floor of the area of a series of n points stacked on
+// below: at each point, the line of the nearest series below that has one,
+// which is the running total stackSeries raised the series by. Unstacked
+// areas, and points with nothing below, are filled down to base.
+func (l *LineChart) areaFloor(below []Series, n int, base float64) []float64 {
+	floor := make([]float64, n)
+	for j := range floor {
+		floor[j] = base
+		if !l.opts.Stacked {
+			continue
+		}
+		for k := len(below) - 1; k >= 0; k-- {
+			if j < len(below[k].Data) && !missingPoint(below[k].Data[j]) {
+				floor[j] = below[k].Data[j]
+				break
+			}
+		}
+	}
+	return floor
+}
@@ -0,0 +1,96 @@
+package termcharts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLineChart_Render_Fill(t *testing.T) {
+	got := NewLineChart(
+		WithData([]float64{1, 3, 2, 4}), WithFill(true),
+		WithWidth(16), WithHeight(4), WithShowAxes(false), WithColor(false), WithStyle(StyleASCII),
+	).Render()
+	want := "              /*\n" +
+		"    /*\\\\    //..\n" +
+		"  //....\\\\*/....\n" +
+		"*/..............\n"
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLineChart_Render_FillStacked(t *testing.T) {
+	series := []Series{
+		{Label: "api", Data: []float64{2, 3, 2, 4, 3}},
+		{Label: "web", Data: []float64{1, 2, 2, 1, 3}},
+		{Label: "batch", Data: []float64{1, 1, 2, 2, 1}},
+	}
+	for _, style := range []RenderStyle{StyleUnicode, StyleBraille} {
+		t.Run(style.String(), func(t *testing.T) {
+			out := NewLineChart(
+				WithSeries(series), WithStacking(true), WithFill(true),
+				WithStyle(style), WithColor(false), WithWidth(40), WithHeight(12),
+			).Render()
+			rows := strings.Split(out, "\n")
+
+			// Each series is shaded between its line and the one below, the
+			// bottom series down to the axis
+			bottom, top := rows[9], rows[1]
+			if !strings.ContainsRune(bottom, areaShades[0]) || strings.ContainsAny(bottom, "▒▓") {
+				t.Errorf("bottom row %q should be shaded like the first series only", bottom)
+			}
+			if !strings.ContainsRune(top, areaShades[2]) || strings.ContainsAny(top, "░▒") {
+				t.Errorf("top row %q should be shaded like the last series only", top)
+			}
+			if !strings.ContainsRune(out, areaShades[1]) {
+				t.Errorf("Render() =\n%s\nhas no area of the second series", out)
+			}
+		})
+	}
+}
+
+func TestLineChart_Render_FillBase(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []LineOption
+		want string
+	}{
+		{
+			// Areas of negative values reach up to zero
+			name: "negative values",
+			opts: []LineOption{WithData([]float64{-2, -2, -2, -2})},
+			want: "..........\n..........\n*--*--*--*\n",
+		},
+		{
+			name: "zero below the axis",
+			opts: []LineOption{WithData([]float64{5, 5, 6}), WithYAxis(AxisConfig{Min: 4, Max: 6})},
+			want: "       //*\n*---*//...\n..........\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append(tt.opts, WithFill(true), WithWidth(10), WithHeight(3), WithShowAxes(false), WithColor(false), WithStyle(StyleASCII))
+			got := NewLineChart(opts...).Render()
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineChart_Render_FillOverlap(t *testing.T) {
+	// The lower series is shaded in front of the higher one
+	out := NewLineChart(
+		WithSeries([]Series{
+			{Label: "high", Data: []float64{8, 8, 8}},
+			{Label: "low", Data: []float64{2, 2, 2}},
+		}),
+		WithYAxis(AxisConfig{Min: 0, Max: 10}),
+		WithFill(true), WithStyle(StyleUnicode), WithColor(false),
+		WithWidth(20), WithHeight(8), WithShowAxes(false),
+	).Render()
+	rows := strings.Split(out, "\n")
+	if bottom := rows[7]; !strings.ContainsRune(bottom, areaShades[1]) || strings.ContainsRune(bottom, areaShades[0]) {
+		t.Errorf("bottom row %q should be shaded like the lower series", bottom)
+	}
+}
@@ -0,0 +1,332 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// AxisScale specifies how values are mapped along an axis.
+type AxisScale int
+
+const (
+	// ScaleLinear spaces values evenly along the axis.
+	ScaleLinear AxisScale = iota
+	// ScaleLog spaces values by order of magnitude (base 10).
+	// Non-positive values cannot be placed on a log axis and are drawn at its minimum.
+	ScaleLog
+)
+
+// String returns the string representation of the AxisScale.
+func (s AxisScale) String() string {
+	switch s {
+	case ScaleLinear:
+		return "linear"
+	case ScaleLog:
+		return "log"
+	default:
+		return "unknown"
+	}
+}
+
+// AxisConfig controls how a chart axis is scaled and labeled.
+// The zero value gives the chart's default behavior.
+type AxisConfig struct {
+	// Min is the lowest value on the axis.
+	Min float64
+	// Max is the highest value on the axis. When Max <= Min the range
+	// is computed from the data.
+	Max float64
+	// Scale is the value mapping (linear or logarithmic).
+	Scale AxisScale
+	// Ticks is the number of tick labels to show (0 = chart default).
+	Ticks int
+	// Format formats tick and value labels (nil = chart default).
+	Format func(float64) string
+	// Hidden hides the axis line and its labels.
+	Hidden bool
+}
+
+// AxisOption configures an axis of a bar chart, line chart, sparkline,
+// composed chart, or small multiples, or the values of a comparison chart or
+// confusion matrix.
+type AxisOption interface {
+	BarOption
+	LineOption
+	SparklineOption
+	ComposeOption
+	ComparisonOption
+	ConfusionOption
+	MultiplesOption
+}
+
+// axisOption is an option that applies to charts with axes.
+type axisOption func(*Options)
+
+func (f axisOption) applyBar(o *Options)       { f(o) }
+func (f axisOption) applyLine(o *Options)      { f(o) }
+func (f axisOption) applySparkline(o *Options) { f(o) }
+func (f axisOption) applyCompose(o *Options)   { f(o) }
+
+// WithXAxis configures the horizontal axis. For line charts this is the
+// data point axis: Ticks limits how many labels are shown (generating index
+// labels when none are set), Format formats generated labels, and Hidden hides
+// the axis. For bar charts it is the category axis; only Hidden applies.
+//
+// Example:
+//
+//	termcharts.WithXAxis(termcharts.AxisConfig{Ticks: 5})
+func WithXAxis(cfg AxisConfig) AxisOption {
+	return axisOption(func(o *Options) {
+		o.XAxis = cfg
+	})
+}
+
+// WithYAxis configures the value axis. Line charts use every field; bar
+// charts use Max as the full bar length and Format for displayed values;
+// sparklines use Min, Max, and Scale, and Format for WithSparkStats values.
+//
+// Example:
+//
+//	termcharts.WithYAxis(termcharts.AxisConfig{
+//	    Min:    0,
+//	    Max:    100,
+//	    Ticks:  5,
+//	    Format: func(v float64) string { return fmt.Sprintf("%.0f%%", v) },
+//	})
+func WithYAxis(cfg AxisConfig) AxisOption {
+	return axisOption(func(o *Options) {
+		o.YAxis = cfg
+	})
+}
+
+// WithYTicks labels the value axis of a line or composed chart with about n
+// round numbers, such as 0, 25, 50, 75, and 100, instead of a label on every
+// row. Unless WithYAxis fixes the range, the axis is widened to the nearest
+// round numbers around the data, so the top and bottom rows are labeled.
+// Steps are 1, 2, 2.5, or 5 times a power of ten. On a log scale n spreads
+// the labels evenly, like AxisConfig.Ticks.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithData(cpu),
+//	    termcharts.WithHeight(30),
+//	    termcharts.WithYTicks(5),
+//	)
+func WithYTicks(n int) PlotOption {
+	return plotOption(func(o *Options) {
+		o.YTicks = n
+	})
+}
+
+// WithYUnit appends unit to every value axis label and displayed value, such
+// as values beside bars, sparkline stats, legend stats, and text summaries, so
+// "1.2k" reads as "1.2k ms" on a dashboard shared with other metrics. The unit
+// follows the number after a space, including numbers formatted by
+// AxisConfig.Format. Histograms, whose value axis counts samples, add it to
+// their bin labels instead.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithData(latencies),
+//	    termcharts.WithYUnit("ms"),
+//	)
+func WithYUnit(unit string) AxisOption {
+	return axisOption(func(o *Options) {
+		o.YUnit = unit
+	})
+}
+
+// withUnit appends unit to the formatted number text, after a space.
+func withUnit(text, unit string) string {
+	if unit == "" {
+		return text
+	}
+	return text + " " + unit
+}
+
+// niceSteps are the mantissas of round axis steps.
+var niceSteps = [...]float64{1, 2, 2.5, 5}
+
+// maxTickDecades bounds the decades of steps niceTicks tries.
+const maxTickDecades = 4
+
+// niceTicks returns the smallest round step with at most ticks multiples in
+// the range [lo, hi], and the first and last of them. With widen set, the
+// range is first widened to multiples of the step, so both ends are labeled;
+// a widened range gives back the same step and range. A widened range that
+// crosses zero always has a tick below, at, and above zero, so it gets at
+// least three. When no round step fits, the range is returned unchanged.
+func niceTicks(lo, hi float64, ticks int, widen bool) (first, last, step float64) {
+	if ticks < 2 {
+		ticks = 2
+	}
+	if widen && lo < 0 && hi > 0 && ticks < 3 {
+		ticks = 3
+	}
+	span := hi - lo
+	if span <= 0 || math.IsInf(span, 0) || math.IsNaN(span) {
+		return lo, hi, 1
+	}
+
+	// Without widening, steps down to about span/(ticks+1) can fit; a few
+	// decades up from there always do unless the step overflows
+	mag := math.Pow(10, math.Floor(math.Log10(span/float64(ticks+1))))
+	for decade := 0; decade < maxTickDecades && !math.IsInf(mag, 0); decade++ {
+		for _, m := range niceSteps {
+			step = m * mag
+			if widen {
+				first = math.Floor(lo/step+1e-9) * step
+				last = math.Ceil(hi/step-1e-9) * step
+			} else {
+				first = math.Ceil(lo/step-1e-9) * step
+				last = math.Floor(hi/step+1e-9) * step
+			}
+			if math.Round((last-first)/step)+1 <= float64(ticks) {
+				return first, last, step
+			}
+		}
+		mag *= 10
+	}
+	return lo, hi, span
+}
+
+// yTickRange widens the value axis range [lo, hi] to the round numbers
+// labeled with WithYTicks. Fixed ranges and log axes are kept as they are.
+func (o *Options) yTickRange(lo, hi float64) (float64, float64) {
+	if o.YTicks <= 0 || o.YAxis.Scale != ScaleLinear || o.YAxis.fixedRange() {
+		return lo, hi
+	}
+	lo, hi, _ = niceTicks(lo, hi, o.YTicks, true)
+	return lo, hi
+}
+
+// stepDecimals returns the number of decimal places needed to print
+// multiples of step exactly.
+func stepDecimals(step float64) int {
+	d := 0
+	for scaled := step; d < 10 && math.Abs(scaled-math.Round(scaled)) > 1e-9*math.Max(1, scaled); d++ {
+		scaled *= 10
+	}
+	return d
+}
+
+// validate checks the axis configuration for values that cannot be drawn.
+func (a AxisConfig) validate(name string) error {
+	if a.Scale != ScaleLinear && a.Scale != ScaleLog {
+		return fmt.Errorf("%w: unknown %s axis scale %d", ErrInvalidOption, name, a.Scale)
+	}
+	if a.Ticks < 0 {
+		return fmt.Errorf("%w: %s axis tick count %d is negative", ErrInvalidOption, name, a.Ticks)
+	}
+	if a.Max < a.Min {
+		return fmt.Errorf("%w: %s axis max %g is less than min %g", ErrInvalidOption, name, a.Max, a.Min)
+	}
+	if a.Scale == ScaleLog && a.fixedRange() && a.Min <= 0 {
+		return fmt.Errorf("%w: %s axis min must be positive on a log scale", ErrInvalidOption, name)
+	}
+	return nil
+}
+
+// fixedRange reports whether the axis range is set explicitly.
+func (a AxisConfig) fixedRange() bool {
+	return a.Max > a.Min
+}
+
+// resolveRange returns the axis range across the data sets, honoring a fixed
+// range. On a log axis a non-positive lower bound is raised to the smallest
+// positive value in the data.
+func (a AxisConfig) resolveRange(sets ...[]float64) (float64, float64) {
+	min, max, found := 0.0, 0.0, false
+	for _, data := range sets {
+		if len(data) == 0 {
+			continue
+		}
+		lo, hi := internal.MinMax(data)
+		if !found || lo < min {
+			min = lo
+		}
+		if !found || hi > max {
+			max = hi
+		}
+		found = true
+	}
+	if a.fixedRange() {
+		min, max = a.Min, a.Max
+	}
+
+	if a.Scale == ScaleLog && min <= 0 {
+		min = math.Inf(1)
+		for _, data := range sets {
+			for _, v := range data {
+				if v > 0 && v < min {
+					min = v
+				}
+			}
+		}
+		if math.IsInf(min, 1) {
+			min = 1
+		}
+		if max < min {
+			max = min
+		}
+	}
+
+	return min, max
+}
+
+// project maps a value into axis space, where values are evenly spaced.
+func (a AxisConfig) project(v float64) float64 {
+	if a.Scale != ScaleLog {
+		return v
+	}
+	if v <= 0 {
+		return math.Inf(-1)
+	}
+	return math.Log10(v)
+}
+
+// unproject maps a position in axis space back to a value.
+func (a AxisConfig) unproject(v float64) float64 {
+	if a.Scale != ScaleLog {
+		return v
+	}
+	return math.Pow(10, v)
+}
+
+// format formats v with the axis formatter, or with def in the number format
+// of locale if none is set.
+func (a AxisConfig) format(v float64, def, locale string) string {
+	if a.Format != nil {
+		return a.Format(v)
+	}
+	return localizeNumber(fmt.Sprintf(def, v), locale)
+}
+
+// isTick reports whether position i of n receives a tick label.
+// With the default tick count every position is labeled.
+func (a AxisConfig) isTick(i, n int) bool {
+	if a.Ticks <= 0 || a.Ticks >= n {
+		return true
+	}
+	if a.Ticks == 1 {
+		return i == 0
+	}
+	for k := 0; k < a.Ticks; k++ {
+		if tickPosition(k, a.Ticks, n) == i {
+			return true
+		}
+	}
+	return false
+}
+
+// tickPosition returns the position of tick k of ticks spread over n positions.
+func tickPosition(k, ticks, n int) int {
+	if ticks <= 1 {
+		return 0
+	}
+	return int(math.Round(float64(k) * float64(n-1) / float64(ticks-1)))
+}
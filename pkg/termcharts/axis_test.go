@@ -0,0 +1,382 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAxisScale_String(t *testing.T) {
+	tests := []struct {
+		scale    AxisScale
+		expected string
+	}{
+		{ScaleLinear, "linear"},
+		{ScaleLog, "log"},
+		{AxisScale(9), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.scale.String(); got != tt.expected {
+			t.Errorf("AxisScale(%d).String() = %v, want %v", tt.scale, got, tt.expected)
+		}
+	}
+}
+
+func TestAxisConfig_resolveRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		axis    AxisConfig
+		data    []float64
+		wantMin float64
+		wantMax float64
+	}{
+		{
+			name:    "auto range",
+			data:    []float64{3, 1, 7},
+			wantMin: 1,
+			wantMax: 7,
+		},
+		{
+			name:    "fixed range",
+			axis:    AxisConfig{Min: 0, Max: 100},
+			data:    []float64{3, 1, 7},
+			wantMin: 0,
+			wantMax: 100,
+		},
+		{
+			name:    "max not above min is auto",
+			axis:    AxisConfig{Min: 5, Max: 5},
+			data:    []float64{3, 1, 7},
+			wantMin: 1,
+			wantMax: 7,
+		},
+		{
+			name:    "log raises non-positive min",
+			axis:    AxisConfig{Scale: ScaleLog},
+			data:    []float64{0, 10, 1000},
+			wantMin: 10,
+			wantMax: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max := tt.axis.resolveRange(tt.data)
+			if min != tt.wantMin || max != tt.wantMax {
+				t.Errorf("resolveRange() = (%v, %v), want (%v, %v)", min, max, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestAxisConfig_isTick(t *testing.T) {
+	tests := []struct {
+		name  string
+		ticks int
+		n     int
+		want  []int
+	}{
+		{name: "default labels every position", ticks: 0, n: 4, want: []int{0, 1, 2, 3}},
+		{name: "more ticks than positions", ticks: 10, n: 3, want: []int{0, 1, 2}},
+		{name: "single tick", ticks: 1, n: 5, want: []int{0}},
+		{name: "ends and middle", ticks: 3, n: 9, want: []int{0, 4, 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			axis := AxisConfig{Ticks: tt.ticks}
+			var got []int
+			for i := 0; i < tt.n; i++ {
+				if axis.isTick(i, tt.n) {
+					got = append(got, i)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ticks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNiceTicks(t *testing.T) {
+	tests := []struct {
+		name           string
+		lo, hi         float64
+		ticks          int
+		widen          bool
+		min, max, step float64
+	}{
+		{name: "quarters", widen: true, lo: 3, hi: 97, ticks: 5, min: 0, max: 100, step: 25},
+		{name: "already round", widen: true, lo: 0, hi: 100, ticks: 5, min: 0, max: 100, step: 25},
+		{name: "step grows to fit", widen: true, lo: -7, hi: 13, ticks: 3, min: -20, max: 20, step: 20},
+		{name: "fractions", widen: true, lo: 0.13, hi: 0.4, ticks: 4, min: 0.1, max: 0.4, step: 0.1},
+		{name: "fixed range", lo: 10, hi: 90, ticks: 3, min: 25, max: 75, step: 25},
+		{name: "single tick", widen: true, lo: 2, hi: 9, ticks: 1, min: 0, max: 10, step: 10},
+		{name: "one tick across zero", widen: true, lo: -3, hi: 7, ticks: 1, min: -10, max: 10, step: 10},
+		{name: "two ticks across zero", widen: true, lo: -3, hi: 7, ticks: 2, min: -10, max: 10, step: 10},
+		{name: "huge across zero", widen: true, lo: -1e300, hi: 1e300, ticks: 2, min: -1e300, max: 1e300, step: 1e300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max, step := niceTicks(tt.lo, tt.hi, tt.ticks, tt.widen)
+			near := func(got, want float64) bool { return math.Abs(got-want) <= 1e-9*math.Max(1, math.Abs(want)) }
+			if !near(min, tt.min) || !near(max, tt.max) || !near(step, tt.step) {
+				t.Errorf("niceTicks(%v, %v, %d) = %v, %v, %v, want %v, %v, %v",
+					tt.lo, tt.hi, tt.ticks, min, max, step, tt.min, tt.max, tt.step)
+			}
+			// Widening again changes nothing
+			if min2, max2, step2 := niceTicks(min, max, tt.ticks, tt.widen); tt.widen && (min2 != min || max2 != max || step2 != step) {
+				t.Errorf("niceTicks is not stable: %v, %v, %v", min2, max2, step2)
+			}
+		})
+	}
+}
+
+func TestLineChart_YTicks(t *testing.T) {
+	opts := []LineOption{WithColor(false), WithHeight(13), WithWidth(30), WithShowAxes(true), WithXAxis(AxisConfig{Hidden: true})}
+
+	result := NewLineChart(append(opts, WithData([]float64{3, 40, 97}), WithYTicks(5))...).Render()
+	if got, want := yTickLabels(result), "100 75 50 25 0"; got != want {
+		t.Errorf("y axis = %q, want %q", got, want)
+	}
+
+	// A fixed range is not widened
+	result = NewLineChart(append(opts, WithData([]float64{3, 40, 97}), WithYTicks(3),
+		WithYAxis(AxisConfig{Min: 10, Max: 90}))...).Render()
+	if got, want := yTickLabels(result), "75 50 25"; got != want {
+		t.Errorf("fixed range y axis = %q, want %q", got, want)
+	}
+
+	// Composed charts share the widened axis
+	chart := Compose([]Layer{{Kind: LayerLine, Series: Series{Data: []float64{0.13, 0.4, 0.27}}}},
+		WithColor(false), WithHeight(10), WithWidth(30), WithYTicks(4), WithXAxis(AxisConfig{Hidden: true}))
+	if got, want := yTickLabels(chart.Render()), "0.4 0.3 0.2 0.1"; got != want {
+		t.Errorf("composed y axis = %q, want %q", got, want)
+	}
+}
+
+func TestLineChart_YTicksAcrossZero(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []float64
+		ticks int
+		want  string
+	}{
+		{name: "one tick", data: []float64{-3, 7, 2}, ticks: 1, want: "10 0 -10"},
+		{name: "two ticks", data: []float64{-3, 7, 2}, ticks: 2, want: "10 0 -10"},
+		{name: "huge values", data: []float64{-1e300, 1e300}, ticks: 1},
+		{name: "huge values, two ticks", data: []float64{-1e300, 1e300}, ticks: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewLineChart(WithData(tt.data), WithYTicks(tt.ticks), WithColor(false),
+				WithHeight(9), WithWidth(30), WithShowAxes(true), WithXAxis(AxisConfig{Hidden: true})).Render()
+			labels := yTickLabels(result)
+			if tt.want != "" && labels != tt.want {
+				t.Errorf("y axis = %q, want %q", labels, tt.want)
+			}
+			if len(strings.Fields(labels)) < 2 {
+				t.Errorf("y axis should be labeled at both ends, got %q:\n%s", labels, result)
+			}
+		})
+	}
+}
+
+// yTickLabels returns the numeric y axis labels of a rendered chart.
+func yTickLabels(out string) string {
+	var labels []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if _, err := strconv.ParseFloat(fields[0], 64); err == nil {
+			labels = append(labels, fields[0])
+		}
+	}
+	return strings.Join(labels, " ")
+}
+
+func TestLineChart_YAxis(t *testing.T) {
+	data := WithData([]float64{10, 20, 30})
+
+	t.Run("fixed range and format", func(t *testing.T) {
+		line := NewLineChart(data, WithColor(false), WithHeight(8), WithYAxis(AxisConfig{
+			Min:    0,
+			Max:    100,
+			Ticks:  2,
+			Format: func(v float64) string { return fmt.Sprintf("%.0f%%", v) },
+		}))
+		result := line.Render()
+
+		if !strings.Contains(result, "100%") || !strings.Contains(result, "0%") {
+			t.Errorf("expected formatted axis bounds, got:\n%s", result)
+		}
+		if strings.Contains(result, "30.0") {
+			t.Errorf("default formatter should not be used, got:\n%s", result)
+		}
+	})
+
+	t.Run("log scale", func(t *testing.T) {
+		line := NewLineChart(WithData([]float64{1, 10, 100, 1000}), WithColor(false), WithHeight(6),
+			WithYAxis(AxisConfig{Scale: ScaleLog}))
+		result := line.Render()
+
+		if !strings.Contains(result, "1000.0") || !strings.Contains(result, "1.0") {
+			t.Errorf("expected log axis bounds, got:\n%s", result)
+		}
+	})
+
+	t.Run("hidden", func(t *testing.T) {
+		line := NewLineChart(data, WithColor(false), WithStyle(StyleASCII), WithYAxis(AxisConfig{Hidden: true}))
+		result := line.Render()
+
+		if strings.Contains(result, "30.0") {
+			t.Errorf("hidden Y axis should have no labels, got:\n%s", result)
+		}
+	})
+}
+
+func TestLineChart_XAxis(t *testing.T) {
+	t.Run("generated ticks", func(t *testing.T) {
+		line := NewLineChart(WithData([]float64{1, 2, 3, 4, 5}), WithColor(false), WithStyle(StyleASCII),
+			WithXAxis(AxisConfig{Ticks: 3}))
+		lines := strings.Split(strings.TrimRight(line.Render(), "\n"), "\n")
+		last := strings.Fields(lines[len(lines)-1])
+
+		if fmt.Sprint(last) != "[0 2 4]" {
+			t.Errorf("X axis labels = %v, want [0 2 4]", last)
+		}
+	})
+
+	t.Run("thinned labels", func(t *testing.T) {
+		line := NewLineChart(WithData([]float64{1, 2, 3, 4, 5}), WithColor(false), WithStyle(StyleASCII),
+			WithLabels([]string{"A", "B", "C", "D", "E"}), WithXAxis(AxisConfig{Ticks: 2}))
+		lines := strings.Split(strings.TrimRight(line.Render(), "\n"), "\n")
+		last := strings.Fields(lines[len(lines)-1])
+
+		if fmt.Sprint(last) != "[A E]" {
+			t.Errorf("X axis labels = %v, want [A E]", last)
+		}
+	})
+
+	t.Run("hidden", func(t *testing.T) {
+		line := NewLineChart(WithData([]float64{1, 2, 3}), WithColor(false), WithStyle(StyleASCII),
+			WithLabels([]string{"Jan", "Feb", "Mar"}), WithXAxis(AxisConfig{Hidden: true}))
+		result := line.Render()
+
+		if strings.Contains(result, "Jan") || strings.Contains(result, "---") {
+			t.Errorf("hidden X axis should not be drawn, got:\n%s", result)
+		}
+	})
+}
+
+func TestBarChart_YAxis(t *testing.T) {
+	bar := NewBarChart(
+		WithData([]float64{50, 200}),
+		WithLabels([]string{"A", "B"}),
+		WithColor(false),
+		WithStyle(StyleASCII),
+		WithShowValues(true),
+		WithWidth(30),
+		WithYAxis(AxisConfig{Max: 100, Format: func(v float64) string { return fmt.Sprintf("%.0f", v) }}),
+	)
+	lines := strings.Split(strings.TrimRight(bar.Render(), "\n"), "\n")
+
+	half := strings.Count(lines[0], "#")
+	full := strings.Count(lines[1], "#")
+	if full == 0 || half != full/2 {
+		t.Errorf("bar lengths = %d and %d, want half and full width", half, full)
+	}
+	if !strings.HasSuffix(lines[1], " 200") {
+		t.Errorf("value should use axis formatter, got %q", lines[1])
+	}
+}
+
+func TestBarChart_XAxisHidden(t *testing.T) {
+	bar := NewBarChart(
+		WithData([]float64{1, 2}),
+		WithLabels([]string{"Alpha", "Beta"}),
+		WithColor(false),
+		WithXAxis(AxisConfig{Hidden: true}),
+	)
+
+	if result := bar.Render(); strings.Contains(result, "Alpha") {
+		t.Errorf("hidden category axis should not show labels, got:\n%s", result)
+	}
+}
+
+func TestSparkline_YAxis(t *testing.T) {
+	spark := NewSparkline(
+		WithData([]float64{0, 50, 100, 150}),
+		WithStyle(StyleASCII),
+		WithYAxis(AxisConfig{Min: 0, Max: 100}),
+	)
+
+	if got := spark.Render(); got != "_=@@" {
+		t.Errorf("Render() = %q, want %q", got, "_=@@")
+	}
+}
+
+func TestWithYUnit(t *testing.T) {
+	data := []float64{90, 1200}
+	opts := []Option{WithData(data), WithColor(false), WithStyle(StyleASCII), WithWidth(40), WithHeight(8)}
+	kilo := WithYAxis(AxisConfig{Format: func(v float64) string { return fmt.Sprintf("%.1fk", v/1000) }})
+
+	tests := []struct {
+		name  string
+		chart Chart
+		want  []string
+	}{
+		{
+			name:  "bar values",
+			chart: NewBarChart(Combine(opts...), WithShowValues(true), WithYUnit("ms")),
+			want:  []string{" 90.0 ms\n", "1200.0 ms\n"},
+		},
+		{
+			name:  "axis labels after a formatter",
+			chart: NewLineChart(Combine(opts...), kilo, WithYUnit("ms")),
+			want:  []string{"1.2k ms ", "0.1k ms "},
+		},
+		{
+			name:  "sparkline stats",
+			chart: NewSparkline(Combine(opts...), WithSparkStats(true), WithYUnit("ms")),
+			want:  []string{"min 90.0 ms  max 1200.0 ms  last 1200.0 ms"},
+		},
+		{
+			name:  "text summary",
+			chart: NewSparkline(Combine(opts...), WithTextSummary(true), WithYUnit("ms")),
+			want:  []string{"min 90.0 ms, max 1200.0 ms"},
+		},
+		{
+			name:  "legend stats",
+			chart: NewLineChart(Combine(opts...), WithSeries([]Series{{Label: "a", Data: data}, {Label: "b", Data: data}}), WithData(nil), WithLegendStats(LegendCurrent), WithYUnit("ms")),
+			want:  []string{"a  cur 1200.0 ms"},
+		},
+		{
+			// Histogram counts have no unit; their bins do
+			name:  "histogram bins",
+			chart: NewHistogram(Combine(opts...), WithBins(2), WithShowValues(true), WithYUnit("ms")),
+			want:  []string{"[90, 645) ms", "# 1\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.chart.Render()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Render() missing %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+
+	if got := NewBarChart(Combine(opts...), WithShowValues(true)).Render(); strings.Contains(got, "ms") {
+		t.Errorf("Render() without a unit = %q", got)
+	}
+}
@@ -0,0 +1,149 @@
+package termcharts
+
+import (
+	"math"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// Shading of confidence bands drawn by line charts.
+const (
+	bandShade      = '░'
+	asciiBandShade = '.'
+)
+
+// hasBand reports whether s has an upper or lower bound to shade.
+func (s Series) hasBand() bool {
+	return len(s.Upper) > 0 || len(s.Lower) > 0
+}
+
+// bandColor returns the style spec a band is shaded in: the series color,
+// faint, so the line stays in front of it.
+func bandColor(color string) string {
+	if color == "" {
+		return "faint"
+	}
+	return "faint " + color
+}
+
+// seriesBand returns the lower and upper bound of the band of s at each of
+// width columns, spread across the columns like the line's points and
+// linearly interpolated between them. A column the band does not reach has
+// NaN bounds. A missing bound is the data itself.
+func seriesBand(s Series, width int) (lower, upper []float64) {
+	lower, upper = make([]float64, width), make([]float64, width)
+	for x := range lower {
+		lower[x], upper[x] = math.NaN(), math.NaN()
+	}
+	n := len(s.Data)
+	if n == 0 || width <= 0 {
+		return lower, upper
+	}
+
+	bound := func(b []float64, i int) float64 {
+		if len(b) == 0 {
+			return s.Data[i]
+		}
+		return b[i]
+	}
+	// widen extends the band at column x to cover lo and hi
+	widen := func(x int, lo, hi float64) {
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		if math.IsNaN(lower[x]) || lo < lower[x] {
+			lower[x] = lo
+		}
+		if math.IsNaN(upper[x]) || hi > upper[x] {
+			upper[x] = hi
+		}
+	}
+
+	if n == 1 {
+		widen(width/2, bound(s.Lower, 0), bound(s.Upper, 0))
+		return lower, upper
+	}
+	column := func(i int) float64 { return float64(i) / float64(n-1) * float64(width-1) }
+	for i := 0; i < n-1; i++ {
+		x1, x2 := column(i), column(i+1)
+		lo1, lo2 := bound(s.Lower, i), bound(s.Lower, i+1)
+		hi1, hi2 := bound(s.Upper, i), bound(s.Upper, i+1)
+		for x := int(x1); x <= int(x2) && x < width; x++ {
+			t := 0.0
+			if x2 > x1 {
+				t = math.Max(0, math.Min(1, (float64(x)-x1)/(x2-x1)))
+			}
+			widen(x, lo1+(lo2-lo1)*t, hi1+(hi2-hi1)*t)
+		}
+	}
+	return lower, upper
+}
+
+// drawBandASCII shades the band of each series onto the empty cells of the
+// grid, rows 0 (top) to height-1 mapping maxVal to minVal. Bands are drawn
+// before the lines, which then draw over them.
+func drawBandASCII(grid [][]rune, colors [][]string, series []Series, width, height int, minVal, maxVal float64, useUnicode bool) {
+	shade := bandShade
+	if !useUnicode {
+		shade = asciiBandShade
+	}
+	for _, s := range series {
+		if !s.hasBand() {
+			continue
+		}
+		color := bandColor(s.Color)
+		lower, upper := seriesBand(s, width)
+		for x := 0; x < width; x++ {
+			if math.IsNaN(lower[x]) {
+				continue
+			}
+			top := bandRow(upper[x], height, minVal, maxVal)
+			bottom := bandRow(lower[x], height, minVal, maxVal)
+			for y := top; y <= bottom; y++ {
+				if grid[y][x] == ' ' {
+					grid[y][x] = shade
+					colors[y][x] = color
+				}
+			}
+		}
+	}
+}
+
+// bandCells returns the band color of each character cell of a chart drawn
+// with sub-cell dots, dotRows to a cell, or "" where no band is drawn. Bounds
+// are placed at dot resolution, like the lines, so a cell is shaded when the
+// band covers any of its dots.
+func bandCells(series []Series, charWidth, charHeight, dotRows int, minVal, maxVal float64) [][]string {
+	var cells [][]string
+	for _, s := range series {
+		if !s.hasBand() {
+			continue
+		}
+		if cells == nil {
+			cells = make([][]string, charHeight)
+			for y := range cells {
+				cells[y] = make([]string, charWidth)
+			}
+		}
+		color := bandColor(s.Color)
+		lower, upper := seriesBand(s, charWidth)
+		for x := 0; x < charWidth; x++ {
+			if math.IsNaN(lower[x]) {
+				continue
+			}
+			top := bandRow(upper[x], charHeight*dotRows, minVal, maxVal) / dotRows
+			bottom := bandRow(lower[x], charHeight*dotRows, minVal, maxVal) / dotRows
+			for y := top; y <= bottom; y++ {
+				cells[y][x] = color
+			}
+		}
+	}
+	return cells
+}
+
+// bandRow returns the row of v on a plot rows high, where row 0 is maxVal,
+// placed like the points of a line.
+func bandRow(v float64, rows int, minVal, maxVal float64) int {
+	y := int((maxVal - v) / (maxVal - minVal) * float64(rows-1))
+	return internal.ClampInt(y, 0, rows-1)
+}
@@ -0,0 +1,116 @@
+package termcharts
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSeriesBand(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name         string
+		series       Series
+		width        int
+		lower, upper []float64
+	}{
+		{
+			name:   "interpolated between points",
+			series: Series{Data: []float64{2, 4, 6}, Lower: []float64{1, 3, 5}, Upper: []float64{3, 5, 9}},
+			width:  5,
+			lower:  []float64{1, 2, 3, 4, 5},
+			upper:  []float64{3, 4, 5, 7, 9},
+		},
+		{
+			name:   "missing bound is the data",
+			series: Series{Data: []float64{2, 4}, Upper: []float64{3, 6}},
+			width:  3,
+			lower:  []float64{2, 3, 4},
+			upper:  []float64{3, 4.5, 6},
+		},
+		{
+			name:   "more points than columns",
+			series: Series{Data: []float64{0, 0, 0, 0, 0}, Lower: []float64{-1, -5, -1, -1, -1}, Upper: []float64{1, 1, 1, 8, 1}},
+			width:  2,
+			lower:  []float64{-5, -1},
+			upper:  []float64{8, 1},
+		},
+		{
+			name:   "single point is centered",
+			series: Series{Data: []float64{5}, Lower: []float64{4}, Upper: []float64{7}},
+			width:  4,
+			lower:  []float64{nan, nan, 4, nan},
+			upper:  []float64{nan, nan, 7, nan},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, upper := seriesBand(tt.series, tt.width)
+			if !sameFloats(lower, tt.lower) || !sameFloats(upper, tt.upper) {
+				t.Errorf("seriesBand() = %v, %v, want %v, %v", lower, upper, tt.lower, tt.upper)
+			}
+		})
+	}
+}
+
+func TestLineChart_Render_ConfidenceBand(t *testing.T) {
+	series := []Series{{
+		Label: "forecast",
+		Data:  []float64{10, 12, 14, 16},
+		Lower: []float64{10, 11, 12, 13},
+		Upper: []float64{10, 13, 16, 22},
+	}}
+
+	t.Run("ascii", func(t *testing.T) {
+		out := NewLineChart(WithSeries(series), WithWidth(30), WithHeight(10), WithStyle(StyleASCII), WithColor(false)).Render()
+
+		// The axis reaches the upper bound, and the line is drawn over the band
+		if got := strings.Fields(yTickLabels(out))[0]; got != "22.0" {
+			t.Errorf("top Y label = %q, want 22.0 from the upper bound\n%s", got, out)
+		}
+		if !strings.Contains(out, "..") || !strings.Contains(out, "/") {
+			t.Errorf("want a shaded band and a line, got:\n%s", out)
+		}
+		if got := strings.Count(out, "*"); got != 4 {
+			t.Errorf("drew %d points, want all 4 over the band:\n%s", got, out)
+		}
+	})
+
+	t.Run("braille", func(t *testing.T) {
+		out := NewLineChart(WithSeries(series), WithWidth(30), WithHeight(10), WithStyle(StyleBraille), WithColor(false)).Render()
+		if !strings.ContainsRune(out, bandShade) {
+			t.Errorf("want a shaded band, got:\n%s", out)
+		}
+	})
+
+	t.Run("faint series color", func(t *testing.T) {
+		colored := []Series{series[0]}
+		colored[0].Color = "green"
+		out := NewLineChart(WithSeries(colored), WithWidth(30), WithHeight(10), WithStyle(StyleUnicode), WithColor(true)).Render()
+		if !strings.Contains(out, colorCode("faint green")+string(bandShade)) {
+			t.Errorf("want the band in faint green, got %q", out)
+		}
+	})
+
+	t.Run("without bounds", func(t *testing.T) {
+		plain := []Series{{Label: "forecast", Data: series[0].Data}}
+		out := NewLineChart(WithSeries(plain), WithWidth(30), WithHeight(10), WithStyle(StyleUnicode), WithColor(false)).Render()
+		if strings.ContainsRune(out, bandShade) {
+			t.Errorf("want no band, got:\n%s", out)
+		}
+	})
+}
+
+// sameFloats reports whether a and b hold the same values, treating NaNs as equal.
+func sameFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && !(math.IsNaN(a[i]) && math.IsNaN(b[i])) {
+			return false
+		}
+	}
+	return true
+}
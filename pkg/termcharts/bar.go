@@ -1,7 +1,7 @@
 package termcharts
 
 import (
-	"fmt"
+	"bytes"
 	"math"
 	"strings"
 
@@ -12,7 +12,11 @@ import (
 // Bar charts can be rendered horizontally or vertically and support
 // single or multiple data series with grouped or stacked modes.
 type BarChart struct {
-	opts *Options
+	opts    *Options
+	err     error
+	footer  string         // Drawn below the chart, e.g. "page 2 of 7 (11-20 of 64)"
+	headers map[int]string // Group headers, drawn above the category they start
+	out     *rowStream     // Receives horizontal rows as they are drawn, for RenderTo
 }
 
 // BarMode specifies how multiple series are displayed in a bar chart.
@@ -52,33 +56,130 @@ const barCharASCII = '#'
 //	    termcharts.WithLabels([]string{"Q1", "Q2", "Q3", "Q4"}),
 //	)
 //	fmt.Println(bar.Render())
-func NewBarChart(opts ...Option) *BarChart {
-	options := NewOptions(opts...)
-	return &BarChart{
+func NewBarChart(opts ...BarOption) *BarChart {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyBar(options)
+	}
+	b := &BarChart{
 		opts: options,
 	}
+	if options.Strict {
+		b.err = b.validateOptions()
+	}
+	return b
 }
 
+// Update applies opts to the bar chart, replacing the options they set.
+// The next Render draws the bar chart with the new options; with WithStrict
+// they are validated again. Use it to feed new data into a live display.
+func (b *BarChart) Update(opts ...BarOption) {
+	for _, opt := range opts {
+		opt.applyBar(b.opts)
+	}
+	b.err = nil
+	if b.opts.Strict {
+		b.err = b.validateOptions()
+	}
+}
+
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the bar chart Updatable.
+func (b *BarChart) Apply(opts ...Option) { b.Update(Combine(opts...)) }
+
 // Render generates the bar chart as a multi-line string.
+// It returns an empty string if the chart cannot be drawn; use RenderE to find out why.
 func (b *BarChart) Render() string {
-	// Validate data
-	if len(b.opts.Data) == 0 && len(b.opts.Series) == 0 {
-		return ""
+	out, _ := b.RenderE()
+	return out
+}
+
+// RenderE generates the bar chart as a multi-line string.
+// It returns ErrEmptyData, ErrInvalidData, or ErrInvalidDimensions
+// (possibly wrapped) when the chart cannot be drawn.
+func (b *BarChart) RenderE() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if err := b.opts.checkPoints(); err != nil {
+		return "", err
+	}
+
+	// A width or height of 0 is the terminal's; others are clamped
+	if opts := b.opts.sized(); opts != b.opts {
+		return (&BarChart{opts: opts, out: b.out}).RenderE()
+	}
+	if opts := b.opts.percentScaled(); opts != b.opts {
+		return (&BarChart{opts: opts, out: b.out}).RenderE()
+	}
+	if opts := b.opts.aligned(); opts != b.opts {
+		return (&BarChart{opts: opts, out: b.out}).RenderE()
+	}
+	if opts, footer := b.opts.paged(); opts != b.opts {
+		// Every page is scaled like the whole chart, with a line for the footer
+		if !opts.YAxis.fixedRange() {
+			opts.YAxis.Min, opts.YAxis.Max = 0, b.fullMax()
+		}
+		opts.Height = internal.Max(opts.Height-1, minHeight)
+		return (&BarChart{opts: opts, footer: footer, out: b.out}).RenderE()
+	}
+	if opts, headers := b.opts.grouped(); opts != b.opts {
+		return (&BarChart{opts: opts, footer: b.footer, headers: headers, out: b.out}).RenderE()
+	}
+	if b.opts.noData() {
+		return b.opts.placeholder(b.opts.Width, b.opts.Height, b.shouldUseUnicode(), b.isColorEnabled()), nil
 	}
 
 	// If multi-series, render grouped or stacked
 	if len(b.opts.Series) > 0 {
+		if err := validateSeries(b.opts.Series); err != nil {
+			return "", err
+		}
 		if b.opts.Direction == Horizontal {
-			return b.renderHorizontalMultiSeries()
+			return b.finish(b.renderHorizontalMultiSeries()), nil
 		}
-		return b.renderVerticalMultiSeries()
+		return b.finish(b.renderVerticalMultiSeries()), nil
+	}
+
+	if err := validateData(b.opts.Data); err != nil {
+		return "", err
 	}
 
 	// Render based on direction
 	if b.opts.Direction == Horizontal {
-		return b.renderHorizontal()
+		return b.finish(b.renderHorizontal()), nil
 	}
-	return b.renderVertical()
+	return b.finish(b.renderVertical()), nil
+}
+
+// finish draws the footer below the rendered chart, if there is one, and
+// runs the output hooks.
+func (b *BarChart) finish(out string) string {
+	if b.footer != "" {
+		footer := fitTitle(b.footer, b.opts.Width, b.shouldUseUnicode())
+		if b.isColorEnabled() {
+			theme := b.opts.Theme
+			if theme == nil {
+				theme = DefaultTheme
+			}
+			footer = Colorize(footer, b.opts.axisColor(theme), true)
+		}
+		out += footer + "\n"
+	}
+	return b.opts.postProcess(out)
+}
+
+// fullMax returns the value of the longest bar across all categories, for
+// scaling a page of them like the whole chart.
+func (b *BarChart) fullMax() float64 {
+	if len(b.opts.Series) == 0 {
+		return findMax(b.opts.Data)
+	}
+	theme := b.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	return b.calculateMaxValue(b.opts.visibleSeries(b.opts.Series, theme))
 }
 
 // renderHorizontal renders a horizontal bar chart.
@@ -88,11 +189,6 @@ func (b *BarChart) renderHorizontal() string {
 	data := b.opts.Data
 	labels := b.opts.Labels
 
-	// Check for invalid values
-	if !internal.AllValid(data) {
-		return ""
-	}
-
 	// Determine character set based on style
 	useUnicode := b.shouldUseUnicode()
 
@@ -104,106 +200,135 @@ func (b *BarChart) renderHorizontal() string {
 	}
 
 	// Find max value for scaling
-	maxVal := findMax(data)
-	if maxVal == 0 {
-		maxVal = 1 // Avoid division by zero
-	}
+	maxVal := b.valueMax(findMax(data))
 
 	// Calculate bar width (leave room for labels and values)
 	maxLabelWidth := 0
-	if b.opts.ShowAxes && len(labels) > 0 {
-		maxLabelWidth = maxStringLength(labels) + 1
+	if b.showCategoryAxis() && len(labels) > 0 {
+		maxLabelWidth = b.opts.labelGutter(labels) + 1
 	}
 
+	var valueTexts []string
 	valueWidth := 0
 	if b.opts.ShowValues {
-		valueWidth = len(fmt.Sprintf(" %.1f", maxVal)) + 1
+		valueTexts, valueWidth = b.valueColumn(data)
 	}
 
-	// Calculate available width for bars
-	barWidth := b.opts.Width - maxLabelWidth - valueWidth - 2
-	if barWidth < 1 {
-		barWidth = 20 // Minimum bar width
-	}
+	// Fit labels, bars, and values within the chart width
+	layout := layoutBarRow(b.opts.Width, maxLabelWidth, valueWidth, b.showCategoryAxis())
+	barWidth := layout.barWidth
 
-	var result strings.Builder
+	result := getBuffer()
+	defer putBuffer(result)
+	result.Grow(frameBytes(b.opts.Width, len(data)))
 
 	// Render title if provided
 	if b.opts.Title != "" {
-		titleText := b.opts.Title
+		titleText := fitTitle(b.opts.Title, b.opts.Width, useUnicode)
 		if colorEnabled {
-			titleText = Colorize(titleText, theme.Text, true)
+			titleText = Colorize(titleText, b.opts.titleColor(theme), true)
 		}
 		result.WriteString(titleText)
 		result.WriteString("\n")
 	}
 
-	// Render each bar
+	// Render each bar beside its label
 	for i, val := range data {
-		// Render label
-		if b.opts.ShowAxes {
-			label := ""
-			if i < len(labels) {
-				label = labels[i]
-			}
-			labelText := fmt.Sprintf("%-*s ", maxLabelWidth, label)
-			if colorEnabled {
-				labelText = Colorize(labelText, theme.Muted, true)
-			}
-			result.WriteString(labelText)
-		}
+		b.writeGroupHeader(result, i, useUnicode, colorEnabled, theme)
 
-		// Calculate bar length
-		barLen := int(float64(barWidth) * (val / maxVal))
-		if barLen < 0 {
-			barLen = 0
+		label := ""
+		if i < len(labels) {
+			label = labels[i]
 		}
+		b.writeLabelRow(result, label, layout, useUnicode, colorEnabled, theme, func() {
+			// Calculate bar length
+			barLen, tiny := b.barCells(val, maxVal, barWidth)
+			if tiny {
+				writeTinyBar(result, 1, false, useUnicode, colorEnabled, theme.Primary)
+				return
+			}
+			b.writeBar(result, barLen, barWidth, useUnicode, colorEnabled, theme.Primary)
+		}, func() {
+			// Render value
+			if layout.values {
+				valueText := valueTexts[i]
+				if colorEnabled {
+					valueText = Colorize(valueText, theme.Muted, true)
+				}
+				result.WriteString(valueText)
+			}
+		})
+		b.out.flush(result)
+	}
 
-		// Render bar
-		bar := b.renderBar(barLen, barWidth, useUnicode, colorEnabled, theme.Primary)
-		result.WriteString(bar)
+	return result.String()
+}
 
-		// Render value
-		if b.opts.ShowValues {
-			valueText := fmt.Sprintf(" %.1f", val)
-			if colorEnabled {
-				valueText = Colorize(valueText, theme.Muted, true)
-			}
-			result.WriteString(valueText)
-		}
+// writeCategoryLabel writes a horizontal bar label left-aligned to width
+// columns, truncating it if needed, followed by the space before the bar.
+func writeCategoryLabel(result *bytes.Buffer, label string, width int, useUnicode, colorEnabled bool, color string) {
+	if !colorEnabled {
+		color = ""
+	}
+	label = truncateLabel(label, width, useUnicode)
+	run := newColorRun(result)
+	run.writeString(label, color)
+	run.writeRepeat(' ', width-internal.StringWidth(label), color)
+	run.writeRune(' ', color)
+	run.end()
+}
 
-		result.WriteString("\n")
+// barCells returns how many of cells a bar of val fills, where maxVal fills
+// them all, and whether the bar is tiny: a positive value too small to fill
+// a cell, which WithMinBar draws as a marker.
+func (b *BarChart) barCells(val, maxVal float64, cells int) (int, bool) {
+	filled := int(float64(cells) * (val / maxVal))
+	if filled < 0 {
+		filled = 0
 	}
+	return filled, b.opts.MinBar && filled == 0 && val > 0
+}
 
-	return result.String()
+// writeTinyBar writes the marker of a tiny bar, width characters wide: an
+// eighth of a cell at the start of a horizontal bar, or at the bottom of a
+// vertical one.
+func writeTinyBar(result *bytes.Buffer, width int, vertical, useUnicode, colorEnabled bool, color string) {
+	marker := '▏'
+	switch {
+	case vertical && useUnicode:
+		marker = '▁'
+	case vertical:
+		marker = '_'
+	case !useUnicode:
+		marker = '|'
+	}
+	if !colorEnabled {
+		color = ""
+	}
+	run := newColorRun(result)
+	run.writeRepeat(marker, width, color)
+	run.end()
 }
 
-// renderBar renders a single horizontal bar with the given length.
-func (b *BarChart) renderBar(length, maxWidth int, useUnicode bool, colorEnabled bool, color string) string {
-	var bar strings.Builder
+// writeBar writes a single horizontal bar with the given length.
+func (b *BarChart) writeBar(result *bytes.Buffer, length, maxWidth int, useUnicode bool, colorEnabled bool, color string) {
+	if length > maxWidth {
+		length = maxWidth
+	}
+	if length <= 0 {
+		return
+	}
 
-	if useUnicode {
-		// Render full blocks
-		fullBlocks := length
-		for i := 0; i < fullBlocks && i < maxWidth; i++ {
-			char := string('█')
-			if colorEnabled {
-				char = Colorize(char, color, true)
-			}
-			bar.WriteString(char)
-		}
-	} else {
-		// ASCII mode - use '#' characters
-		for i := 0; i < length && i < maxWidth; i++ {
-			char := string(barCharASCII)
-			if colorEnabled {
-				char = Colorize(char, color, true)
-			}
-			bar.WriteString(char)
-		}
+	// Full blocks in Unicode mode, '#' characters in ASCII mode
+	char := b.opts.fillChar(useUnicode)
+	if !colorEnabled {
+		color = ""
 	}
 
-	return bar.String()
+	// The whole bar is one color run, so it gets a single escape sequence
+	run := newColorRun(result)
+	run.writeRepeat(char, length, color)
+	run.end()
 }
 
 // renderVertical renders a vertical bar chart.
@@ -213,11 +338,6 @@ func (b *BarChart) renderVertical() string {
 	data := b.opts.Data
 	labels := b.opts.Labels
 
-	// Check for invalid values
-	if !internal.AllValid(data) {
-		return ""
-	}
-
 	// Determine character set based on style
 	useUnicode := b.shouldUseUnicode()
 
@@ -229,30 +349,29 @@ func (b *BarChart) renderVertical() string {
 	}
 
 	// Find max value for scaling
-	maxVal := findMax(data)
-	if maxVal == 0 {
-		maxVal = 1
-	}
+	maxVal := b.valueMax(findMax(data))
 
 	// Calculate bar height
 	barHeight := b.opts.Height
 	if b.opts.Title != "" {
 		barHeight-- // Leave room for title
 	}
-	if b.opts.ShowAxes && len(labels) > 0 {
+	if b.showCategoryAxis() && len(labels) > 0 {
 		barHeight-- // Leave room for labels
 	}
 	if barHeight < 3 {
-		barHeight = 10 // Minimum height
+		barHeight = 3 // Minimum height
 	}
 
-	var result strings.Builder
+	result := getBuffer()
+	defer putBuffer(result)
+	result.Grow(frameBytes(len(data)*4, barHeight))
 
 	// Render title if provided
 	if b.opts.Title != "" {
-		titleText := b.opts.Title
+		titleText := fitTitle(b.opts.Title, b.opts.Width, useUnicode)
 		if colorEnabled {
-			titleText = Colorize(titleText, theme.Text, true)
+			titleText = Colorize(titleText, b.opts.titleColor(theme), true)
 		}
 		result.WriteString(titleText)
 		result.WriteString("\n")
@@ -266,13 +385,14 @@ func (b *BarChart) renderVertical() string {
 	for row := barHeight; row > 0; row-- {
 		for i, val := range data {
 			// Calculate how many rows this bar should fill
-			barRows := int(float64(barHeight) * (val / maxVal))
+			barRows, tiny := b.barCells(val, maxVal, barHeight)
 
 			// Determine if this row should have a bar
 			if row <= barRows {
 				// Render bar
-				char := b.renderVerticalBar(useUnicode, colorEnabled, theme.Primary)
-				result.WriteString(strings.Repeat(char, barWidth))
+				b.writeVerticalBar(result, barWidth, useUnicode, colorEnabled, theme.Primary)
+			} else if tiny && row == 1 {
+				writeTinyBar(result, barWidth, true, useUnicode, colorEnabled, theme.Primary)
 			} else {
 				// Render empty space
 				result.WriteString(strings.Repeat(" ", barWidth))
@@ -287,24 +407,20 @@ func (b *BarChart) renderVertical() string {
 	}
 
 	// Render labels if enabled
-	if b.opts.ShowAxes && len(labels) > 0 {
+	if b.showCategoryAxis() && len(labels) > 0 {
 		for i := range data {
 			label := ""
 			if i < len(labels) {
 				label = labels[i]
 				// Truncate or pad to bar width
-				if len(label) > barWidth {
-					label = label[:barWidth]
-				} else {
-					label = fmt.Sprintf("%-*s", barWidth, label)
-				}
+				label = internal.PadRight(label, barWidth)
 			} else {
 				label = strings.Repeat(" ", barWidth)
 			}
 
 			labelText := label
 			if colorEnabled {
-				labelText = Colorize(labelText, theme.Muted, true)
+				labelText = Colorize(labelText, b.opts.axisColor(theme), true)
 			}
 			result.WriteString(labelText)
 
@@ -319,17 +435,17 @@ func (b *BarChart) renderVertical() string {
 	return result.String()
 }
 
-// renderVerticalBar renders a single character for a vertical bar.
-func (b *BarChart) renderVerticalBar(useUnicode bool, colorEnabled bool, color string) string {
-	char := string(barCharASCII)
-	if useUnicode {
-		char = string('█')
+// writeVerticalBar writes one row of a vertical bar, width characters wide,
+// as a single color run.
+func (b *BarChart) writeVerticalBar(result *bytes.Buffer, width int, useUnicode bool, colorEnabled bool, color string) {
+	char := b.opts.fillChar(useUnicode)
+	if !colorEnabled {
+		color = ""
 	}
 
-	if colorEnabled {
-		return Colorize(char, color, true)
-	}
-	return char
+	run := newColorRun(result)
+	run.writeRepeat(char, width, color)
+	run.end()
 }
 
 // shouldUseUnicode determines whether to use Unicode characters based on style.
@@ -343,6 +459,29 @@ func (b *BarChart) shouldUseUnicode() bool {
 	return internal.SupportsUnicode()
 }
 
+// validateOptions reports options that bar charts cannot honor.
+func (b *BarChart) validateOptions() error {
+	if err := b.opts.Validate(); err != nil {
+		return err
+	}
+	if b.opts.Style.subCell() {
+		return conflict("%s style is only supported by line charts", b.opts.Style)
+	}
+	if len(b.opts.Series) == 0 && b.opts.BarMode == BarModeStacked {
+		return conflict("stacked bar mode requires multiple series; use WithSeries")
+	}
+	if b.opts.MinBar && b.opts.BarMode == BarModeStacked {
+		return conflict("WithMinBar does not apply to stacked bars; remove it or use BarModeGrouped")
+	}
+	if len(b.opts.Series) == 0 && b.opts.ShowLegend {
+		return conflict("a legend requires multiple series; use WithSeries")
+	}
+	if b.opts.GroupDelimiter != "" && b.opts.Direction == Vertical {
+		return conflict("category grouping only applies to horizontal bar charts")
+	}
+	return b.opts.validatePaging()
+}
+
 // isColorEnabled determines whether colors should be used.
 func (b *BarChart) isColorEnabled() bool {
 	if b.opts.ColorEnabled != nil {
@@ -351,6 +490,92 @@ func (b *BarChart) isColorEnabled() bool {
 	return internal.SupportsColor()
 }
 
+// renderLegend renders the legend for a multi-series bar chart.
+func (b *BarChart) renderLegend(series []Series, useUnicode, colorEnabled bool, theme *Theme) string {
+	marker := "█"
+	if !useUnicode {
+		marker = "#"
+	}
+	return chartLegend(b.opts, series, marker, colorEnabled, theme).Render()
+}
+
+// showCategoryAxis reports whether category labels are drawn.
+func (b *BarChart) showCategoryAxis() bool {
+	return b.opts.ShowAxes && !b.opts.XAxis.Hidden
+}
+
+// valueMax returns the value drawn as a full-length bar: the Y axis maximum
+// when one is set with WithYAxis, otherwise dataMax.
+func (b *BarChart) valueMax(dataMax float64) float64 {
+	if b.opts.YAxis.fixedRange() {
+		return b.opts.YAxis.Max
+	}
+	if dataMax == 0 {
+		return 1 // Avoid division by zero
+	}
+	return dataMax
+}
+
+// formatValue formats a value displayed next to a bar.
+func (b *BarChart) formatValue(val float64) string {
+	return " " + withUnit(b.opts.YAxis.format(val, "%.1f", b.opts.Locale), b.opts.YUnit)
+}
+
+// valueColumn formats the values displayed next to bars for a column at
+// the end of the bars, aligned on their decimal marks, and returns the
+// column's width.
+func (b *BarChart) valueColumn(values []float64) ([]string, int) {
+	texts := make([]string, len(values))
+	for i, v := range values {
+		texts[i] = b.formatValue(v)
+	}
+	texts = alignDecimals(texts, b.opts.Locale)
+	return texts, maxStringLength(texts)
+}
+
+// alignDecimals pads numbers on the left so their decimal marks line up
+// when they are written in a column. Numbers without a decimal mark align
+// on the end of their integer part.
+func alignDecimals(texts []string, locale string) []string {
+	nf, ok := lookupLocale(locale)
+	if !ok {
+		nf = numberFormat{decimal: ".", group: ","}
+	}
+	split := make([]int, len(texts))
+	intWidth := 0
+	for i, text := range texts {
+		split[i] = decimalSplit(text, nf)
+		intWidth = internal.Max(intWidth, internal.StringWidth(text[:split[i]]))
+	}
+	aligned := make([]string, len(texts))
+	for i, text := range texts {
+		aligned[i] = strings.Repeat(" ", intWidth-internal.StringWidth(text[:split[i]])) + text
+	}
+	return aligned
+}
+
+// decimalSplit returns the index at which the fraction of the number in
+// text starts: its decimal mark, or the end of its integer part, counting
+// group separators between digits.
+func decimalSplit(text string, nf numberFormat) int {
+	isDigit := func(i int) bool { return i < len(text) && text[i] >= '0' && text[i] <= '9' }
+	i := strings.IndexFunc(text, func(r rune) bool { return r >= '0' && r <= '9' })
+	if i < 0 {
+		return len(text)
+	}
+	for i < len(text) {
+		switch {
+		case isDigit(i):
+			i++
+		case nf.group != "" && strings.HasPrefix(text[i:], nf.group) && isDigit(i+len(nf.group)):
+			i += len(nf.group)
+		default:
+			return i
+		}
+	}
+	return i
+}
+
 // findMax finds the maximum value in a slice of floats.
 func findMax(data []float64) float64 {
 	if len(data) == 0 {
@@ -365,12 +590,12 @@ func findMax(data []float64) float64 {
 	return max
 }
 
-// maxStringLength returns the length of the longest string in a slice.
+// maxStringLength returns the display width of the widest string in a slice.
 func maxStringLength(strings []string) int {
 	max := 0
 	for _, s := range strings {
-		if len(s) > max {
-			max = len(s)
+		if w := internal.StringWidth(s); w > max {
+			max = w
 		}
 	}
 	return max
@@ -454,13 +679,6 @@ func (b *BarChart) renderHorizontalMultiSeries() string {
 	series := b.opts.Series
 	labels := b.opts.Labels
 
-	// Validate all series data
-	for _, s := range series {
-		if !internal.AllValid(s.Data) {
-			return ""
-		}
-	}
-
 	// Determine character set and color settings
 	useUnicode := b.shouldUseUnicode()
 	colorEnabled := b.isColorEnabled()
@@ -477,144 +695,235 @@ func (b *BarChart) renderHorizontalMultiSeries() string {
 		}
 	}
 
+	// Only plotted series are scaled and drawn; the legend lists them all
+	visible := b.opts.visibleSeries(series, theme)
+
 	// Calculate max value based on bar mode
-	maxVal := b.calculateMaxValue(series)
-	if maxVal == 0 {
-		maxVal = 1
-	}
+	maxVal := b.valueMax(b.calculateMaxValue(visible))
 
 	// Calculate label width
 	maxLabelWidth := 0
-	if b.opts.ShowAxes && len(labels) > 0 {
-		maxLabelWidth = maxStringLength(labels) + 1
+	if b.showCategoryAxis() && len(labels) > 0 {
+		maxLabelWidth = b.opts.labelGutter(labels) + 1
 	}
 
-	// Calculate bar width
-	barWidth := b.opts.Width - maxLabelWidth - 2
-	if barWidth < 1 {
-		barWidth = 20
+	// Stacked bars can end in their category's total
+	var totalTexts []string
+	valueWidth := 0
+	if b.opts.ShowValues && b.opts.BarMode == BarModeStacked {
+		totals := make([]float64, numCategories)
+		for cat := range totals {
+			totals[cat] = stackTotal(visible, cat)
+		}
+		totalTexts, valueWidth = b.valueColumn(totals)
 	}
 
-	var result strings.Builder
+	// Fit labels, bars, and totals within the chart width
+	layout := layoutBarRow(b.opts.Width, maxLabelWidth, valueWidth, b.showCategoryAxis())
+
+	result := getBuffer()
+	defer putBuffer(result)
+	result.Grow(frameBytes(b.opts.Width, numCategories))
 
 	// Render title
 	if b.opts.Title != "" {
-		titleText := b.opts.Title
+		titleText := fitTitle(b.opts.Title, b.opts.Width, useUnicode)
 		if colorEnabled {
-			titleText = Colorize(titleText, theme.Text, true)
+			titleText = Colorize(titleText, b.opts.titleColor(theme), true)
 		}
 		result.WriteString(titleText)
 		result.WriteString("\n")
 	}
 
 	// Render based on mode
-	if b.opts.BarMode == BarModeStacked {
-		b.renderHorizontalStacked(&result, series, labels, numCategories, maxVal, barWidth, maxLabelWidth, useUnicode, colorEnabled, theme)
-	} else {
-		b.renderHorizontalGrouped(&result, series, labels, numCategories, maxVal, barWidth, maxLabelWidth, useUnicode, colorEnabled, theme)
+	switch {
+	case len(visible) == 0:
+		// Every series is hidden; only the legend is drawn
+	case b.opts.BarMode == BarModeStacked:
+		b.renderHorizontalStacked(result, visible, labels, totalTexts, numCategories, maxVal, layout, useUnicode, colorEnabled, theme)
+	default:
+		b.renderHorizontalGrouped(result, visible, labels, numCategories, maxVal, layout, useUnicode, colorEnabled, theme)
 	}
 
 	// Render legend if enabled
 	if b.opts.ShowLegend {
 		result.WriteString("\n")
-		for i, s := range series {
-			color := theme.GetSeriesColor(i)
-			if s.Color != "" {
-				color = s.Color
-			}
-			legendChar := "█"
-			if !useUnicode {
-				legendChar = "#"
-			}
-			if colorEnabled {
-				legendChar = Colorize(legendChar, color, true)
-			}
-			result.WriteString(fmt.Sprintf("%s %s  ", legendChar, s.Label))
-		}
-		result.WriteString("\n")
+		result.WriteString(b.renderLegend(series, useUnicode, colorEnabled, theme))
 	}
 
 	return result.String()
 }
 
 // renderHorizontalGrouped renders horizontal grouped bars.
-func (b *BarChart) renderHorizontalGrouped(result *strings.Builder, series []Series, labels []string, numCategories int, maxVal float64, barWidth, maxLabelWidth int, useUnicode, colorEnabled bool, theme *Theme) {
+func (b *BarChart) renderHorizontalGrouped(result *bytes.Buffer, series []Series, labels []string, numCategories int, maxVal float64, layout barRow, useUnicode, colorEnabled bool, theme *Theme) {
+	barWidth := layout.barWidth
 	for cat := 0; cat < numCategories; cat++ {
-		// Render label for this category
-		if b.opts.ShowAxes {
-			label := ""
-			if cat < len(labels) {
-				label = labels[cat]
-			}
-			labelText := fmt.Sprintf("%-*s ", maxLabelWidth, label)
-			if colorEnabled {
-				labelText = Colorize(labelText, theme.Muted, true)
-			}
-			result.WriteString(labelText)
+		b.writeGroupHeader(result, cat, useUnicode, colorEnabled, theme)
+
+		label := ""
+		if cat < len(labels) {
+			label = labels[cat]
 		}
+		b.writeLabelRow(result, label, layout, useUnicode, colorEnabled, theme, func() {
+			// Render bars for each series side by side
+			for i, s := range series {
+				val := 0.0
+				if cat < len(s.Data) {
+					val = s.Data[cat]
+				}
 
-		// Render bars for each series side by side
-		for i, s := range series {
-			val := 0.0
-			if cat < len(s.Data) {
-				val = s.Data[cat]
-			}
+				barLen, tiny := b.barCells(val, maxVal, barWidth/len(series))
 
-			barLen := int(float64(barWidth/len(series)) * (val / maxVal))
-			if barLen < 0 {
-				barLen = 0
-			}
+				color := b.opts.seriesColor(theme, i, s.Label)
+				if s.Color != "" {
+					color = s.Color
+				}
 
-			color := theme.GetSeriesColor(i)
-			if s.Color != "" {
-				color = s.Color
+				if tiny {
+					writeTinyBar(result, 1, false, useUnicode, colorEnabled, color)
+					continue
+				}
+				b.writeBar(result, barLen, barWidth/len(series), useUnicode, colorEnabled, color)
 			}
+		}, func() {})
+		b.out.flush(result)
+	}
+}
 
-			bar := b.renderBar(barLen, barWidth/len(series), useUnicode, colorEnabled, color)
-			result.WriteString(bar)
+// renderHorizontalStacked renders horizontal stacked bars, ending each in
+// its total from totals when values are shown.
+func (b *BarChart) renderHorizontalStacked(result *bytes.Buffer, series []Series, labels, totals []string, numCategories int, maxVal float64, layout barRow, useUnicode, colorEnabled bool, theme *Theme) {
+	colors := make([]string, len(series))
+	for i, s := range series {
+		colors[i] = b.opts.seriesColor(theme, i, s.Label)
+		if s.Color != "" {
+			colors[i] = s.Color
 		}
-		result.WriteString("\n")
 	}
-}
 
-// renderHorizontalStacked renders horizontal stacked bars.
-func (b *BarChart) renderHorizontalStacked(result *strings.Builder, series []Series, labels []string, numCategories int, maxVal float64, barWidth, maxLabelWidth int, useUnicode, colorEnabled bool, theme *Theme) {
+	barWidth := layout.barWidth
+	values := make([]float64, len(series))
 	for cat := 0; cat < numCategories; cat++ {
-		// Render label for this category
-		if b.opts.ShowAxes {
-			label := ""
-			if cat < len(labels) {
-				label = labels[cat]
+		b.writeGroupHeader(result, cat, useUnicode, colorEnabled, theme)
+
+		label := ""
+		if cat < len(labels) {
+			label = labels[cat]
+		}
+		b.writeLabelRow(result, label, layout, useUnicode, colorEnabled, theme, func() {
+			// Render the series stacked end to end in one bar
+			for i, s := range series {
+				values[i] = 0
+				if cat < len(s.Data) {
+					values[i] = s.Data[cat]
+				}
 			}
-			labelText := fmt.Sprintf("%-*s ", maxLabelWidth, label)
-			if colorEnabled {
-				labelText = Colorize(labelText, theme.Muted, true)
+			b.writeStackedBar(result, values, colors, maxVal, barWidth, useUnicode, colorEnabled)
+		}, func() {
+			// Render the stack's total
+			if layout.values {
+				valueText := totals[cat]
+				if colorEnabled {
+					valueText = Colorize(valueText, theme.Muted, true)
+				}
+				result.WriteString(valueText)
 			}
-			result.WriteString(labelText)
+		})
+		b.out.flush(result)
+	}
+}
+
+// stackTotal returns the length of category cat's stacked bar: the sum of
+// the positive values of series in that category.
+func stackTotal(series []Series, cat int) float64 {
+	total := 0.0
+	for _, s := range series {
+		if cat < len(s.Data) && s.Data[cat] > 0 {
+			total += s.Data[cat]
 		}
+	}
+	return total
+}
 
-		// Render stacked bars (each series stacked horizontally)
-		for i, s := range series {
-			val := 0.0
-			if cat < len(s.Data) {
-				val = s.Data[cat]
-			}
+// writeStackedBar writes a horizontal bar of one segment per value, scaled
+// so that a stack totaling maxVal spans barWidth columns. Segment ends are
+// placed from running totals, so rounding does not add up along the bar, and
+// in Unicode mode to an eighth of a column: the bar ends in a partial block,
+// and a cell shared by two segments is a partial block in the first one's
+// color on the second one's. Without color, shared cells are full blocks so
+// the bar has no gaps. With a fill character, segments end on whole cells.
+func (b *BarChart) writeStackedBar(result *bytes.Buffer, values []float64, colors []string, maxVal float64, barWidth int, useUnicode, colorEnabled bool) {
+	unit := 1
+	full := b.opts.fillChar(useUnicode)
+	if useUnicode && b.opts.FillChar == 0 {
+		// Partial blocks only line up with full blocks
+		unit = 8
+	}
+	if !colorEnabled {
+		colors = make([]string, len(values))
+	}
 
-			barLen := int(float64(barWidth) * (val / maxVal))
-			if barLen < 0 {
-				barLen = 0
-			}
+	// ends[i] is where segment i ends, in units from the start of the bar;
+	// values above a fixed axis maximum are cut off at the bar width
+	limit := barWidth * unit
+	ends := make([]int, len(values))
+	total := 0.0
+	for i, val := range values {
+		if val > 0 {
+			total += val
+		}
+		ends[i] = internal.ClampInt(int(math.Round(total/maxVal*float64(limit))), 0, limit)
+	}
+	length := 0
+	if len(ends) > 0 {
+		length = ends[len(ends)-1]
+	}
 
-			color := theme.GetSeriesColor(i)
-			if s.Color != "" {
-				color = s.Color
+	run := newColorRun(result)
+	seg := 0
+	for start := 0; start < length; start += unit {
+		end := start + unit
+		for ends[seg] <= start {
+			seg++
+		}
+		switch {
+		case length < end:
+			// The bar ends inside this cell
+			run.writeRune(partialBlocks[length-start], colors[seg])
+		case ends[seg] < end:
+			// The segment ends inside this cell; find the one that fills its end
+			next := seg + 1
+			for ends[next] < end {
+				next++
 			}
-
-			bar := b.renderBar(barLen, barWidth, useUnicode, colorEnabled, color)
-			result.WriteString(bar)
+			covered := ends[seg] - start
+			if split := splitColor(colors[seg], colors[next]); split != "" {
+				run.writeRune(partialBlocks[covered], split)
+			} else if covered*2 >= unit {
+				run.writeRune(full, colors[seg])
+			} else {
+				run.writeRune(full, colors[next])
+			}
+		default:
+			run.writeRune(full, colors[seg])
 		}
-		result.WriteString("\n")
 	}
+	run.end()
+}
+
+// partialBlocks are left-aligned blocks filling 0 to 7 eighths of a cell.
+var partialBlocks = [8]rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+// splitColor returns a style that draws in fg on a bg background, or "" if
+// either is not a plain color that can be combined that way.
+func splitColor(fg, bg string) string {
+	if _, ok := colorToRGB(fg); !ok {
+		return ""
+	}
+	if _, ok := colorToRGB(bg); !ok {
+		return ""
+	}
+	return fg + " on " + bg
 }
 
 // renderVerticalMultiSeries renders a vertical bar chart with multiple series.
@@ -624,13 +933,6 @@ func (b *BarChart) renderVerticalMultiSeries() string {
 	series := b.opts.Series
 	labels := b.opts.Labels
 
-	// Validate all series data
-	for _, s := range series {
-		if !internal.AllValid(s.Data) {
-			return ""
-		}
-	}
-
 	// Determine character set and color settings
 	useUnicode := b.shouldUseUnicode()
 	colorEnabled := b.isColorEnabled()
@@ -647,71 +949,62 @@ func (b *BarChart) renderVerticalMultiSeries() string {
 		}
 	}
 
+	// Only plotted series are scaled and drawn; the legend lists them all
+	visible := b.opts.visibleSeries(series, theme)
+
 	// Calculate max value based on bar mode
-	maxVal := b.calculateMaxValue(series)
-	if maxVal == 0 {
-		maxVal = 1
-	}
+	maxVal := b.valueMax(b.calculateMaxValue(visible))
 
 	// Calculate bar height
 	barHeight := b.opts.Height
 	if b.opts.Title != "" {
 		barHeight--
 	}
-	if b.opts.ShowAxes && len(labels) > 0 {
+	if b.showCategoryAxis() && len(labels) > 0 {
 		barHeight--
 	}
 	if b.opts.ShowLegend {
 		barHeight -= 2
 	}
 	if barHeight < 3 {
-		barHeight = 10
+		barHeight = 3
 	}
 
-	var result strings.Builder
+	result := getBuffer()
+	defer putBuffer(result)
+	result.Grow(frameBytes(numCategories*(len(series)*3+2), barHeight))
 
 	// Render title
 	if b.opts.Title != "" {
-		titleText := b.opts.Title
+		titleText := fitTitle(b.opts.Title, b.opts.Width, useUnicode)
 		if colorEnabled {
-			titleText = Colorize(titleText, theme.Text, true)
+			titleText = Colorize(titleText, b.opts.titleColor(theme), true)
 		}
 		result.WriteString(titleText)
 		result.WriteString("\n")
 	}
 
 	// Render based on mode
-	if b.opts.BarMode == BarModeStacked {
-		b.renderVerticalStacked(&result, series, labels, numCategories, maxVal, barHeight, useUnicode, colorEnabled, theme)
-	} else {
-		b.renderVerticalGrouped(&result, series, labels, numCategories, maxVal, barHeight, useUnicode, colorEnabled, theme)
+	switch {
+	case len(visible) == 0:
+		// Every series is hidden; only the legend is drawn
+	case b.opts.BarMode == BarModeStacked:
+		b.renderVerticalStacked(result, visible, labels, numCategories, maxVal, barHeight, useUnicode, colorEnabled, theme)
+	default:
+		b.renderVerticalGrouped(result, visible, labels, numCategories, maxVal, barHeight, useUnicode, colorEnabled, theme)
 	}
 
 	// Render legend if enabled
 	if b.opts.ShowLegend {
 		result.WriteString("\n")
-		for i, s := range series {
-			color := theme.GetSeriesColor(i)
-			if s.Color != "" {
-				color = s.Color
-			}
-			legendChar := "█"
-			if !useUnicode {
-				legendChar = "#"
-			}
-			if colorEnabled {
-				legendChar = Colorize(legendChar, color, true)
-			}
-			result.WriteString(fmt.Sprintf("%s %s  ", legendChar, s.Label))
-		}
-		result.WriteString("\n")
+		result.WriteString(b.renderLegend(series, useUnicode, colorEnabled, theme))
 	}
 
 	return result.String()
 }
 
 // renderVerticalGrouped renders vertical grouped bars.
-func (b *BarChart) renderVerticalGrouped(result *strings.Builder, series []Series, labels []string, numCategories int, maxVal float64, barHeight int, useUnicode, colorEnabled bool, theme *Theme) {
+func (b *BarChart) renderVerticalGrouped(result *bytes.Buffer, series []Series, labels []string, numCategories int, maxVal float64, barHeight int, useUnicode, colorEnabled bool, theme *Theme) {
 	barWidth := 3                  // Width of each bar
 	groupSpacing := 2              // Space between groups
 	barSpacing := 0                // Space between bars in a group
@@ -726,15 +1019,16 @@ func (b *BarChart) renderVerticalGrouped(result *strings.Builder, series []Serie
 					val = s.Data[cat]
 				}
 
-				barRows := int(float64(barHeight) * (val / maxVal))
-				color := theme.GetSeriesColor(i)
+				barRows, tiny := b.barCells(val, maxVal, barHeight)
+				color := b.opts.seriesColor(theme, i, s.Label)
 				if s.Color != "" {
 					color = s.Color
 				}
 
 				if row <= barRows {
-					char := b.renderVerticalBar(useUnicode, colorEnabled, color)
-					result.WriteString(strings.Repeat(char, barWidth))
+					b.writeVerticalBar(result, barWidth, useUnicode, colorEnabled, color)
+				} else if tiny && row == 1 {
+					writeTinyBar(result, barWidth, true, useUnicode, colorEnabled, color)
 				} else {
 					result.WriteString(strings.Repeat(" ", barWidth))
 				}
@@ -753,24 +1047,18 @@ func (b *BarChart) renderVerticalGrouped(result *strings.Builder, series []Serie
 		result.WriteString("\n")
 	}
 
-	// Render labels
-	if b.opts.ShowAxes && len(labels) > 0 {
+	// Render labels centered under their groups, abbreviated to fit
+	if b.showCategoryAxis() && len(labels) > 0 {
 		for cat := 0; cat < numCategories; cat++ {
 			label := ""
 			if cat < len(labels) {
-				label = labels[cat]
-				if len(label) > groupWidth {
-					label = label[:groupWidth]
-				} else {
-					label = fmt.Sprintf("%-*s", groupWidth, label)
-				}
-			} else {
-				label = strings.Repeat(" ", groupWidth)
+				label = truncateLabel(labels[cat], groupWidth, useUnicode)
 			}
+			label = internal.Center(label, groupWidth)
 
 			labelText := label
 			if colorEnabled {
-				labelText = Colorize(labelText, theme.Muted, true)
+				labelText = Colorize(labelText, b.opts.axisColor(theme), true)
 			}
 			result.WriteString(labelText)
 
@@ -783,7 +1071,7 @@ func (b *BarChart) renderVerticalGrouped(result *strings.Builder, series []Serie
 }
 
 // renderVerticalStacked renders vertical stacked bars.
-func (b *BarChart) renderVerticalStacked(result *strings.Builder, series []Series, labels []string, numCategories int, maxVal float64, barHeight int, useUnicode, colorEnabled bool, theme *Theme) {
+func (b *BarChart) renderVerticalStacked(result *bytes.Buffer, series []Series, labels []string, numCategories int, maxVal float64, barHeight int, useUnicode, colorEnabled bool, theme *Theme) {
 	barWidth := 3  // Width of each bar
 	spacing := 1   // Space between bars
 
@@ -821,12 +1109,11 @@ func (b *BarChart) renderVerticalStacked(result *strings.Builder, series []Serie
 			}
 
 			if seriesIdx >= 0 {
-				color := theme.GetSeriesColor(seriesIdx)
+				color := b.opts.seriesColor(theme, seriesIdx, series[seriesIdx].Label)
 				if series[seriesIdx].Color != "" {
 					color = series[seriesIdx].Color
 				}
-				char := b.renderVerticalBar(useUnicode, colorEnabled, color)
-				result.WriteString(strings.Repeat(char, barWidth))
+				b.writeVerticalBar(result, barWidth, useUnicode, colorEnabled, color)
 			} else {
 				result.WriteString(strings.Repeat(" ", barWidth))
 			}
@@ -839,23 +1126,19 @@ func (b *BarChart) renderVerticalStacked(result *strings.Builder, series []Serie
 	}
 
 	// Render labels
-	if b.opts.ShowAxes && len(labels) > 0 {
+	if b.showCategoryAxis() && len(labels) > 0 {
 		for cat := 0; cat < numCategories; cat++ {
 			label := ""
 			if cat < len(labels) {
 				label = labels[cat]
-				if len(label) > barWidth {
-					label = label[:barWidth]
-				} else {
-					label = fmt.Sprintf("%-*s", barWidth, label)
-				}
+				label = internal.PadRight(label, barWidth)
 			} else {
 				label = strings.Repeat(" ", barWidth)
 			}
 
 			labelText := label
 			if colorEnabled {
-				labelText = Colorize(labelText, theme.Muted, true)
+				labelText = Colorize(labelText, b.opts.axisColor(theme), true)
 			}
 			result.WriteString(labelText)
 
@@ -880,13 +1163,7 @@ func (b *BarChart) calculateMaxValue(series []Series) float64 {
 
 		maxSum := 0.0
 		for cat := 0; cat < numCategories; cat++ {
-			sum := 0.0
-			for _, s := range series {
-				if cat < len(s.Data) {
-					sum += s.Data[cat]
-				}
-			}
-			if sum > maxSum {
+			if sum := stackTotal(series, cat); sum > maxSum {
 				maxSum = sum
 			}
 		}
@@ -1,9 +1,15 @@
 package termcharts
 
 import (
+	"bytes"
+	"errors"
 	"math"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
 )
 
 func TestNewBarChart(t *testing.T) {
@@ -579,6 +585,66 @@ func TestBarChart_Render_StackedHorizontal(t *testing.T) {
 	}
 }
 
+func TestBarChart_Render_StackedTotals(t *testing.T) {
+	series := []Series{
+		{Label: "A", Data: []float64{10, 20}},
+		{Label: "B", Data: []float64{5, -4}},
+	}
+	result := NewBarChart(
+		WithSeries(series),
+		WithLabels([]string{"Q1", "Q2"}),
+		WithBarMode(BarModeStacked),
+		WithShowValues(true),
+		WithLegend(Legend{Values: LegendSum}),
+		WithWidth(20),
+		WithStyle(StyleASCII),
+		WithColor(false),
+	).Render()
+
+	// Totals line up after the bars; negative segments are not stacked
+	expected := "Q1  #######   15.0\n" +
+		"Q2  ######### 20.0\n" +
+		"\n" +
+		"# A (sum 30.0)  \n" +
+		"# B (sum 1.0)   \n"
+	if result != expected {
+		t.Errorf("Render() =\n%q\nwant\n%q", result, expected)
+	}
+}
+
+func TestWriteStackedBar(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		maxVal   float64
+		width    int
+		unicode  bool
+		color    bool
+		expected string
+	}{
+		{name: "running totals", values: []float64{1, 1, 1}, maxVal: 3, width: 10, expected: "##########"},
+		{name: "partial end", values: []float64{1}, maxVal: 4, width: 3, unicode: true, expected: "▊"},
+		{name: "shared cell uncolored", values: []float64{1, 1}, maxVal: 2, width: 3, unicode: true, expected: "███"},
+		{
+			name: "shared cell colored", values: []float64{1, 1}, maxVal: 2, width: 3, unicode: true, color: true,
+			expected: colorRed + "█" + colorReset + "\033[31;42m▌" + colorReset + colorGreen + "█" + colorReset,
+		},
+		{name: "negative skipped", values: []float64{-5, 2}, maxVal: 2, width: 4, expected: "####"},
+		{name: "capped at width", values: []float64{3, 3}, maxVal: 4, width: 4, expected: "####"},
+	}
+
+	b := NewBarChart()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			b.writeStackedBar(&buf, tt.values, []string{"red", "green", "blue"}[:len(tt.values)], tt.maxVal, tt.width, tt.unicode, tt.color)
+			if got := buf.String(); got != tt.expected {
+				t.Errorf("writeStackedBar() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestBarChart_Render_GroupedVertical(t *testing.T) {
 	series := []Series{
 		{Label: "2023", Data: []float64{10, 20, 30}},
@@ -614,6 +680,49 @@ func TestBarChart_Render_GroupedVertical(t *testing.T) {
 	}
 }
 
+func TestBarChart_Render_TruncatedLabels(t *testing.T) {
+	opts := []BarOption{WithData([]float64{3, 1}), WithLabels([]string{"Kubernetes nodes", "VMs"}), WithWidth(16), WithColor(false)}
+	tests := []struct {
+		style    RenderStyle
+		expected string
+	}{
+		{style: StyleUnicode, expected: "Kubern… ████████\nVMs     ██\n"},
+		{style: StyleASCII, expected: "Kubern. ########\nVMs     ##\n"},
+	}
+	for _, tt := range tests {
+		if got := NewBarChart(append(opts, WithStyle(tt.style))...).Render(); got != tt.expected {
+			t.Errorf("Render() with style %v = %q, want %q", tt.style, got, tt.expected)
+		}
+	}
+}
+
+func TestBarChart_Render_GroupedVerticalLabels(t *testing.T) {
+	series := []Series{
+		{Label: "2023", Data: []float64{10, 20}},
+		{Label: "2024", Data: []float64{15, 25}},
+	}
+	for _, tt := range []struct {
+		style    RenderStyle
+		expected string
+	}{
+		{style: StyleASCII, expected: "  Q1    Septe."},
+		{style: StyleUnicode, expected: "  Q1    Septe…"},
+	} {
+		bar := NewBarChart(
+			WithSeries(series),
+			WithLabels([]string{"Q1", "September"}),
+			WithDirection(Vertical),
+			WithHeight(8),
+			WithStyle(tt.style),
+			WithColor(false),
+		)
+		lines := strings.Split(strings.TrimSuffix(bar.Render(), "\n"), "\n")
+		if got := lines[len(lines)-1]; got != tt.expected {
+			t.Errorf("%v label row = %q, want %q", tt.style, got, tt.expected)
+		}
+	}
+}
+
 func TestBarChart_Render_StackedVertical(t *testing.T) {
 	series := []Series{
 		{Label: "Product A", Data: []float64{10, 20, 30}},
@@ -787,3 +896,184 @@ func TestCalculateMaxValue_Grouped(t *testing.T) {
 		t.Errorf("Expected max grouped value %f, got %f", expected, maxVal)
 	}
 }
+
+func TestBarChart_Render_WideLabels(t *testing.T) {
+	t.Run("horizontal bars align", func(t *testing.T) {
+		bar := NewBarChart(
+			WithData([]float64{10, 20, 30}),
+			WithLabels([]string{"東京", "NY", "🚀x"}),
+			WithStyle(StyleUnicode),
+			WithColor(false),
+			WithWidth(30),
+		)
+		lines := strings.Split(strings.TrimSuffix(bar.Render(), "\n"), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("expected 3 lines, got %d", len(lines))
+		}
+		for _, line := range lines {
+			barStart := strings.Index(line, "█")
+			if got := internal.StringWidth(line[:barStart]); got != 6 {
+				t.Errorf("bar in %q starts at column %d, want 6", line, got)
+			}
+		}
+	})
+
+	t.Run("vertical labels truncate by width", func(t *testing.T) {
+		bar := NewBarChart(
+			WithData([]float64{10, 20, 30}),
+			WithLabels([]string{"東京都", "NY", "🚀x"}),
+			WithDirection(Vertical),
+			WithStyle(StyleUnicode),
+			WithColor(false),
+			WithHeight(6),
+		)
+		lines := strings.Split(strings.TrimSuffix(bar.Render(), "\n"), "\n")
+		labelLine := lines[len(lines)-1]
+		if labelLine != "東  NY  🚀x" {
+			t.Errorf("label line = %q, want %q", labelLine, "東  NY  🚀x")
+		}
+	})
+}
+
+func TestBarChart_Render_HiddenSeries(t *testing.T) {
+	series := []Series{
+		{Label: "2023", Data: []float64{10, 20}},
+		{Label: "2024", Data: []float64{100, 200}, Hidden: true},
+		{Label: "2025", Data: []float64{20, 10}},
+	}
+	opts := []BarOption{WithLabels([]string{"Q1", "Q2"}), WithShowLegend(true), WithWidth(40), WithStyle(StyleASCII), WithColor(false)}
+	result := NewBarChart(append(opts, WithSeries(series))...).Render()
+	shown := NewBarChart(append(opts, WithSeries([]Series{series[0], series[2]}))...).Render()
+
+	// The hidden series is neither drawn nor scaled against, but keeps its
+	// place in the legend
+	bars, legend, _ := strings.Cut(result, "\n\n")
+	if want, _, _ := strings.Cut(shown, "\n\n"); bars != want {
+		t.Errorf("bars =\n%s\nwant\n%s", bars, want)
+	}
+	if legend != "# 2023    2024  # 2025  \n" {
+		t.Errorf("legend = %q", legend)
+	}
+
+	// Hidden series keep their colors
+	colored := NewBarChart(WithSeries(series), WithColor(true)).Render()
+	if !strings.Contains(colored, colorCode(DefaultTheme.GetSeriesColor(2))+"█") {
+		t.Errorf("third series should keep its color: %q", colored)
+	}
+
+	// Hiding every series leaves the legend
+	all := NewBarChart(WithSeries(series), WithHiddenSeries("2023", "2025"), WithShowLegend(true), WithColor(false), WithStyle(StyleASCII)).Render()
+	if all != "\n  2023    2024    2025  \n" {
+		t.Errorf("Render() with every series hidden = %q", all)
+	}
+}
+
+func TestBarChart_Render_ValueColumn(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []BarOption
+		values []string
+	}{
+		{"default format", nil, []string{"    5.0", "  120.0", "   42.5", " 1000.0"}},
+		{"locale", []BarOption{WithLocale("de-DE")}, []string{"     5,0", "   120,0", "    42,5", " 1.000,0"}},
+		{"custom format", []BarOption{WithYAxis(AxisConfig{Format: func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) + " ms" }})},
+			[]string{"    5 ms", "  120 ms", "   42.5 ms", " 1000 ms"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]BarOption{
+				WithData([]float64{5, 120, 42.5, 1000}),
+				WithLabels([]string{"a", "b", "c", "d"}),
+				WithShowValues(true),
+				WithWidth(40),
+				WithStyle(StyleASCII),
+				WithColor(false),
+			}, tt.opts...)
+			lines := strings.Split(strings.TrimSuffix(NewBarChart(opts...).Render(), "\n"), "\n")
+			if len(lines) != len(tt.values) {
+				t.Fatalf("got %d lines, want %d", len(lines), len(tt.values))
+			}
+			column := len(lines[0]) - len(tt.values[0])
+			for i, line := range lines {
+				if !strings.HasSuffix(line, tt.values[i]) || len(line)-len(tt.values[i]) != column {
+					t.Errorf("line %d = %q, want it to end in %q at column %d", i, line, tt.values[i], column)
+				}
+			}
+		})
+	}
+}
+
+func TestAlignDecimals(t *testing.T) {
+	got := alignDecimals([]string{" 1.5", " 12.25", " 300", " -4.0%"}, "")
+	want := []string{"   1.5", "  12.25", " 300", "  -4.0%"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("alignDecimals() = %q, want %q", got, want)
+	}
+}
+
+func TestBarChart_Render_MinBar(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []BarOption
+		want string
+	}{
+		{
+			name: "tiny values vanish by default",
+			opts: []BarOption{WithStyle(StyleUnicode)},
+			want: "a  ████████\n" +
+				"b  \n" +
+				"c  \n",
+		},
+		{
+			name: "tiny values get a marker",
+			opts: []BarOption{WithStyle(StyleUnicode), WithMinBar(true)},
+			want: "a  ████████\n" +
+				"b  ▏\n" +
+				"c  \n",
+		},
+		{
+			name: "ASCII marker",
+			opts: []BarOption{WithStyle(StyleASCII), WithMinBar(true)},
+			want: "a  ########\n" +
+				"b  |\n" +
+				"c  \n",
+		},
+		{
+			name: "vertical marker",
+			opts: []BarOption{WithStyle(StyleUnicode), WithMinBar(true), WithDirection(Vertical), WithHeight(4)},
+			want: "███        \n" +
+				"███        \n" +
+				"███ ▁▁▁    \n" +
+				"a   b   c  \n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]BarOption{
+				WithData([]float64{1000, 1, 0}),
+				WithLabels([]string{"a", "b", "c"}),
+				WithWidth(12),
+				WithColor(false),
+			}, tt.opts...)
+			if got := NewBarChart(opts...).Render(); got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	grouped := NewBarChart(
+		WithSeries([]Series{{Label: "x", Data: []float64{100, 0}}, {Label: "y", Data: []float64{1, 1}}}),
+		WithLabels([]string{"a", "b"}), WithMinBar(true), WithWidth(30), WithStyle(StyleUnicode), WithColor(false),
+	).Render()
+	if !strings.Contains(grouped, "b  ▏\n") {
+		t.Errorf("grouped tiny bar should have a marker, got\n%s", grouped)
+	}
+
+	strict := NewBarChart(
+		WithStrict(true), WithMinBar(true), WithBarMode(BarModeStacked),
+		WithSeries([]Series{{Data: []float64{1}}, {Data: []float64{2}}}),
+	)
+	if _, err := strict.RenderE(); !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("RenderE() error = %v, want %v", err, ErrConflictingOptions)
+	}
+}
@@ -0,0 +1,108 @@
+package termcharts
+
+import (
+	"bytes"
+	"strings"
+)
+
+// WithGroupDelimiter groups the categories of a horizontal bar chart by the
+// part of their label before sep, so "us/east" and "us/west" are drawn
+// together below a "us" header with the group's subtotal, labeled "east" and
+// "west". Groups keep the order of their first category, and categories keep
+// their order within a group. Labels without sep are drawn as they are,
+// outside any group. With WithTopN or WithPage, the categories drawn are
+// grouped, and subtotals are of those categories. "" draws no groups.
+//
+// Example:
+//
+//	chart := termcharts.NewBarChart(
+//	    termcharts.WithData([]float64{12, 8, 20, 5}),
+//	    termcharts.WithLabels([]string{"us/east", "eu/west", "us/west", "eu/north"}),
+//	    termcharts.WithGroupDelimiter("/"),
+//	)
+func WithGroupDelimiter(sep string) BarOption {
+	return barOption(func(o *Options) {
+		o.GroupDelimiter = sep
+	})
+}
+
+// groupIndent indents the labels of grouped categories below their header.
+const groupIndent = "  "
+
+// grouped returns the options with categories ordered by group and labels
+// shortened to the part after the delimiter, and the header drawn above
+// each category that starts a group. It returns o itself and nil when
+// categories are not grouped.
+func (o *Options) grouped() (*Options, map[int]string) {
+	if o.GroupDelimiter == "" || o.Direction != Horizontal || len(o.Labels) == 0 {
+		return o, nil
+	}
+
+	// Collect groups in order of first appearance; ungrouped labels are
+	// groups of their own, without a header
+	type group struct {
+		name     string
+		indices  []int
+		subtotal float64
+	}
+	var groups []*group
+	byName := make(map[string]*group)
+	n := o.categoryCount()
+	names := make([]string, n)
+	inGroup := make([]bool, n)
+	for i := range names {
+		label := ""
+		if i < len(o.Labels) {
+			label = o.Labels[i]
+		}
+		prefix, rest, found := strings.Cut(label, o.GroupDelimiter)
+		if !found {
+			groups = append(groups, &group{indices: []int{i}})
+			continue
+		}
+		names[i], inGroup[i] = rest, true
+		g, ok := byName[prefix]
+		if !ok {
+			g = &group{name: prefix}
+			byName[prefix] = g
+			groups = append(groups, g)
+		}
+		g.indices = append(g.indices, i)
+		g.subtotal += o.categoryTotal(i)
+	}
+	if len(byName) == 0 {
+		return o, nil
+	}
+
+	indices := make([]int, 0, n)
+	headers := make(map[int]string, len(byName))
+	for _, g := range groups {
+		if byName[g.name] == g {
+			headers[len(indices)] = g.name + " (" + withUnit(o.YAxis.format(g.subtotal, "%.1f", o.Locale), o.YUnit) + ")"
+		}
+		indices = append(indices, g.indices...)
+	}
+	grouped := o.categories(indices)
+	grouped.GroupDelimiter = ""
+	for k, i := range indices {
+		if inGroup[i] {
+			grouped.Labels[k] = groupIndent + names[i]
+		}
+	}
+	return grouped, headers
+}
+
+// writeGroupHeader writes the header of the group starting at category cat,
+// if there is one, on a line of its own.
+func (b *BarChart) writeGroupHeader(result *bytes.Buffer, cat int, useUnicode, colorEnabled bool, theme *Theme) {
+	header, ok := b.headers[cat]
+	if !ok {
+		return
+	}
+	header = truncateLabel(header, b.opts.Width, useUnicode)
+	if colorEnabled {
+		header = Colorize(header, b.opts.titleColor(theme), true)
+	}
+	result.WriteString(header)
+	result.WriteString("\n")
+}
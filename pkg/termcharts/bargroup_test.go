@@ -0,0 +1,76 @@
+package termcharts
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBarChart_GroupDelimiter(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []string
+		opts   []BarOption
+		want   string
+	}{
+		{
+			name:   "groups in order of first appearance",
+			labels: []string{"us/east", "eu/west", "total", "us/west"},
+			want: "us (17.0)\n" +
+				"  east  ###############\n" +
+				"  west  ######\n" +
+				"eu (8.0)\n" +
+				"  west  ##########\n" +
+				"total   #########################\n",
+		},
+		{
+			name:   "subtotals use the value format",
+			labels: []string{"a/x", "a/y", "b", "c"},
+			opts:   []BarOption{WithYAxis(AxisConfig{Format: func(v float64) string { return fmt.Sprintf("$%.0f", v) }})},
+			want: "a ($20)\n" +
+				"  x  ################\n" +
+				"  y  ###########\n" +
+				"b    ############################\n" +
+				"c    #######\n",
+		},
+		{
+			name:   "no label has the delimiter",
+			labels: []string{"a", "b", "c", "d"},
+			want: "a  ##################\n" +
+				"b  ############\n" +
+				"c  ##############################\n" +
+				"d  #######\n",
+		},
+		{
+			name:   "top n groups the categories drawn",
+			labels: []string{"us/east", "eu/west", "total", "us/west"},
+			opts:   []BarOption{WithTopN(2)},
+			want: "total   #########################\n" +
+				"us (12.0)\n" +
+				"  east  ###############\n" +
+				"top 2 of 4\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]BarOption{WithData([]float64{12, 8, 20, 5}), WithLabels(tt.labels), WithGroupDelimiter("/"),
+				WithWidth(34), WithColor(false), WithStyle(StyleASCII)}, tt.opts...)
+			got, err := NewBarChart(opts...).RenderE()
+			if err != nil {
+				t.Fatalf("RenderE() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderE() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBarChart_GroupDelimiterVertical(t *testing.T) {
+	chart := NewBarChart(WithData([]float64{1, 2}), WithLabels([]string{"a/x", "a/y"}),
+		WithGroupDelimiter("/"), WithDirection(Vertical), WithStrict(true))
+	if _, err := chart.RenderE(); !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("RenderE() error = %v, want ErrConflictingOptions", err)
+	}
+}
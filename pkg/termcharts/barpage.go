@@ -0,0 +1,166 @@
+package termcharts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WithTopN keeps the n categories of a bar chart with the largest values,
+// largest first, and notes how many were left out below the chart, e.g.
+// "top 10 of 64". The value of a category of a multi-series chart is the
+// total of its series. 0 keeps every category.
+//
+// Example:
+//
+//	chart := termcharts.NewBarChart(
+//	    termcharts.WithData(requestsPerEndpoint),
+//	    termcharts.WithLabels(endpoints),
+//	    termcharts.WithTopN(10),
+//	)
+func WithTopN(n int) BarOption {
+	return barOption(func(o *Options) {
+		o.TopN = n
+	})
+}
+
+// WithPage draws one page of the categories of a bar chart, size categories
+// long, with a footer such as "page 2 of 7 (11-20 of 64)". Pages are counted
+// from 1; a page past the last one shows the last page. With WithTopN, the
+// top categories are paged. Page 0 shows every category.
+//
+// Example:
+//
+//	chart := termcharts.NewBarChart(
+//	    termcharts.WithData(sizes),
+//	    termcharts.WithLabels(packages),
+//	    termcharts.WithPage(2, 20),
+//	)
+func WithPage(page, size int) BarOption {
+	return barOption(func(o *Options) {
+		o.Page, o.PageSize = page, size
+	})
+}
+
+// validatePaging checks the options set with WithTopN and WithPage.
+func (o *Options) validatePaging() error {
+	if o.TopN < 0 {
+		return fmt.Errorf("%w: top category count %d is negative", ErrInvalidOption, o.TopN)
+	}
+	if o.Page < 0 {
+		return fmt.Errorf("%w: page %d is negative", ErrInvalidOption, o.Page)
+	}
+	if o.Page > 0 && o.PageSize <= 0 {
+		return fmt.Errorf("%w: page size %d must be positive", ErrInvalidOption, o.PageSize)
+	}
+	return nil
+}
+
+// paged returns the options with only the categories selected by WithTopN
+// and WithPage, and the footer describing them, or o itself and "" when
+// every category is drawn.
+func (o *Options) paged() (*Options, string) {
+	total := o.categoryCount()
+	indices := make([]int, total)
+	for i := range indices {
+		indices[i] = i
+	}
+	var notes []string
+	if o.TopN > 0 && o.TopN < total {
+		values := make([]float64, total)
+		for i := range values {
+			values[i] = o.categoryTotal(i)
+		}
+		sort.SliceStable(indices, func(a, b int) bool { return values[indices[a]] > values[indices[b]] })
+		indices = indices[:o.TopN]
+		notes = append(notes, fmt.Sprintf("top %d of %d", o.TopN, total))
+	}
+	if o.Page > 0 && o.PageSize > 0 && o.PageSize < len(indices) {
+		pages := (len(indices) + o.PageSize - 1) / o.PageSize
+		page := o.Page
+		if page > pages {
+			page = pages
+		}
+		start := (page - 1) * o.PageSize
+		end := start + o.PageSize
+		if end > len(indices) {
+			end = len(indices)
+		}
+		shown := fmt.Sprintf("%d-%d", start+1, end)
+		if end == start+1 {
+			shown = fmt.Sprint(end)
+		}
+		notes = append(notes, fmt.Sprintf("page %d of %d (%s of %d)", page, pages, shown, len(indices)))
+		indices = indices[start:end]
+	}
+	if len(notes) == 0 {
+		return o, ""
+	}
+
+	paged := o.categories(indices)
+	paged.TopN, paged.Page = 0, 0
+	return paged, strings.Join(notes, ", ")
+}
+
+// categories returns a copy of the options with the categories at indices,
+// in their order: the data points, series values, and labels.
+func (o *Options) categories(indices []int) *Options {
+	pick := func(data []float64) []float64 {
+		picked := make([]float64, 0, len(indices))
+		for _, i := range indices {
+			if i < len(data) {
+				picked = append(picked, data[i])
+			} else {
+				picked = append(picked, 0)
+			}
+		}
+		return picked
+	}
+	c := *o
+	if len(o.Data) > 0 {
+		c.Data = pick(o.Data)
+	}
+	if len(o.Series) > 0 {
+		c.Series = make([]Series, len(o.Series))
+		for j, s := range o.Series {
+			s.Data = pick(s.Data)
+			c.Series[j] = s
+		}
+	}
+	if len(o.Labels) > 0 {
+		c.Labels = make([]string, len(indices))
+		for k, i := range indices {
+			if i < len(o.Labels) {
+				c.Labels[k] = o.Labels[i]
+			}
+		}
+	}
+	return &c
+}
+
+// categoryCount returns the number of categories: the data points, or the
+// longest series.
+func (o *Options) categoryCount() int {
+	n := len(o.Data)
+	for _, s := range o.Series {
+		if len(s.Data) > n {
+			n = len(s.Data)
+		}
+	}
+	return n
+}
+
+// categoryTotal returns the value of category i: its data point, or the
+// total of the series at i.
+func (o *Options) categoryTotal(i int) float64 {
+	if len(o.Series) == 0 {
+		return o.Data[i]
+	}
+	total := 0.0
+	for _, s := range o.Series {
+		if i < len(s.Data) {
+			total += s.Data[i]
+		}
+	}
+	return total
+}
@@ -0,0 +1,110 @@
+package termcharts
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBarChart_Paging(t *testing.T) {
+	data := []float64{5, 40, 12, 33, 8, 20}
+	labels := []string{"a", "b", "c", "d", "e", "f"}
+
+	tests := []struct {
+		name string
+		opts []BarOption
+		want string
+	}{
+		{
+			name: "top n",
+			opts: []BarOption{WithTopN(3)},
+			want: "b  ##########################\n" +
+				"d  #####################\n" +
+				"f  #############\n" +
+				"top 3 of 6\n",
+		},
+		{
+			name: "last page scaled like the whole chart",
+			opts: []BarOption{WithPage(2, 4)},
+			want: "e  #####\n" +
+				"f  #############\n" +
+				"page 2 of 2 (5-6 of 6)\n",
+		},
+		{
+			name: "page past the end shows the last page",
+			opts: []BarOption{WithTopN(5), WithPage(9, 2)},
+			want: "e  #####\n" +
+				"top 5 of 6, page 3 of 3 (5 of.\n",
+		},
+		{
+			name: "footer fits the width",
+			opts: []BarOption{WithTopN(5), WithPage(2, 2), WithWidth(minWidth)},
+			want: "f  ##\n" +
+				"c  #\n" +
+				"top 5 o.\n",
+		},
+		{
+			name: "everything fits",
+			opts: []BarOption{WithTopN(6), WithPage(1, 10)},
+			want: "a  ###\n" +
+				"b  ##########################\n" +
+				"c  #######\n" +
+				"d  #####################\n" +
+				"e  #####\n" +
+				"f  #############\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]BarOption{WithData(data), WithLabels(labels), WithWidth(30), WithColor(false), WithStyle(StyleASCII)}, tt.opts...)
+			got, err := NewBarChart(opts...).RenderE()
+			if err != nil {
+				t.Fatalf("RenderE() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderE() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBarChart_PagingSeries(t *testing.T) {
+	chart := NewBarChart(
+		WithSeries([]Series{{Label: "x", Data: []float64{1, 9, 4}}, {Label: "y", Data: []float64{2, 0, 8}}}),
+		WithLabels([]string{"a", "b", "c"}),
+		WithTopN(2), WithColor(false), WithStyle(StyleASCII), WithWidth(30),
+	)
+	got, err := chart.RenderE()
+	if err != nil {
+		t.Fatalf("RenderE() error = %v", err)
+	}
+	// c totals 12 and b 9, so c comes first and a is left out
+	want := NewBarChart(
+		WithSeries([]Series{{Label: "x", Data: []float64{4, 9}}, {Label: "y", Data: []float64{8, 0}}}),
+		WithLabels([]string{"c", "b"}),
+		WithColor(false), WithStyle(StyleASCII), WithWidth(30),
+	).Render() + "top 2 of 3\n"
+	if got != want {
+		t.Errorf("RenderE() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBarChart_PagingErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  BarOption
+	}{
+		{name: "negative top n", opt: WithTopN(-1)},
+		{name: "negative page", opt: WithPage(-1, 10)},
+		{name: "page without size", opt: WithPage(1, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBarChart(WithData([]float64{1, 2}), WithStrict(true), tt.opt).RenderE()
+			if !errors.Is(err, ErrInvalidOption) {
+				t.Errorf("RenderE() error = %v, want %v", err, ErrInvalidOption)
+			}
+		})
+	}
+}
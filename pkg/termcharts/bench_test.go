@@ -0,0 +1,147 @@
+package termcharts
+
+import (
+	"math"
+	"testing"
+)
+
+// benchData returns n points of a smooth wave.
+func benchData(n int) []float64 {
+	data := make([]float64, n)
+	for i := range data {
+		data[i] = 50 + 40*math.Sin(float64(i)/10)
+	}
+	return data
+}
+
+// benchLabels returns n short labels.
+func benchLabels(n int) []string {
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = string(rune('A' + i%26))
+	}
+	return labels
+}
+
+// benchRender renders chart b.N times, reporting allocations.
+func benchRender(b *testing.B, chart Chart) {
+	b.Helper()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		chart.Render()
+	}
+}
+
+func BenchmarkBarChart_Render(b *testing.B) {
+	benchRender(b, NewBarChart(
+		WithData(benchData(20)),
+		WithLabels(benchLabels(20)),
+		WithShowValues(true),
+		WithStyle(StyleUnicode),
+		WithColor(true),
+	))
+}
+
+func BenchmarkBarChart_RenderVerticalGrouped(b *testing.B) {
+	benchRender(b, NewBarChart(
+		WithSeries([]Series{{Label: "a", Data: benchData(12)}, {Label: "b", Data: benchData(12)}}),
+		WithLabels(benchLabels(12)),
+		WithDirection(Vertical),
+		WithStyle(StyleUnicode),
+		WithColor(true),
+		WithShowLegend(true),
+	))
+}
+
+func BenchmarkLineChart_Render(b *testing.B) {
+	benchRender(b, NewLineChart(
+		WithData(benchData(200)),
+		WithStyle(StyleUnicode),
+		WithColor(true),
+	))
+}
+
+func BenchmarkLineChart_RenderBraille(b *testing.B) {
+	benchRender(b, NewLineChart(
+		WithData(benchData(200)),
+		WithStyle(StyleBraille),
+		WithColor(true),
+	))
+}
+
+func BenchmarkLineChart_RenderBrailleManySeries(b *testing.B) {
+	series := make([]Series, 12)
+	for i := range series {
+		series[i] = Series{Label: benchLabels(12)[i], Data: benchData(2000)}
+		for j := range series[i].Data {
+			series[i].Data[j] += float64(i * 5)
+		}
+	}
+	benchRender(b, NewLineChart(
+		WithSeries(series),
+		WithStyle(StyleBraille),
+		WithColor(true),
+		WithWidth(400),
+		WithHeight(80),
+	))
+}
+
+func BenchmarkLineChart_RenderLarge(b *testing.B) {
+	benchRender(b, NewLineChart(
+		WithData(benchData(100000)),
+		WithStyle(StyleUnicode),
+		WithColor(false),
+	))
+}
+
+func BenchmarkPieChart_Render(b *testing.B) {
+	benchRender(b, NewPieChart(
+		WithData(benchData(6)),
+		WithLabels(benchLabels(6)),
+		WithStyle(StyleUnicode),
+		WithColor(true),
+	))
+}
+
+func BenchmarkSparkline_Render(b *testing.B) {
+	benchRender(b, NewSparkline(
+		WithData(benchData(60)),
+		WithStyle(StyleUnicode),
+		WithColor(true),
+	))
+}
+
+func BenchmarkSparkline_AppendRender(b *testing.B) {
+	spark := NewSparkline(
+		WithData(benchData(60)),
+		WithStyle(StyleUnicode),
+		WithColor(true),
+	)
+	buf := make([]byte, 0, 1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = spark.AppendRender(buf[:0])
+	}
+}
+
+func BenchmarkBigText_Render(b *testing.B) {
+	benchRender(b, NewBigText(
+		WithData(benchData(30)),
+		WithTitle("Requests/s"),
+		WithShowSparkline(true),
+		WithStyle(StyleUnicode),
+		WithColor(true),
+	))
+}
+
+func BenchmarkCompose_Render(b *testing.B) {
+	benchRender(b, Compose([]Layer{
+		{Kind: LayerBar, Series: Series{Label: "bars", Data: benchData(24)}},
+		{Kind: LayerLine, Series: Series{Label: "trend", Data: TrendLine(benchData(24))}},
+	},
+		WithStyle(StyleUnicode),
+		WithColor(true),
+	))
+}
@@ -0,0 +1,287 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// BigText represents a KPI panel: a large number drawn in block characters,
+// with an optional label, a delta indicator, and an embedded sparkline.
+// The displayed value is the last data point; the delta compares it with the
+// point before it, and the sparkline shows the whole series.
+type BigText struct {
+	opts *Options
+	err  error
+}
+
+// bigGlyphs holds 5-row glyphs for the characters BigText can draw large.
+// '#' marks a filled cell.
+var bigGlyphs = map[rune][5]string{
+	'0': {"###", "# #", "# #", "# #", "###"},
+	'1': {" # ", "## ", " # ", " # ", "###"},
+	'2': {"###", "  #", "###", "#  ", "###"},
+	'3': {"###", "  #", "###", "  #", "###"},
+	'4': {"# #", "# #", "###", "  #", "  #"},
+	'5': {"###", "#  ", "###", "  #", "###"},
+	'6': {"###", "#  ", "###", "# #", "###"},
+	'7': {"###", "  #", "  #", "  #", "  #"},
+	'8': {"###", "# #", "###", "# #", "###"},
+	'9': {"###", "# #", "###", "  #", "###"},
+	'.': {" ", " ", " ", " ", "#"},
+	',': {" ", " ", " ", "#", "#"},
+	'-': {"   ", "   ", "###", "   ", "   "},
+	'+': {"   ", " # ", "###", " # ", "   "},
+	'%': {"# #", "  #", " # ", "#  ", "# #"},
+	':': {" ", "#", " ", "#", " "},
+	' ': {" ", " ", " ", " ", " "},
+	// Group separators written by WithLocale
+	'\u00a0': {" ", " ", " ", " ", " "},
+	'\u202f': {" ", " ", " ", " ", " "},
+	'\u2019': {"#", "#", " ", " ", " "},
+}
+
+// bigTextHeight is the number of rows in a large glyph.
+const bigTextHeight = 5
+
+// NewBigText creates a new KPI panel with the given options.
+// At minimum, data must be provided via WithData option.
+//
+// Example:
+//
+//	kpi := termcharts.NewBigText(
+//	    termcharts.WithData([]float64{1180, 1204, 1255}),
+//	    termcharts.WithTitle("Requests/s"),
+//	    termcharts.WithShowSparkline(true),
+//	)
+//	fmt.Println(kpi.Render())
+func NewBigText(opts ...BigTextOption) *BigText {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyBigText(options)
+	}
+	b := &BigText{
+		opts: options,
+	}
+	if options.Strict {
+		b.err = b.validateOptions()
+	}
+	return b
+}
+
+// Update applies opts to the KPI panel, replacing the options they set.
+// The next Render draws the KPI panel with the new options; with WithStrict
+// they are validated again. Use it to feed new data into a live display.
+func (b *BigText) Update(opts ...BigTextOption) {
+	for _, opt := range opts {
+		opt.applyBigText(b.opts)
+	}
+	b.err = nil
+	if b.opts.Strict {
+		b.err = b.validateOptions()
+	}
+}
+
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the KPI panel Updatable.
+func (b *BigText) Apply(opts ...Option) { b.Update(Combine(opts...)) }
+
+// Render generates the KPI panel as a multi-line string.
+// It returns an empty string if the panel cannot be drawn; use RenderE to find out why.
+func (b *BigText) Render() string {
+	out, _ := b.RenderE()
+	return out
+}
+
+// RenderE generates the KPI panel as a multi-line string.
+// It returns ErrEmptyData, ErrInvalidData, or ErrInvalidDimensions
+// (possibly wrapped) when the panel cannot be drawn.
+func (b *BigText) RenderE() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	// A width or height of 0 is the terminal's; others are clamped
+	if opts := b.opts.sized(); opts != b.opts {
+		return (&BigText{opts: opts}).RenderE()
+	}
+	if b.opts.noData() {
+		return b.opts.placeholder(b.opts.Width, b.opts.Height, b.shouldUseUnicode(), b.isColorEnabled()), nil
+	}
+
+	// Validate data
+	if err := validateData(b.opts.Data); err != nil {
+		return "", err
+	}
+
+	data := b.opts.Data
+	value := data[len(data)-1]
+
+	// Get rendering settings
+	useUnicode := b.shouldUseUnicode()
+	colorEnabled := b.isColorEnabled()
+	theme := b.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+
+	var result strings.Builder
+
+	// Render label if provided
+	if b.opts.Title != "" {
+		titleText := fitTitle(b.opts.Title, b.opts.Width, useUnicode)
+		if colorEnabled {
+			titleText = Colorize(titleText, b.opts.titleColor(theme), true)
+		}
+		result.WriteString(titleText)
+		result.WriteString("\n")
+	}
+
+	// Render the value in large glyphs, or plainly if it does not fit
+	valueText := b.formatValue(value)
+	fill := "█"
+	if !useUnicode {
+		fill = "#"
+	}
+	rows, width := renderBigText(valueText, fill)
+	if b.opts.Width > 0 && width > b.opts.Width {
+		rows = []string{valueText}
+	}
+	for _, row := range rows {
+		row = strings.TrimRight(row, " ")
+		if colorEnabled {
+			row = Colorize(row, theme.Primary, true)
+		}
+		result.WriteString(row)
+		result.WriteString("\n")
+	}
+
+	// Render delta against the previous value
+	if len(data) > 1 {
+		result.WriteString(formatTrend(data[len(data)-2], value, useUnicode, colorEnabled, b.opts.Locale))
+		result.WriteString("\n")
+	}
+
+	// Render embedded sparkline
+	if b.opts.ShowSparkline && len(data) > 1 {
+		spark := NewSparkline(
+			WithData(data),
+			WithWidth(b.opts.Width),
+			WithStyle(b.opts.Style),
+			WithTheme(theme),
+			WithColor(colorEnabled),
+		)
+		result.WriteString(spark.Render())
+		result.WriteString("\n")
+	}
+
+	return b.opts.postProcess(result.String()), nil
+}
+
+// formatValue formats the displayed value.
+func (b *BigText) formatValue(v float64) string {
+	if b.opts.ValueFormat != nil {
+		return b.opts.ValueFormat(v)
+	}
+	if v == math.Trunc(v) {
+		return localizeNumber(fmt.Sprintf("%.0f", v), b.opts.Locale)
+	}
+	return localizeNumber(fmt.Sprintf("%.1f", v), b.opts.Locale)
+}
+
+// renderBigText draws text in large glyphs using fill for filled cells.
+// Characters without a glyph are skipped. It returns the rows and their width.
+func renderBigText(text, fill string) ([]string, int) {
+	rows := make([]strings.Builder, bigTextHeight)
+	for i := range rows {
+		// Glyphs are at most three cells wide, plus a space between them
+		rows[i].Grow(len(text) * (3*len(fill) + 1))
+	}
+	width := 0
+	first := true
+	for _, r := range text {
+		glyph, ok := bigGlyphs[r]
+		if !ok {
+			continue
+		}
+		if !first {
+			for i := range rows {
+				rows[i].WriteString(" ")
+			}
+			width++
+		}
+		first = false
+		for i, line := range glyph {
+			for j := 0; j < len(line); j++ {
+				if line[j] == '#' {
+					rows[i].WriteString(fill)
+				} else {
+					rows[i].WriteByte(line[j])
+				}
+			}
+		}
+		width += len(glyph[0])
+	}
+
+	result := make([]string, bigTextHeight)
+	for i := range rows {
+		result[i] = rows[i].String()
+	}
+	return result, width
+}
+
+// shouldUseUnicode determines whether to use Unicode characters based on style.
+func (b *BigText) shouldUseUnicode() bool {
+	if b.opts.Style == StyleASCII {
+		return false
+	} else if b.opts.Style == StyleUnicode {
+		return true
+	}
+	// StyleAuto - detect Unicode support
+	return internal.SupportsUnicode()
+}
+
+// validateOptions reports options that KPI panels cannot honor.
+func (b *BigText) validateOptions() error {
+	if err := b.opts.Validate(); err != nil {
+		return err
+	}
+	if b.opts.Style.subCell() {
+		return conflict("%s style is only supported by line charts", b.opts.Style)
+	}
+	if len(b.opts.Series) > 0 {
+		return conflict("KPI panels display a single data set; use WithData instead of WithSeries")
+	}
+	if b.opts.Direction == Vertical {
+		return conflict("KPI panels have no direction; remove WithDirection(Vertical)")
+	}
+	if b.opts.BarMode != BarModeGrouped {
+		return conflict("bar mode only applies to bar charts")
+	}
+	return nil
+}
+
+// isColorEnabled determines whether colors should be used.
+func (b *BigText) isColorEnabled() bool {
+	if b.opts.ColorEnabled != nil {
+		return *b.opts.ColorEnabled
+	}
+	return internal.SupportsColor()
+}
+
+// KPI is a convenience function that creates and renders a KPI panel
+// showing the last value of data with a label, delta, and sparkline.
+//
+// Example:
+//
+//	fmt.Println(termcharts.KPI("Active users", []float64{980, 1010, 1052}))
+func KPI(label string, data []float64) string {
+	kpi := NewBigText(
+		WithData(data),
+		WithTitle(label),
+		WithShowSparkline(true),
+	)
+	return kpi.Render()
+}
@@ -0,0 +1,134 @@
+package termcharts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBigText_Render(t *testing.T) {
+	kpi := NewBigText(
+		WithData([]float64{50, 52}),
+		WithTitle("Users"),
+		WithColor(false),
+		WithStyle(StyleASCII),
+	)
+	lines := strings.Split(strings.TrimRight(kpi.Render(), "\n"), "\n")
+
+	expected := []string{
+		"Users",
+		"### ###",
+		"#     #",
+		"### ###",
+		"  # #",
+		"### ###",
+		"^ +4.0%",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(expected), strings.Join(lines, "\n"))
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], expected[i])
+		}
+	}
+}
+
+func TestBigText_Delta(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		expected string
+	}{
+		{name: "increase", data: []float64{100, 104.2}, expected: "▲ +4.2%"},
+		{name: "decrease", data: []float64{200, 150}, expected: "▼ -25.0%"},
+		{name: "unchanged", data: []float64{7, 7}, expected: "= +0.0%"},
+		{name: "from zero", data: []float64{0, 5}, expected: "▲ +5"},
+		{name: "negative base", data: []float64{-10, -5}, expected: "▲ +50.0%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kpi := NewBigText(WithData(tt.data), WithColor(false), WithStyle(StyleUnicode))
+			lines := strings.Split(strings.TrimRight(kpi.Render(), "\n"), "\n")
+			if got := lines[len(lines)-1]; got != tt.expected {
+				t.Errorf("delta = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBigText_TitleFitsWidth(t *testing.T) {
+	kpi := NewBigText(WithData([]float64{7}), WithTitle("Active users in the last hour"),
+		WithWidth(12), WithColor(false), WithStyle(StyleASCII))
+	lines := strings.Split(strings.TrimRight(kpi.Render(), "\n"), "\n")
+
+	if want := "Active user."; lines[0] != want {
+		t.Errorf("title = %q, want %q", lines[0], want)
+	}
+}
+
+func TestBigText_SingleValue(t *testing.T) {
+	kpi := NewBigText(WithData([]float64{3}), WithColor(false), WithShowSparkline(true))
+	lines := strings.Split(strings.TrimRight(kpi.Render(), "\n"), "\n")
+
+	// No delta or sparkline without history
+	if len(lines) != bigTextHeight {
+		t.Errorf("got %d lines, want %d", len(lines), bigTextHeight)
+	}
+}
+
+func TestBigText_Sparkline(t *testing.T) {
+	kpi := NewBigText(
+		WithData([]float64{1, 5, 2, 8}),
+		WithColor(false),
+		WithStyle(StyleASCII),
+		WithShowSparkline(true),
+	)
+	lines := strings.Split(strings.TrimRight(kpi.Render(), "\n"), "\n")
+
+	if got := lines[len(lines)-1]; got != SparkASCII([]float64{1, 5, 2, 8}) {
+		t.Errorf("sparkline = %q, want %q", got, SparkASCII([]float64{1, 5, 2, 8}))
+	}
+}
+
+func TestBigText_ValueFormat(t *testing.T) {
+	kpi := NewBigText(
+		WithData([]float64{0.5}),
+		WithColor(false),
+		WithWidth(3),
+		WithValueFormat(func(v float64) string { return fmt.Sprintf("%.0f%%", v*100) }),
+	)
+
+	// Too wide for the panel, so the value is shown plainly
+	if got := strings.TrimRight(kpi.Render(), "\n"); got != "50%" {
+		t.Errorf("Render() = %q, want %q", got, "50%")
+	}
+}
+
+func TestBigText_RenderE(t *testing.T) {
+	if _, err := NewBigText().RenderE(); !errors.Is(err, ErrEmptyData) {
+		t.Errorf("RenderE() error = %v, want %v", err, ErrEmptyData)
+	}
+
+	kpi := NewBigText(WithStrict(true), WithData([]float64{1}), WithStyle(StyleBraille))
+	if _, err := kpi.RenderE(); !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("RenderE() error = %v, want %v", err, ErrConflictingOptions)
+	}
+}
+
+func TestRenderBigText(t *testing.T) {
+	rows, width := renderBigText("1.5", "#")
+
+	if width != 9 {
+		t.Errorf("width = %d, want 9", width)
+	}
+	if rows[4] != "### # ###" {
+		t.Errorf("bottom row = %q, want %q", rows[4], "### # ###")
+	}
+
+	if _, width := renderBigText("x", "#"); width != 0 {
+		t.Errorf("unsupported characters should be skipped, width = %d", width)
+	}
+}
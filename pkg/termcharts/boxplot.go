@@ -0,0 +1,557 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// BoxStats summarizes a sample as a box-and-whisker diagram draws it: the
+// quartiles, the whiskers, and the outliers beyond them.
+type BoxStats struct {
+	// Min and Max are the smallest and largest samples, outliers included.
+	Min, Max float64
+	// Q1, Median, and Q3 are the quartiles, interpolated linearly between
+	// samples.
+	Q1, Median, Q3 float64
+	// LowerWhisker and UpperWhisker are the smallest and largest samples
+	// within 1.5 interquartile ranges of the box.
+	LowerWhisker, UpperWhisker float64
+	// Outliers are the samples beyond the whiskers, in ascending order.
+	Outliers []float64
+}
+
+// ComputeBoxStats returns the box-and-whisker summary of samples. Samples
+// more than 1.5 times the interquartile range (Q3-Q1) below Q1 or above Q3
+// are outliers; the whiskers reach the furthest samples that are not. It
+// returns the zero BoxStats for no samples.
+func ComputeBoxStats(samples []float64) BoxStats {
+	if len(samples) == 0 {
+		return BoxStats{}
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	stats := BoxStats{
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Q1:     quantile(sorted, 0.25),
+		Median: quantile(sorted, 0.5),
+		Q3:     quantile(sorted, 0.75),
+	}
+	fence := 1.5 * (stats.Q3 - stats.Q1)
+	lo, hi := stats.Q1-fence, stats.Q3+fence
+	stats.LowerWhisker, stats.UpperWhisker = stats.Q1, stats.Q3
+	for _, v := range sorted {
+		if v < lo || v > hi {
+			stats.Outliers = append(stats.Outliers, v)
+			continue
+		}
+		stats.LowerWhisker = math.Min(stats.LowerWhisker, v)
+		stats.UpperWhisker = math.Max(stats.UpperWhisker, v)
+	}
+	return stats
+}
+
+// BoxPlot draws the distribution of raw samples per category as
+// box-and-whisker diagrams on a shared value scale: a box from the first to
+// the third quartile split at the median, whiskers out to the furthest
+// samples within 1.5 interquartile ranges, and the samples beyond them as
+// outliers.
+type BoxPlot struct {
+	opts *Options
+	err  error
+}
+
+// boxGlyphs are the characters a box plot is drawn with.
+type boxGlyphs struct {
+	whisker, lowCap, highCap, median, outlier rune
+}
+
+// Box plot characters, for horizontal and vertical boxes. The box itself is
+// drawn with the fill character.
+var (
+	boxGlyphsHorizontal      = boxGlyphs{'─', '├', '┤', '┃', '•'}
+	boxGlyphsVertical        = boxGlyphs{'│', '┴', '┬', '━', '•'}
+	boxGlyphsHorizontalASCII = boxGlyphs{'-', '|', '|', '|', 'o'}
+	boxGlyphsVerticalASCII   = boxGlyphs{'|', '-', '-', '=', 'o'}
+)
+
+// NewBoxPlot creates a box plot with one box per series set with
+// WithSeries, each series holding the raw samples of a category, such as
+// the request latencies of a service; its label names the box. WithData
+// draws a single unnamed box. Boxes are horizontal rows by default;
+// WithDirection(Vertical) draws them as columns. The value axis is labeled
+// with round numbers unless WithShowAxes(false) hides it; WithYAxis fixes
+// its range, scale, and Format. WithShowValues adds the median beside each
+// horizontal box.
+//
+// Example:
+//
+//	box := termcharts.NewBoxPlot(
+//	    termcharts.WithSeries([]termcharts.Series{
+//	        {Label: "checkout", Data: checkoutLatency},
+//	        {Label: "search", Data: searchLatency},
+//	    }),
+//	    termcharts.WithTitle("p50-p99 latency (ms)"),
+//	)
+//	fmt.Print(box.Render())
+func NewBoxPlot(opts ...BarOption) *BoxPlot {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyBar(options)
+	}
+	b := &BoxPlot{
+		opts: options,
+	}
+	if options.Strict {
+		b.err = b.validateOptions()
+	}
+	return b
+}
+
+// Update applies opts to the box plot, replacing the options they set.
+// The next Render summarizes the samples again; with WithStrict the options
+// are validated again.
+func (b *BoxPlot) Update(opts ...BarOption) {
+	for _, opt := range opts {
+		opt.applyBar(b.opts)
+	}
+	b.err = nil
+	if b.opts.Strict {
+		b.err = b.validateOptions()
+	}
+}
+
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the box plot Updatable.
+func (b *BoxPlot) Apply(opts ...Option) { b.Update(Combine(opts...)) }
+
+// Render generates the box plot as a multi-line string.
+// It returns an empty string if the chart cannot be drawn; use RenderE to find out why.
+func (b *BoxPlot) Render() string {
+	out, _ := b.RenderE()
+	return out
+}
+
+// RenderE generates the box plot as a multi-line string.
+// It returns ErrEmptyData, ErrInvalidData, or ErrInvalidDimensions
+// (possibly wrapped) when the chart cannot be drawn.
+func (b *BoxPlot) RenderE() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if err := validateDimensions(b.opts); err != nil {
+		return "", err
+	}
+	if err := b.opts.checkPoints(); err != nil {
+		return "", err
+	}
+
+	// A width of 0 is the terminal's; others are clamped
+	if opts := b.opts.sized(); opts != b.opts {
+		return (&BoxPlot{opts: opts}).RenderE()
+	}
+	if b.opts.noData() {
+		return b.opts.placeholder(b.opts.Width, b.opts.Height, b.shouldUseUnicode(), b.isColorEnabled()), nil
+	}
+
+	series := b.opts.Series
+	if len(series) == 0 {
+		series = []Series{{Data: b.opts.Data}}
+	}
+	if err := validateSeries(series); err != nil {
+		return "", err
+	}
+
+	theme := b.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	boxes := b.opts.visibleSeries(series, theme)
+	if b.opts.Direction == Vertical {
+		return b.opts.postProcess(b.renderVertical(boxes, theme)), nil
+	}
+	return b.opts.postProcess(b.renderHorizontal(boxes, theme)), nil
+}
+
+// boxCell is a character of the plot and its color.
+type boxCell struct {
+	r     rune
+	color string
+}
+
+// newBoxGrid returns rows of width blank cells.
+func newBoxGrid(rows, width int) [][]boxCell {
+	grid := make([][]boxCell, rows)
+	for i := range grid {
+		grid[i] = make([]boxCell, width)
+		for j := range grid[i] {
+			grid[i][j].r = ' '
+		}
+	}
+	return grid
+}
+
+// writeBoxCells writes cells with one escape sequence per run of a color,
+// leaving out trailing blanks.
+func writeBoxCells(result *strings.Builder, cells []boxCell, colorEnabled bool) {
+	run := newColorRun(result)
+	for _, cell := range cells[:plottedWidth(cells)] {
+		color := ""
+		if colorEnabled {
+			color = cell.color
+		}
+		run.writeRune(cell.r, color)
+	}
+	run.end()
+}
+
+// valueRange returns the value axis range across the boxes, in axis space,
+// widened around a single value. The range is not rounded out, so the boxes
+// use the whole plot.
+func (b *BoxPlot) valueRange(boxes []Series) (float64, float64) {
+	sets := make([][]float64, len(boxes))
+	for i, s := range boxes {
+		sets[i] = s.Data
+	}
+	axis := b.opts.YAxis
+	lo, hi := axis.resolveRange(sets...)
+	lo, hi = axis.project(lo), axis.project(hi)
+	if hi <= lo {
+		lo, hi = lo-0.5, hi+0.5
+	}
+	return lo, hi
+}
+
+// tickCount returns the number of value axis labels for an axis length
+// columns or rows long, about one per spacing, unless the Y axis sets Ticks.
+func (b *BoxPlot) tickCount(length, spacing int) int {
+	if b.opts.YAxis.Ticks > 0 {
+		return b.opts.YAxis.Ticks
+	}
+	return internal.Max(length/spacing, 2)
+}
+
+// showAxis reports whether the value axis is drawn.
+func (b *BoxPlot) showAxis() bool {
+	return b.opts.ShowAxes && !b.opts.YAxis.Hidden
+}
+
+// writeTitle writes the title line, if a title is set.
+func (b *BoxPlot) writeTitle(result *strings.Builder, theme *Theme, useUnicode, colorEnabled bool) {
+	if b.opts.Title == "" {
+		return
+	}
+	title := fitTitle(b.opts.Title, b.opts.Width, useUnicode)
+	if colorEnabled {
+		title = Colorize(title, b.opts.titleColor(theme), true)
+	}
+	result.WriteString(title)
+	result.WriteString("\n")
+}
+
+// renderHorizontal draws one row per box, its label to the left and the
+// value axis below.
+func (b *BoxPlot) renderHorizontal(boxes []Series, theme *Theme) string {
+	useUnicode := b.shouldUseUnicode()
+	colorEnabled := b.isColorEnabled()
+	glyphs := boxGlyphsHorizontal
+	if !useUnicode {
+		glyphs = boxGlyphsHorizontalASCII
+	}
+	axisColor := b.opts.axisColor(theme)
+
+	// Labels take up to a third of the width
+	labelWidth := 0
+	for _, s := range boxes {
+		labelWidth = internal.Max(labelWidth, internal.StringWidth(s.Label))
+	}
+	labelWidth = internal.Min(labelWidth, b.opts.Width/3)
+	indent := 0
+	if labelWidth > 0 {
+		indent = labelWidth + 1
+	}
+
+	stats := make([]BoxStats, len(boxes))
+	valueWidth := 0
+	for i, s := range boxes {
+		stats[i] = ComputeBoxStats(s.Data)
+		if b.opts.ShowValues && len(s.Data) > 0 {
+			valueWidth = internal.Max(valueWidth, internal.StringWidth(b.formatValue(stats[i].Median))+1)
+		}
+	}
+	width := internal.Max(b.opts.Width-indent-valueWidth, 1)
+	lo, hi := b.valueRange(boxes)
+	col := func(v float64) int {
+		pos := (b.opts.YAxis.project(v) - lo) / (hi - lo)
+		return internal.ClampInt(int(math.Round(pos*float64(width-1))), 0, width-1)
+	}
+
+	var result strings.Builder
+	b.writeTitle(&result, theme, useUnicode, colorEnabled)
+
+	fill := b.opts.fillChar(useUnicode)
+	for i, s := range boxes {
+		if labelWidth > 0 {
+			label := padRight(truncateLabel(s.Label, labelWidth, useUnicode), labelWidth)
+			result.WriteString(Colorize(label, axisColor, colorEnabled))
+			result.WriteString(" ")
+		}
+		if len(s.Data) == 0 {
+			result.WriteString("\n")
+			continue
+		}
+
+		st := stats[i]
+		row := newBoxGrid(1, width)[0]
+		set := func(c int, r rune) { row[c] = boxCell{r, s.Color} }
+		for c := col(st.LowerWhisker); c <= col(st.UpperWhisker); c++ {
+			set(c, glyphs.whisker)
+		}
+		set(col(st.LowerWhisker), glyphs.lowCap)
+		set(col(st.UpperWhisker), glyphs.highCap)
+		for c := col(st.Q1); c <= col(st.Q3); c++ {
+			set(c, fill)
+		}
+		set(col(st.Median), glyphs.median)
+		for _, v := range st.Outliers {
+			set(col(v), glyphs.outlier)
+		}
+		writeBoxCells(&result, row, colorEnabled)
+		if b.opts.ShowValues {
+			// Medians start after the plot, so they line up
+			result.WriteString(strings.Repeat(" ", width+1-plottedWidth(row)))
+			result.WriteString(b.formatValue(st.Median))
+		}
+		result.WriteString("\n")
+	}
+
+	if b.showAxis() {
+		rule := "─"
+		if !useUnicode {
+			rule = "-"
+		}
+		result.WriteString(strings.Repeat(" ", indent))
+		result.WriteString(Colorize(strings.Repeat(rule, width), axisColor, colorEnabled))
+		result.WriteString("\n")
+
+		buf := getBuffer()
+		renderXAxisLabels(buf, b.valueTicks(lo, hi, b.tickCount(width, 8)), indent, width, useUnicode, colorEnabled, axisColor)
+		result.WriteString(strings.TrimRight(buf.String(), " \n") + "\n")
+		putBuffer(buf)
+	}
+
+	return result.String()
+}
+
+// plottedWidth returns the number of cells up to the last that is not blank.
+func plottedWidth(cells []boxCell) int {
+	end := len(cells)
+	for end > 0 && cells[end-1].r == ' ' {
+		end--
+	}
+	return end
+}
+
+// renderVertical draws one column per box, the value axis to the left and
+// the labels below.
+func (b *BoxPlot) renderVertical(boxes []Series, theme *Theme) string {
+	useUnicode := b.shouldUseUnicode()
+	colorEnabled := b.isColorEnabled()
+	glyphs := boxGlyphsVertical
+	if !useUnicode {
+		glyphs = boxGlyphsVerticalASCII
+	}
+	axisColor := b.opts.axisColor(theme)
+
+	// The plot leaves room for the title, the axis rule, and the labels
+	rows := b.opts.Height
+	if b.opts.Title != "" {
+		rows--
+	}
+	if b.showAxis() {
+		rows--
+	}
+	labeled := false
+	for _, s := range boxes {
+		labeled = labeled || s.Label != ""
+	}
+	if labeled {
+		rows--
+	}
+	rows = internal.Max(rows, 2)
+
+	// The axis labels the rows nearest round numbers, like WithYTicks,
+	// within the range of the samples
+	lo, hi := b.valueRange(boxes)
+	axisOpts := *b.opts
+	axisOpts.YTicks = b.tickCount(rows, 3)
+	if axisOpts.YAxis.Scale == ScaleLinear {
+		axisOpts.YAxis.Min, axisOpts.YAxis.Max = lo, hi
+	}
+	var yLabels []string
+	axisWidth := 0
+	if b.showAxis() {
+		yLabels, axisWidth = yAxisLabels(&axisOpts, rows, lo, hi)
+	}
+	width := internal.Max(b.opts.Width-axisWidth, 1)
+
+	// Each box is centered in an equal slot, an odd number of columns wide
+	// so its whiskers and caps are centered on it
+	slot := internal.Max(width/internal.Max(len(boxes), 1), 1)
+	boxWidth := internal.ClampInt(slot-2, 1, 7)
+	if boxWidth%2 == 0 {
+		boxWidth--
+	}
+	row := func(v float64) int {
+		pos := (hi - b.opts.YAxis.project(v)) / (hi - lo)
+		return internal.ClampInt(int(math.Round(pos*float64(rows-1))), 0, rows-1)
+	}
+
+	fill := b.opts.fillChar(useUnicode)
+	grid := newBoxGrid(rows, width)
+	for i, s := range boxes {
+		if len(s.Data) == 0 {
+			continue
+		}
+		st := ComputeBoxStats(s.Data)
+		left := i*slot + (slot-boxWidth)/2
+		center := left + boxWidth/2
+		set := func(r, c int, ch rune) {
+			if c < width {
+				grid[r][c] = boxCell{ch, s.Color}
+			}
+		}
+		across := func(r int, ch rune) {
+			for c := left; c < left+boxWidth; c++ {
+				set(r, c, ch)
+			}
+		}
+
+		for r := row(st.UpperWhisker); r <= row(st.LowerWhisker); r++ {
+			set(r, center, glyphs.whisker)
+		}
+		capRule := '─'
+		if !useUnicode {
+			capRule = '-'
+		}
+		across(row(st.UpperWhisker), capRule)
+		set(row(st.UpperWhisker), center, glyphs.highCap)
+		across(row(st.LowerWhisker), capRule)
+		set(row(st.LowerWhisker), center, glyphs.lowCap)
+		for r := row(st.Q3); r <= row(st.Q1); r++ {
+			across(r, fill)
+		}
+		across(row(st.Median), glyphs.median)
+		for _, v := range st.Outliers {
+			set(row(v), center, glyphs.outlier)
+		}
+	}
+
+	var result strings.Builder
+	b.writeTitle(&result, theme, useUnicode, colorEnabled)
+	for r, cells := range grid {
+		if axisWidth > 0 {
+			label := yLabels[r]
+			result.WriteString(Colorize(strings.Repeat(" ", axisWidth-1-internal.StringWidth(label))+label+" ", axisColor, colorEnabled && label != ""))
+		}
+		writeBoxCells(&result, cells, colorEnabled)
+		result.WriteString("\n")
+	}
+
+	if b.showAxis() {
+		rule := "─"
+		if !useUnicode {
+			rule = "-"
+		}
+		result.WriteString(strings.Repeat(" ", axisWidth))
+		result.WriteString(Colorize(strings.Repeat(rule, width), axisColor, colorEnabled))
+		result.WriteString("\n")
+	}
+	if labeled {
+		var line strings.Builder
+		line.WriteString(strings.Repeat(" ", axisWidth))
+		for _, s := range boxes {
+			label := truncateLabel(s.Label, internal.Max(slot-1, 1), useUnicode)
+			pad := (slot - internal.StringWidth(label)) / 2
+			line.WriteString(strings.Repeat(" ", pad))
+			line.WriteString(Colorize(label, axisColor, colorEnabled))
+			line.WriteString(strings.Repeat(" ", slot-pad-internal.StringWidth(label)))
+		}
+		result.WriteString(strings.TrimRight(line.String(), " "))
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
+
+// valueTicks returns the tick labels of a horizontal value axis spanning
+// [lo, hi] in axis space: at most n round numbers on a linear scale, and
+// powers of ten on a log scale.
+func (b *BoxPlot) valueTicks(lo, hi float64, n int) []xTick {
+	axis := b.opts.YAxis
+	tick := func(v float64, def string) xTick {
+		return xTick{
+			pos:   (v - lo) / (hi - lo),
+			label: withUnit(axis.format(axis.unproject(v), def, b.opts.Locale), b.opts.YUnit),
+		}
+	}
+
+	var ticks []xTick
+	if axis.Scale == ScaleLog {
+		for v := math.Ceil(lo - 1e-9); v <= hi+1e-9; v++ {
+			ticks = append(ticks, tick(v, "%g"))
+		}
+		if len(ticks) < 2 {
+			ticks = []xTick{tick(lo, "%.1f"), tick(hi, "%.1f")}
+		}
+		return ticks
+	}
+
+	first, last, step := niceTicks(lo, hi, n, false)
+	def := fmt.Sprintf("%%.%df", stepDecimals(step))
+	for v := first; v <= last+step*1e-9; v += step {
+		t := math.Round(v/step) * step
+		if t == 0 {
+			t = 0 // Not -0
+		}
+		ticks = append(ticks, tick(t, def))
+	}
+	return ticks
+}
+
+// formatValue formats a median shown beside its box.
+func (b *BoxPlot) formatValue(v float64) string {
+	return withUnit(b.opts.YAxis.format(v, "%.1f", b.opts.Locale), b.opts.YUnit)
+}
+
+// validateOptions checks the options for values the box plot cannot draw
+// sensibly, and for combinations that contradict each other.
+func (b *BoxPlot) validateOptions() error {
+	if len(b.opts.Labels) > 0 {
+		return conflict("a box plot names its boxes with the series labels; remove WithLabels")
+	}
+	return b.opts.Validate()
+}
+
+// shouldUseUnicode determines whether to use Unicode characters based on style.
+func (b *BoxPlot) shouldUseUnicode() bool {
+	if b.opts.Style == StyleASCII {
+		return false
+	} else if b.opts.Style == StyleUnicode || b.opts.Style.subCell() {
+		return true
+	}
+	return internal.SupportsUnicode()
+}
+
+// isColorEnabled determines whether colors should be used.
+func (b *BoxPlot) isColorEnabled() bool {
+	if b.opts.ColorEnabled != nil {
+		return *b.opts.ColorEnabled
+	}
+	return internal.SupportsColor()
+}
@@ -0,0 +1,165 @@
+package termcharts
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputeBoxStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []float64
+		want    BoxStats
+	}{
+		{
+			name:    "no samples",
+			samples: nil,
+			want:    BoxStats{},
+		},
+		{
+			name:    "one sample",
+			samples: []float64{7},
+			want:    BoxStats{Min: 7, Max: 7, Q1: 7, Median: 7, Q3: 7, LowerWhisker: 7, UpperWhisker: 7},
+		},
+		{
+			name:    "no outliers",
+			samples: []float64{5, 1, 4, 2, 3},
+			want:    BoxStats{Min: 1, Max: 5, Q1: 2, Median: 3, Q3: 4, LowerWhisker: 1, UpperWhisker: 5},
+		},
+		{
+			// IQR 2.5 puts the fences at -0.5 and 9.5
+			name:    "outliers beyond 1.5 IQR",
+			samples: []float64{20, 2, 3, 4, 5, 6, -10},
+			want: BoxStats{
+				Min: -10, Max: 20, Q1: 2.5, Median: 4, Q3: 5.5,
+				LowerWhisker: 2, UpperWhisker: 6, Outliers: []float64{-10, 20},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeBoxStats(tt.samples); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ComputeBoxStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoxPlot_Render(t *testing.T) {
+	series := []Series{
+		{Label: "api", Data: []float64{2, 3, 4, 5, 6, 20}},
+		{Label: "db", Data: []float64{8, 9, 10, 11, 12}},
+	}
+	tests := []struct {
+		name string
+		opts []BarOption
+		want string
+	}{
+		{
+			name: "horizontal",
+			opts: []BarOption{WithWidth(24), WithShowValues(true)},
+			want: "api ├█┃█          • 4.5\n" +
+				"db       █┃█┤       10.0\n" +
+				"    ───────────────\n" +
+				"         10      20\n",
+		},
+		{
+			name: "ASCII without axis",
+			opts: []BarOption{WithWidth(24), WithStyle(StyleASCII), WithShowAxes(false)},
+			want: "api |##|#              o\n" +
+				"db        |#|##|\n",
+		},
+		{
+			name: "vertical",
+			opts: []BarOption{WithWidth(20), WithHeight(10), WithDirection(Vertical)},
+			want: "     20   •\n" +
+				"        \n" +
+				"        \n" +
+				"               ─┬─\n" +
+				"     10        ━━━\n" +
+				"         ─┬─   ─┴─\n" +
+				"         ━━━\n" +
+				"         ███\n" +
+				"        ────────────\n" +
+				"         api    db\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]BarOption{WithSeries(series), WithColor(false), WithStyle(StyleUnicode)}, tt.opts...)
+			got, err := NewBoxPlot(opts...).RenderE()
+			if err != nil {
+				t.Fatalf("RenderE() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderE() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoxPlot_Series(t *testing.T) {
+	samples := []float64{1, 2, 3, 4, 5}
+	opts := []BarOption{WithColor(false), WithWidth(30), WithStyle(StyleUnicode)}
+
+	// A single box from WithData is drawn without a label gutter
+	single := NewBoxPlot(append(opts, WithData(samples))...).Render()
+	if lines := strings.Split(single, "\n"); len(lines) < 2 || !strings.HasPrefix(lines[1], "─") {
+		t.Errorf("single box should have no label gutter, got\n%s", single)
+	}
+
+	// Hidden series are left out, and the others keep their scale
+	hidden := NewBoxPlot(append(opts, WithSeries([]Series{
+		{Label: "shown", Data: samples},
+		{Label: "gone", Data: []float64{50, 60}, Hidden: true},
+	}))...).Render()
+	if strings.Contains(hidden, "gone") {
+		t.Errorf("hidden series should not be drawn, got\n%s", hidden)
+	}
+	if strings.Contains(hidden, "50") || strings.Contains(hidden, "60") {
+		t.Errorf("hidden series should not widen the axis, got\n%s", hidden)
+	}
+}
+
+func TestBoxPlot_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []BarOption
+		wantErr error
+	}{
+		{
+			name:    "no samples",
+			opts:    []BarOption{WithData(nil)},
+			wantErr: ErrEmptyData,
+		},
+		{
+			name:    "NaN sample",
+			opts:    []BarOption{WithSeries([]Series{{Label: "a", Data: []float64{1, math.NaN()}}})},
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "strict labels",
+			opts:    []BarOption{WithStrict(true), WithData([]float64{1, 2}), WithLabels([]string{"a"})},
+			wantErr: ErrConflictingOptions,
+		},
+		{
+			name:    "strict negative width",
+			opts:    []BarOption{WithStrict(true), WithData([]float64{1, 2}), WithWidth(-1)},
+			wantErr: ErrInvalidDimensions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := NewBoxPlot(tt.opts...).RenderE()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RenderE() error = %v, want %v", err, tt.wantErr)
+			}
+			if out != "" {
+				t.Errorf("RenderE() output = %q, want empty", out)
+			}
+		})
+	}
+}
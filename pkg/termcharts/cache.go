@@ -0,0 +1,231 @@
+package termcharts
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// RenderCache keeps the output of recent renders, keyed by a hash of each
+// chart's type, data, and options, so rendering a chart identical to one
+// already rendered returns the stored output without drawing it again. It
+// suits watch loops that redraw on a timer whether or not the data changed.
+//
+// Funcs, such as an axis Format or a post-processor, are compared by
+// identity, so a chart given a new closure each time is rendered again. The
+// terminal's capabilities and its size, read on every render, are part of
+// the key, so a chart sized to the terminal is rendered again when the
+// terminal is resized. Charts from
+// outside this package, and charts with insets from outside it, are always
+// rendered. Errors are returned but not stored.
+//
+// A RenderCache is safe for concurrent use.
+//
+// Example:
+//
+//	cache := termcharts.NewRenderCache(8)
+//	for range ticker.C {
+//	    chart := termcharts.NewLineChart(termcharts.WithData(readings()))
+//	    out, err := cache.Render(chart)
+//	    ...
+//	}
+type RenderCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // Most recently used first
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+// cacheEntry is a stored render.
+type cacheEntry struct {
+	key [sha256.Size]byte
+	out string
+}
+
+// cacheKeyer is implemented by charts whose renders can be cached. cacheKey
+// writes everything the chart's output depends on to w, and reports false if
+// the output depends on something it cannot write.
+type cacheKeyer interface {
+	cacheKey(w io.Writer) bool
+}
+
+// NewRenderCache creates a cache that keeps the size most recently used
+// renders. Sizes below 1 keep one.
+func NewRenderCache(size int) *RenderCache {
+	return &RenderCache{
+		size:    internal.Max(size, 1),
+		order:   list.New(),
+		entries: make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+// Render returns the output of chart, from the cache if an identical chart
+// was rendered before, or by rendering it and storing the output. Like
+// RenderFrame, it uses RenderE when the chart implements ChartE.
+func (c *RenderCache) Render(chart Chart) (string, error) {
+	key, ok := chartKey(chart)
+	if !ok {
+		return renderChart(chart)
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		c.mu.Unlock()
+		return elem.Value.(*cacheEntry).out, nil
+	}
+	c.mu.Unlock()
+
+	// Render outside the lock, so slow charts do not hold up cache hits
+	out, err := renderChart(chart)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.entries[key] = c.order.PushFront(&cacheEntry{key: key, out: out})
+		for c.order.Len() > c.size {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*cacheEntry).key)
+		}
+	}
+	return out, nil
+}
+
+// Len returns the number of renders in the cache.
+func (c *RenderCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Reset empties the cache.
+func (c *RenderCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = make(map[[sha256.Size]byte]*list.Element)
+}
+
+// chartKey returns the cache key of chart, and false if its renders cannot
+// be cached.
+func chartKey(chart Chart) ([sha256.Size]byte, bool) {
+	var key [sha256.Size]byte
+	keyer, ok := chart.(cacheKeyer)
+	if !ok {
+		return key, false
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%T\n", chart)
+	if !keyer.cacheKey(h) {
+		return key, false
+	}
+
+	// Charts sized or styled by the terminal look different in another one
+	size := internal.GetTerminalSize()
+	fmt.Fprintf(h, "%d %d %t %t %d\n", size.Width, size.Height,
+		internal.SupportsColor(), internal.SupportsUnicode(), internal.ColorDepth())
+	copy(key[:], h.Sum(nil))
+	return key, true
+}
+
+// writeKey writes the options to w for a cache key. It reports false if an
+// inset cannot be cached.
+func (o *Options) writeKey(w io.Writer) bool {
+	writeFloats(w, o.Data)
+	writeSeriesKey(w, o.Series...)
+
+	// Pointers are written by value, so a chart updated in place gets a new
+	// key; funcs can only be written by identity
+	rest := *o
+	rest.Data, rest.Series, rest.Insets = nil, nil, nil
+	rest.ColorEnabled, rest.Theme, rest.Legend, rest.ColorScale = nil, nil, nil, nil
+	fmt.Fprintf(w, "%#v\n", rest)
+	if o.ColorEnabled != nil {
+		fmt.Fprintf(w, "color %t\n", *o.ColorEnabled)
+	}
+	if o.Theme != nil {
+		fmt.Fprintf(w, "theme %#v\n", *o.Theme)
+	}
+	if o.Legend != nil {
+		fmt.Fprintf(w, "legend %#v\n", *o.Legend)
+	}
+	if o.ColorScale != nil {
+		fmt.Fprintf(w, "scale %#v\n", *o.ColorScale)
+	}
+
+	for _, inset := range o.Insets {
+		key, ok := chartKey(inset.Chart)
+		if !ok {
+			return false
+		}
+		fmt.Fprintf(w, "inset %x %d %d %d\n", key, inset.Position, inset.OffsetX, inset.OffsetY)
+	}
+	return true
+}
+
+// writeSeriesKey writes series to w for a cache key.
+func writeSeriesKey(w io.Writer, series ...Series) {
+	for _, s := range series {
+		fmt.Fprintf(w, "series %q %q %t\n", s.Label, s.Color, s.Hidden)
+		writeFloats(w, s.Data)
+		writeFloats(w, s.Upper)
+		writeFloats(w, s.Lower)
+	}
+}
+
+// writeFloats writes the length and exact bits of values to w.
+func writeFloats(w io.Writer, values []float64) {
+	// One write is much cheaper than formatting each value
+	buf := make([]byte, 8*(len(values)+1))
+	binary.LittleEndian.PutUint64(buf, uint64(len(values)))
+	for i, v := range values {
+		binary.LittleEndian.PutUint64(buf[8*(i+1):], math.Float64bits(v))
+	}
+	w.Write(buf)
+}
+
+func (b *BarChart) cacheKey(w io.Writer) bool  { return b.opts.writeKey(w) }
+func (l *LineChart) cacheKey(w io.Writer) bool { return l.opts.writeKey(w) }
+func (p *PieChart) cacheKey(w io.Writer) bool  { return p.opts.writeKey(w) }
+func (s *Sparkline) cacheKey(w io.Writer) bool { return s.opts.writeKey(w) }
+func (b *BigText) cacheKey(w io.Writer) bool   { return b.opts.writeKey(w) }
+func (h *Histogram) cacheKey(w io.Writer) bool { return h.opts.writeKey(w) }
+func (b *BoxPlot) cacheKey(w io.Writer) bool   { return b.opts.writeKey(w) }
+
+func (c *ComparisonChart) cacheKey(w io.Writer) bool {
+	writeSeriesKey(w, c.left, c.right)
+	return c.opts.writeKey(w)
+}
+
+func (m *ConfusionMatrixChart) cacheKey(w io.Writer) bool {
+	fmt.Fprintf(w, "%q\n", m.labels)
+	for _, row := range m.matrix {
+		writeFloats(w, row)
+	}
+	return m.opts.writeKey(w)
+}
+
+func (c *ComposedChart) cacheKey(w io.Writer) bool {
+	for _, layer := range c.layers {
+		fmt.Fprintf(w, "layer %d\n", layer.Kind)
+		writeSeriesKey(w, layer.Series)
+	}
+	return c.opts.writeKey(w)
+}
+
+func (m *SmallMultiplesChart) cacheKey(w io.Writer) bool {
+	// The factory is written by identity, like other funcs
+	fmt.Fprintf(w, "factory %p\n", m.factory)
+	writeSeriesKey(w, m.series...)
+	return m.opts.writeKey(w)
+}
@@ -0,0 +1,130 @@
+package termcharts
+
+import (
+	"errors"
+	"testing"
+)
+
+// staticChart is a chart from outside the package, which cannot be cached.
+type staticChart string
+
+func (c staticChart) Render() string { return string(c) }
+
+// countingBars returns a bar chart of data, and a counter of its renders.
+func countingBars(data []float64, renders *int) *BarChart {
+	count := func(lines []string) []string {
+		*renders++
+		return lines
+	}
+	return NewBarChart(WithData(data), WithWidth(30), WithColor(false), WithPostProcessor(count))
+}
+
+func TestRenderCache_Hit(t *testing.T) {
+	cache := NewRenderCache(4)
+	renders := 0
+	chart := countingBars([]float64{1, 2, 3}, &renders)
+
+	first, err := cache.Render(chart)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	second, _ := cache.Render(chart)
+
+	if renders != 1 {
+		t.Errorf("chart rendered %d times, want 1", renders)
+	}
+	if first != second || first != chart.Render() {
+		t.Errorf("cached output = %q, want %q", second, first)
+	}
+}
+
+func TestRenderCache_Miss(t *testing.T) {
+	tests := []struct {
+		name   string
+		update Option
+	}{
+		{"data", WithData([]float64{1, 2, 4})},
+		{"labels", WithLabels([]string{"a", "b", "c"})},
+		{"title", WithTitle("Load")},
+		{"width", WithWidth(40)},
+		{"theme", WithTheme(DarkTheme)},
+		{"series", WithSeries([]Series{{Label: "a", Data: []float64{1, 2, 3}}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewRenderCache(4)
+			renders := 0
+			chart := countingBars([]float64{1, 2, 3}, &renders)
+			_, _ = cache.Render(chart)
+
+			chart.Update(tt.update)
+			_, _ = cache.Render(chart)
+
+			if renders != 2 {
+				t.Errorf("chart rendered %d times after update, want 2", renders)
+			}
+		})
+	}
+}
+
+func TestRenderCache_IdenticalCharts(t *testing.T) {
+	cache := NewRenderCache(4)
+	_, _ = cache.Render(NewSparkline(WithData([]float64{1, 5, 3})))
+	_, _ = cache.Render(NewSparkline(WithData([]float64{1, 5, 3})))
+	_, _ = cache.Render(NewLineChart(WithData([]float64{1, 5, 3}), WithWidth(30), WithHeight(5)))
+
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d, want 2: identical charts share an entry, other chart types do not", cache.Len())
+	}
+}
+
+func TestRenderCache_Eviction(t *testing.T) {
+	cache := NewRenderCache(2)
+	renders := 0
+	a := countingBars([]float64{1}, &renders)
+	b := countingBars([]float64{2}, &renders)
+	c := countingBars([]float64{3}, &renders)
+
+	_, _ = cache.Render(a)
+	_, _ = cache.Render(b)
+	_, _ = cache.Render(a) // a is now the most recently used
+	_, _ = cache.Render(c) // evicts b
+	_, _ = cache.Render(a)
+	_, _ = cache.Render(b)
+
+	if renders != 4 {
+		t.Errorf("charts rendered %d times, want 4", renders)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d, want 2", cache.Len())
+	}
+
+	cache.Reset()
+	if cache.Len() != 0 {
+		t.Errorf("Len after Reset = %d, want 0", cache.Len())
+	}
+}
+
+func TestRenderCache_Errors(t *testing.T) {
+	cache := NewRenderCache(4)
+	chart := NewBarChart(WithData([]float64{1, 2}), WithWidth(-1), WithStrict(true))
+
+	_, err := cache.Render(chart)
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Errorf("error = %v, want ErrInvalidDimensions", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len = %d, want 0: errors are not cached", cache.Len())
+	}
+}
+
+func TestRenderCache_Uncacheable(t *testing.T) {
+	cache := NewRenderCache(4)
+	out, err := cache.Render(staticChart("text\n"))
+	if err != nil || out != "text\n" {
+		t.Errorf("Render = %q, %v; want %q, nil", out, err, "text\n")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len = %d, want 0: charts from outside the package are not cached", cache.Len())
+	}
+}
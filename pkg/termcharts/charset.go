@@ -0,0 +1,73 @@
+package termcharts
+
+import (
+	"fmt"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// FillOption configures a chart that draws filled bars (bar, composed, or
+// comparison charts).
+type FillOption interface {
+	BarOption
+	ComposeOption
+	ComparisonOption
+}
+
+// fillOption is an option that applies to bar, composed, and comparison charts.
+type fillOption func(*Options)
+
+func (f fillOption) applyBar(o *Options)        { f(o) }
+func (f fillOption) applyCompose(o *Options)    { f(o) }
+func (f fillOption) applyComparison(o *Options) { f(o) }
+
+// WithFillChar sets the character bars are filled with, in place of '█', or
+// '#' in ASCII mode, for fonts where full blocks render poorly, e.g. '▓' or
+// '='. It applies to bar charts, the bar layers of composed charts, and
+// comparison charts, in every style. Stacked bars with a fill character end on whole cells rather
+// than eighths of a cell. The character must be one column wide.
+func WithFillChar(char rune) FillOption {
+	return fillOption(func(o *Options) {
+		o.FillChar = char
+	})
+}
+
+// WithSparkChars sets the characters a sparkline is drawn with, from the
+// lowest value to the highest, in place of "▁▂▃▄▅▆▇█", or "_.-=+*#@" in
+// ASCII mode, e.g. []rune("⣀⣤⣶⣿") or []rune(" ░▒▓█"). Any number of two or
+// more characters, each one column wide, can be used; values are spread
+// evenly across them.
+func WithSparkChars(chars []rune) SparklineOption {
+	return sparklineOption(func(o *Options) {
+		o.SparkChars = chars
+	})
+}
+
+// fillChar returns the character bars are filled with.
+func (o *Options) fillChar(useUnicode bool) rune {
+	switch {
+	case o.FillChar != 0:
+		return o.FillChar
+	case useUnicode:
+		return '█'
+	default:
+		return barCharASCII
+	}
+}
+
+// validateCharsets checks that the fill and sparkline characters are each
+// one column wide, and that a sparkline has at least two characters.
+func (o *Options) validateCharsets() error {
+	if o.FillChar != 0 && internal.StringWidth(string(o.FillChar)) != 1 {
+		return fmt.Errorf("%w: fill character %q is not one column wide", ErrInvalidOption, o.FillChar)
+	}
+	if len(o.SparkChars) == 1 {
+		return fmt.Errorf("%w: sparklines need at least two characters, got %q", ErrInvalidOption, string(o.SparkChars))
+	}
+	for _, r := range o.SparkChars {
+		if internal.StringWidth(string(r)) != 1 {
+			return fmt.Errorf("%w: sparkline character %q is not one column wide", ErrInvalidOption, r)
+		}
+	}
+	return nil
+}
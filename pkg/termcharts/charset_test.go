@@ -0,0 +1,95 @@
+package termcharts
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithFillChar(t *testing.T) {
+	labels := WithLabels([]string{"a", "b"})
+	tests := []struct {
+		name  string
+		chart Updatable
+		want  string
+	}{
+		{
+			name:  "horizontal bars",
+			chart: NewBarChart(WithData([]float64{2, 4}), labels, WithWidth(10), WithFillChar('=')),
+			want:  "a  ===\nb  ======\n",
+		},
+		{
+			name: "vertical bars",
+			chart: NewBarChart(WithData([]float64{2, 4}), WithDirection(Vertical), WithWidth(10), WithHeight(4),
+				WithShowAxes(false), WithFillChar('▓')),
+			want: "    ▓▓▓\n    ▓▓▓\n▓▓▓ ▓▓▓\n▓▓▓ ▓▓▓\n",
+		},
+		{
+			name: "stacked bars end on whole cells",
+			chart: NewBarChart(WithSeries([]Series{{Label: "x", Data: []float64{1, 3}}, {Label: "y", Data: []float64{2, 1}}}),
+				WithBarMode(BarModeStacked), labels, WithWidth(12), WithFillChar('=')),
+			want: "a  ======\nb  ========\n",
+		},
+		{
+			name: "composed bar layer",
+			chart: Compose([]Layer{{Kind: LayerBar, Series: Series{Data: []float64{1, 2}}}},
+				WithWidth(12), WithHeight(4), WithShowAxes(false), WithFillChar('%')),
+			want: "      %%%%% \n%%%%% %%%%% \n%%%%% %%%%% \n%%%%% %%%%% \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.chart.Apply(WithColor(false), WithStyle(StyleUnicode))
+			if got := tt.chart.Render(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithSparkChars(t *testing.T) {
+	tests := []struct {
+		name  string
+		chars []rune
+		style RenderStyle
+		want  string
+	}{
+		{name: "shade ramp", chars: []rune(" ░▒▓█"), style: StyleUnicode, want: " ░▒▓█"},
+		{name: "overrides ascii", chars: []rune("0123456789"), style: StyleASCII, want: "02469"},
+		{name: "two characters", chars: []rune("⣀⣿"), style: StyleUnicode, want: "⣀⣀⣀⣀⣿"},
+		{name: "single character ignored", chars: []rune("x"), style: StyleASCII, want: "_.=*@"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spark := NewSparkline(WithData([]float64{1, 2, 3, 4, 5}), WithSparkChars(tt.chars), WithStyle(tt.style), WithColor(false))
+			if got := spark.Render(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_Charsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{name: "default", wantErr: nil},
+		{name: "narrow fill", opts: []Option{func(o *Options) { o.FillChar = '▓' }}, wantErr: nil},
+		{name: "wide fill", opts: []Option{func(o *Options) { o.FillChar = '🟦' }}, wantErr: ErrInvalidOption},
+		{name: "control fill", opts: []Option{func(o *Options) { o.FillChar = '\t' }}, wantErr: ErrInvalidOption},
+		{name: "spark ramp", opts: []Option{func(o *Options) { o.SparkChars = []rune(".oO") }}, wantErr: nil},
+		{name: "single spark char", opts: []Option{func(o *Options) { o.SparkChars = []rune("x") }}, wantErr: ErrInvalidOption},
+		{name: "wide spark char", opts: []Option{func(o *Options) { o.SparkChars = []rune("a界") }}, wantErr: ErrInvalidOption},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewOptions(tt.opts...).Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -16,7 +16,12 @@
 // The library auto-detects terminal capabilities and adjusts rendering accordingly.
 package termcharts
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
 
 // Chart represents a terminal-based data visualization.
 // All chart types implement this interface.
@@ -26,14 +31,32 @@ type Chart interface {
 	Render() string
 }
 
+// ChartE is a Chart that can report why rendering failed.
+// Callers can use errors.Is with ErrEmptyData, ErrInvalidData, or
+// ErrInvalidDimensions to distinguish failures instead of checking for "".
+type ChartE interface {
+	Chart
+	// RenderE generates the chart like Render, returning an error
+	// instead of an empty string when the chart cannot be drawn.
+	RenderE() (string, error)
+}
+
 // Series represents a labeled data series for multi-series charts.
 type Series struct {
 	// Label is the display name for this data series.
 	Label string
 	// Data contains the numeric values to visualize.
 	Data []float64
-	// Color is an optional color for this series (empty means auto-assign).
+	// Color is an optional color or style spec for this series (empty means auto-assign).
 	Color string
+	// Hidden leaves the series out of the plot and the value range. It keeps
+	// its color and stays in the legend, dimmed.
+	Hidden bool
+	// Upper and Lower bound a shaded band around the series on line charts,
+	// such as a forecast's confidence interval. Each holds one bound per data
+	// point (nil = the data itself, so a band can extend to one side only).
+	Upper []float64
+	Lower []float64
 }
 
 // Direction specifies the orientation of a chart.
@@ -64,6 +87,131 @@ var (
 	ErrEmptyData = errors.New("data cannot be empty")
 	// ErrInvalidData indicates the data contains invalid values (NaN, Inf, etc.).
 	ErrInvalidData = errors.New("data contains invalid values")
-	// ErrInvalidDimensions indicates chart dimensions are too small to render.
+	// ErrInvalidDimensions indicates chart dimensions are negative or too large.
+	// Charts clamp such sizes when rendering; strict mode reports them instead.
 	ErrInvalidDimensions = errors.New("chart dimensions too small")
+	// ErrInvalidOption indicates an option has a value outside its allowed range.
+	ErrInvalidOption = errors.New("invalid option value")
+	// ErrLabelMismatch indicates the number of labels does not match the number of data points.
+	ErrLabelMismatch = errors.New("labels do not match data points")
+	// ErrConflictingOptions indicates options were combined in a way the chart cannot honor.
+	ErrConflictingOptions = errors.New("conflicting options")
+	// ErrLimitExceeded indicates the input is larger than the chart's Limits allow.
+	ErrLimitExceeded = errors.New("chart limit exceeded")
+	// ErrInvalidSpec indicates a chart spec that cannot be decoded or describes no valid chart.
+	ErrInvalidSpec = errors.New("invalid chart spec")
 )
+
+// validateData checks that data is non-empty and contains only finite values.
+func validateData(data []float64) error {
+	if len(data) == 0 {
+		return ErrEmptyData
+	}
+	for i, v := range data {
+		if !internal.IsValid(v) {
+			return fmt.Errorf("%w: value at index %d is %v", ErrInvalidData, i, v)
+		}
+	}
+	return nil
+}
+
+// seriesHidden reports whether s is left out of the plot, because it is
+// Hidden or its label was passed to WithHiddenSeries.
+func (o *Options) seriesHidden(s Series) bool {
+	if s.Hidden {
+		return true
+	}
+	for _, label := range o.HiddenSeries {
+		if label == s.Label {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleSeries returns the series of all that are plotted. Series without
+// a color are given the color of their position in all, or of their label
+// with WithColorByLabel, so hiding a series does not recolor the others.
+func (o *Options) visibleSeries(all []Series, theme *Theme) []Series {
+	visible := make([]Series, 0, len(all))
+	for i, s := range all {
+		if o.seriesHidden(s) {
+			continue
+		}
+		if s.Color == "" {
+			s.Color = o.seriesColor(theme, i, s.Label)
+		}
+		visible = append(visible, s)
+	}
+	return visible
+}
+
+// validateSeries checks that at least one series has data and that every
+// series contains only finite values.
+func validateSeries(series []Series) error {
+	hasData := false
+	for i, s := range series {
+		if len(s.Data) > 0 {
+			hasData = true
+		}
+		for j, v := range s.Data {
+			if !internal.IsValid(v) {
+				return fmt.Errorf("%w: series %d value at index %d is %v", ErrInvalidData, i, j, v)
+			}
+		}
+		if err := validateBounds(i, s); err != nil {
+			return err
+		}
+	}
+	if !hasData {
+		return ErrEmptyData
+	}
+	return nil
+}
+
+// validateBounds checks that the confidence band bounds of series i, if
+// set, have one finite value per data point.
+func validateBounds(i int, s Series) error {
+	for _, b := range []struct {
+		name   string
+		values []float64
+	}{{"upper", s.Upper}, {"lower", s.Lower}} {
+		if b.values == nil {
+			continue
+		}
+		if len(b.values) != len(s.Data) {
+			return fmt.Errorf("%w: series %d has %d %s bounds for %d values", ErrInvalidData, i, len(b.values), b.name, len(s.Data))
+		}
+		for j, v := range b.values {
+			if !internal.IsValid(v) {
+				return fmt.Errorf("%w: series %d %s bound at index %d is %v", ErrInvalidData, i, b.name, j, v)
+			}
+		}
+	}
+	return nil
+}
+
+// validateDimensions checks that the configured width and height are usable.
+func validateDimensions(opts *Options) error {
+	if opts.Width < 0 {
+		return fmt.Errorf("%w: width %d is negative", ErrInvalidDimensions, opts.Width)
+	}
+	if opts.Height < 0 {
+		return fmt.Errorf("%w: height %d is negative", ErrInvalidDimensions, opts.Height)
+	}
+	if max := opts.maxWidth(); max > 0 && opts.Width > max {
+		return fmt.Errorf("%w: width %d exceeds the limit of %d columns", ErrInvalidDimensions, opts.Width, max)
+	}
+	if max := opts.maxHeight(); max > 0 && opts.Height > max {
+		return fmt.Errorf("%w: height %d exceeds the limit of %d rows", ErrInvalidDimensions, opts.Height, max)
+	}
+	return nil
+}
+
+// renderChart renders chart, returning the error of a ChartE that cannot be drawn.
+func renderChart(chart Chart) (string, error) {
+	if c, ok := chart.(ChartE); ok {
+		return c.RenderE()
+	}
+	return chart.Render(), nil
+}
@@ -2,7 +2,11 @@ package termcharts
 
 import (
 	"errors"
+	"math"
+	"strings"
 	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
 )
 
 func TestDirection_String(t *testing.T) {
@@ -59,6 +63,21 @@ func TestErrors(t *testing.T) {
 			err:      ErrInvalidDimensions,
 			expected: "chart dimensions too small",
 		},
+		{
+			name:     "ErrInvalidOption",
+			err:      ErrInvalidOption,
+			expected: "invalid option value",
+		},
+		{
+			name:     "ErrLabelMismatch",
+			err:      ErrLabelMismatch,
+			expected: "labels do not match data points",
+		},
+		{
+			name:     "ErrConflictingOptions",
+			err:      ErrConflictingOptions,
+			expected: "conflicting options",
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,3 +145,237 @@ func TestSeries(t *testing.T) {
 		t.Errorf("Series.Color = %v, want %v", s.Color, "blue")
 	}
 }
+
+func TestChartE_RenderE(t *testing.T) {
+	constructors := map[string]func(...Option) ChartE{
+		"bar":       func(opts ...Option) ChartE { return NewBarChart(Combine(opts...)) },
+		"line":      func(opts ...Option) ChartE { return NewLineChart(Combine(opts...)) },
+		"pie":       func(opts ...Option) ChartE { return NewPieChart(Combine(opts...)) },
+		"sparkline": func(opts ...Option) ChartE { return NewSparkline(Combine(opts...)) },
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{
+			name:    "valid data",
+			opts:    []Option{WithData([]float64{1, 2, 3})},
+			wantErr: nil,
+		},
+		{
+			name:    "empty data",
+			opts:    []Option{WithData([]float64{})},
+			wantErr: ErrEmptyData,
+		},
+		{
+			name:    "NaN value",
+			opts:    []Option{WithData([]float64{1, math.NaN(), 3})},
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "infinite value",
+			opts:    []Option{WithData([]float64{1, math.Inf(1)})},
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "strict negative width",
+			opts:    []Option{WithStrict(true), WithData([]float64{1, 2, 3}), WithWidth(-5)},
+			wantErr: ErrInvalidDimensions,
+		},
+		{
+			name:    "strict enormous height",
+			opts:    []Option{WithStrict(true), WithData([]float64{1, 2, 3}), WithHeight(1 << 30)},
+			wantErr: ErrInvalidDimensions,
+		},
+	}
+
+	for chartName, newChart := range constructors {
+		for _, tt := range tests {
+			t.Run(chartName+"/"+tt.name, func(t *testing.T) {
+				chart := newChart(append(tt.opts, WithColor(false))...)
+				out, err := chart.RenderE()
+
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("RenderE() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil && out != "" {
+					t.Errorf("RenderE() output should be empty on error, got %q", out)
+				}
+				if tt.wantErr == nil && out == "" {
+					t.Error("RenderE() returned empty output without an error")
+				}
+				if chart.Render() != out {
+					t.Error("Render() should match RenderE() output")
+				}
+			})
+		}
+	}
+}
+
+func TestRender_ClampsDimensions(t *testing.T) {
+	data := WithData([]float64{4, 8, 15, 16, 23, 42})
+	for _, size := range []int{-5, 1, 1 << 40} {
+		charts := map[string]ChartE{
+			"bar":       NewBarChart(data, WithWidth(size), WithHeight(size)),
+			"vertical":  NewBarChart(data, WithDirection(Vertical), WithWidth(size), WithHeight(size)),
+			"line":      NewLineChart(data, WithWidth(size), WithHeight(size)),
+			"pie":       NewPieChart(data, WithWidth(size), WithHeight(size)),
+			"sparkline": NewSparkline(data, WithWidth(size)),
+			"bigtext":   NewBigText(data, WithWidth(size), WithHeight(size)),
+		}
+		for name, chart := range charts {
+			out, err := chart.RenderE()
+			if err != nil || out == "" {
+				t.Errorf("%s at size %d: RenderE() = %q, %v", name, size, out, err)
+				continue
+			}
+			lines := strings.Split(out, "\n")
+			if len(lines) > DefaultMaxHeight+1 {
+				t.Errorf("%s at size %d: %d lines", name, size, len(lines))
+			}
+			for _, line := range lines {
+				if w := internal.StringWidth(internal.StripANSI(line)); w > DefaultMaxWidth {
+					t.Errorf("%s at size %d: line is %d columns wide", name, size, w)
+					break
+				}
+			}
+		}
+	}
+
+	// A tiny line chart keeps to a minimal plot rather than a full-size one
+	out := NewLineChart(data, WithWidth(1), WithHeight(1), WithColor(false)).Render()
+	if w := internal.StringWidth(strings.SplitN(out, "\n", 2)[0]); w > 20 {
+		t.Errorf("line chart at width 1 is %d columns wide", w)
+	}
+}
+
+func TestChartE_MultiSeries(t *testing.T) {
+	tests := []struct {
+		name    string
+		series  []Series
+		wantErr error
+	}{
+		{
+			name:    "all series empty",
+			series:  []Series{{Label: "A"}, {Label: "B", Data: []float64{}}},
+			wantErr: ErrEmptyData,
+		},
+		{
+			name:    "invalid value in second series",
+			series:  []Series{{Label: "A", Data: []float64{1}}, {Label: "B", Data: []float64{math.NaN()}}},
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "bounds shorter than data",
+			series:  []Series{{Label: "A", Data: []float64{1, 2}, Upper: []float64{3}}},
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "invalid bound",
+			series:  []Series{{Label: "A", Data: []float64{1, 2}, Lower: []float64{0, math.Inf(-1)}}},
+			wantErr: ErrInvalidData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charts := []ChartE{
+				NewBarChart(WithSeries(tt.series)),
+				NewLineChart(WithSeries(tt.series)),
+			}
+			for _, chart := range charts {
+				if _, err := chart.RenderE(); !errors.Is(err, tt.wantErr) {
+					t.Errorf("%T.RenderE() error = %v, want %v", chart, err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestPieChart_RenderE_NoPositiveValues(t *testing.T) {
+	pie := NewPieChart(WithData([]float64{0, -5}))
+
+	if _, err := pie.RenderE(); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("RenderE() error = %v, want %v", err, ErrInvalidData)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	data := WithData([]float64{1, 2, 3})
+
+	tests := []struct {
+		name    string
+		chart   ChartE
+		wantErr error
+	}{
+		{
+			name:  "valid bar chart",
+			chart: NewBarChart(WithStrict(true), data),
+		},
+		{
+			name:    "bar chart with braille",
+			chart:   NewBarChart(WithStrict(true), data, WithStyle(StyleBraille)),
+			wantErr: ErrConflictingOptions,
+		},
+		{
+			name:    "bar chart with sextants",
+			chart:   NewBarChart(WithStrict(true), data, WithStyle(StyleSextant)),
+			wantErr: ErrConflictingOptions,
+		},
+		{
+			name:    "stacked bar chart without series",
+			chart:   NewBarChart(WithStrict(true), data, WithBarMode(BarModeStacked)),
+			wantErr: ErrConflictingOptions,
+		},
+		{
+			name:    "bar chart with mismatched labels",
+			chart:   NewBarChart(WithStrict(true), data, WithLabels([]string{"A"})),
+			wantErr: ErrLabelMismatch,
+		},
+		{
+			name:  "valid line chart",
+			chart: NewLineChart(WithStrict(true), data, WithBraille()),
+		},
+		{
+			name: "vertical line chart",
+			chart: NewLineChart(WithStrict(true), data, Option(func(o *Options) {
+				o.Direction = Vertical
+			})),
+			wantErr: ErrConflictingOptions,
+		},
+		{
+			name:    "pie chart with series",
+			chart:   NewPieChart(WithStrict(true), WithSeries([]Series{{Data: []float64{1}}})),
+			wantErr: ErrConflictingOptions,
+		},
+		{
+			name:    "sparkline with negative width",
+			chart:   NewSparkline(WithStrict(true), data, WithWidth(-1)),
+			wantErr: ErrInvalidDimensions,
+		},
+		{
+			name:  "non-strict chart ignores conflicts",
+			chart: NewBarChart(data, WithStyle(StyleBraille), WithLabels([]string{"A"})),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.chart.RenderE()
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("RenderE() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RenderE() error = %v, want %v", err, tt.wantErr)
+			}
+			if out != "" || tt.chart.Render() != "" {
+				t.Error("chart with invalid options should render an empty string")
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package termcharts
+
+import (
+	"math"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// WithColorBar draws a color bar below charts that color cells by value,
+// such as confusion matrices: a row of cells running through the colors of
+// the scale, labeled with its minimum, middle, and maximum values, so colors
+// can be read back as values.
+//
+// Example:
+//
+//	cm := termcharts.NewConfusionMatrix(labels, counts, termcharts.WithColorBar(true))
+func WithColorBar(show bool) Option {
+	return func(o *Options) {
+		o.ColorBar = show
+	}
+}
+
+// colorBar returns a color bar of scale from lo to hi, width columns wide,
+// and its label line. Each column takes the color of its value, and the
+// glyph of shades for its position, so the bar still reads without color.
+// The middle label is dropped when the labels would touch.
+func colorBar(scale *ColorScale, shades []rune, lo, hi float64, width int, format func(float64) string, colorEnabled bool) (bar, labels string) {
+	var b strings.Builder
+	for x := 0; x < width; x++ {
+		t := 1.0
+		if width > 1 {
+			t = float64(x) / float64(width-1)
+		}
+		glyph := string(shades[int(math.Ceil(t*float64(len(shades)-1)))])
+		b.WriteString(Colorize(glyph, scale.Color(lo+(hi-lo)*t), colorEnabled))
+	}
+
+	line := []rune(strings.Repeat(" ", width))
+	place := func(text string, at int) {
+		r := []rune(text)
+		at = internal.ClampInt(at, 0, internal.Max(width-len(r), 0))
+		if at+len(r) > width {
+			return
+		}
+		// Keep a space on either side of the label
+		for i := internal.Max(at-1, 0); i < internal.Min(at+len(r)+1, width); i++ {
+			if line[i] != ' ' {
+				return
+			}
+		}
+		copy(line[at:], r)
+	}
+	minLabel, midLabel, maxLabel := format(lo), format((lo+hi)/2), format(hi)
+	place(minLabel, 0)
+	place(maxLabel, width-len([]rune(maxLabel)))
+	place(midLabel, (width-len([]rune(midLabel)))/2)
+	return b.String(), strings.TrimRight(string(line), " ")
+}
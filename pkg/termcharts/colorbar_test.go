@@ -0,0 +1,37 @@
+package termcharts
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestColorBar(t *testing.T) {
+	scale := NewBandedColorScale(ColorStop{Value: 0, Color: "blue"}, ColorStop{Value: 50, Color: "red"})
+	format := func(v float64) string { return fmt.Sprintf("%g", v) }
+
+	tests := []struct {
+		name       string
+		width      int
+		hi         float64
+		wantBar    string
+		wantLabels string
+	}{
+		{name: "all labels", width: 11, hi: 100, wantBar: "...........", wantLabels: "0   50  100"},
+		{name: "middle label dropped", width: 7, hi: 100, wantBar: ".......", wantLabels: "0   100"},
+		{name: "labels wider than bar", width: 2, hi: 1000, wantBar: "..", wantLabels: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bar, labels := colorBar(scale, []rune{'.'}, 0, tt.hi, tt.width, format, false)
+			if bar != tt.wantBar || labels != tt.wantLabels {
+				t.Errorf("colorBar() = %q, %q, want %q, %q", bar, labels, tt.wantBar, tt.wantLabels)
+			}
+		})
+	}
+
+	bar, _ := colorBar(scale, []rune{'█'}, 0, 100, 2, format, true)
+	if want := Colorize("█", "blue", true) + Colorize("█", "red", true); bar != want {
+		t.Errorf("colored colorBar() = %q, want %q", bar, want)
+	}
+}
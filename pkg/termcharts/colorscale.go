@@ -0,0 +1,263 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// ColorDepth is the number of colors a ColorScale reduces its colors to.
+type ColorDepth int
+
+const (
+	// ColorDepthAuto detects the terminal's depth from COLORTERM and TERM.
+	ColorDepthAuto ColorDepth = iota
+	// ColorDepth16 uses the standard palette names, such as "red".
+	ColorDepth16
+	// ColorDepth256 uses indexes into the 256-color palette, such as "208".
+	ColorDepth256
+	// ColorDepthTrue uses 24-bit hex colors, such as "#ff8700".
+	ColorDepthTrue
+)
+
+// ColorStop is the color of a ColorScale at a value.
+type ColorStop struct {
+	Value float64
+	Color string
+}
+
+// ColorScale maps values to colors. A gradient scale, from NewColorScale,
+// blends the colors of the stops around a value; a banded scale, from
+// NewBandedColorScale, gives a value the color of the highest stop at or
+// below it, like sparkline thresholds. Scales are immutable and safe for
+// concurrent use.
+//
+// Example:
+//
+//	scale := termcharts.NewColorScale(
+//	    termcharts.ColorStop{Value: 0, Color: "#2166ac"},
+//	    termcharts.ColorStop{Value: 50, Color: "#f7f7f7"},
+//	    termcharts.ColorStop{Value: 100, Color: "#b2182b"},
+//	)
+//	fmt.Println(termcharts.Colorize("72°", scale.Color(72), true))
+type ColorScale struct {
+	stops  []ColorStop
+	banded bool
+	depth  ColorDepth
+}
+
+// NewColorScale creates a gradient scale through stops, in any order.
+// Values between two stops get a blend of their colors; values beyond the
+// first or last stop get its color. Stops must be palette names, palette
+// indexes, or hex colors to be blended; a value next to a stop that is not,
+// such as a style spec, gets the color of the nearer stop. Blended colors are
+// reduced to the terminal's color depth; see WithDepth.
+func NewColorScale(stops ...ColorStop) *ColorScale {
+	return &ColorScale{stops: sortedStops(stops)}
+}
+
+// NewBandedColorScale creates a banded scale: a value gets the color of the
+// highest stop at or below it, unchanged, and no color below the first stop.
+func NewBandedColorScale(stops ...ColorStop) *ColorScale {
+	return &ColorScale{stops: sortedStops(stops), banded: true}
+}
+
+// sortedStops returns a copy of stops sorted by value.
+func sortedStops(stops []ColorStop) []ColorStop {
+	sorted := append([]ColorStop(nil), stops...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Value < sorted[j].Value })
+	return sorted
+}
+
+// WithDepth returns a copy of the scale that reduces blended colors to depth
+// instead of the detected one, e.g. ColorDepth256 for output that is saved
+// and viewed elsewhere.
+func (s *ColorScale) WithDepth(depth ColorDepth) *ColorScale {
+	c := *s
+	c.depth = depth
+	return &c
+}
+
+// Stops returns a copy of the scale's stops, sorted by value.
+func (s *ColorScale) Stops() []ColorStop {
+	return append([]ColorStop(nil), s.stops...)
+}
+
+// Color returns the color of v, for use with Colorize or as a chart color.
+// It returns "" for a scale without stops, for NaN, and, on a banded scale,
+// for values below the first stop.
+func (s *ColorScale) Color(v float64) string {
+	if len(s.stops) == 0 || math.IsNaN(v) {
+		return ""
+	}
+
+	// i is the first stop above v
+	i := sort.Search(len(s.stops), func(i int) bool { return s.stops[i].Value > v })
+	if s.banded {
+		if i == 0 {
+			return ""
+		}
+		return s.stops[i-1].Color
+	}
+	switch i {
+	case 0:
+		return s.reduce(s.stops[0].Color)
+	case len(s.stops):
+		return s.reduce(s.stops[i-1].Color)
+	}
+
+	lo, hi := s.stops[i-1], s.stops[i]
+	t := (v - lo.Value) / (hi.Value - lo.Value)
+	from, ok1 := colorToRGB(lo.Color)
+	to, ok2 := colorToRGB(hi.Color)
+	if !ok1 || !ok2 {
+		if t < 0.5 {
+			return lo.Color
+		}
+		return hi.Color
+	}
+	var rgb [3]uint8
+	for c := range rgb {
+		rgb[c] = uint8(float64(from[c]) + (float64(to[c])-float64(from[c]))*t + 0.5)
+	}
+	return s.reduce(fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]))
+}
+
+// WithColorScale colors sparkline characters by the value they draw, and
+// confusion matrix cells by their share of the row, from 0 to 1, with scale
+// instead of the theme's intensity colors. It takes the place of
+// WithThresholds and needs color to be enabled.
+//
+// Example:
+//
+//	termcharts.NewSparkline(
+//	    termcharts.WithData(latencies),
+//	    termcharts.WithColorScale(termcharts.NewColorScale(
+//	        termcharts.ColorStop{Value: 0, Color: "green"},
+//	        termcharts.ColorStop{Value: 500, Color: "red"},
+//	    )),
+//	)
+func WithColorScale(scale *ColorScale) Option {
+	return func(o *Options) {
+		o.ColorScale = scale
+	}
+}
+
+// reduce returns color at the scale's color depth. Colors that do not
+// resolve to RGB, such as style specs, are returned unchanged.
+func (s *ColorScale) reduce(color string) string {
+	rgb, ok := colorToRGB(color)
+	if !ok {
+		return color
+	}
+	switch s.resolvedDepth() {
+	case ColorDepth16:
+		if _, named := colorRGB[color]; named {
+			return color
+		}
+		return nearestNamed(rgb)
+	case ColorDepth256:
+		if _, named := colorRGB[color]; named {
+			return color
+		}
+		return fmt.Sprint(nearestXterm(rgb))
+	default:
+		if _, hex := parseHexColor(color); hex {
+			return color
+		}
+		return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+	}
+}
+
+// resolvedDepth returns the scale's depth, detecting it if it is auto.
+func (s *ColorScale) resolvedDepth() ColorDepth {
+	if s.depth != ColorDepthAuto {
+		return s.depth
+	}
+	switch internal.ColorDepth() {
+	case internal.DepthTrue:
+		return ColorDepthTrue
+	case internal.Depth256:
+		return ColorDepth256
+	default:
+		return ColorDepth16
+	}
+}
+
+// namedColors are the distinct colors of the standard palette, in the order
+// ties go to.
+var namedColors = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white", "gray"}
+
+// nearestNamed returns the standard palette name closest to rgb.
+func nearestNamed(rgb [3]uint8) string {
+	best, bestDist := "", math.MaxInt
+	for _, name := range namedColors {
+		if d := rgbDistance(rgb, colorRGB[name]); d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+// xtermLevels are the channel values of the 6x6x6 color cube of the
+// 256-color palette.
+var xtermLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// xtermBasic are the RGB values of palette indexes 0 to 15.
+var xtermBasic = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// xtermRGB returns the RGB value of index n of the 256-color palette: the 16
+// basic colors, then the color cube, then 24 grays.
+func xtermRGB(n int) [3]uint8 {
+	switch {
+	case n < 16:
+		return xtermBasic[n]
+	case n < 232:
+		n -= 16
+		return [3]uint8{xtermLevels[n/36], xtermLevels[n/6%6], xtermLevels[n%6]}
+	default:
+		g := uint8(8 + 10*(n-232))
+		return [3]uint8{g, g, g}
+	}
+}
+
+// nearestXterm returns the index of the color cube or gray ramp entry of the
+// 256-color palette closest to rgb. The basic colors vary between terminals,
+// so they are not used.
+func nearestXterm(rgb [3]uint8) int {
+	var cube [3]int
+	for c, v := range rgb {
+		best := 0
+		for i, level := range xtermLevels {
+			if internal.Abs(int(v)-int(level)) < internal.Abs(int(v)-int(xtermLevels[best])) {
+				best = i
+			}
+		}
+		cube[c] = best
+	}
+	index := 16 + cube[0]*36 + cube[1]*6 + cube[2]
+
+	avg := (int(rgb[0]) + int(rgb[1]) + int(rgb[2])) / 3
+	gray := 232 + internal.ClampInt((avg-3)/10, 0, 23)
+	if rgbDistance(rgb, xtermRGB(gray)) < rgbDistance(rgb, xtermRGB(index)) {
+		return gray
+	}
+	return index
+}
+
+// rgbDistance returns the squared distance between two colors.
+func rgbDistance(a, b [3]uint8) int {
+	d := 0
+	for c := range a {
+		diff := int(a[c]) - int(b[c])
+		d += diff * diff
+	}
+	return d
+}
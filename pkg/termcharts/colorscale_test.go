@@ -0,0 +1,126 @@
+package termcharts
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestColorScale_Color(t *testing.T) {
+	gradient := NewColorScale(
+		ColorStop{Value: 100, Color: "#ff0000"},
+		ColorStop{Value: 0, Color: "#0000ff"},
+	).WithDepth(ColorDepthTrue)
+	banded := NewBandedColorScale(
+		ColorStop{Value: 80, Color: "red"},
+		ColorStop{Value: 50, Color: "yellow"},
+	)
+
+	tests := []struct {
+		name  string
+		scale *ColorScale
+		v     float64
+		want  string
+	}{
+		{name: "gradient low end", scale: gradient, v: 0, want: "#0000ff"},
+		{name: "gradient midpoint", scale: gradient, v: 50, want: "#800080"},
+		{name: "gradient quarter", scale: gradient, v: 25, want: "#4000bf"},
+		{name: "gradient below first stop", scale: gradient, v: -10, want: "#0000ff"},
+		{name: "gradient above last stop", scale: gradient, v: 500, want: "#ff0000"},
+		{name: "gradient NaN", scale: gradient, v: math.NaN(), want: ""},
+		{name: "named stops blend", scale: NewColorScale(ColorStop{0, "black"}, ColorStop{1, "white"}).WithDepth(ColorDepthTrue), v: 0.5, want: "#737373"},
+		{name: "style spec stop nearer", scale: NewColorScale(ColorStop{0, "bold red"}, ColorStop{1, "blue"}), v: 0.2, want: "bold red"},
+		{name: "banded below first stop", scale: banded, v: 10, want: ""},
+		{name: "banded at stop", scale: banded, v: 50, want: "yellow"},
+		{name: "banded between stops", scale: banded, v: 79, want: "yellow"},
+		{name: "banded above last stop", scale: banded, v: 99, want: "red"},
+		{name: "no stops", scale: NewColorScale(), v: 1, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scale.Color(tt.v); got != tt.want {
+				t.Errorf("Color(%v) = %q, want %q", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorScale_WithDepth(t *testing.T) {
+	scale := NewColorScale(
+		ColorStop{Value: 0, Color: "#000000"},
+		ColorStop{Value: 1, Color: "#ff8700"},
+	)
+
+	tests := []struct {
+		name  string
+		depth ColorDepth
+		v     float64
+		want  string
+	}{
+		{name: "truecolor", depth: ColorDepthTrue, v: 1, want: "#ff8700"},
+		{name: "256 colors", depth: ColorDepth256, v: 1, want: "208"},
+		{name: "256 colors gray", depth: ColorDepth256, v: 0, want: "16"},
+		{name: "16 colors", depth: ColorDepth16, v: 1, want: "yellow"},
+		{name: "16 colors dark", depth: ColorDepth16, v: 0, want: "black"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scale.WithDepth(tt.depth).Color(tt.v); got != tt.want {
+				t.Errorf("Color(%v) = %q, want %q", tt.v, got, tt.want)
+			}
+		})
+	}
+
+	if got := NewColorScale(ColorStop{0, "red"}).WithDepth(ColorDepth256).Color(0); got != "red" {
+		t.Errorf("named stop at 256 colors = %q, want it unchanged", got)
+	}
+}
+
+func TestNearestXterm(t *testing.T) {
+	tests := []struct {
+		rgb  [3]uint8
+		want int
+	}{
+		{rgb: [3]uint8{255, 135, 0}, want: 208},
+		{rgb: [3]uint8{0, 0, 255}, want: 21},
+		{rgb: [3]uint8{128, 128, 128}, want: 244},
+		{rgb: [3]uint8{255, 255, 255}, want: 231},
+	}
+
+	for _, tt := range tests {
+		if got := nearestXterm(tt.rgb); got != tt.want {
+			t.Errorf("nearestXterm(%v) = %d, want %d", tt.rgb, got, tt.want)
+		}
+		if got := xtermRGB(nearestXterm(tt.rgb)); rgbDistance(got, tt.rgb) > 3*10*10 {
+			t.Errorf("xtermRGB(nearestXterm(%v)) = %v, too far", tt.rgb, got)
+		}
+	}
+}
+
+func TestColorize_PaletteIndex(t *testing.T) {
+	if got, want := Colorize("x", "208", true), "\033[38;5;208mx\033[0m"; got != want {
+		t.Errorf("Colorize(208) = %q, want %q", got, want)
+	}
+	if got, want := Colorize("x", "bold on 17", true), "\033[1;48;5;17mx\033[0m"; got != want {
+		t.Errorf("Colorize(bold on 17) = %q, want %q", got, want)
+	}
+}
+
+func TestWithColorScale(t *testing.T) {
+	scale := NewBandedColorScale(ColorStop{0, "green"}, ColorStop{5, "red"})
+	got := NewSparkline(WithData([]float64{1, 9}), WithColor(true), WithStyle(StyleASCII),
+		WithThresholds(map[float64]string{0: "blue"}), WithColorScale(scale)).Render()
+	if !strings.Contains(got, Colorize("_", "green", true)) || !strings.Contains(got, Colorize("@", "red", true)) {
+		t.Errorf("sparkline = %q, want scale colors", got)
+	}
+
+	cm := NewConfusionMatrix([]string{"a", "b"}, [][]float64{{9, 1}, {5, 5}}, WithColor(true),
+		WithStyle(StyleASCII), WithColorScale(NewBandedColorScale(ColorStop{0, "blue"}, ColorStop{0.5, "red"}))).Render()
+	for _, want := range []string{Colorize("@ 9 (90%)", "red", true), Colorize(". 1 (10%)", "blue", true)} {
+		if !strings.Contains(cm, want) {
+			t.Errorf("confusion matrix = %q, want it to contain %q", cm, want)
+		}
+	}
+}
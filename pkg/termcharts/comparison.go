@@ -0,0 +1,301 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// ComparisonChart draws two data sets as back-to-back horizontal bars from a
+// shared center axis, like a population pyramid: one row per category, the
+// first data set growing to the left and the second to the right.
+type ComparisonChart struct {
+	opts        *Options
+	err         error // Set by NewComparison when WithStrict finds invalid options
+	left, right Series
+}
+
+// ComparisonOption configures a comparison chart. Every Option is a
+// ComparisonOption, as are the axis options, whose WithYAxis formats values,
+// and WithFillChar.
+type ComparisonOption interface {
+	applyComparison(*Options)
+}
+
+func (f Option) applyComparison(o *Options)     { f(o) }
+func (f axisOption) applyComparison(o *Options) { f(o) }
+
+// NewComparison creates a comparison chart of left and right, such as the
+// measurements of a release before and after a change. Categories are named
+// by WithLabels, and the series labels head the two sides. Both sides share
+// one scale, so bars of the same length are the same value. WithShowValues
+// adds each value at the outer end of its bar, formatted by WithYAxis; the
+// title, width, style, fill, theme, and locale options apply as for other
+// charts. Series colors default to the theme's first two series colors.
+// Category labels take at most a third of the width, and values are left out
+// when the width has no room for them beside the bars.
+//
+// Example:
+//
+//	cmp := termcharts.NewComparison(
+//	    termcharts.Series{Label: "v1.4", Data: []float64{120, 340, 95}},
+//	    termcharts.Series{Label: "v1.5", Data: []float64{110, 210, 90}},
+//	    termcharts.WithLabels([]string{"startup", "p99", "idle"}),
+//	    termcharts.WithShowValues(true),
+//	)
+//	fmt.Print(cmp.Render())
+func NewComparison(left, right Series, opts ...ComparisonOption) *ComparisonChart {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyComparison(options)
+	}
+	c := &ComparisonChart{
+		opts:  options,
+		left:  left,
+		right: right,
+	}
+	if options.Strict {
+		c.err = c.validateOptions()
+	}
+	return c
+}
+
+// Update applies opts to the comparison, replacing the options they set.
+// The data sets are not changed; with WithStrict the options are validated
+// again.
+func (c *ComparisonChart) Update(opts ...ComparisonOption) {
+	for _, opt := range opts {
+		opt.applyComparison(c.opts)
+	}
+	c.err = nil
+	if c.opts.Strict {
+		c.err = c.validateOptions()
+	}
+}
+
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the comparison Updatable.
+func (c *ComparisonChart) Apply(opts ...Option) { c.Update(Combine(opts...)) }
+
+// Render generates the comparison chart as a multi-line string.
+// It returns an empty string if the chart cannot be drawn; use RenderE to find out why.
+func (c *ComparisonChart) Render() string {
+	out, _ := c.RenderE()
+	return out
+}
+
+// RenderE generates the comparison chart as a multi-line string. It returns
+// ErrEmptyData when both data sets are empty, and ErrInvalidData (wrapped)
+// for a negative or non-finite value.
+func (c *ComparisonChart) RenderE() (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	if err := c.validate(); err != nil {
+		return "", err
+	}
+	if err := c.opts.checkPoints(c.left.Data, c.right.Data); err != nil {
+		return "", err
+	}
+
+	// A width of 0 is the terminal's; others are clamped
+	if opts := c.opts.sized(); opts != c.opts {
+		return (&ComparisonChart{opts: opts, left: c.left, right: c.right}).RenderE()
+	}
+
+	colorEnabled := c.isColorEnabled()
+	useUnicode := c.shouldUseUnicode()
+	theme := c.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	leftColor, rightColor := c.left.Color, c.right.Color
+	if leftColor == "" {
+		leftColor = c.opts.seriesColor(theme, 0, c.left.Label)
+	}
+	if rightColor == "" {
+		rightColor = c.opts.seriesColor(theme, 1, c.right.Label)
+	}
+	axisColor := ""
+	if colorEnabled {
+		axisColor = c.opts.axisColor(theme)
+	}
+	axis := "│"
+	if !useUnicode {
+		axis = "|"
+	}
+
+	rows := internal.Max(len(c.left.Data), len(c.right.Data))
+	value := func(data []float64, i int) float64 {
+		if i < len(data) {
+			return data[i]
+		}
+		return 0
+	}
+
+	// Labels take up to a third of the width
+	labelWidth := 0
+	for _, label := range c.opts.Labels {
+		labelWidth = internal.Max(labelWidth, internal.StringWidth(label))
+	}
+	labelWidth = internal.Min(labelWidth, c.opts.Width/3)
+	labelGap := 0
+	if labelWidth > 0 {
+		labelGap = 1
+	}
+
+	// Each side holds its bars and, outside them, their values
+	maxVal := 0.0
+	valueWidth := 0
+	for i := 0; i < rows; i++ {
+		for _, v := range []float64{value(c.left.Data, i), value(c.right.Data, i)} {
+			maxVal = math.Max(maxVal, v)
+			if c.opts.ShowValues {
+				valueWidth = internal.Max(valueWidth, internal.StringWidth(c.formatValue(v))+1)
+			}
+		}
+	}
+	if c.opts.YAxis.fixedRange() {
+		maxVal = c.opts.YAxis.Max
+	}
+	if maxVal == 0 {
+		maxVal = 1 // Avoid division by zero
+	}
+	side := (c.opts.Width - labelWidth - labelGap - 1) / 2
+	if side-valueWidth < 1 && c.opts.ShowValues {
+		// No room for the values beside the bars
+		opts := *c.opts
+		opts.ShowValues = false
+		return (&ComparisonChart{opts: &opts, left: c.left, right: c.right}).RenderE()
+	}
+	barWidth := side - valueWidth
+	fill := string(c.opts.fillChar(useUnicode))
+	barLen := func(v float64) int {
+		return internal.ClampInt(int(float64(barWidth)*v/maxVal+0.5), 0, barWidth)
+	}
+	bar := func(v float64, color string) string {
+		if barLen(v) == 0 {
+			return ""
+		}
+		return Colorize(strings.Repeat(fill, barLen(v)), color, colorEnabled)
+	}
+
+	var result strings.Builder
+	if c.opts.Title != "" {
+		title := fitTitle(c.opts.Title, c.opts.Width, useUnicode)
+		if colorEnabled {
+			title = Colorize(title, c.opts.titleColor(theme), true)
+		}
+		result.WriteString(title)
+		result.WriteString("\n")
+	}
+
+	// The series labels head their sides, next to the axis
+	if c.left.Label != "" || c.right.Label != "" {
+		left := truncateLabel(c.left.Label, side, useUnicode)
+		right := truncateLabel(c.right.Label, side, useUnicode)
+		line := strings.Repeat(" ", labelWidth+labelGap+side-internal.StringWidth(left)) +
+			Colorize(left, axisColor, colorEnabled) + " " + Colorize(right, axisColor, colorEnabled)
+		result.WriteString(strings.TrimRight(line, " "))
+		result.WriteString("\n")
+	}
+
+	for i := 0; i < rows; i++ {
+		var line strings.Builder
+		if labelWidth > 0 {
+			label := ""
+			if i < len(c.opts.Labels) {
+				label = truncateLabel(c.opts.Labels[i], labelWidth, useUnicode)
+			}
+			line.WriteString(Colorize(padRight(label, labelWidth), axisColor, colorEnabled))
+			line.WriteString(" ")
+		}
+
+		// The left bar grows away from the axis, its value beyond it
+		lv, rv := value(c.left.Data, i), value(c.right.Data, i)
+		left := bar(lv, leftColor)
+		if c.opts.ShowValues {
+			left = c.formatValue(lv) + " " + left
+		}
+		line.WriteString(strings.Repeat(" ", side-barLen(lv)-c.valueWidth(lv)))
+		line.WriteString(left)
+		line.WriteString(Colorize(axis, axisColor, colorEnabled))
+		line.WriteString(bar(rv, rightColor))
+		if c.opts.ShowValues {
+			line.WriteString(" " + c.formatValue(rv))
+		}
+		result.WriteString(strings.TrimRight(line.String(), " "))
+		result.WriteString("\n")
+	}
+
+	return c.opts.postProcess(result.String()), nil
+}
+
+// formatValue formats a value displayed next to a bar.
+func (c *ComparisonChart) formatValue(v float64) string {
+	return c.opts.YAxis.format(v, "%.1f", c.opts.Locale)
+}
+
+// valueWidth returns the columns the value of v takes beside its bar.
+func (c *ComparisonChart) valueWidth(v float64) int {
+	if !c.opts.ShowValues {
+		return 0
+	}
+	return internal.StringWidth(c.formatValue(v)) + 1
+}
+
+// validateOptions reports options that comparison charts cannot honor.
+func (c *ComparisonChart) validateOptions() error {
+	if err := c.opts.Validate(); err != nil {
+		return err
+	}
+	if c.opts.Style.subCell() {
+		return conflict("%s style is only supported by line charts", c.opts.Style)
+	}
+	if len(c.opts.Data) > 0 || len(c.opts.Series) > 0 {
+		return conflict("a comparison chart takes its data sets from NewComparison; remove WithData and WithSeries")
+	}
+	if c.opts.Direction == Vertical {
+		return conflict("comparison charts are drawn horizontally; remove WithDirection(Vertical)")
+	}
+	if c.opts.PercentAxis {
+		return conflict("comparison charts share one scale of values; remove WithPercentAxis")
+	}
+	return nil
+}
+
+// validate checks that there is data and that every value is a
+// non-negative finite number.
+func (c *ComparisonChart) validate() error {
+	if len(c.left.Data) == 0 && len(c.right.Data) == 0 {
+		return ErrEmptyData
+	}
+	for _, s := range []Series{c.left, c.right} {
+		for i, v := range s.Data {
+			if !internal.IsValid(v) || v < 0 {
+				return fmt.Errorf("%w: value at index %d of %q is %v", ErrInvalidData, i, s.Label, v)
+			}
+		}
+	}
+	return nil
+}
+
+// shouldUseUnicode determines whether to use Unicode characters based on style.
+func (c *ComparisonChart) shouldUseUnicode() bool {
+	if c.opts.Style == StyleASCII {
+		return false
+	} else if c.opts.Style == StyleUnicode || c.opts.Style.subCell() {
+		return true
+	}
+	return internal.SupportsUnicode()
+}
+
+// isColorEnabled determines whether colors should be used.
+func (c *ComparisonChart) isColorEnabled() bool {
+	if c.opts.ColorEnabled != nil {
+		return *c.opts.ColorEnabled
+	}
+	return internal.SupportsColor()
+}
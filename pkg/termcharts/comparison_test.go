@@ -0,0 +1,146 @@
+package termcharts
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+func TestComparison(t *testing.T) {
+	before := Series{Label: "v1.4", Data: []float64{120, 340, 95}}
+	after := Series{Label: "v1.5", Data: []float64{110, 210, 90}}
+	labels := []string{"startup", "p99", "idle"}
+
+	tests := []struct {
+		name        string
+		left, right Series
+		opts        []Option
+		want        string
+	}{
+		{
+			name:  "shared center axis",
+			left:  before,
+			right: after,
+			opts:  []Option{WithLabels(labels), WithWidth(40), WithStyle(StyleUnicode)},
+			want: "                   v1.4 v1.5\n" +
+				"startup           █████│█████\n" +
+				"p99     ███████████████│█████████\n" +
+				"idle               ████│████\n",
+		},
+		{
+			name:  "values beyond the bars",
+			left:  before,
+			right: after,
+			opts:  []Option{WithLabels(labels), WithWidth(50), WithShowValues(true), WithTitle("Latency")},
+			want: "Latency\n" +
+				"                        v1.4 v1.5\n" +
+				"startup          120.0 #####|##### 110.0\n" +
+				"p99     340.0 ##############|######### 210.0\n" +
+				"idle               95.0 ####|#### 90.0\n",
+		},
+		{
+			name:  "unlabeled and uneven",
+			left:  Series{Data: []float64{4, 2}},
+			right: Series{Data: []float64{2}},
+			opts:  []Option{WithWidth(21)},
+			want: "##########|#####\n" +
+				"     #####|\n",
+		},
+		{
+			name:  "narrow width leaves out values",
+			left:  before,
+			right: after,
+			opts:  []Option{WithLabels(labels), WithWidth(20), WithShowValues(true)},
+			want: "         v1.4 v1.5\n" +
+				"start.     ##|##\n" +
+				"p99    ######|####\n" +
+				"idle       ##|##\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithColor(false), WithStyle(StyleASCII)}, tt.opts...)
+			got, err := NewComparison(tt.left, tt.right, Combine(opts...)).RenderE()
+			if err != nil {
+				t.Fatalf("RenderE() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderE() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparison_Colors(t *testing.T) {
+	got := NewComparison(Series{Data: []float64{1}}, Series{Data: []float64{1}, Color: "green"},
+		WithColor(true), WithStyle(StyleASCII), WithWidth(11)).Render()
+	want := Colorize("#####", DefaultTheme.GetSeriesColor(0), true) +
+		Colorize("|", DefaultTheme.Muted, true) + Colorize("#####", "green", true)
+	if !strings.Contains(got, want) {
+		t.Errorf("Render() = %q, want it to contain %q", got, want)
+	}
+}
+
+func TestComparison_Width(t *testing.T) {
+	left := Series{Label: "before the change", Data: []float64{120, 34000, 95, 0}}
+	right := Series{Label: "after the change", Data: []float64{110, 21000, 90000, 4}}
+	labels := []string{"startup time", "p99 latency", "idle memory", "errors"}
+
+	for _, style := range []RenderStyle{StyleASCII, StyleUnicode} {
+		for width := minWidth; width <= 80; width++ {
+			out, err := NewComparison(left, right, WithLabels(labels), WithShowValues(true), WithWidth(width),
+				WithStyle(style), WithColor(false), WithTitle("Latency by release")).RenderE()
+			if err != nil {
+				t.Fatalf("width %d: RenderE() error = %v", width, err)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+				if got := internal.StringWidth(line); got > width {
+					t.Fatalf("width %d: line %q is %d columns wide\n%s", width, line, got, out)
+				}
+			}
+		}
+	}
+}
+
+func TestComparison_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		left, right Series
+		opts        []ComparisonOption
+		want        error
+	}{
+		{name: "empty", want: ErrEmptyData},
+		{name: "negative", left: Series{Data: []float64{1, -1}}, want: ErrInvalidData},
+		{name: "NaN", right: Series{Data: []float64{math.NaN()}}, want: ErrInvalidData},
+		{
+			name: "strict data",
+			left: Series{Data: []float64{1}},
+			opts: []ComparisonOption{WithStrict(true), WithData([]float64{1})},
+			want: ErrConflictingOptions,
+		},
+		{
+			name: "strict percent axis",
+			left: Series{Data: []float64{1}},
+			opts: []ComparisonOption{WithStrict(true), WithPercentAxis(true)},
+			want: ErrConflictingOptions,
+		},
+		{
+			name: "strict negative width",
+			left: Series{Data: []float64{1}},
+			opts: []ComparisonOption{WithStrict(true), WithWidth(-1)},
+			want: ErrInvalidDimensions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewComparison(tt.left, tt.right, tt.opts...).RenderE(); !errors.Is(err, tt.want) {
+				t.Errorf("RenderE() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,679 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// LayerKind specifies how a layer of a composed chart is drawn.
+type LayerKind int
+
+const (
+	// LayerBar draws the series as vertical bars rising from zero.
+	// Several bar layers are grouped side by side within each category.
+	LayerBar LayerKind = iota
+	// LayerLine draws the series as a connected line.
+	LayerLine
+	// LayerScatter draws the series as unconnected points.
+	LayerScatter
+	// LayerCurve draws the series as a smooth line of Braille dots, its
+	// points spread evenly across the width of the chart rather than placed
+	// in categories. It suits curves sampled more finely than the
+	// categories, such as a density estimate over histogram bins, and does
+	// not add categories of its own.
+	LayerCurve
+)
+
+// String returns the string representation of the LayerKind.
+func (k LayerKind) String() string {
+	switch k {
+	case LayerBar:
+		return "bar"
+	case LayerLine:
+		return "line"
+	case LayerScatter:
+		return "scatter"
+	case LayerCurve:
+		return "curve"
+	default:
+		return unknownString
+	}
+}
+
+// Layer is one data series of a composed chart and the way it is drawn.
+type Layer struct {
+	// Kind selects how the series is drawn.
+	Kind LayerKind
+	// Series is the data to draw. Its label and color are used in the legend.
+	Series Series
+}
+
+// ComposedChart draws several layers, such as bars and a line, on one
+// canvas with a shared set of axes and a combined legend. Data point i of
+// every layer is drawn in category i, so layers line up with each other
+// and with the labels set by WithLabels.
+type ComposedChart struct {
+	opts   *Options
+	layers []Layer
+	err    error
+}
+
+// Compose creates a chart that draws layers on a shared canvas, in order,
+// so later layers are drawn on top of earlier ones. The value axis covers
+// every layer, and includes zero when there are bar layers.
+//
+// Example:
+//
+//	chart := termcharts.Compose([]termcharts.Layer{
+//	    {Kind: termcharts.LayerBar, Series: termcharts.Series{Label: "Actual", Data: actual}},
+//	    {Kind: termcharts.LayerLine, Series: termcharts.Series{Label: "Forecast", Data: forecast}},
+//	}, termcharts.WithLabels(months))
+//	fmt.Println(chart.Render())
+func Compose(layers []Layer, opts ...ComposeOption) *ComposedChart {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyCompose(options)
+	}
+	c := &ComposedChart{
+		opts:   options,
+		layers: layers,
+	}
+	if options.Strict {
+		c.err = c.validateOptions()
+	}
+	return c
+}
+
+// Update applies opts to the composed chart, replacing the options they set.
+// The next Render draws the composed chart with the new options; with WithStrict
+// they are validated again. Layers are not changed.
+func (c *ComposedChart) Update(opts ...ComposeOption) {
+	for _, opt := range opts {
+		opt.applyCompose(c.opts)
+	}
+	c.err = nil
+	if c.opts.Strict {
+		c.err = c.validateOptions()
+	}
+}
+
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the composed chart Updatable.
+func (c *ComposedChart) Apply(opts ...Option) { c.Update(Combine(opts...)) }
+
+// Render generates the composed chart as a multi-line string.
+// It returns an empty string if the chart cannot be drawn; use RenderE to find out why.
+func (c *ComposedChart) Render() string {
+	out, _ := c.RenderE()
+	return out
+}
+
+// RenderE generates the composed chart as a multi-line string.
+// It returns ErrEmptyData, ErrInvalidData, ErrInvalidDimensions, or
+// ErrInvalidOption (possibly wrapped) when the chart cannot be drawn.
+func (c *ComposedChart) RenderE() (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	if err := c.opts.checkPoints(seriesData(c.series())...); err != nil {
+		return "", err
+	}
+
+	// A width or height of 0 is the terminal's; others are clamped
+	if opts := c.opts.sized(); opts != c.opts {
+		return (&ComposedChart{opts: opts, layers: c.layers}).RenderE()
+	}
+	if opts := c.opts.percentScaled(seriesData(c.series())...); opts != c.opts {
+		return (&ComposedChart{opts: opts, layers: c.layers}).RenderE()
+	}
+	if c.opts.EmptyMessage != "" && seriesEmpty(c.series()) {
+		return c.opts.placeholder(c.opts.Width, c.opts.Height, c.shouldUseUnicode(), c.isColorEnabled()), nil
+	}
+
+	if len(c.layers) == 0 {
+		return "", ErrEmptyData
+	}
+	for i, layer := range c.layers {
+		if layer.Kind < LayerBar || layer.Kind > LayerCurve {
+			return "", fmt.Errorf("%w: layer %d has unknown kind %d", ErrInvalidOption, i, layer.Kind)
+		}
+	}
+
+	// Check for invalid values
+	if err := validateSeries(c.series()); err != nil {
+		return "", err
+	}
+
+	// Summaries describe the layers
+	opts := *c.opts
+	opts.Series = c.series()
+	return opts.postProcess(c.render()), nil
+}
+
+// series returns the series of every layer, in order.
+func (c *ComposedChart) series() []Series {
+	series := make([]Series, len(c.layers))
+	for i, layer := range c.layers {
+		series[i] = layer.Series
+	}
+	return series
+}
+
+// composeCanvas maps data points onto the cells of a composed chart.
+type composeCanvas struct {
+	grid   [][]rune
+	colors [][]string
+	width  int
+	height int
+	points int
+	axis   AxisConfig
+	lo, hi float64
+}
+
+// column returns the center column of category i.
+func (cv *composeCanvas) column(i int) int {
+	slot := float64(cv.width) / float64(cv.points)
+	return internal.ClampInt(int(slot*(float64(i)+0.5)), 0, cv.width-1)
+}
+
+// row returns the row of value v, with 0 at the top.
+func (cv *composeCanvas) row(v float64) int {
+	p := cv.axis.project(v)
+	if math.IsInf(p, -1) {
+		// Non-positive values on a log axis sit at its minimum
+		p = cv.lo
+	}
+	y := int((cv.hi - p) / (cv.hi - cv.lo) * float64(cv.height-1))
+	return internal.ClampInt(y, 0, cv.height-1)
+}
+
+// render draws the layers, axes, and legend.
+//
+//nolint:gocyclo // Complex rendering logic
+func (c *ComposedChart) render() string {
+	width := c.opts.Width
+	height := c.opts.Height
+
+	showXAxis := c.opts.ShowAxes && !c.opts.XAxis.Hidden
+
+	// Reserve space for title and axes
+	chartHeight := height
+	if c.opts.Title != "" {
+		chartHeight--
+	}
+	if showXAxis {
+		chartHeight -= 2 // Bottom axis and labels
+	}
+	if chartHeight < 3 {
+		chartHeight = 3
+	}
+
+	// Find the shared value range
+	lo, hi := c.valueRange()
+	axis := c.opts.YAxis
+	plo, phi := axis.project(lo), axis.project(hi)
+	if plo == phi {
+		phi = plo + 1
+	}
+	plo, phi = c.opts.yTickRange(plo, phi)
+
+	yLabels, yAxisWidth := yAxisLabels(c.opts, chartHeight, plo, phi)
+	chartWidth := width - yAxisWidth
+	if chartWidth < 10 {
+		chartWidth = 10
+	}
+
+	// Get styling
+	useUnicode := c.shouldUseUnicode()
+	colorEnabled := c.isColorEnabled()
+	theme := c.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+
+	canvas := &composeCanvas{
+		width:  chartWidth,
+		height: chartHeight,
+		points: c.points(),
+		axis:   axis,
+		lo:     plo,
+		hi:     phi,
+	}
+
+	// Staggered X axis labels take a row from the plot
+	if showXAxis && xLabelRows(c.xAxisTicks(canvas), chartWidth, useUnicode) > 1 && chartHeight > 3 {
+		chartHeight--
+		yLabels, yAxisWidth = yAxisLabels(c.opts, chartHeight, plo, phi)
+		chartWidth = internal.Max(width-yAxisWidth, 10)
+		canvas.width, canvas.height = chartWidth, chartHeight
+	}
+	cells := getGrid(chartWidth, chartHeight)
+	defer putGrid(cells)
+	canvas.grid, canvas.colors = cells.rows, cells.colorRows
+
+	// Draw each layer on top of the previous ones
+	bars := 0
+	for _, layer := range c.layers {
+		if layer.Kind == LayerBar && !c.opts.seriesHidden(layer.Series) {
+			bars++
+		}
+	}
+	barIdx := 0
+	for idx, layer := range c.layers {
+		if c.opts.seriesHidden(layer.Series) {
+			continue
+		}
+		color := layer.Series.Color
+		if color == "" {
+			color = c.opts.seriesColor(theme, idx, layer.Series.Label)
+		}
+
+		switch layer.Kind {
+		case LayerBar:
+			c.drawBars(canvas, layer.Series.Data, barIdx, bars, useUnicode, color)
+			barIdx++
+		case LayerLine:
+			c.drawLineLayer(canvas, layer.Series.Data, useUnicode, color)
+		case LayerScatter:
+			drawScatterLayer(canvas, layer.Series.Data, useUnicode, color)
+		case LayerCurve:
+			drawCurveLayer(canvas, layer.Series.Data, useUnicode, color)
+		}
+	}
+
+	// Build result
+	result := getBuffer()
+	defer putBuffer(result)
+	result.Grow(frameBytes(yAxisWidth+chartWidth, chartHeight))
+
+	// Render title if provided
+	if c.opts.Title != "" {
+		titleText := c.opts.Title
+		if colorEnabled {
+			titleText = Colorize(titleText, c.opts.titleColor(theme), true)
+		}
+		result.WriteString(titleText)
+		result.WriteString("\n")
+	}
+
+	// Render chart rows
+	run := newColorRun(result)
+	muted := ""
+	if colorEnabled {
+		muted = c.opts.axisColor(theme)
+	}
+	for row := 0; row < chartHeight; row++ {
+		// Y axis label
+		if yLabels != nil {
+			writeYLabel(run, yLabels[row], yAxisWidth, muted)
+		}
+
+		// Chart content, one color sequence per run of same-colored cells
+		for col := 0; col < chartWidth; col++ {
+			color := ""
+			if colorEnabled {
+				color = canvas.colors[row][col]
+			}
+			run.writeRune(canvas.grid[row][col], color)
+		}
+		run.end()
+		result.WriteString("\n")
+	}
+
+	// Render X axis if showing axes
+	if showXAxis {
+		// Axis line
+		if yAxisWidth > 0 {
+			result.WriteString(strings.Repeat(" ", yAxisWidth))
+		}
+		axisLine := strings.Repeat("─", chartWidth)
+		if !useUnicode {
+			axisLine = strings.Repeat("-", chartWidth)
+		}
+		if colorEnabled {
+			axisLine = Colorize(axisLine, c.opts.axisColor(theme), true)
+		}
+		result.WriteString(axisLine)
+		result.WriteString("\n")
+
+		// X axis labels
+		if ticks := c.xAxisTicks(canvas); len(ticks) > 0 {
+			renderXAxisLabels(result, ticks, yAxisWidth, chartWidth, useUnicode, colorEnabled, c.opts.axisColor(theme))
+		}
+	}
+
+	// Render combined legend
+	if len(c.layers) > 1 {
+		marker := "●"
+		if !useUnicode {
+			marker = "*"
+		}
+		result.WriteString("\n")
+		result.WriteString(chartLegend(c.opts, c.series(), marker, colorEnabled, theme).Render())
+	}
+
+	return result.String()
+}
+
+// drawBars draws a bar layer. Bar layer barIdx of bars takes its share of
+// each category's width, leaving a one-column gap between categories. When
+// a category is narrower than its bars, the bars that don't fit are skipped.
+func (c *ComposedChart) drawBars(canvas *composeCanvas, data []float64, barIdx, bars int, useUnicode bool, color string) {
+	char := c.opts.fillChar(useUnicode)
+
+	// Bars rise from zero, or from the bottom when zero is off the axis
+	base := canvas.row(math.Max(canvas.axis.unproject(canvas.lo), math.Min(0, canvas.axis.unproject(canvas.hi))))
+
+	slot := float64(canvas.width) / float64(canvas.points)
+	for i, v := range data {
+		start := int(slot * float64(i))
+		usable := int(slot*float64(i+1)) - start
+		if usable > 2 {
+			usable-- // Gap between categories
+		}
+		fit := internal.Min(bars, usable)
+		if barIdx >= fit {
+			continue
+		}
+		barWidth := usable / fit
+		left := start + (usable-barWidth*fit)/2 + barIdx*barWidth
+
+		top, bottom := canvas.row(v), base
+		if top > bottom {
+			top, bottom = bottom, top
+		}
+		for y := top; y <= bottom; y++ {
+			for x := internal.Max(left, 0); x < left+barWidth && x < canvas.width; x++ {
+				canvas.grid[y][x] = char
+				canvas.colors[y][x] = color
+			}
+		}
+	}
+}
+
+// drawLineLayer draws a line layer. The line is drawn on its own grid and
+// then placed over the canvas, so it stays visible on top of bars.
+func (c *ComposedChart) drawLineLayer(canvas *composeCanvas, data []float64, useUnicode bool, color string) {
+	cells := getGrid(canvas.width, canvas.height)
+	defer putGrid(cells)
+	grid, colors := cells.rows, cells.colorRows
+
+	indices := c.drawnIndices(data, canvas.width)
+	points := make([][2]int, len(indices))
+	for i, idx := range indices {
+		points[i] = [2]int{canvas.column(idx), canvas.row(data[idx])}
+	}
+	for i := 0; i < len(points)-1; i++ {
+		drawLine(grid, colors, points[i][0], points[i][1], points[i+1][0], points[i+1][1], useUnicode, color)
+	}
+	for _, p := range points {
+		if useUnicode {
+			grid[p[1]][p[0]] = lineDot
+		} else {
+			grid[p[1]][p[0]] = asciiDot
+		}
+		colors[p[1]][p[0]] = color
+	}
+
+	for y := range grid {
+		for x, r := range grid[y] {
+			if r != ' ' {
+				canvas.grid[y][x] = r
+				canvas.colors[y][x] = colors[y][x]
+			}
+		}
+	}
+}
+
+// Scatter glyphs for cells holding one point, a few points, and many points.
+var (
+	scatterGlyphs      = [3]rune{lineDot, '●', '●'}
+	scatterGlyphsASCII = [3]rune{asciiDot, '#', '@'}
+)
+
+// scatterLevel returns the weight of a scatter cell holding count points:
+// 0 for one point, 1 for two or three, and 2 for four or more.
+func scatterLevel(count int) int {
+	switch {
+	case count >= 4:
+		return 2
+	case count >= 2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// drawScatterLayer draws a scatter layer. Every point is counted into its
+// cell, and cells holding more points are drawn with heavier glyphs, bold
+// at the heaviest, so dense regions stand out instead of every occupied
+// cell looking the same.
+func drawScatterLayer(canvas *composeCanvas, data []float64, useUnicode bool, color string) {
+	glyphs := scatterGlyphs
+	if !useUnicode {
+		glyphs = scatterGlyphsASCII
+	}
+
+	counts := make(map[[2]int]int)
+	for i, v := range data {
+		counts[[2]int{canvas.column(i), canvas.row(v)}]++
+	}
+	for cell, count := range counts {
+		x, y := cell[0], cell[1]
+		level := scatterLevel(count)
+		canvas.grid[y][x] = glyphs[level]
+		canvas.colors[y][x] = color
+		if level == len(glyphs)-1 && color != "" {
+			canvas.colors[y][x] = "bold " + color
+		}
+	}
+}
+
+// drawCurveLayer draws a curve layer, its points spread evenly from the
+// left edge of the canvas to the right, as Braille dots joined by lines.
+// Cells the curve passes through show its dots in place of what was drawn
+// beneath. Without Unicode each point is drawn as a dot in its cell.
+func drawCurveLayer(canvas *composeCanvas, data []float64, useUnicode bool, color string) {
+	if len(data) == 0 {
+		return
+	}
+	cell := brailleCell
+	cols, rows := canvas.width, canvas.height
+	if useUnicode {
+		cols, rows = cols*cell.cols, rows*cell.rows
+	}
+	x := func(i int) int {
+		if len(data) == 1 {
+			return cols / 2
+		}
+		return int(float64(i)/float64(len(data)-1)*float64(cols-1) + 0.5)
+	}
+	y := func(v float64) int {
+		p := canvas.axis.project(v)
+		if math.IsInf(p, -1) {
+			p = canvas.lo
+		}
+		return internal.ClampInt(int((canvas.hi-p)/(canvas.hi-canvas.lo)*float64(rows-1)+0.5), 0, rows-1)
+	}
+
+	if !useUnicode {
+		for i, v := range data {
+			canvas.grid[y(v)][x(i)] = asciiDot
+			canvas.colors[y(v)][x(i)] = color
+		}
+		return
+	}
+
+	dots := make([][]bool, rows)
+	for i := range dots {
+		dots[i] = make([]bool, cols)
+	}
+	for i := range data {
+		next := internal.Min(i+1, len(data)-1)
+		drawBrailleLine(dots, canvas.colors, x(i), y(data[i]), x(next), y(data[next]), canvas.width, canvas.height, color)
+	}
+	for cy := 0; cy < canvas.height; cy++ {
+		for cx := 0; cx < canvas.width; cx++ {
+			pattern := 0
+			for r := 0; r < cell.rows; r++ {
+				for c := 0; c < cell.cols; c++ {
+					if dots[cy*cell.rows+r][cx*cell.cols+c] {
+						pattern |= cell.bits[r][c]
+					}
+				}
+			}
+			if pattern != 0 {
+				canvas.grid[cy][cx] = cell.glyph(pattern)
+			}
+		}
+	}
+}
+
+// drawnIndices returns the indices of the points of a line layer to draw,
+// downsampled per WithMaxPoints. Points keep their category column.
+func (c *ComposedChart) drawnIndices(data []float64, columns int) []int {
+	max := c.opts.maxPoints(columns)
+	if max == 0 {
+		max = len(data)
+	}
+	return internal.DownsampleIndices(data, max)
+}
+
+// valueRange returns the value axis range across the plotted layers,
+// honoring a range set with WithYAxis. Without a fixed range, bar layers
+// extend the range to zero.
+func (c *ComposedChart) valueRange() (float64, float64) {
+	sets := make([][]float64, 0, len(c.layers)+1)
+	hasBars := false
+	for _, layer := range c.layers {
+		if c.opts.seriesHidden(layer.Series) {
+			continue
+		}
+		sets = append(sets, layer.Series.Data)
+		if layer.Kind == LayerBar {
+			hasBars = true
+		}
+	}
+
+	axis := c.opts.YAxis
+	if hasBars && !axis.fixedRange() && axis.Scale == ScaleLinear {
+		sets = append(sets, []float64{0})
+	}
+	return axis.resolveRange(sets...)
+}
+
+// points returns the number of categories, the length of the longest layer
+// other than curves.
+func (c *ComposedChart) points() int {
+	points := 0
+	for _, layer := range c.layers {
+		if layer.Kind != LayerCurve && len(layer.Series.Data) > points {
+			points = len(layer.Series.Data)
+		}
+	}
+	return points
+}
+
+// xAxisTicks returns the category labels to draw, centered on each category.
+// Without labels, index labels are generated when a tick count is configured.
+func (c *ComposedChart) xAxisTicks(canvas *composeCanvas) []xTick {
+	axis := c.opts.XAxis
+	labels := c.opts.Labels
+	n := canvas.points
+
+	pos := func(i int) float64 {
+		if canvas.width <= 1 {
+			return 0
+		}
+		return float64(canvas.column(i)) / float64(canvas.width-1)
+	}
+
+	var ticks []xTick
+	if len(labels) == 0 {
+		if axis.Ticks <= 0 {
+			return nil
+		}
+		for i := 0; i < n; i++ {
+			if axis.isTick(i, n) {
+				ticks = append(ticks, xTick{pos: pos(i), label: axis.format(float64(i), "%.0f", c.opts.Locale)})
+			}
+		}
+		return ticks
+	}
+
+	for i, label := range labels {
+		if i < n && axis.isTick(i, n) {
+			ticks = append(ticks, xTick{pos: pos(i), label: label})
+		}
+	}
+	return ticks
+}
+
+// shouldUseUnicode determines whether to use Unicode characters.
+func (c *ComposedChart) shouldUseUnicode() bool {
+	if c.opts.Style == StyleASCII {
+		return false
+	}
+	if c.opts.Style == StyleUnicode || c.opts.Style.subCell() {
+		return true
+	}
+	return internal.SupportsUnicode()
+}
+
+// validateOptions reports options that composed charts cannot honor.
+func (c *ComposedChart) validateOptions() error {
+	if err := c.opts.Validate(); err != nil {
+		return err
+	}
+	if c.opts.Style.subCell() {
+		return conflict("%s style is only supported by line charts", c.opts.Style)
+	}
+	if len(c.opts.Data) > 0 || len(c.opts.Series) > 0 {
+		return conflict("composed charts take their data from layers; remove WithData and WithSeries")
+	}
+	if c.opts.Direction == Vertical {
+		return conflict("composed charts are always vertical bars over categories; remove WithDirection(Vertical)")
+	}
+	if c.opts.BarMode != BarModeGrouped {
+		return conflict("composed charts group bar layers; remove WithBarMode")
+	}
+	return nil
+}
+
+// isColorEnabled determines whether colors should be used.
+func (c *ComposedChart) isColorEnabled() bool {
+	if c.opts.ColorEnabled != nil {
+		return *c.opts.ColorEnabled
+	}
+	return internal.SupportsColor()
+}
+
+// TrendLine returns the least-squares linear fit of data, evaluated at every
+// index, for drawing a trend line layer over a scatter or bar layer.
+// Data with fewer than two values is returned unchanged.
+func TrendLine(data []float64) []float64 {
+	n := float64(len(data))
+	if len(data) < 2 {
+		return append([]float64(nil), data...)
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range data {
+		x := float64(i)
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+	}
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	intercept := (sumY - slope*sumX) / n
+
+	trend := make([]float64, len(data))
+	for i := range trend {
+		trend[i] = intercept + slope*float64(i)
+	}
+	return trend
+}
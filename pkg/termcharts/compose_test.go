@@ -0,0 +1,222 @@
+package termcharts
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompose_Render(t *testing.T) {
+	chart := Compose([]Layer{
+		{Kind: LayerBar, Series: Series{Data: []float64{1, 3, 2}}},
+		{Kind: LayerLine, Series: Series{Data: []float64{2, 2, 3}}},
+	},
+		WithLabels([]string{"a", "b", "c"}),
+		WithWidth(20),
+		WithHeight(6),
+		WithColor(false),
+		WithStyle(StyleASCII),
+		WithYAxis(AxisConfig{Hidden: true}),
+	)
+
+	expected := strings.Join([]string{
+		"      ######  //*   ",
+		"   *------*///##### ",
+		"##### ###### ###### ",
+		"##### ###### ###### ",
+		"--------------------",
+		"   a      b     c   ",
+		"",
+		"* Series 1  ",
+		"* Series 2  ",
+		"",
+	}, "\n")
+	if got := chart.Render(); got != expected {
+		t.Errorf("Render() =\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestCompose_SharedAxis(t *testing.T) {
+	chart := Compose([]Layer{
+		{Kind: LayerBar, Series: Series{Label: "Actual", Data: []float64{10, 20}}},
+		{Kind: LayerScatter, Series: Series{Label: "Target", Data: []float64{50, 40}}},
+	}, WithWidth(30), WithHeight(8), WithColor(false), WithStyle(StyleASCII))
+
+	out := chart.Render()
+	lines := strings.Split(out, "\n")
+
+	// One value axis spans both layers, from zero for the bars to the highest point
+	if !strings.HasPrefix(strings.TrimSpace(lines[0]), "50.0") {
+		t.Errorf("top axis label should be the highest value, got %q", lines[0])
+	}
+	if !strings.HasPrefix(strings.TrimSpace(lines[5]), "0.0") {
+		t.Errorf("bottom axis label should be zero for bar layers, got %q", lines[5])
+	}
+	if !strings.Contains(out, "* Actual  * Target") {
+		t.Errorf("expected combined legend, got:\n%s", out)
+	}
+}
+
+func TestCompose_ScatterDensity(t *testing.T) {
+	// Forty points across ten columns put four points in each column
+	data := []float64{9, 9, 9, 9, 9, 9, 0, 0, 9, 0, 0, 0}
+	for len(data) < 40 {
+		data = append(data, 4.5)
+	}
+	opts := []ComposeOption{WithWidth(10), WithHeight(3), WithShowAxes(false), WithStyle(StyleASCII)}
+	layers := []Layer{{Kind: LayerScatter, Series: Series{Label: "samples", Data: data, Color: "green"}}}
+
+	lines := strings.Split(Compose(layers, append(opts, WithColor(false))...).Render(), "\n")
+	expected := []string{"@#*       ", "   @@@@@@@", " ##       "}
+	for i, want := range expected {
+		if lines[i] != want {
+			t.Errorf("row %d = %q, want %q", i, lines[i], want)
+		}
+	}
+
+	// The densest cells are also drawn bold
+	colored := Compose(layers, append(opts, WithColor(true))...).Render()
+	if !strings.HasPrefix(colored, colorCode("bold green")+"@") {
+		t.Errorf("want the densest cell in bold green, got %q", colored)
+	}
+}
+
+func TestCompose_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		chart   *ComposedChart
+		wantErr error
+	}{
+		{
+			name:    "no layers",
+			chart:   Compose(nil),
+			wantErr: ErrEmptyData,
+		},
+		{
+			name:    "empty layers",
+			chart:   Compose([]Layer{{Kind: LayerLine}}),
+			wantErr: ErrEmptyData,
+		},
+		{
+			name:    "unknown layer kind",
+			chart:   Compose([]Layer{{Kind: LayerKind(9), Series: Series{Data: []float64{1}}}}),
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "invalid dimensions",
+			chart:   Compose([]Layer{{Series: Series{Data: []float64{1}}}}, WithStrict(true), WithWidth(-1)),
+			wantErr: ErrInvalidDimensions,
+		},
+		{
+			name:    "strict data conflict",
+			chart:   Compose([]Layer{{Series: Series{Data: []float64{1}}}}, WithStrict(true), WithData([]float64{1})),
+			wantErr: ErrConflictingOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.chart.RenderE(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("RenderE() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLayerKind_String(t *testing.T) {
+	tests := map[LayerKind]string{
+		LayerBar:      "bar",
+		LayerLine:     "line",
+		LayerScatter:  "scatter",
+		LayerCurve:    "curve",
+		LayerKind(99): "unknown",
+	}
+	for kind, expected := range tests {
+		if got := kind.String(); got != expected {
+			t.Errorf("LayerKind(%d).String() = %q, want %q", kind, got, expected)
+		}
+	}
+}
+
+func TestTrendLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		expected []float64
+	}{
+		{name: "linear", data: []float64{1, 2, 3}, expected: []float64{1, 2, 3}},
+		{name: "fit", data: []float64{1, 3, 2, 4}, expected: []float64{1.3, 2.1, 2.9, 3.7}},
+		{name: "single value", data: []float64{5}, expected: []float64{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TrendLine(tt.data)
+			for i := range got {
+				got[i] = float64(int(got[i]*10+0.5)) / 10
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("TrendLine() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompose_HiddenLayer(t *testing.T) {
+	layers := []Layer{
+		{Kind: LayerBar, Series: Series{Label: "actual", Data: []float64{1, 3, 2}}},
+		{Kind: LayerLine, Series: Series{Label: "forecast", Data: []float64{20, 20, 30}}},
+	}
+	opts := []ComposeOption{WithWidth(20), WithHeight(6), WithColor(false), WithStyle(StyleASCII)}
+
+	hidden := Compose(layers, append(opts, WithHiddenSeries("forecast"))...).Render()
+	alone := Compose(layers[:1], opts...).Render()
+	if got, want := strings.SplitN(hidden, "\n\n", 2)[0], strings.TrimSuffix(alone, "\n"); got != want {
+		t.Errorf("hidden layer should not be drawn or scaled:\n%s\nwant:\n%s", got, want)
+	}
+	if !strings.HasSuffix(hidden, "* actual    \n  forecast  \n") {
+		t.Errorf("hidden layer should stay in the legend:\n%s", hidden)
+	}
+}
+
+func TestCompose_ManyBarPoints(t *testing.T) {
+	// With more categories than columns, bars that don't fit are skipped
+	a, b := make([]float64, 100), make([]float64, 100)
+	for i := range a {
+		a[i], b[i] = float64(i), float64(100-i)
+	}
+	chart := Compose([]Layer{
+		{Kind: LayerBar, Series: Series{Label: "a", Data: a}},
+		{Kind: LayerBar, Series: Series{Label: "b", Data: b}},
+	}, WithWidth(60), WithHeight(8), WithColor(false), WithStyle(StyleASCII))
+
+	out := chart.Render()
+	for _, line := range strings.Split(out, "\n") {
+		if w := len(line); w > 60 {
+			t.Errorf("line wider than the chart (%d): %q", w, line)
+		}
+	}
+	if !strings.Contains(out, "#") {
+		t.Errorf("expected bars to be drawn, got:\n%s", out)
+	}
+}
+
+func TestCompose_CurveLayer(t *testing.T) {
+	bars := Layer{Kind: LayerBar, Series: Series{Data: []float64{1, 3, 2}}}
+	curve := Layer{Kind: LayerCurve, Series: Series{Data: []float64{0, 1, 2, 3, 2, 1, 0, 1, 2, 3}}}
+	opts := []ComposeOption{WithLabels([]string{"a", "b", "c"}), WithWidth(30), WithHeight(8), WithColor(false),
+		WithStyle(StyleUnicode), WithYAxis(AxisConfig{Hidden: true})}
+
+	got := Compose([]Layer{bars, curve}, opts...).Render()
+	if !strings.ContainsAny(got, "⠁⠉⠒⠤⣀⡠⠔⠊⢀⡀⠈") {
+		t.Errorf("expected a Braille curve, got:\n%s", got)
+	}
+
+	// A curve spans the categories without adding its own
+	alone := Compose([]Layer{bars}, opts...).Render()
+	labels := func(out string) string { return strings.SplitN(strings.SplitN(out, "─\n", 2)[1], "\n", 2)[0] }
+	if labels(got) != labels(alone) {
+		t.Errorf("x axis labels = %q, want %q", labels(got), labels(alone))
+	}
+}
@@ -0,0 +1,382 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// Layout of confusion matrices: the widest class label drawn, and the spaces
+// between columns.
+const (
+	maxMatrixLabel = 16
+	matrixGap      = 2
+)
+
+// Forms of confusion matrix cells, from the widest to the narrowest. A matrix
+// is drawn with the widest form that fits its width.
+const (
+	matrixCellShare = iota // Shade, count, and share of the row
+	matrixCellCount        // Shade and count
+	matrixCellShade        // Shade only
+)
+
+// Shades of confusion matrix cells, from an empty cell to one holding a
+// whole row.
+var (
+	matrixShades      = []rune{' ', '░', '▒', '▓', '█'}
+	matrixShadesASCII = []rune{' ', '.', ':', '#', '@'}
+)
+
+// ConfusionMatrixChart draws a confusion matrix as a labeled heatmap: one row
+// per actual class and one column per predicted class, each cell showing its
+// count and its share of the row.
+type ConfusionMatrixChart struct {
+	opts   *Options
+	err    error // Set by NewConfusionMatrix when WithStrict finds invalid options
+	labels []string
+	matrix [][]float64
+}
+
+// ConfusionOption configures a confusion matrix. Every Option is a
+// ConfusionOption, as are the axis options, whose WithYAxis formats counts.
+type ConfusionOption interface {
+	applyConfusion(*Options)
+}
+
+func (f Option) applyConfusion(o *Options)     { f(o) }
+func (f axisOption) applyConfusion(o *Options) { f(o) }
+
+// NewConfusionMatrix creates a confusion matrix chart for the classes in
+// labels, where matrix[i][j] counts the samples of class i predicted as
+// class j. Each cell shows its count and the percentage of its row, and is
+// shaded by that percentage, so a good classifier shows a dark diagonal.
+// Counts are formatted by WithYAxis, WithColorScale colors cells by their
+// share, from 0 to 1, and WithColorBar adds a color bar of shares below the
+// matrix; the title, style, theme, and locale options apply as for other
+// charts. When the matrix is wider than WithWidth its labels are truncated,
+// and its cells drop their shares, then their counts, leaving the shades.
+//
+// Example:
+//
+//	cm := termcharts.NewConfusionMatrix(
+//	    []string{"cat", "dog", "bird"},
+//	    [][]float64{{45, 3, 2}, {4, 38, 8}, {1, 6, 43}},
+//	    termcharts.WithTitle("Validation"),
+//	)
+//	fmt.Print(cm.Render())
+func NewConfusionMatrix(labels []string, matrix [][]float64, opts ...ConfusionOption) *ConfusionMatrixChart {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyConfusion(options)
+	}
+	m := &ConfusionMatrixChart{
+		opts:   options,
+		labels: labels,
+		matrix: matrix,
+	}
+	if options.Strict {
+		m.err = m.validateOptions()
+	}
+	return m
+}
+
+// Update applies opts to the matrix, replacing the options they set.
+// Labels and counts are not changed; with WithStrict the options are
+// validated again.
+func (m *ConfusionMatrixChart) Update(opts ...ConfusionOption) {
+	for _, opt := range opts {
+		opt.applyConfusion(m.opts)
+	}
+	m.err = nil
+	if m.opts.Strict {
+		m.err = m.validateOptions()
+	}
+}
+
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the matrix Updatable.
+func (m *ConfusionMatrixChart) Apply(opts ...Option) { m.Update(Combine(opts...)) }
+
+// Render generates the confusion matrix as a multi-line string.
+// It returns an empty string if the matrix cannot be drawn; use RenderE to find out why.
+func (m *ConfusionMatrixChart) Render() string {
+	out, _ := m.RenderE()
+	return out
+}
+
+// RenderE generates the confusion matrix as a multi-line string. It returns
+// ErrEmptyData, ErrLabelMismatch, or ErrInvalidData (possibly wrapped) when
+// the matrix is empty, has a row per label missing, is not square, or holds
+// a negative or non-finite count, and ErrInvalidDimensions when even its
+// shades do not fit the width.
+func (m *ConfusionMatrixChart) RenderE() (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	if err := m.validate(); err != nil {
+		return "", err
+	}
+	if err := m.opts.checkPoints(m.matrix...); err != nil {
+		return "", err
+	}
+
+	// A width of 0 is the terminal's; others are clamped
+	if opts := m.opts.sized(); opts != m.opts {
+		return (&ConfusionMatrixChart{opts: opts, labels: m.labels, matrix: m.matrix}).RenderE()
+	}
+
+	colorEnabled := m.isColorEnabled()
+	useUnicode := m.shouldUseUnicode()
+	theme := m.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	shades := matrixShades
+	if !useUnicode {
+		shades = matrixShadesASCII
+	}
+	scale := m.opts.ColorScale
+	if scale == nil {
+		scale = matrixScale(theme)
+	}
+	percentOf := func(share float64) string {
+		return localizeNumber(fmt.Sprintf("%.0f%%", share*100), m.opts.Locale)
+	}
+
+	n := len(m.labels)
+	counts := make([][]string, n)
+	percents := make([][]string, n)
+	glyphs := make([][]rune, n)
+	colors := make([][]string, n)
+	for i, row := range m.matrix {
+		total := 0.0
+		for _, v := range row {
+			total += v
+		}
+		counts[i], percents[i] = make([]string, n), make([]string, n)
+		glyphs[i], colors[i] = make([]rune, n), make([]string, n)
+		for j, v := range row {
+			share := 0.0
+			percents[i][j] = "-"
+			if total > 0 {
+				share = v / total
+				percents[i][j] = percentOf(share)
+			}
+			glyphs[i][j] = shades[int(math.Ceil(share*float64(len(shades)-1)))]
+			counts[i][j] = m.opts.YAxis.format(v, "%g", m.opts.Locale)
+			colors[i][j] = scale.Color(share)
+		}
+	}
+
+	layout, ok := m.fit(glyphs, counts, percents, useUnicode)
+	if !ok {
+		return "", fmt.Errorf("%w: a confusion matrix of %d classes needs a width of at least %d, got %d",
+			ErrInvalidDimensions, n, 1+n*(matrixGap+1), m.opts.Width)
+	}
+	labelWidth, widths, cells := layout.labelWidth, layout.widths, layout.cells
+
+	axisColor := ""
+	if colorEnabled {
+		axisColor = m.opts.axisColor(theme)
+	}
+	gap := strings.Repeat(" ", matrixGap)
+	var result strings.Builder
+	writeRow := func(head, headColor string, texts, textColors []string) {
+		var line strings.Builder
+		line.WriteString(Colorize(padRight(head, labelWidth), headColor, colorEnabled))
+		for j, text := range texts {
+			line.WriteString(gap)
+			color := ""
+			if textColors != nil {
+				color = textColors[j]
+			}
+			line.WriteString(Colorize(padRight(text, widths[j]), color, colorEnabled))
+		}
+		result.WriteString(strings.TrimRight(line.String(), " "))
+		result.WriteString("\n")
+	}
+
+	if m.opts.Title != "" {
+		title := fitTitle(m.opts.Title, m.opts.Width, useUnicode)
+		if colorEnabled {
+			title = Colorize(title, m.opts.titleColor(theme), true)
+		}
+		result.WriteString(title)
+		result.WriteString("\n")
+	}
+	predicted := truncateLabel("predicted", m.opts.Width-labelWidth-matrixGap, useUnicode)
+	result.WriteString(strings.Repeat(" ", labelWidth+matrixGap))
+	result.WriteString(Colorize(predicted, axisColor, colorEnabled))
+	result.WriteString("\n")
+	writeRow(truncateLabel("actual", labelWidth, useUnicode), axisColor, layout.columns, nil)
+	for i := range m.matrix {
+		writeRow(layout.rows[i], "", cells[i], colors[i])
+	}
+	if m.opts.ColorBar {
+		// The bar spans the columns of cells
+		width := matrixGap * (n - 1)
+		for _, w := range widths {
+			width += w
+		}
+		bar, barLabels := colorBar(scale, shades, 0, 1, width, percentOf, colorEnabled)
+		indent := strings.Repeat(" ", labelWidth+matrixGap)
+		result.WriteString("\n" + indent + bar + "\n")
+		if barLabels != "" {
+			result.WriteString(indent + barLabels)
+		}
+		result.WriteString("\n")
+	}
+
+	return m.opts.postProcess(result.String()), nil
+}
+
+// matrixLayout is the text of a confusion matrix fitted to its width.
+type matrixLayout struct {
+	rows, columns []string // Class labels down the side and across the top
+	labelWidth    int      // Width of the column of row labels
+	cells         [][]string
+	widths        []int // Width of each column of cells
+}
+
+// fit lays out the matrix in the widest form of cells that fits the width,
+// so each column is as wide as its widest cell. Labels keep their full width
+// when the matrix fits with them; otherwise column labels are truncated to
+// their cells and row labels to the width left over. It reports false when
+// even the shades do not fit.
+func (m *ConfusionMatrixChart) fit(glyphs [][]rune, counts, percents [][]string, useUnicode bool) (matrixLayout, bool) {
+	n := len(m.labels)
+	labels := make([]string, n)
+	labelWidth := internal.StringWidth("actual")
+	for i, label := range m.labels {
+		labels[i] = truncateLabel(label, maxMatrixLabel, useUnicode)
+		labelWidth = internal.Max(labelWidth, internal.StringWidth(labels[i]))
+	}
+
+	for form := matrixCellShare; form <= matrixCellShade; form++ {
+		layout := matrixLayout{cells: make([][]string, n), widths: make([]int, n)}
+		for i := range glyphs {
+			layout.cells[i] = make([]string, n)
+			for j, glyph := range glyphs[i] {
+				switch form {
+				case matrixCellShare:
+					layout.cells[i][j] = fmt.Sprintf("%c %s (%s)", glyph, counts[i][j], percents[i][j])
+				case matrixCellCount:
+					layout.cells[i][j] = fmt.Sprintf("%c %s", glyph, counts[i][j])
+				default:
+					layout.cells[i][j] = string(glyph)
+				}
+				layout.widths[j] = internal.Max(layout.widths[j], internal.StringWidth(layout.cells[i][j]))
+			}
+		}
+
+		cellsWidth, fullWidth := matrixGap*n, labelWidth+matrixGap*n
+		for j, w := range layout.widths {
+			cellsWidth += w
+			fullWidth += internal.Max(w, internal.StringWidth(labels[j]))
+		}
+		if fullWidth <= m.opts.Width {
+			for j := range layout.widths {
+				layout.widths[j] = internal.Max(layout.widths[j], internal.StringWidth(labels[j]))
+			}
+			layout.labelWidth, layout.rows, layout.columns = labelWidth, labels, labels
+			return layout, true
+		}
+		if cellsWidth >= m.opts.Width {
+			continue
+		}
+
+		layout.labelWidth = internal.Min(labelWidth, m.opts.Width-cellsWidth)
+		layout.rows, layout.columns = make([]string, n), make([]string, n)
+		for i, label := range labels {
+			layout.rows[i] = truncateLabel(label, layout.labelWidth, useUnicode)
+			layout.columns[i] = truncateLabel(label, layout.widths[i], useUnicode)
+		}
+		return layout, true
+	}
+	return matrixLayout{}, false
+}
+
+// validateOptions reports options that confusion matrices cannot honor.
+func (m *ConfusionMatrixChart) validateOptions() error {
+	if err := m.opts.Validate(); err != nil {
+		return err
+	}
+	if m.opts.Style.subCell() {
+		return conflict("%s style is only supported by line charts", m.opts.Style)
+	}
+	if len(m.opts.Data) > 0 || len(m.opts.Series) > 0 {
+		return conflict("a confusion matrix takes its counts from NewConfusionMatrix; remove WithData and WithSeries")
+	}
+	if len(m.opts.Labels) > 0 {
+		return conflict("a confusion matrix takes its class labels from NewConfusionMatrix; remove WithLabels")
+	}
+	if m.opts.Direction == Vertical {
+		return conflict("confusion matrices have no direction; remove WithDirection(Vertical)")
+	}
+	if m.opts.PercentAxis {
+		return conflict("confusion matrices always show shares of the row; remove WithPercentAxis")
+	}
+	return nil
+}
+
+// validate checks that the matrix is square, has a row per label, and holds
+// only non-negative finite counts.
+func (m *ConfusionMatrixChart) validate() error {
+	if len(m.matrix) == 0 {
+		return ErrEmptyData
+	}
+	if len(m.labels) != len(m.matrix) {
+		return fmt.Errorf("%w: %d labels for %d matrix rows", ErrLabelMismatch, len(m.labels), len(m.matrix))
+	}
+	for i, row := range m.matrix {
+		if len(row) != len(m.matrix) {
+			return fmt.Errorf("%w: matrix row %d has %d columns, want %d", ErrInvalidData, i, len(row), len(m.matrix))
+		}
+		for j, v := range row {
+			if !internal.IsValid(v) || v < 0 {
+				return fmt.Errorf("%w: count at row %d, column %d is %v", ErrInvalidData, i, j, v)
+			}
+		}
+	}
+	return nil
+}
+
+// matrixScale returns the default colors of cells by their share of the row:
+// muted for small shares, the primary color for medium ones, and the accent
+// color for large ones.
+func matrixScale(theme *Theme) *ColorScale {
+	return NewBandedColorScale(
+		ColorStop{Value: math.Inf(-1), Color: theme.Muted},
+		ColorStop{Value: 0.33, Color: theme.Primary},
+		ColorStop{Value: 0.66, Color: theme.Accent},
+	)
+}
+
+// padRight pads text with spaces to width columns.
+func padRight(text string, width int) string {
+	if pad := width - internal.StringWidth(text); pad > 0 {
+		return text + strings.Repeat(" ", pad)
+	}
+	return text
+}
+
+// shouldUseUnicode determines whether to use Unicode characters based on style.
+func (m *ConfusionMatrixChart) shouldUseUnicode() bool {
+	if m.opts.Style == StyleASCII {
+		return false
+	} else if m.opts.Style == StyleUnicode || m.opts.Style.subCell() {
+		return true
+	}
+	return internal.SupportsUnicode()
+}
+
+// isColorEnabled determines whether colors should be used.
+func (m *ConfusionMatrixChart) isColorEnabled() bool {
+	if m.opts.ColorEnabled != nil {
+		return *m.opts.ColorEnabled
+	}
+	return internal.SupportsColor()
+}
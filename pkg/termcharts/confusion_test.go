@@ -0,0 +1,217 @@
+package termcharts
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+func TestConfusionMatrix(t *testing.T) {
+	labels := []string{"cat", "dog", "bird"}
+	matrix := [][]float64{{45, 3, 2}, {4, 38, 8}, {0, 0, 0}}
+
+	tests := []struct {
+		name   string
+		labels []string
+		matrix [][]float64
+		opts   []Option
+		want   string
+	}{
+		{
+			name:   "unicode shades",
+			labels: labels,
+			matrix: matrix,
+			opts:   []Option{WithTitle("Validation"), WithStyle(StyleUnicode)},
+			want: "Validation\n" +
+				"        predicted\n" +
+				"actual  cat         dog         bird\n" +
+				"cat     █ 45 (90%)  ░ 3 (6%)    ░ 2 (4%)\n" +
+				"dog     ░ 4 (8%)    █ 38 (76%)  ░ 8 (16%)\n" +
+				"bird      0 (-)       0 (-)       0 (-)\n",
+		},
+		{
+			name:   "ascii shades",
+			labels: labels,
+			matrix: matrix,
+			opts:   []Option{WithStyle(StyleASCII)},
+			want: "        predicted\n" +
+				"actual  cat         dog         bird\n" +
+				"cat     @ 45 (90%)  . 3 (6%)    . 2 (4%)\n" +
+				"dog     . 4 (8%)    @ 38 (76%)  . 8 (16%)\n" +
+				"bird      0 (-)       0 (-)       0 (-)\n",
+		},
+		{
+			name:   "localized counts",
+			labels: []string{"yes", "no"},
+			matrix: [][]float64{{1.5, 0.5}, {1, 1}},
+			opts:   []Option{WithStyle(StyleASCII), WithLocale("de-DE")},
+			want: "        predicted\n" +
+				"actual  yes          no\n" +
+				"yes     # 1,5 (75%)  . 0,5 (25%)\n" +
+				"no      : 1 (50%)    : 1 (50%)\n",
+		},
+		{
+			name:   "color bar",
+			labels: []string{"a", "b"},
+			matrix: [][]float64{{1, 0}, {0, 1}},
+			opts:   []Option{WithStyle(StyleASCII), WithColorBar(true)},
+			want: "        predicted\n" +
+				"actual  a           b\n" +
+				"a       @ 1 (100%)    0 (0%)\n" +
+				"b         0 (0%)    @ 1 (100%)\n" +
+				"\n" +
+				"         .....:::::#####@@@@@@\n" +
+				"        0%       50%      100%\n",
+		},
+		{
+			name:   "long labels truncated",
+			labels: []string{"a-very-long-class-name", "b"},
+			matrix: [][]float64{{1, 0}, {0, 1}},
+			opts:   []Option{WithStyle(StyleASCII)},
+			want: "                  predicted\n" +
+				"actual            a-very-long-cla.  b\n" +
+				"a-very-long-cla.  @ 1 (100%)          0 (0%)\n" +
+				"b                   0 (0%)          @ 1 (100%)\n",
+		},
+		{
+			name:   "narrow width drops shares",
+			labels: labels,
+			matrix: matrix,
+			opts:   []Option{WithStyle(StyleASCII), WithWidth(30)},
+			want: "        predicted\n" +
+				"actual  cat   dog   bird\n" +
+				"cat     @ 45  . 3   . 2\n" +
+				"dog     . 4   @ 38  . 8\n" +
+				"bird      0     0     0\n",
+		},
+		{
+			name:   "narrower width truncates labels",
+			labels: labels,
+			matrix: matrix,
+			opts:   []Option{WithStyle(StyleASCII), WithWidth(20)},
+			want: "     predicted\n" +
+				"ac.  cat   dog   bi.\n" +
+				"cat  @ 45  . 3   . 2\n" +
+				"dog  . 4   @ 38  . 8\n" +
+				"bi.    0     0     0\n",
+		},
+		{
+			name:   "narrowest width draws shades",
+			labels: labels,
+			matrix: matrix,
+			opts:   []Option{WithStyle(StyleASCII), WithWidth(10)},
+			want: "   predic.\n" +
+				"a  c  d  b\n" +
+				"c  @  .  .\n" +
+				"d  .  @  .\n" +
+				"b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithColor(false)}, tt.opts...)
+			got, err := NewConfusionMatrix(tt.labels, tt.matrix, Combine(opts...)).RenderE()
+			if err != nil {
+				t.Fatalf("RenderE() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderE() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfusionMatrix_Color(t *testing.T) {
+	got := NewConfusionMatrix([]string{"a", "b"}, [][]float64{{9, 1}, {5, 5}},
+		WithColor(true), WithStyle(StyleASCII)).Render()
+
+	for _, want := range []string{
+		Colorize("@ 9 (90%)", DefaultTheme.Accent, true),
+		Colorize(". 1 (10%)", DefaultTheme.Muted, true),
+		Colorize(": 5 (50%)", DefaultTheme.Primary, true),
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestConfusionMatrix_Width(t *testing.T) {
+	labels := []string{"a-very-long-class-name", "dog", "bird", "fish"}
+	matrix := [][]float64{{4500, 3, 2, 0}, {4, 3800, 8, 1}, {1, 6, 4300, 2}, {0, 0, 0, 0}}
+
+	for _, style := range []RenderStyle{StyleASCII, StyleUnicode} {
+		for width := 13; width <= 80; width++ {
+			out, err := NewConfusionMatrix(labels, matrix, WithWidth(width), WithStyle(style),
+				WithColor(false), WithColorBar(true), WithTitle("Validation of the classifier")).RenderE()
+			if err != nil {
+				t.Fatalf("width %d: RenderE() error = %v", width, err)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+				if got := internal.StringWidth(line); got > width {
+					t.Fatalf("width %d: line %q is %d columns wide\n%s", width, line, got, out)
+				}
+			}
+		}
+	}
+}
+
+func TestConfusionMatrix_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		matrix  [][]float64
+		opts    []ConfusionOption
+		wantErr error
+	}{
+		{name: "empty", wantErr: ErrEmptyData},
+		{name: "missing label", labels: []string{"a"}, matrix: [][]float64{{1, 0}, {0, 1}}, wantErr: ErrLabelMismatch},
+		{name: "not square", labels: []string{"a", "b"}, matrix: [][]float64{{1, 0}, {0}}, wantErr: ErrInvalidData},
+		{name: "negative count", labels: []string{"a", "b"}, matrix: [][]float64{{1, -1}, {0, 1}}, wantErr: ErrInvalidData},
+		{name: "NaN count", labels: []string{"a", "b"}, matrix: [][]float64{{1, 0}, {math.NaN(), 1}}, wantErr: ErrInvalidData},
+		{
+			name:    "too narrow",
+			labels:  []string{"a", "b", "c"},
+			matrix:  [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+			opts:    []ConfusionOption{WithWidth(9)},
+			wantErr: ErrInvalidDimensions,
+		},
+		{
+			name:    "strict data",
+			labels:  []string{"a", "b"},
+			matrix:  [][]float64{{1, 0}, {0, 1}},
+			opts:    []ConfusionOption{WithStrict(true), WithData([]float64{1, 2})},
+			wantErr: ErrConflictingOptions,
+		},
+		{
+			name:    "strict braille",
+			labels:  []string{"a", "b"},
+			matrix:  [][]float64{{1, 0}, {0, 1}},
+			opts:    []ConfusionOption{WithStrict(true), WithStyle(StyleBraille)},
+			wantErr: ErrConflictingOptions,
+		},
+		{
+			name:    "strict negative width",
+			labels:  []string{"a", "b"},
+			matrix:  [][]float64{{1, 0}, {0, 1}},
+			opts:    []ConfusionOption{WithStrict(true), WithWidth(-1)},
+			wantErr: ErrInvalidDimensions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := NewConfusionMatrix(tt.labels, tt.matrix, tt.opts...)
+			if _, err := cm.RenderE(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("RenderE() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := cm.Render(); got != "" {
+				t.Errorf("Render() = %q, want empty string", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package termcharts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Escape sequences that hide and show the terminal cursor.
+const (
+	cursorHide = "\033[?25l"
+	cursorShow = "\033[?25h"
+)
+
+// ClearLines returns the escape sequence that erases the n lines above the
+// cursor and leaves the cursor at the start of the first of them, so the
+// next chart is printed where the previous one was. It suits programs that
+// re-render a chart periodically without a LiveRenderer; n is the number of
+// lines the previous frame printed, including its final newline.
+// It returns "" when n is not positive.
+//
+// Example:
+//
+//	out := chart.Render()
+//	fmt.Print(out)
+//	// ...later
+//	fmt.Print(termcharts.ClearLines(strings.Count(out, "\n")))
+//	fmt.Print(next.Render())
+func ClearLines(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return "\r" + strings.Repeat("\033[1A\033[2K", n)
+}
+
+// MoveCursorUp returns the escape sequence that moves the cursor up n lines
+// to the start of the line, so a frame of the same height can be printed
+// over the previous one. It returns "" when n is not positive.
+func MoveCursorUp(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("\033[%dA\r", n)
+}
+
+// HideCursor returns the escape sequence that hides the terminal cursor,
+// which stops it flickering across the chart during redraws. Pair it with
+// ShowCursor before the program exits.
+func HideCursor() string {
+	return cursorHide
+}
+
+// ShowCursor returns the escape sequence that shows the terminal cursor
+// again after HideCursor.
+func ShowCursor() string {
+	return cursorShow
+}
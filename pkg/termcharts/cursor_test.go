@@ -0,0 +1,28 @@
+package termcharts
+
+import "testing"
+
+func TestCursorHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{name: "clear none", got: ClearLines(0), expected: ""},
+		{name: "clear negative", got: ClearLines(-2), expected: ""},
+		{name: "clear one", got: ClearLines(1), expected: "\r\033[1A\033[2K"},
+		{name: "clear three", got: ClearLines(3), expected: "\r\033[1A\033[2K\033[1A\033[2K\033[1A\033[2K"},
+		{name: "up none", got: MoveCursorUp(0), expected: ""},
+		{name: "up four", got: MoveCursorUp(4), expected: "\033[4A\r"},
+		{name: "hide", got: HideCursor(), expected: "\033[?25l"},
+		{name: "show", got: ShowCursor(), expected: "\033[?25h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package termcharts
+
+import "github.com/neilpeterson/termcharts/internal"
+
+// PlotOption configures a chart that plots points along the X axis (line or
+// composed charts).
+type PlotOption interface {
+	LineOption
+	ComposeOption
+}
+
+// plotOption is an option that applies to line and composed charts.
+type plotOption func(*Options)
+
+func (f plotOption) applyLine(o *Options)    { f(o) }
+func (f plotOption) applyCompose(o *Options) { f(o) }
+
+// pointsPerColumn is the number of points kept per plot column by default:
+// the minimum and maximum of the values that fall into it.
+const pointsPerColumn = 2
+
+// WithMaxPoints caps the number of points drawn per series. Longer series are
+// downsampled before drawing by keeping the minimum and maximum of evenly
+// sized buckets, so peaks and dips stay visible while a 500k-sample slice
+// renders as fast as a short one. The default (0) keeps two points per plot
+// column (per dot column in Braille mode); a negative n disables downsampling.
+// Line layers of composed charts are downsampled; bar layers are not, and
+// scatter layers count every point to shade cells by density.
+//
+// Downsampling only affects drawing: text summaries and legend values still
+// use every point.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithData(samples), // 500,000 values
+//	    termcharts.WithMaxPoints(1000),
+//	)
+func WithMaxPoints(n int) PlotOption {
+	return plotOption(func(o *Options) {
+		o.MaxPoints = n
+	})
+}
+
+// maxPoints returns the number of points a series drawn across columns plot
+// columns may keep, or 0 if downsampling is disabled.
+func (o *Options) maxPoints(columns int) int {
+	switch {
+	case o.MaxPoints > 0:
+		return o.MaxPoints
+	case o.MaxPoints < 0:
+		return 0
+	default:
+		return pointsPerColumn * columns
+	}
+}
+
+// downsample returns the points of data to draw across columns plot columns.
+func (o *Options) downsample(data []float64, columns int) []float64 {
+	max := o.maxPoints(columns)
+	if max == 0 {
+		return data
+	}
+	return internal.Downsample(data, max)
+}
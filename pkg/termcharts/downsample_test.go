@@ -0,0 +1,85 @@
+package termcharts
+
+import (
+	"strings"
+	"testing"
+)
+
+// spikySamples returns n samples of a sawtooth with a single spike.
+func spikySamples(n int) []float64 {
+	data := make([]float64, n)
+	for i := range data {
+		data[i] = float64(i % 50)
+	}
+	data[n/3] = 500
+	return data
+}
+
+func TestWithMaxPoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		opt      PlotOption
+		expected int
+	}{
+		{name: "default", opt: WithMaxPoints(0), expected: 2 * 60},
+		{name: "explicit", opt: WithMaxPoints(500), expected: 500},
+		{name: "disabled", opt: WithMaxPoints(-1), expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			tt.opt.applyLine(opts)
+			if got := opts.maxPoints(60); got != tt.expected {
+				t.Errorf("maxPoints(60) = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLineChart_Downsampling(t *testing.T) {
+	data := spikySamples(200000)
+
+	for _, style := range []RenderStyle{StyleUnicode, StyleBraille} {
+		t.Run(style.String(), func(t *testing.T) {
+			line := NewLineChart(
+				WithData(data),
+				WithStyle(style),
+				WithColor(false),
+				WithWidth(60),
+				WithHeight(10),
+				WithShowAxes(false),
+			)
+			lines := strings.Split(line.Render(), "\n")
+			// The spike reaches the top row
+			if strings.TrimSpace(lines[0]) == "" {
+				t.Errorf("expected the spike on the top row, got:\n%s", strings.Join(lines, "\n"))
+			}
+		})
+	}
+}
+
+func TestCompose_Downsampling(t *testing.T) {
+	data := spikySamples(100000)
+	chart := Compose([]Layer{
+		{Kind: LayerScatter, Series: Series{Label: "samples", Data: data}},
+	},
+		WithStyle(StyleASCII),
+		WithColor(false),
+		WithWidth(50),
+		WithHeight(8),
+		WithShowAxes(false),
+		WithMaxPoints(100),
+		WithTextSummary(true),
+	)
+
+	result := chart.Render()
+	lines := strings.Split(result, "\n")
+	if !strings.Contains(lines[0], "*") {
+		t.Errorf("expected the spike on the top row, got:\n%s", result)
+	}
+	// The summary describes every point, not just the drawn ones
+	if !strings.Contains(result, "100000 values") {
+		t.Errorf("expected summary of all points, got:\n%s", result)
+	}
+}
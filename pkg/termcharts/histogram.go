@@ -0,0 +1,427 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// BinRule specifies how a histogram chooses its number of bins.
+type BinRule int
+
+const (
+	// BinSturges uses log2(n)+1 bins for n samples. It suits samples that
+	// are roughly normally distributed, and is the default.
+	BinSturges BinRule = iota
+	// BinFreedmanDiaconis sizes bins by the spread of the middle half of
+	// the samples, so outliers do not widen every bin. It suits large or
+	// skewed samples.
+	BinFreedmanDiaconis
+)
+
+// String returns the string representation of the BinRule.
+func (r BinRule) String() string {
+	switch r {
+	case BinSturges:
+		return "sturges"
+	case BinFreedmanDiaconis:
+		return "freedman-diaconis"
+	default:
+		return unknownString
+	}
+}
+
+// maxBins caps the number of bins a rule chooses, so a few extreme outliers
+// cannot make a Freedman-Diaconis histogram thousands of rows long.
+const maxBins = 100
+
+// Histogram draws the distribution of raw samples as bars: the samples are
+// sorted into bins of equal width, and each bar is the number of samples in
+// its bin, labeled with the bin's range.
+type Histogram struct {
+	opts *Options
+	err  error
+}
+
+// NewHistogram creates a histogram of the samples set with WithData. The
+// number of bins is set with WithBins, or chosen by the rule set with
+// WithBinRule. The histogram is drawn as a bar chart of the counts, so bar
+// options such as WithDirection, WithShowValues, and WithFillChar apply; the
+// labels are the bin ranges, and counts are formatted as whole numbers
+// unless WithYAxis sets a Format. WithCumulative draws running totals, and
+// WithPercentAxis shows the counts as percentages of all samples.
+//
+// Example:
+//
+//	hist := termcharts.NewHistogram(
+//	    termcharts.WithData(latencies),
+//	    termcharts.WithBins(10),
+//	    termcharts.WithShowValues(true),
+//	)
+//	fmt.Println(hist.Render())
+func NewHistogram(opts ...BarOption) *Histogram {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyBar(options)
+	}
+	h := &Histogram{
+		opts: options,
+	}
+	if options.Strict {
+		h.err = h.validateOptions()
+	}
+	return h
+}
+
+// WithBins sets the number of bins a histogram sorts its samples into, in
+// place of the number chosen by its bin rule. 0 uses the bin rule. Bar
+// charts ignore it.
+func WithBins(n int) BarOption {
+	return barOption(func(o *Options) {
+		o.Bins = n
+	})
+}
+
+// WithBinRule sets the rule a histogram chooses its number of bins by when
+// WithBins does not set one. Bar charts ignore it.
+func WithBinRule(rule BinRule) BarOption {
+	return barOption(func(o *Options) {
+		o.BinRule = rule
+	})
+}
+
+// WithCumulative draws each bar of a histogram as the running total of the
+// samples in its bin and every bin before it, so the last bar counts every
+// sample. With WithPercentAxis the totals are shown as percentages of all
+// samples, rising to 100%. Bar charts ignore it.
+func WithCumulative(cumulative bool) BarOption {
+	return barOption(func(o *Options) {
+		o.Cumulative = cumulative
+	})
+}
+
+// WithDensity overlays a kernel density estimate of the samples on a
+// histogram, as a curve of Braille dots, for a smoothed view of the
+// distribution. The curve is scaled to the bin counts, so it follows the
+// tops of the bars; with WithCumulative it is the smoothed running total.
+// The histogram is then drawn as vertical bars on a count axis with a
+// legend, so the curve can run across them. Bar charts ignore it.
+//
+// Example:
+//
+//	hist := termcharts.NewHistogram(
+//	    termcharts.WithData(latencies),
+//	    termcharts.WithDensity(true),
+//	)
+func WithDensity(density bool) BarOption {
+	return barOption(func(o *Options) {
+		o.Density = density
+	})
+}
+
+// Update applies opts to the histogram, replacing the options they set.
+// The next Render bins the samples again; with WithStrict the options are
+// validated again.
+func (h *Histogram) Update(opts ...BarOption) {
+	for _, opt := range opts {
+		opt.applyBar(h.opts)
+	}
+	h.err = nil
+	if h.opts.Strict {
+		h.err = h.validateOptions()
+	}
+}
+
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the histogram Updatable.
+func (h *Histogram) Apply(opts ...Option) { h.Update(Combine(opts...)) }
+
+// Render generates the histogram as a multi-line string.
+// It returns an empty string if the chart cannot be drawn; use RenderE to find out why.
+func (h *Histogram) Render() string {
+	out, _ := h.RenderE()
+	return out
+}
+
+// RenderE generates the histogram as a multi-line string.
+// It returns ErrEmptyData, ErrInvalidData, or ErrInvalidDimensions
+// (possibly wrapped) when the chart cannot be drawn.
+func (h *Histogram) RenderE() (string, error) {
+	return h.render(nil)
+}
+
+// render draws the histogram, passing the rows of horizontal bars to out as
+// they are drawn when out is not nil.
+func (h *Histogram) render(out *rowStream) (string, error) {
+	if h.err != nil {
+		return "", h.err
+	}
+	if err := h.opts.checkPoints(); err != nil {
+		return "", err
+	}
+	if h.opts.noData() {
+		return (&BarChart{opts: h.opts, out: out}).RenderE()
+	}
+	if err := validateData(h.opts.Data); err != nil {
+		return "", err
+	}
+
+	edges, counts := binSamples(h.opts.Data, h.binCount())
+	if h.opts.Cumulative {
+		for i := 1; i < len(counts); i++ {
+			counts[i] += counts[i-1]
+		}
+	}
+	// A percent axis shows the counts as shares of all samples
+	if h.opts.PercentAxis {
+		for i := range counts {
+			counts[i] /= float64(len(h.opts.Data))
+		}
+	}
+	opts := *h.opts
+	opts.Data = counts
+	opts.Labels = binLabels(edges, opts.XAxis, opts.Locale, opts.Direction == Vertical)
+
+	// The unit is that of the samples the bins span, not of the counts
+	for i := range opts.Labels {
+		opts.Labels[i] = withUnit(opts.Labels[i], opts.YUnit)
+	}
+	opts.YUnit = ""
+	if opts.YAxis.Format == nil && !opts.PercentAxis {
+		locale := opts.Locale
+		opts.YAxis.Format = func(v float64) string {
+			return localizeNumber(fmt.Sprintf("%.0f", v), locale)
+		}
+	}
+	if opts.Density {
+		return h.renderDensity(&opts, edges)
+	}
+	return (&BarChart{opts: &opts, out: out}).RenderE()
+}
+
+// densityTicks is the number of labeled counts on the axis of a histogram
+// with a density curve, unless WithYTicks sets one.
+const densityTicks = 5
+
+// renderDensity draws the bins in opts as the bars of a composed chart, with
+// the density estimate of the samples as a curve over them.
+func (h *Histogram) renderDensity(opts *Options, edges []float64) (string, error) {
+	samples := h.opts.Data
+	lo, hi := edges[0], edges[len(edges)-1]
+
+	// Vertical bars are labeled with their lower edges
+	labels := binLabels(edges, opts.XAxis, opts.Locale, true)
+	for i := range labels {
+		labels[i] = withUnit(labels[i], h.opts.YUnit)
+	}
+	layers := []Layer{{Kind: LayerBar, Series: Series{Label: "samples", Data: opts.Data}}}
+
+	// The curve is scaled from a probability to the counts of the bars: a
+	// density by the bin width, and either by the number of samples unless
+	// the counts are shares
+	points := 2 * opts.sized().Width
+	if curve := densityCurve(samples, lo, hi, points, h.opts.Cumulative); curve != nil {
+		scale := 1.0
+		if !h.opts.Cumulative {
+			scale = (hi - lo) / float64(len(edges)-1)
+		}
+		if !h.opts.PercentAxis {
+			scale *= float64(len(samples))
+		}
+		for i := range curve {
+			curve[i] *= scale
+		}
+		layers = append(layers, Layer{Kind: LayerCurve, Series: Series{Label: "density", Data: curve}})
+	}
+
+	// Counts are labeled at round numbers rather than on every row
+	composed := *opts
+	composed.Data, composed.Labels = nil, labels
+	composed.Direction = Horizontal
+	if composed.YTicks == 0 {
+		composed.YTicks = densityTicks
+	}
+	return (&ComposedChart{opts: &composed, layers: layers}).RenderE()
+}
+
+// densityCurve returns the Gaussian kernel density estimate of samples at
+// points values evenly spaced from lo to hi, or with cumulative its integral,
+// the share of samples up to each value. The bandwidth is chosen by
+// Silverman's rule of thumb. It returns nil when the samples have no spread.
+func densityCurve(samples []float64, lo, hi float64, points int, cumulative bool) []float64 {
+	n := float64(len(samples))
+	if len(samples) < 2 || hi <= lo || points < 2 {
+		return nil
+	}
+	mean := 0.0
+	for _, v := range samples {
+		mean += v
+	}
+	mean /= n
+	variance := 0.0
+	for _, v := range samples {
+		variance += (v - mean) * (v - mean)
+	}
+	sd := math.Sqrt(variance / (n - 1))
+
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	spread := sd
+	if iqr := (quantile(sorted, 0.75) - quantile(sorted, 0.25)) / 1.34; iqr > 0 && iqr < spread {
+		spread = iqr
+	}
+	bandwidth := 0.9 * spread * math.Pow(n, -0.2)
+	if bandwidth <= 0 {
+		return nil
+	}
+
+	curve := make([]float64, points)
+	for i := range curve {
+		x := lo + (hi-lo)*float64(i)/float64(points-1)
+		sum := 0.0
+		for _, v := range samples {
+			z := (x - v) / bandwidth
+			if cumulative {
+				sum += 0.5 * math.Erfc(-z/math.Sqrt2)
+			} else {
+				sum += math.Exp(-z*z/2) / math.Sqrt(2*math.Pi)
+			}
+		}
+		curve[i] = sum / n
+		if !cumulative {
+			curve[i] /= bandwidth
+		}
+	}
+	return curve
+}
+
+// binCount returns the number of bins to sort the samples into.
+func (h *Histogram) binCount() int {
+	if h.opts.Bins > 0 {
+		return h.opts.Bins
+	}
+	if h.opts.BinRule == BinFreedmanDiaconis {
+		return freedmanDiaconisBins(h.opts.Data)
+	}
+	return sturgesBins(len(h.opts.Data))
+}
+
+// validateOptions checks the options for values the histogram cannot draw
+// sensibly, and for combinations that contradict each other.
+func (h *Histogram) validateOptions() error {
+	if len(h.opts.Series) > 0 {
+		return conflict("a histogram takes samples with WithData, not series")
+	}
+	if len(h.opts.Labels) > 0 {
+		return conflict("a histogram labels its bars with the bin ranges; remove WithLabels")
+	}
+	if h.opts.Density && (h.opts.ShowValues || h.opts.MinBar) {
+		return conflict("a histogram with a density curve is drawn on a count axis; remove WithShowValues and WithMinBar")
+	}
+	return (&BarChart{opts: h.opts}).validateOptions()
+}
+
+// sturgesBins returns the number of bins Sturges' rule gives n samples.
+func sturgesBins(n int) int {
+	if n < 2 {
+		return 1
+	}
+	return int(math.Ceil(math.Log2(float64(n)))) + 1
+}
+
+// freedmanDiaconisBins returns the number of bins of width 2*IQR/cbrt(n)
+// that cover the samples. Samples whose middle half has no spread fall back
+// to Sturges' rule.
+func freedmanDiaconisBins(samples []float64) int {
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	iqr := quantile(sorted, 0.75) - quantile(sorted, 0.25)
+	spread := sorted[len(sorted)-1] - sorted[0]
+	if iqr <= 0 || spread <= 0 {
+		return sturgesBins(len(samples))
+	}
+	width := 2 * iqr / math.Cbrt(float64(len(samples)))
+	return internal.ClampInt(int(math.Ceil(spread/width)), 1, maxBins)
+}
+
+// quantile returns the q quantile of sorted samples, interpolating linearly
+// between the samples either side of it.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (sorted[i+1]-sorted[i])*(pos-float64(i))
+}
+
+// binSamples sorts samples into bins of equal width between their minimum
+// and maximum. It returns the bins+1 edges of the bins and the number of
+// samples in each. Each bin holds the samples from its lower edge up to,
+// but not including, its upper edge; the last bin also holds the maximum.
+// Samples that are all the same value fall into one bin.
+func binSamples(samples []float64, bins int) (edges, counts []float64) {
+	min, max := internal.MinMax(samples)
+	if max == min {
+		bins = 1
+	}
+	edges = make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = min + (max-min)*float64(i)/float64(bins)
+	}
+	edges[bins] = max
+
+	counts = make([]float64, bins)
+	for _, v := range samples {
+		bin := bins - 1
+		if max > min {
+			bin = internal.ClampInt(int((v-min)/(max-min)*float64(bins)), 0, bins-1)
+		}
+		counts[bin]++
+	}
+	return edges, counts
+}
+
+// binLabels labels each bin with its range, "[lo, hi)", or "[lo, hi]" for
+// the last bin, which includes its upper edge, or, with lowerOnly, for the
+// narrow labels of vertical bars, with its lower edge alone. Edges are formatted by the X
+// axis Format, or with enough decimals to tell neighboring edges apart (none
+// when every edge is a whole number), and
+// separated by a semicolon in locales that write decimal commas.
+func binLabels(edges []float64, axis AxisConfig, locale string, lowerOnly bool) []string {
+	bins := len(edges) - 1
+	decimals := 0
+	if width := (edges[bins] - edges[0]) / float64(bins); width > 0 {
+		decimals = internal.Max(0, 1-int(math.Floor(math.Log10(width))))
+	}
+	whole := true
+	for _, edge := range edges {
+		whole = whole && edge == math.Trunc(edge)
+	}
+	if whole {
+		decimals = 0
+	}
+	format := fmt.Sprintf("%%.%df", decimals)
+
+	// Edges are separated by a semicolon where a comma is the decimal separator
+	sep := ", "
+	if nf, ok := lookupLocale(locale); ok && nf.decimal == "," {
+		sep = "; "
+	}
+
+	labels := make([]string, bins)
+	for i := range labels {
+		if lowerOnly {
+			labels[i] = axis.format(edges[i], format, locale)
+			continue
+		}
+		closing := ")"
+		if i == bins-1 {
+			closing = "]"
+		}
+		labels[i] = "[" + axis.format(edges[i], format, locale) + sep + axis.format(edges[i+1], format, locale) + closing
+	}
+	return labels
+}
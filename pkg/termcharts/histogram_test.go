@@ -0,0 +1,332 @@
+package termcharts
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBinRule_String(t *testing.T) {
+	tests := map[BinRule]string{
+		BinSturges:          "sturges",
+		BinFreedmanDiaconis: "freedman-diaconis",
+		BinRule(99):         "unknown",
+	}
+	for rule, expected := range tests {
+		if got := rule.String(); got != expected {
+			t.Errorf("BinRule(%d).String() = %q, want %q", rule, got, expected)
+		}
+	}
+}
+
+func TestSturgesBins(t *testing.T) {
+	tests := map[int]int{0: 1, 1: 1, 2: 2, 8: 4, 100: 8, 1000: 11}
+	for n, want := range tests {
+		if got := sturgesBins(n); got != want {
+			t.Errorf("sturgesBins(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestFreedmanDiaconisBins(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []float64
+		want    int
+	}{
+		{
+			// IQR 4.5 over 8 samples gives bins 4.5 wide across a spread of 14
+			name:    "spread",
+			samples: []float64{1, 3, 4, 5, 6, 8, 9, 15},
+			want:    4,
+		},
+		{
+			name:    "no spread in the middle half falls back to Sturges",
+			samples: []float64{0, 5, 5, 5, 5, 5, 5, 10},
+			want:    4,
+		},
+		{
+			name:    "outliers are capped",
+			samples: append(spreadSamples(999), 1e9),
+			want:    maxBins,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := freedmanDiaconisBins(tt.samples); got != tt.want {
+				t.Errorf("freedmanDiaconisBins() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// spreadSamples returns the samples 1 to n.
+func spreadSamples(n int) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = float64(i + 1)
+	}
+	return samples
+}
+
+func TestBinSamples(t *testing.T) {
+	tests := []struct {
+		name       string
+		samples    []float64
+		bins       int
+		wantEdges  []float64
+		wantCounts []float64
+	}{
+		{
+			name:       "maximum falls in the last bin",
+			samples:    []float64{0, 1, 2, 2, 3, 4},
+			bins:       2,
+			wantEdges:  []float64{0, 2, 4},
+			wantCounts: []float64{2, 4},
+		},
+		{
+			name:       "empty bins",
+			samples:    []float64{0, 0, 9},
+			bins:       3,
+			wantEdges:  []float64{0, 3, 6, 9},
+			wantCounts: []float64{2, 0, 1},
+		},
+		{
+			name:       "equal samples share one bin",
+			samples:    []float64{7, 7, 7},
+			bins:       5,
+			wantEdges:  []float64{7, 7},
+			wantCounts: []float64{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edges, counts := binSamples(tt.samples, tt.bins)
+			if !reflect.DeepEqual(edges, tt.wantEdges) {
+				t.Errorf("edges = %v, want %v", edges, tt.wantEdges)
+			}
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("counts = %v, want %v", counts, tt.wantCounts)
+			}
+		})
+	}
+}
+
+func TestBinLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		edges     []float64
+		axis      AxisConfig
+		locale    string
+		lowerOnly bool
+		want      []string
+	}{
+		{
+			name:  "whole edges",
+			edges: []float64{0, 5, 10},
+			want:  []string{"[0, 5)", "[5, 10]"},
+		},
+		{
+			name:  "fractional edges",
+			edges: []float64{1, 2.5, 4},
+			want:  []string{"[1.0, 2.5)", "[2.5, 4.0]"},
+		},
+		{
+			name:  "narrow bins",
+			edges: []float64{0, 0.025, 0.05},
+			want:  []string{"[0.000, 0.025)", "[0.025, 0.050]"},
+		},
+		{
+			name:   "decimal comma",
+			edges:  []float64{1, 2.5, 4},
+			locale: "de-DE",
+			want:   []string{"[1,0; 2,5)", "[2,5; 4,0]"},
+		},
+		{
+			name:  "axis format",
+			edges: []float64{0, 50, 100},
+			axis:  AxisConfig{Format: func(v float64) string { return strings.Repeat("|", int(v/50)) }},
+			want:  []string{"[, |)", "[|, ||]"},
+		},
+		{
+			name:      "lower edges",
+			edges:     []float64{0, 5, 10},
+			lowerOnly: true,
+			want:      []string{"0", "5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := binLabels(tt.edges, tt.axis, tt.locale, tt.lowerOnly); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("binLabels() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistogram_Render(t *testing.T) {
+	samples := []float64{1, 2, 2, 3, 3, 3, 4, 4, 5, 9}
+	tests := []struct {
+		name   string
+		opts   []BarOption
+		labels []string
+		counts []float64
+	}{
+		{
+			name:   "Sturges",
+			labels: []string{"[1.0, 2.6)", "[2.6, 4.2)", "[4.2, 5.8)", "[5.8, 7.4)", "[7.4, 9.0]"},
+			counts: []float64{3, 5, 1, 0, 1},
+		},
+		{
+			name:   "fixed bins",
+			opts:   []BarOption{WithBins(2)},
+			labels: []string{"[1, 5)", "[5, 9]"},
+			counts: []float64{8, 2},
+		},
+		{
+			name:   "vertical",
+			opts:   []BarOption{WithBins(2), WithDirection(Vertical)},
+			labels: []string{"1", "5"},
+			counts: []float64{8, 2},
+		},
+		{
+			name:   "cumulative",
+			opts:   []BarOption{WithCumulative(true)},
+			labels: []string{"[1.0, 2.6)", "[2.6, 4.2)", "[4.2, 5.8)", "[5.8, 7.4)", "[7.4, 9.0]"},
+			counts: []float64{3, 8, 9, 9, 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common := []BarOption{WithColor(false), WithWidth(40), WithHeight(10), WithShowValues(true)}
+			got := NewHistogram(append(append([]BarOption{WithData(samples)}, common...), tt.opts...)...).Render()
+
+			// A histogram is a bar chart of its bin counts, shown as whole numbers
+			want := NewBarChart(append(append([]BarOption{
+				WithData(tt.counts), WithLabels(tt.labels),
+				WithYAxis(AxisConfig{Format: func(v float64) string { return fmt.Sprintf("%.0f", v) }}),
+			}, common...), tt.opts...)...).Render()
+			if got != want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestHistogram_CumulativePercent(t *testing.T) {
+	samples := []float64{1, 2, 2, 3, 3, 3, 4, 4, 5, 9}
+	got := NewHistogram(WithData(samples), WithBins(2), WithCumulative(true), WithPercentAxis(true),
+		WithShowValues(true), WithColor(false), WithWidth(40)).Render()
+
+	// The running totals are shares of all samples, ending at 100%
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], " 80%") || !strings.HasSuffix(lines[1], " 100%") {
+		t.Errorf("Render() =\n%s\nwant bars of 80%% and 100%%", got)
+	}
+}
+
+func TestDensityCurve(t *testing.T) {
+	samples := []float64{1, 2, 2, 3, 3, 3, 4, 4, 5, 9}
+	lo, hi, points := -5.0, 15.0, 401
+
+	// The density integrates to about one over a range covering the samples
+	density := densityCurve(samples, lo, hi, points, false)
+	area := 0.0
+	for _, v := range density {
+		area += v * (hi - lo) / float64(points-1)
+	}
+	if math.Abs(area-1) > 0.01 {
+		t.Errorf("density integrates to %v, want 1", area)
+	}
+
+	// The cumulative curve rises from zero to one
+	cumulative := densityCurve(samples, lo, hi, points, true)
+	for i := 1; i < len(cumulative); i++ {
+		if cumulative[i] < cumulative[i-1] {
+			t.Fatalf("cumulative curve falls at %d: %v < %v", i, cumulative[i], cumulative[i-1])
+		}
+	}
+	if cumulative[0] > 0.01 || cumulative[points-1] < 0.99 {
+		t.Errorf("cumulative curve runs from %v to %v, want 0 to 1", cumulative[0], cumulative[points-1])
+	}
+
+	if got := densityCurve([]float64{4, 4, 4}, 3, 5, points, false); got != nil {
+		t.Errorf("samples without spread should have no curve, got %d points", len(got))
+	}
+}
+
+func TestHistogram_Density(t *testing.T) {
+	samples := []float64{12, 15, 11, 19, 14, 22, 17, 13, 16, 18, 14, 15, 25, 13}
+	opts := []BarOption{WithData(samples), WithDensity(true), WithColor(false), WithWidth(50), WithHeight(12)}
+
+	got := NewHistogram(append(opts, WithStyle(StyleUnicode))...).Render()
+	if !strings.ContainsAny(got, "⠁⠉⠒⠤⣀⡠⠔⠊") {
+		t.Errorf("expected a Braille density curve, got:\n%s", got)
+	}
+	if !strings.Contains(got, "● samples  ● density") {
+		t.Errorf("expected a legend for the bars and the curve, got:\n%s", got)
+	}
+
+	// Without Unicode the curve is drawn as dots
+	if ascii := NewHistogram(append(opts, WithStyle(StyleASCII))...).Render(); !strings.Contains(ascii, "*") || strings.ContainsAny(ascii, "⠁⣀█") {
+		t.Errorf("expected an ASCII curve, got:\n%s", ascii)
+	}
+
+	// Values cannot be shown on the count axis
+	if _, err := NewHistogram(append(opts, WithShowValues(true), WithStrict(true))...).RenderE(); !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("RenderE() error = %v, want ErrConflictingOptions", err)
+	}
+}
+
+func TestHistogram_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []BarOption
+		wantErr error
+	}{
+		{
+			name:    "no samples",
+			opts:    []BarOption{WithData(nil)},
+			wantErr: ErrEmptyData,
+		},
+		{
+			name:    "NaN sample",
+			opts:    []BarOption{WithData([]float64{1, math.NaN()})},
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "strict negative bins",
+			opts:    []BarOption{WithStrict(true), WithData([]float64{1, 2}), WithBins(-1)},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "strict unknown bin rule",
+			opts:    []BarOption{WithStrict(true), WithData([]float64{1, 2}), WithBinRule(BinRule(9))},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "strict labels",
+			opts:    []BarOption{WithStrict(true), WithData([]float64{1, 2}), WithLabels([]string{"a"})},
+			wantErr: ErrConflictingOptions,
+		},
+		{
+			name:    "strict series",
+			opts:    []BarOption{WithStrict(true), WithSeries([]Series{{Label: "a", Data: []float64{1}}})},
+			wantErr: ErrConflictingOptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := NewHistogram(tt.opts...).RenderE()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RenderE() error = %v, want %v", err, tt.wantErr)
+			}
+			if out != "" {
+				t.Errorf("RenderE() output = %q, want empty", out)
+			}
+		})
+	}
+}
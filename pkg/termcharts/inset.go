@@ -0,0 +1,162 @@
+package termcharts
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// InsetPosition specifies the corner of a chart an inset is drawn in.
+type InsetPosition int
+
+const (
+	// InsetTopRight places the inset in the top-right corner.
+	InsetTopRight InsetPosition = iota
+	// InsetTopLeft places the inset in the top-left corner.
+	InsetTopLeft
+	// InsetBottomRight places the inset in the bottom-right corner.
+	InsetBottomRight
+	// InsetBottomLeft places the inset in the bottom-left corner.
+	InsetBottomLeft
+)
+
+// String returns the string representation of the InsetPosition.
+func (p InsetPosition) String() string {
+	switch p {
+	case InsetTopRight:
+		return "top-right"
+	case InsetTopLeft:
+		return "top-left"
+	case InsetBottomRight:
+		return "bottom-right"
+	case InsetBottomLeft:
+		return "bottom-left"
+	default:
+		return unknownString
+	}
+}
+
+// Inset is a small chart drawn inside a reserved region of a larger chart,
+// for "detail in context" views such as a sparkline of recent values in the
+// corner of a long-range line chart.
+type Inset struct {
+	// Chart is the chart drawn in the inset, usually a sparkline or small pie chart.
+	Chart Chart
+	// Position is the corner the inset is drawn in.
+	Position InsetPosition
+	// OffsetX moves the inset this many columns in from its corner.
+	OffsetX int
+	// OffsetY moves the inset this many rows in from its corner.
+	OffsetY int
+}
+
+// WithInset embeds a small chart in a corner of the chart. The region the
+// inset covers is cleared, with a one-column margin toward the chart, and the
+// inset is drawn on top. Top corners start below the title. Several insets can
+// be added; they are drawn in order, before any post-processors run.
+//
+// Example:
+//
+//	termcharts.WithInset(termcharts.Inset{
+//	    Chart:    termcharts.NewSparkline(termcharts.WithData(lastHour), termcharts.WithWidth(20)),
+//	    Position: termcharts.InsetTopRight,
+//	})
+func WithInset(inset Inset) Option {
+	return func(o *Options) {
+		o.Insets = append(o.Insets, inset)
+	}
+}
+
+// drawInsets draws the configured insets over rendered output.
+func (o *Options) drawInsets(out string) string {
+	if len(o.Insets) == 0 {
+		return out
+	}
+
+	trailing := strings.HasSuffix(out, "\n")
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	rows := make([][]internal.Cell, len(lines))
+	for i, line := range lines {
+		rows[i] = internal.ParseCells(line)
+	}
+	changed := make([]bool, len(rows))
+
+	top := 0
+	if o.Title != "" {
+		top = 1
+	}
+
+	for _, inset := range o.Insets {
+		if inset.Chart == nil {
+			continue
+		}
+		block := splitFrame(inset.Chart.Render())
+		if len(block) == 0 {
+			continue
+		}
+		overlayInset(rows, changed, block, inset, top)
+	}
+
+	var buf bytes.Buffer
+	for i, row := range rows {
+		if changed[i] {
+			writeCells(&buf, row)
+		} else {
+			buf.WriteString(lines[i])
+		}
+		if i < len(rows)-1 || trailing {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.String()
+}
+
+// overlayInset draws block into rows at the inset's corner, clearing the
+// covered region plus a one-column margin on the side facing the chart.
+func overlayInset(rows [][]internal.Cell, changed []bool, block [][]internal.Cell, inset Inset, top int) {
+	blockWidth := 0
+	for _, line := range block {
+		blockWidth = internal.Max(blockWidth, len(line))
+	}
+	hostWidth := 0
+	for _, row := range rows[top:] {
+		hostWidth = internal.Max(hostWidth, len(row))
+	}
+
+	right := inset.Position == InsetTopRight || inset.Position == InsetBottomRight
+	bottom := inset.Position == InsetBottomRight || inset.Position == InsetBottomLeft
+
+	// Region including the margin
+	width := blockWidth + 1
+	col := inset.OffsetX
+	if right {
+		col = hostWidth - width - inset.OffsetX
+	}
+	row := top + inset.OffsetY
+	if bottom {
+		row = len(rows) - len(block) - inset.OffsetY
+	}
+	if col < 0 || row < top || row+len(block) > len(rows) {
+		// The inset does not fit inside the chart
+		return
+	}
+
+	// The margin sits on the side facing the chart
+	blockCol := col
+	if right {
+		blockCol = col + 1
+	}
+
+	for i, line := range block {
+		r := row + i
+		for len(rows[r]) < col+width {
+			rows[r] = append(rows[r], internal.Cell{Rune: ' '})
+		}
+		for c := col; c < col+width; c++ {
+			rows[r][c] = internal.Cell{Rune: ' '}
+		}
+		copy(rows[r][blockCol:], line)
+		changed[r] = true
+	}
+}
@@ -0,0 +1,90 @@
+package termcharts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithInset(t *testing.T) {
+	host := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	mini := NewSparkline(WithData([]float64{1, 2}), WithStyle(StyleASCII), WithColor(false))
+
+	tests := []struct {
+		name     string
+		inset    Inset
+		expected string
+	}{
+		{
+			name:     "top right",
+			inset:    Inset{Chart: mini, Position: InsetTopRight},
+			expected: "_.-=+ _@",
+		},
+		{
+			name:     "top left",
+			inset:    Inset{Chart: mini, Position: InsetTopLeft},
+			expected: "_@ =+*#@",
+		},
+		{
+			name:     "offset from corner",
+			inset:    Inset{Chart: mini, Position: InsetTopRight, OffsetX: 2},
+			expected: "_.- _@#@",
+		},
+		{
+			name:     "too large to fit",
+			inset:    Inset{Chart: mini, Position: InsetBottomLeft, OffsetY: 1},
+			expected: "_.-=+*#@",
+		},
+		{
+			name:     "no chart",
+			inset:    Inset{},
+			expected: "_.-=+*#@",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spark := NewSparkline(WithData(host), WithStyle(StyleASCII), WithColor(false), WithInset(tt.inset))
+			if got := spark.Render(); got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithInset_BelowTitle(t *testing.T) {
+	mini := NewSparkline(WithData([]float64{1, 2}), WithStyle(StyleASCII), WithColor(false))
+	kpi := NewBigText(
+		WithData([]float64{8}),
+		WithTitle("Load"),
+		WithStyle(StyleASCII),
+		WithColor(false),
+		WithInset(Inset{Chart: mini, Position: InsetTopLeft}),
+		WithInset(Inset{Chart: mini, Position: InsetBottomRight}),
+	)
+
+	lines := strings.Split(kpi.Render(), "\n")
+	expected := []string{"Load", "_@ ", "# #", "###", "# #", " _@", ""}
+	if len(lines) != len(expected) {
+		t.Fatalf("got %d lines, want %d: %q", len(lines), len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], expected[i])
+		}
+	}
+}
+
+func TestInsetPosition_String(t *testing.T) {
+	tests := map[InsetPosition]string{
+		InsetTopRight:     "top-right",
+		InsetTopLeft:      "top-left",
+		InsetBottomRight:  "bottom-right",
+		InsetBottomLeft:   "bottom-left",
+		InsetPosition(99): "unknown",
+	}
+	for pos, expected := range tests {
+		if got := pos.String(); got != expected {
+			t.Errorf("InsetPosition(%d).String() = %q, want %q", pos, got, expected)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package termcharts
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// WithLabelWrap wraps the category labels of a horizontal bar chart that
+// are wider than width columns over two lines, so one long label does not
+// widen the label gutter and leave little room for the bars. Labels are
+// broken at the last space that fits, or within a word that is too long;
+// a second line that is still too wide is shortened with "…". The bar of a
+// wrapped label is drawn on both lines, centered on the label. 0 draws
+// every label on one line, shortening labels only to fit the chart width.
+//
+// Example:
+//
+//	chart := termcharts.NewBarChart(
+//	    termcharts.WithData([]float64{42, 17, 30}),
+//	    termcharts.WithLabels([]string{"Checkout service", "Search", "Recommendation engine"}),
+//	    termcharts.WithLabelWrap(16),
+//	)
+func WithLabelWrap(width int) BarOption {
+	return barOption(func(o *Options) {
+		o.LabelWrap = width
+	})
+}
+
+// labelGutter returns the width of the widest label line, with labels
+// wrapped at the LabelWrap width if it is set.
+func (o *Options) labelGutter(labels []string) int {
+	if o.LabelWrap <= 0 {
+		return maxStringLength(labels)
+	}
+	widest := 0
+	for _, label := range labels {
+		first, rest := wrapLabel(label, o.LabelWrap)
+		widest = internal.Max(widest, internal.StringWidth(first))
+		widest = internal.Max(widest, internal.Min(internal.StringWidth(rest), o.LabelWrap))
+	}
+	return widest
+}
+
+// wrapLabel splits label into a first line of at most width columns and the
+// rest, breaking at the last space that fits, or within the first word if
+// it is wider than width. rest is empty when the label fits on one line.
+func wrapLabel(label string, width int) (first, rest string) {
+	if width <= 0 || internal.StringWidth(label) <= width {
+		return label, ""
+	}
+	for i := len(label) - 1; i > 0; i-- {
+		if label[i] != ' ' {
+			continue
+		}
+		if head := strings.TrimRight(label[:i], " "); head != "" && internal.StringWidth(head) <= width {
+			return head, strings.TrimLeft(label[i:], " ")
+		}
+	}
+	first = internal.Truncate(label, width)
+	if first == "" {
+		return label, ""
+	}
+	return first, label[len(first):]
+}
+
+// writeLabelRow writes a bar row beside the first line of a category label,
+// and, when the label wraps within the gutter, a second row with the rest
+// of the label beside the same bar. writeBar writes the bar, and writeValue
+// the value in the column after the bars, on the first row only.
+func (b *BarChart) writeLabelRow(result *bytes.Buffer, label string, layout barRow, useUnicode, colorEnabled bool, theme *Theme, writeBar, writeValue func()) {
+	rest := ""
+	if layout.labels && b.opts.LabelWrap > 0 {
+		width := internal.Min(b.opts.LabelWrap, layout.labelWidth)
+		label, rest = wrapLabel(label, width)
+		rest = truncateLabel(rest, width, useUnicode)
+	}
+	color := b.opts.axisColor(theme)
+	if layout.labels {
+		writeCategoryLabel(result, label, layout.labelWidth, useUnicode, colorEnabled, color)
+	}
+	start := result.Len()
+	writeBar()
+	bar := append([]byte(nil), result.Bytes()[start:]...)
+	if layout.values {
+		// Values start where the longest bar ends, so they line up
+		result.WriteString(strings.Repeat(" ", internal.Max(layout.barWidth-internal.StringWidth(string(bar)), 0)))
+		writeValue()
+	}
+	result.WriteString("\n")
+
+	if rest != "" {
+		writeCategoryLabel(result, rest, layout.labelWidth, useUnicode, colorEnabled, color)
+		result.Write(bar)
+		result.WriteString("\n")
+	}
+}
@@ -0,0 +1,106 @@
+package termcharts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+func TestWrapLabel(t *testing.T) {
+	tests := []struct {
+		name      string
+		label     string
+		width     int
+		wantFirst string
+		wantRest  string
+	}{
+		{"fits", "Search", 10, "Search", ""},
+		{"at a space", "Checkout service", 10, "Checkout", "service"},
+		{"at the last space that fits", "New York City office", 14, "New York City", "office"},
+		{"long word", "Supercalifragilistic", 8, "Supercal", "ifragilistic"},
+		{"repeated spaces", "North   region", 8, "North", "region"},
+		{"wide characters", "東京 本社ビル", 5, "東京", "本社ビル"},
+		{"no width", "Checkout service", 0, "Checkout service", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, rest := wrapLabel(tt.label, tt.width)
+			if first != tt.wantFirst || rest != tt.wantRest {
+				t.Errorf("wrapLabel(%q, %d) = %q, %q, want %q, %q", tt.label, tt.width, first, rest, tt.wantFirst, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestBarChart_LabelWrap(t *testing.T) {
+	labels := []string{"Checkout service", "Search", "Recommendation engine"}
+	out := NewBarChart(
+		WithData([]float64{40, 10, 20}),
+		WithLabels(labels),
+		WithWidth(40),
+		WithColor(false),
+		WithShowValues(true),
+		WithLabelWrap(14),
+	).Render()
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+
+	want := []string{"Checkout", "service", "Search", "Recommendation", "engine"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), out)
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, want[i]+" ") {
+			t.Errorf("line %d = %q, want it to start with label line %q", i, line, want[i])
+		}
+		if w := internal.StringWidth(line); w > 40 {
+			t.Errorf("line %d is %d columns, want at most 40", i, w)
+		}
+	}
+
+	// The bar spans both lines of a wrapped label; the value is drawn once
+	bar := func(line string) string { return strings.Trim(line[15:], " 0123456789.") }
+	if bar(lines[0]) != bar(lines[1]) || bar(lines[0]) == "" {
+		t.Errorf("bar should be drawn on both lines of a wrapped label:\n%s", out)
+	}
+	if !strings.HasSuffix(lines[0], "40.0") || strings.Contains(lines[1], "40.0") {
+		t.Errorf("value should be on the first line only:\n%s", out)
+	}
+
+	// The gutter is as wide as the widest label line, not the widest label
+	if !strings.HasPrefix(lines[2], "Search          █") {
+		t.Errorf("gutter should be the wrap width plus the space before the bar, got %q", lines[2])
+	}
+}
+
+func TestBarChart_LabelWrapModes(t *testing.T) {
+	series := []Series{{Label: "a", Data: []float64{3, 1}}, {Label: "b", Data: []float64{1, 2}}}
+	labels := []string{"North America", "Europe"}
+	for _, mode := range []BarMode{BarModeGrouped, BarModeStacked} {
+		t.Run(mode.String(), func(t *testing.T) {
+			out := NewBarChart(WithSeries(series), WithLabels(labels), WithBarMode(mode),
+				WithWidth(30), WithColor(false), WithLabelWrap(8)).Render()
+			lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+			if len(lines) != 3 || !strings.HasPrefix(lines[0], "North ") || !strings.HasPrefix(lines[1], "America ") {
+				t.Errorf("%s label should wrap over two lines:\n%s", mode, out)
+			}
+		})
+	}
+}
+
+func TestBarChart_LabelWrapTruncates(t *testing.T) {
+	out := NewBarChart(WithData([]float64{1}), WithLabels([]string{"Supercalifragilisticexpialidocious"}),
+		WithWidth(30), WithColor(false), WithStyle(StyleASCII), WithLabelWrap(10)).Render()
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "Supercalif ") || !strings.HasPrefix(lines[1], "ragilisti. ") {
+		t.Errorf("a label too long for two lines should be shortened on the second:\n%s", out)
+	}
+}
+
+func TestBarChart_LabelWrapInvalid(t *testing.T) {
+	_, err := NewBarChart(WithStrict(true), WithData([]float64{1}), WithLabelWrap(-1)).RenderE()
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("RenderE() error = %v, want ErrInvalidOption", err)
+	}
+}
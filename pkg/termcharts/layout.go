@@ -0,0 +1,90 @@
+package termcharts
+
+import "github.com/neilpeterson/termcharts/internal"
+
+// defaultBarWidth is the length of a full-scale horizontal bar when the chart
+// has no width set.
+const defaultBarWidth = 20
+
+// barRow is the layout of a horizontal bar row: a label column, the bar, and
+// a value suffix.
+type barRow struct {
+	// labels reports whether the label column is drawn.
+	labels bool
+	// labelWidth is the width labels are padded or truncated to, before the
+	// space that separates them from the bar.
+	labelWidth int
+	// barWidth is the length of a full-scale bar.
+	barWidth int
+	// values reports whether value suffixes are drawn.
+	values bool
+}
+
+// layoutBarRow lays out a horizontal bar row within width columns, for a
+// label column labelWidth wide and value suffixes up to valueWidth columns
+// (0 = no values). When the parts do not fit at their natural sizes, the
+// gaps around the bar close and labels are truncated to share the space
+// left by the values with the bar; values are dropped only when there is no
+// room for them at all. The row never exceeds width. With no width set, bars
+// are defaultBarWidth columns.
+func layoutBarRow(width, labelWidth, valueWidth int, showLabels bool) barRow {
+	row := barRow{labels: showLabels, labelWidth: labelWidth, values: valueWidth > 0}
+	if !showLabels {
+		row.labelWidth = 0
+	}
+
+	natural := width - row.labelWidth - 2
+	if row.values {
+		natural -= valueWidth + 1
+	}
+	if width <= 0 || natural >= 1 {
+		row.barWidth = natural
+		if row.barWidth < 1 {
+			row.barWidth = defaultBarWidth
+		}
+		return row
+	}
+
+	// Columns left for label text and the bar, after the separator space
+	avail := width
+	if row.labels {
+		avail--
+	}
+	if row.values {
+		if avail-valueWidth >= 2 {
+			avail -= valueWidth
+		} else {
+			row.values = false
+		}
+	}
+
+	if row.labelWidth > avail/2 {
+		row.labelWidth = avail / 2
+	}
+	if row.labels && avail < 1 {
+		// Not even the separator fits next to a bar
+		row.labels, row.labelWidth = false, 0
+		avail = width
+	}
+	row.barWidth = internal.Max(avail-row.labelWidth, 1)
+	return row
+}
+
+// fitTitle truncates a chart title to the chart width, if one is set.
+func fitTitle(title string, width int, useUnicode bool) string {
+	if width <= 0 {
+		return title
+	}
+	return truncateLabel(title, width, useUnicode)
+}
+
+// truncateLabel shortens a label to at most width columns without splitting
+// a grapheme cluster, ending a shortened label with "…", or "." when the
+// chart is drawn in ASCII, so the cut is visible.
+func truncateLabel(label string, width int, useUnicode bool) string {
+	tail := "…"
+	if !useUnicode {
+		tail = "."
+	}
+	return internal.Abbreviate(label, width, tail)
+}
@@ -0,0 +1,93 @@
+package termcharts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+func TestLayoutBarRow(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		labelWidth int
+		valueWidth int
+		showLabels bool
+		expected   barRow
+	}{
+		{name: "natural", width: 40, labelWidth: 6, valueWidth: 5, showLabels: true,
+			expected: barRow{labels: true, labelWidth: 6, barWidth: 26, values: true}},
+		{name: "no width", width: 0, labelWidth: 6, valueWidth: 5, showLabels: true,
+			expected: barRow{labels: true, labelWidth: 6, barWidth: defaultBarWidth, values: true}},
+		{name: "long labels truncated", width: 30, labelWidth: 40, valueWidth: 5, showLabels: true,
+			expected: barRow{labels: true, labelWidth: 12, barWidth: 12, values: true}},
+		{name: "values dropped", width: 6, labelWidth: 10, valueWidth: 5, showLabels: true,
+			expected: barRow{labels: true, labelWidth: 2, barWidth: 3}},
+		{name: "labels dropped", width: 1, labelWidth: 10, showLabels: true,
+			expected: barRow{barWidth: 1}},
+		{name: "hidden labels", width: 10, labelWidth: 10, valueWidth: 20, showLabels: false,
+			expected: barRow{barWidth: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := layoutBarRow(tt.width, tt.labelWidth, tt.valueWidth, tt.showLabels); got != tt.expected {
+				t.Errorf("layoutBarRow() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncateLabel(t *testing.T) {
+	tests := []struct {
+		name       string
+		label      string
+		width      int
+		useUnicode bool
+		expected   string
+	}{
+		{name: "fits", label: "Memory", width: 6, useUnicode: true, expected: "Memory"},
+		{name: "ellipsis", label: "Memory", width: 4, useUnicode: true, expected: "Mem…"},
+		{name: "ascii", label: "Memory", width: 4, useUnicode: false, expected: "Mem."},
+		{name: "combining mark kept", label: "cafe\u0301 au lait", width: 5, useUnicode: true, expected: "cafe\u0301…"},
+		{name: "wide character not split", label: "東京タワー", width: 6, useUnicode: true, expected: "東京…"},
+		{name: "emoji sequence not split", label: "👩‍💻 team", width: 4, useUnicode: true, expected: "👩‍💻 …"},
+		{name: "too narrow for ellipsis", label: "Memory", width: 1, useUnicode: true, expected: "M"},
+		{name: "zero width", label: "Memory", width: 0, useUnicode: true, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateLabel(tt.label, tt.width, tt.useUnicode); got != tt.expected {
+				t.Errorf("truncateLabel(%q, %d) = %q, want %q", tt.label, tt.width, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRender_FitsWidth(t *testing.T) {
+	long := strings.Repeat("label ", 10)
+	series := []Series{
+		{Label: "requests per second " + long, Data: []float64{30, -5, 20}},
+		{Label: "errors", Data: []float64{1, 2, 3}},
+		{Label: "latency", Data: []float64{10, 20, 15}},
+	}
+	for _, width := range []int{8, 20, 35, 80} {
+		charts := map[string]Chart{
+			"bar values": NewBarChart(WithData([]float64{1200, -35.5, 7}), WithLabels([]string{long, "b", "c"}),
+				WithShowValues(true), WithTitle(long), WithWidth(width)),
+			"bar fixed axis": NewBarChart(WithData([]float64{1200, 5}), WithShowValues(true),
+				WithYAxis(AxisConfig{Max: 100}), WithWidth(width)),
+			"grouped": NewBarChart(WithSeries(series), WithLabels([]string{long, "b", "c"}), WithShowLegend(true), WithWidth(width)),
+			"stacked": NewBarChart(WithSeries(series), WithBarMode(BarModeStacked), WithShowLegend(true), WithWidth(width)),
+		}
+		for name, chart := range charts {
+			for _, line := range strings.Split(chart.Render(), "\n") {
+				if w := internal.StringWidth(internal.StripANSI(line)); w > width {
+					t.Errorf("%s at width %d: line %q is %d columns wide", name, width, line, w)
+				}
+			}
+		}
+	}
+}
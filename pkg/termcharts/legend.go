@@ -0,0 +1,401 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// LegendValues selects the per-series statistics shown in a legend.
+// Values can be combined, e.g. LegendCurrent | LegendMax.
+type LegendValues int
+
+const (
+	// LegendCurrent shows the last value of each series.
+	LegendCurrent LegendValues = 1 << iota
+	// LegendMin shows the minimum value of each series.
+	LegendMin
+	// LegendMax shows the maximum value of each series.
+	LegendMax
+	// LegendSum shows the total of each series; on a stacked bar chart, the
+	// sum of the series' segments.
+	LegendSum
+	// LegendMean shows the mean of each series.
+	LegendMean
+)
+
+// Legend renders a key of series markers and labels. Multi-series charts
+// draw their legends with it, and it can be rendered on its own for custom
+// layouts.
+//
+// Example:
+//
+//	legend := termcharts.Legend{
+//	    Series:  series,
+//	    Columns: 2,
+//	    Values:  termcharts.LegendCurrent | termcharts.LegendMax,
+//	}
+//	fmt.Print(legend.Render())
+type Legend struct {
+	// Series are the entries of the legend, in order. Hidden series are
+	// dimmed, or drawn without a marker when ColorEnabled is not set.
+	Series []Series
+	// Columns is the number of entries per row (0 = all entries on one row).
+	Columns int
+	// Marker is the symbol drawn before each label (empty = "●").
+	Marker string
+	// Values selects statistics shown after each label (0 = none). With one
+	// column, they are aligned in columns of their own.
+	Values LegendValues
+	// Format formats displayed values (nil = one decimal place).
+	Format func(float64) string
+	// Locale selects the number format of displayed values (empty = default).
+	Locale string
+	// Theme provides colors for series without an explicit color (nil = DefaultTheme).
+	Theme *Theme
+	// ColorEnabled draws each marker in its series color.
+	ColorEnabled bool
+	// Style is the style of the labels when ColorEnabled is set (zero = terminal default).
+	Style Style
+	// Width is the widest a line may be; entries wrap onto more rows and
+	// long labels are truncated to fit (0 = no limit). Charts set it to
+	// their width.
+	Width int
+}
+
+// SeriesOption configures a chart that plots multiple series (bar, line, or
+// composed charts, or small multiples).
+type SeriesOption interface {
+	BarOption
+	LineOption
+	ComposeOption
+	MultiplesOption
+}
+
+// seriesOption is an option that applies to multi-series charts.
+type seriesOption func(*Options)
+
+func (f seriesOption) applyBar(o *Options)     { f(o) }
+func (f seriesOption) applyLine(o *Options)    { f(o) }
+func (f seriesOption) applyCompose(o *Options) { f(o) }
+
+// WithLegend customizes the legend of a multi-series chart and enables it.
+// The chart supplies the series and color settings; a Marker, Theme, Columns,
+// Values, and Format set on legend are kept.
+//
+// Example:
+//
+//	termcharts.WithLegend(termcharts.Legend{Columns: 3, Values: termcharts.LegendCurrent})
+func WithLegend(legend Legend) SeriesOption {
+	return seriesOption(func(o *Options) {
+		o.Legend = &legend
+		o.ShowLegend = true
+	})
+}
+
+// WithLegendStats enables the legend of a multi-series chart and shows the
+// statistics selected by values after each label, one series per row with
+// the values aligned in columns, like the legends of monitoring dashboards.
+// Other customization set with WithLegend is kept.
+//
+// Example:
+//
+//	termcharts.WithLegendStats(termcharts.LegendCurrent | termcharts.LegendMin | termcharts.LegendMax | termcharts.LegendMean)
+func WithLegendStats(values LegendValues) SeriesOption {
+	return seriesOption(func(o *Options) {
+		var legend Legend
+		if o.Legend != nil {
+			legend = *o.Legend
+		}
+		legend.Values = values
+		if legend.Columns == 0 {
+			legend.Columns = 1
+		}
+		o.Legend = &legend
+		o.ShowLegend = true
+	})
+}
+
+// Render generates the legend, one line per row of entries.
+// It returns an empty string if there are no series.
+func (l *Legend) Render() string {
+	if len(l.Series) == 0 {
+		return ""
+	}
+
+	theme := l.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	marker := l.Marker
+	if marker == "" {
+		marker = "●"
+	}
+
+	// Measure entries, tracking visible widths for column alignment
+	texts := l.entryTexts()
+	widths := make([]int, len(l.Series))
+	markerWidth := internal.StringWidth(marker) + 1
+	for i, text := range texts {
+		widths[i] = markerWidth + internal.StringWidth(text)
+	}
+
+	columns := l.Columns
+	if columns <= 0 {
+		columns = len(texts)
+	}
+	if l.Width > 0 {
+		// Wrap onto more rows until the widest row fits, then truncate
+		// entries that are too long to fit on a row of their own
+		for columns > 1 && legendRowWidth(widths, columns) > l.Width {
+			columns--
+		}
+		if limit := l.Width - 2 - markerWidth; legendRowWidth(widths, columns) > l.Width {
+			// An ASCII marker means the chart is drawn in ASCII
+			useUnicode := utf8.RuneCountInString(marker) != len(marker)
+			for i, text := range texts {
+				if widths[i]+2 > l.Width {
+					texts[i] = truncateLabel(text, limit, useUnicode)
+					widths[i] = markerWidth + internal.StringWidth(texts[i])
+				}
+			}
+		}
+	}
+	maxWidth := 0
+	for _, w := range widths {
+		if w > maxWidth {
+			maxWidth = w
+		}
+	}
+
+	entries := make([]string, len(l.Series))
+	for i, s := range l.Series {
+		m := marker
+		switch {
+		case s.Hidden && l.ColorEnabled:
+			// Hidden series are dimmed, marker and label alike
+			entries[i] = Colorize(m+" "+texts[i], theme.Muted, true)
+			continue
+		case s.Hidden:
+			m = strings.Repeat(" ", markerWidth-1)
+		case l.ColorEnabled:
+			color := s.Color
+			if color == "" {
+				color = theme.GetSeriesColor(i)
+			}
+			m = Colorize(m, color, true)
+		}
+		entries[i] = m + " " + l.Style.Render(texts[i], l.ColorEnabled)
+	}
+
+	var result strings.Builder
+	for i, entry := range entries {
+		result.WriteString(entry)
+		if columns < len(entries) {
+			// Pad to align columns across rows
+			result.WriteString(strings.Repeat(" ", maxWidth-widths[i]))
+		}
+		result.WriteString("  ")
+		if (i+1)%columns == 0 || i == len(entries)-1 {
+			result.WriteString("\n")
+		}
+	}
+	return result.String()
+}
+
+// legendRowWidth returns the width of the widest row of a legend whose
+// entries are widths wide, laid out in columns columns.
+func legendRowWidth(widths []int, columns int) int {
+	if columns >= len(widths) {
+		// A single row is not padded
+		total := 0
+		for _, w := range widths {
+			total += w + 2
+		}
+		return total
+	}
+	max := 0
+	for _, w := range widths {
+		if w > max {
+			max = w
+		}
+	}
+	return columns * (max + 2)
+}
+
+// entryTexts returns the label and selected statistics of each series. In
+// a single column, statistics follow the labels in aligned columns, such as
+// "CPU     cur 25.0  max 40.0"; otherwise they follow in parentheses, such as
+// "CPU (cur 25.0, max 40.0)".
+func (l *Legend) entryTexts() []string {
+	labels := make([]string, len(l.Series))
+	values := make([][]legendStat, len(l.Series))
+	for i, s := range l.Series {
+		labels[i], values[i] = l.entryParts(i, s)
+	}
+
+	texts := make([]string, len(l.Series))
+	if l.Columns != 1 || l.Values == 0 {
+		for i, label := range labels {
+			texts[i] = label
+			if len(values[i]) > 0 {
+				stats := make([]string, len(values[i]))
+				for j, v := range values[i] {
+					stats[j] = v.name + " " + v.value
+				}
+				texts[i] = fmt.Sprintf("%s (%s)", label, strings.Join(stats, ", "))
+			}
+		}
+		return texts
+	}
+
+	// Pad labels to the widest label and right-align values; a series
+	// without finite values leaves its value columns empty
+	labelWidth := 0
+	var valueWidths []int
+	for i, label := range labels {
+		labelWidth = internal.Max(labelWidth, internal.StringWidth(label))
+		for j, v := range values[i] {
+			if j == len(valueWidths) {
+				valueWidths = append(valueWidths, 0)
+			}
+			valueWidths[j] = internal.Max(valueWidths[j], internal.StringWidth(v.value))
+		}
+	}
+	for i, label := range labels {
+		var text strings.Builder
+		text.WriteString(label)
+		if len(values[i]) > 0 {
+			text.WriteString(strings.Repeat(" ", labelWidth-internal.StringWidth(label)))
+			for j, v := range values[i] {
+				text.WriteString("  " + v.name + " ")
+				text.WriteString(strings.Repeat(" ", valueWidths[j]-internal.StringWidth(v.value)))
+				text.WriteString(v.value)
+			}
+		}
+		texts[i] = text.String()
+	}
+	return texts
+}
+
+// legendStat is a statistic shown in a legend entry, such as "max 40.0".
+type legendStat struct {
+	name, value string
+}
+
+// entryParts returns the label and selected statistics for a series.
+func (l *Legend) entryParts(index int, s Series) (string, []legendStat) {
+	label := s.Label
+	if label == "" {
+		label = fmt.Sprintf("Series %d", index+1)
+	}
+	if l.Values == 0 {
+		return label, nil
+	}
+
+	current, min, max, ok := seriesStats(s.Data)
+	if !ok {
+		return label, nil
+	}
+
+	var values []legendStat
+	if l.Values&LegendCurrent != 0 {
+		values = append(values, legendStat{"cur", l.formatValue(current)})
+	}
+	if l.Values&LegendMin != 0 {
+		values = append(values, legendStat{"min", l.formatValue(min)})
+	}
+	if l.Values&LegendMax != 0 {
+		values = append(values, legendStat{"max", l.formatValue(max)})
+	}
+	if l.Values&(LegendSum|LegendMean) != 0 {
+		sum, n := 0.0, 0
+		for _, v := range s.Data {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				sum += v
+				n++
+			}
+		}
+		if l.Values&LegendSum != 0 {
+			values = append(values, legendStat{"sum", l.formatValue(sum)})
+		}
+		if l.Values&LegendMean != 0 {
+			values = append(values, legendStat{"avg", l.formatValue(sum / float64(n))})
+		}
+	}
+	return label, values
+}
+
+// formatValue formats a legend statistic.
+func (l *Legend) formatValue(v float64) string {
+	if l.Format != nil {
+		return l.Format(v)
+	}
+	return localizeNumber(fmt.Sprintf("%.1f", v), l.Locale)
+}
+
+// seriesStats returns the last, minimum, and maximum finite values of data.
+// ok is false if data has no finite values.
+func seriesStats(data []float64) (current, min, max float64, ok bool) {
+	for _, v := range data {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		if !ok {
+			min, max = v, v
+			ok = true
+		}
+		current = v
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	return current, min, max, ok
+}
+
+// chartLegend returns the legend a multi-series chart draws for series,
+// applying any customization set with WithLegend.
+func chartLegend(opts *Options, series []Series, marker string, colorEnabled bool, theme *Theme) *Legend {
+	var legend Legend
+	if opts.Legend != nil {
+		legend = *opts.Legend
+	}
+	legend.Series = series
+	if len(opts.HiddenSeries) > 0 || opts.ColorByLabel {
+		// Mark series hidden by label, and color them by label, without
+		// changing the caller's slice
+		legend.Series = make([]Series, len(series))
+		for i, s := range series {
+			s.Hidden = opts.seriesHidden(s)
+			if s.Color == "" && opts.ColorByLabel {
+				s.Color = opts.seriesColor(theme, i, s.Label)
+			}
+			legend.Series[i] = s
+		}
+	}
+	legend.ColorEnabled = colorEnabled
+	if legend.Marker == "" {
+		legend.Marker = marker
+	}
+	if legend.Theme == nil {
+		legend.Theme = theme
+	}
+	if legend.Style == (Style{}) {
+		legend.Style = opts.LegendStyle
+	}
+	if legend.Locale == "" {
+		legend.Locale = opts.Locale
+	}
+	if legend.Format == nil && opts.YUnit != "" {
+		locale, unit := legend.Locale, opts.YUnit
+		legend.Format = func(v float64) string {
+			return withUnit(localizeNumber(fmt.Sprintf("%.1f", v), locale), unit)
+		}
+	}
+	if legend.Width == 0 {
+		legend.Width = opts.Width
+	}
+	return &legend
+}
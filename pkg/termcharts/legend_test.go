@@ -0,0 +1,165 @@
+package termcharts
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLegend_Render(t *testing.T) {
+	series := []Series{
+		{Label: "CPU", Data: []float64{10, 40, 25}},
+		{Label: "Memory", Data: []float64{60, 55, 70}},
+		{Data: []float64{1}},
+	}
+
+	tests := []struct {
+		name     string
+		legend   Legend
+		expected string
+	}{
+		{
+			name:     "single row",
+			legend:   Legend{Series: series},
+			expected: "● CPU  ● Memory  ● Series 3  \n",
+		},
+		{
+			name:     "custom marker",
+			legend:   Legend{Series: series[:1], Marker: "#"},
+			expected: "# CPU  \n",
+		},
+		{
+			name:   "columns",
+			legend: Legend{Series: series, Columns: 2},
+			expected: "● CPU       ● Memory    \n" +
+				"● Series 3  \n",
+		},
+		{
+			name:     "current value",
+			legend:   Legend{Series: series[:1], Values: LegendCurrent},
+			expected: "● CPU (cur 25.0)  \n",
+		},
+		{
+			name:     "all values",
+			legend:   Legend{Series: series[1:2], Values: LegendCurrent | LegendMin | LegendMax},
+			expected: "● Memory (cur 70.0, min 55.0, max 70.0)  \n",
+		},
+		{
+			name: "custom format",
+			legend: Legend{
+				Series: series[:1],
+				Values: LegendMax,
+				Format: func(v float64) string { return "40%" },
+			},
+			expected: "● CPU (max 40%)  \n",
+		},
+		{
+			name:     "mean",
+			legend:   Legend{Series: series[:1], Values: LegendMean},
+			expected: "● CPU (avg 25.0)  \n",
+		},
+		{
+			name:   "value columns",
+			legend: Legend{Series: series, Columns: 1, Values: LegendCurrent | LegendMax | LegendMean},
+			expected: "● CPU       cur 25.0  max 40.0  avg 25.0  \n" +
+				"● Memory    cur 70.0  max 70.0  avg 61.7  \n" +
+				"● Series 3  cur  1.0  max  1.0  avg  1.0  \n",
+		},
+		{
+			name:     "empty",
+			legend:   Legend{},
+			expected: "",
+		},
+		{
+			name:   "wraps to width",
+			legend: Legend{Series: series, Width: 24},
+			expected: "● CPU       ● Memory    \n" +
+				"● Series 3  \n",
+		},
+		{
+			name:     "hidden series",
+			legend:   Legend{Series: []Series{series[0], {Label: "Memory", Hidden: true}}},
+			expected: "● CPU    Memory  \n",
+		},
+		{
+			name:     "truncates to width",
+			legend:   Legend{Series: series[1:2], Width: 8},
+			expected: "● Mem…  \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.legend.Render(); got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLegend_Colors(t *testing.T) {
+	legend := Legend{
+		Series:       []Series{{Label: "A"}, {Label: "B", Color: "green"}},
+		ColorEnabled: true,
+	}
+	result := legend.Render()
+
+	if !strings.Contains(result, colorRed+"●"+colorReset) {
+		t.Errorf("first series should use theme color, got %q", result)
+	}
+	if !strings.Contains(result, colorGreen+"●"+colorReset) {
+		t.Errorf("series color should be used, got %q", result)
+	}
+
+	legend.Series[1].Hidden = true
+	if result := legend.Render(); !strings.Contains(result, Colorize("● B", DefaultTheme.Muted, true)) {
+		t.Errorf("hidden series should be dimmed, got %q", result)
+	}
+}
+
+func TestSeriesStats(t *testing.T) {
+	current, min, max, ok := seriesStats([]float64{3, math.NaN(), -2, 8, math.Inf(1), 5})
+	if !ok || current != 5 || min != -2 || max != 8 {
+		t.Errorf("seriesStats() = (%v, %v, %v, %v), want (5, -2, 8, true)", current, min, max, ok)
+	}
+
+	if _, _, _, ok := seriesStats([]float64{math.NaN()}); ok {
+		t.Error("seriesStats() ok = true for data without finite values")
+	}
+}
+
+func TestWithLegend(t *testing.T) {
+	series := WithSeries([]Series{
+		{Label: "A", Data: []float64{1, 2}},
+		{Label: "B", Data: []float64{3, 4}},
+	})
+
+	bar := NewBarChart(series, WithColor(false), WithStyle(StyleASCII),
+		WithLegend(Legend{Values: LegendMax}))
+	if result := bar.Render(); !strings.Contains(result, "# A (max 2.0)  # B (max 4.0)") {
+		t.Errorf("bar legend not customized, got:\n%s", result)
+	}
+
+	line := NewLineChart(series, WithColor(false), WithStyle(StyleASCII),
+		WithLegend(Legend{Columns: 1, Marker: "+"}))
+	if result := line.Render(); !strings.Contains(result, "+ A  \n+ B  \n") {
+		t.Errorf("line legend not customized, got:\n%s", result)
+	}
+}
+
+func TestWithLegendStats(t *testing.T) {
+	chart := NewLineChart(
+		WithSeries([]Series{
+			{Label: "p50", Data: []float64{12, 9, 14}},
+			{Label: "p99", Data: []float64{180, 240, 95}},
+		}),
+		WithLegend(Legend{Marker: "+"}),
+		WithLegendStats(LegendCurrent|LegendMin|LegendMax),
+		WithColor(false), WithStyle(StyleASCII),
+	)
+	want := "+ p50  cur 14.0  min  9.0  max  14.0  \n" +
+		"+ p99  cur 95.0  min 95.0  max 240.0  \n"
+	if result := chart.Render(); !strings.HasSuffix(result, want) {
+		t.Errorf("legend stats not shown in columns, got:\n%s", result)
+	}
+}
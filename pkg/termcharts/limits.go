@@ -0,0 +1,108 @@
+package termcharts
+
+import (
+	"fmt"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// Default limits on the input a chart accepts. See Limits.
+const (
+	// DefaultMaxPoints is the default limit on the data points of a chart.
+	DefaultMaxPoints = 1000000
+	// DefaultMaxWidth is the default limit on the width of a chart, in columns.
+	DefaultMaxWidth = 1000
+	// DefaultMaxHeight is the default limit on the height of a chart, in rows.
+	DefaultMaxHeight = 500
+)
+
+// Limits guard against input too large to chart, such as a file with
+// billions of rows or a width of 100000 columns, so a program charting
+// untrusted input neither runs out of memory nor hangs.
+//
+// A chart with more data points than MaxPoints renders nothing; RenderE
+// returns ErrLimitExceeded. Widths and heights beyond MaxWidth and MaxHeight
+// are clamped to them, or reported as ErrInvalidDimensions with WithStrict.
+// A zero field uses its default, and a negative field removes the limit.
+type Limits struct {
+	// MaxPoints is the most data points a chart accepts, counted across the
+	// data and every series (0 = DefaultMaxPoints).
+	MaxPoints int
+	// MaxWidth is the widest a chart is drawn, in columns (0 = DefaultMaxWidth).
+	MaxWidth int
+	// MaxHeight is the tallest a chart is drawn, in rows (0 = DefaultMaxHeight).
+	MaxHeight int
+}
+
+// WithLimits sets the limits on the input a chart accepts, in place of the
+// defaults. Programs that chart very large data on purpose can raise them;
+// ones that chart untrusted input can lower them.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithData(samples), // 5,000,000 values
+//	    termcharts.WithLimits(termcharts.Limits{MaxPoints: 10000000}),
+//	)
+func WithLimits(limits Limits) Option {
+	return func(o *Options) {
+		o.Limits = limits
+	}
+}
+
+// limit returns a limit, or def if it is 0, or 0 if it is negative, meaning
+// no limit.
+func limit(value, def int) int {
+	switch {
+	case value > 0:
+		return value
+	case value < 0:
+		return 0
+	default:
+		return def
+	}
+}
+
+// maxPointsLimit returns the most data points the chart accepts (0 = no limit).
+func (o *Options) maxPointsLimit() int {
+	return limit(o.Limits.MaxPoints, DefaultMaxPoints)
+}
+
+// maxWidth returns the widest the chart is drawn (0 = no limit).
+func (o *Options) maxWidth() int {
+	return limit(o.Limits.MaxWidth, DefaultMaxWidth)
+}
+
+// maxHeight returns the tallest the chart is drawn (0 = no limit).
+func (o *Options) maxHeight() int {
+	return limit(o.Limits.MaxHeight, DefaultMaxHeight)
+}
+
+// clampSize clamps a width or height to at least min and at most max, unless
+// max is 0, meaning no limit.
+func clampSize(size, min, max int) int {
+	if max == 0 {
+		return internal.Max(size, min)
+	}
+	return internal.ClampInt(size, min, max)
+}
+
+// checkPoints checks that the data, the series, and the extra data together
+// hold no more points than the chart accepts.
+func (o *Options) checkPoints(extra ...[]float64) error {
+	max := o.maxPointsLimit()
+	if max == 0 {
+		return nil
+	}
+	n := len(o.Data)
+	for _, s := range o.Series {
+		n += len(s.Data)
+	}
+	for _, data := range extra {
+		n += len(data)
+	}
+	if n > max {
+		return fmt.Errorf("%w: %d data points exceed the limit of %d", ErrLimitExceeded, n, max)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package termcharts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+func TestLimits_MaxPoints(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5, 6}
+	small := WithLimits(Limits{MaxPoints: 5})
+	spark := func(opts ...Option) Chart { return NewSparkline(Combine(opts...)) }
+
+	tests := []struct {
+		name  string
+		chart ChartE
+	}{
+		{"bar", NewBarChart(WithData(data), small)},
+		{"bar series", NewBarChart(WithSeries([]Series{{Data: data[:3]}, {Data: data[3:]}}), small)},
+		{"line", NewLineChart(WithData(data), small)},
+		{"pie", NewPieChart(WithData(data), small)},
+		{"sparkline", NewSparkline(WithData(data), small)},
+		{"histogram", NewHistogram(WithData(data), small)},
+		{"box plot", NewBoxPlot(WithSeries([]Series{{Data: data[:3]}, {Data: data[3:]}}), small)},
+		{"composed", Compose([]Layer{{Kind: LayerLine, Series: Series{Data: data}}}, small)},
+		{"comparison", NewComparison(Series{Data: data[:3]}, Series{Data: data[3:]}, small)},
+		{"small multiples", SmallMultiples([]Series{{Data: data}}, spark, small)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.chart.RenderE()
+			if !errors.Is(err, ErrLimitExceeded) {
+				t.Fatalf("RenderE() error = %v, want ErrLimitExceeded", err)
+			}
+			if out != "" {
+				t.Errorf("RenderE() = %q, want no output", out)
+			}
+			if !strings.Contains(err.Error(), "6 data points exceed the limit of 5") {
+				t.Errorf("error %q should give the count and the limit", err)
+			}
+		})
+	}
+}
+
+func TestLimits_DefaultMaxPoints(t *testing.T) {
+	data := make([]float64, DefaultMaxPoints+1)
+
+	if _, err := NewSparkline(WithData(data)).RenderE(); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("default limit: error = %v, want ErrLimitExceeded", err)
+	}
+	if _, err := NewSparkline(WithData(data), WithWidth(40), WithLimits(Limits{MaxPoints: -1})).RenderE(); err != nil {
+		t.Errorf("no limit: error = %v, want nil", err)
+	}
+}
+
+func TestLimits_Dimensions(t *testing.T) {
+	data := []float64{1, 2, 3}
+	limits := WithLimits(Limits{MaxWidth: 20, MaxHeight: 4})
+
+	out := NewLineChart(WithData(data), WithWidth(200), WithHeight(100), WithColor(false), limits).Render()
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) > 4+1 {
+		t.Errorf("chart is %d lines, want at most the height limit plus the X axis labels", len(lines))
+	}
+	for _, line := range lines {
+		if w := internal.StringWidth(line); w > 20 {
+			t.Errorf("line %q is %d columns, want at most 20", line, w)
+		}
+	}
+
+	_, err := NewLineChart(WithData(data), WithWidth(30), WithStrict(true), limits).RenderE()
+	if !errors.Is(err, ErrInvalidDimensions) || !strings.Contains(err.Error(), "limit of 20 columns") {
+		t.Errorf("strict width beyond the limit: error = %v, want ErrInvalidDimensions naming the limit", err)
+	}
+
+	// Without a width limit, a width beyond the default is drawn as given
+	out = NewLineChart(WithData(data), WithWidth(DefaultMaxWidth+200), WithHeight(5), WithColor(false), WithLimits(Limits{MaxWidth: -1})).Render()
+	if w := internal.StringWidth(strings.Split(out, "\n")[0]); w <= DefaultMaxWidth {
+		t.Errorf("chart is %d columns, want more than %d with no width limit", w, DefaultMaxWidth)
+	}
+}
@@ -1,8 +1,12 @@
 package termcharts
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/neilpeterson/termcharts/internal"
 )
@@ -12,6 +16,7 @@ import (
 // Unicode characters, or high-resolution Braille patterns.
 type LineChart struct {
 	opts *Options
+	err  error
 }
 
 // Box-drawing characters for ASCII line rendering.
@@ -47,13 +52,79 @@ const brailleBase = 0x2800 // Unicode Braille pattern blank
 
 // brailleDots maps (row, col) within a cell to the bit position.
 // Row 0-3 (top to bottom), Col 0-1 (left to right).
-var brailleDots = [4][2]int{
+var brailleDots = [][]int{
 	{0x01, 0x08}, // Row 0: dots 1, 4
 	{0x02, 0x10}, // Row 1: dots 2, 5
 	{0x04, 0x20}, // Row 2: dots 3, 6
 	{0x40, 0x80}, // Row 3: dots 7, 8
 }
 
+// quadrantGlyphs are the Unicode quadrant blocks, indexed by a pattern with
+// bit 1 top left, 2 top right, 4 bottom left, and 8 bottom right.
+var quadrantGlyphs = []rune(" ▘▝▀▖▌▞▛▗▚▐▜▄▙▟█")
+
+// sextantBase is the first Unicode sextant block, which has only the top
+// left of its 2x3 sub-cells set.
+const sextantBase = 0x1FB00
+
+// dotCell is how a sub-cell style divides a character cell into dots and
+// draws the dots set in a cell.
+type dotCell struct {
+	cols, rows int
+	// bits maps (row, col) within a cell to the bit position.
+	bits [][]int
+	// glyph returns the character for a pattern of set bits.
+	glyph func(pattern int) rune
+}
+
+// Dot cells of the sub-cell styles.
+var (
+	brailleCell = dotCell{cols: 2, rows: 4, bits: brailleDots, glyph: func(pattern int) rune {
+		return rune(brailleBase + pattern)
+	}}
+	quadrantCell = dotCell{cols: 2, rows: 2, bits: [][]int{{1, 2}, {4, 8}}, glyph: func(pattern int) rune {
+		return quadrantGlyphs[pattern]
+	}}
+	sextantCell = dotCell{cols: 2, rows: 3, bits: [][]int{{1, 2}, {4, 8}, {16, 32}}, glyph: sextantGlyph}
+)
+
+// sextantGlyph returns the sextant block for a pattern with bits 1 and 2 in
+// the top row, 4 and 8 in the middle, and 16 and 32 at the bottom. The
+// sextant block range leaves out the patterns that older blocks already
+// draw: blank, left half, right half, and full.
+func sextantGlyph(pattern int) rune {
+	switch pattern {
+	case 0:
+		return ' '
+	case 21:
+		return '▌'
+	case 42:
+		return '▐'
+	case 63:
+		return '█'
+	}
+	offset := pattern - 1
+	if pattern > 21 {
+		offset--
+	}
+	if pattern > 42 {
+		offset--
+	}
+	return rune(sextantBase + offset)
+}
+
+// dotCellFor returns the dot cell of a sub-cell style.
+func dotCellFor(style RenderStyle) dotCell {
+	switch style {
+	case StyleBlock2x2:
+		return quadrantCell
+	case StyleSextant:
+		return sextantCell
+	default:
+		return brailleCell
+	}
+}
+
 // NewLineChart creates a new line chart with the given options.
 // At minimum, data must be provided via WithData option or WithSeries for multi-series.
 //
@@ -65,33 +136,82 @@ var brailleDots = [4][2]int{
 //	    termcharts.WithHeight(10),
 //	)
 //	fmt.Println(line.Render())
-func NewLineChart(opts ...Option) *LineChart {
-	options := NewOptions(opts...)
-	return &LineChart{
+func NewLineChart(opts ...LineOption) *LineChart {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyLine(options)
+	}
+	l := &LineChart{
 		opts: options,
 	}
+	if options.Strict {
+		l.err = l.validateOptions()
+	}
+	return l
 }
 
+// Update applies opts to the line chart, replacing the options they set.
+// The next Render draws the line chart with the new options; with WithStrict
+// they are validated again. Use it to feed new data into a live display.
+func (l *LineChart) Update(opts ...LineOption) {
+	for _, opt := range opts {
+		opt.applyLine(l.opts)
+	}
+	l.err = nil
+	if l.opts.Strict {
+		l.err = l.validateOptions()
+	}
+}
+
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the line chart Updatable.
+func (l *LineChart) Apply(opts ...Option) { l.Update(Combine(opts...)) }
+
 // Render generates the line chart as a multi-line string.
+// It returns an empty string if the chart cannot be drawn; use RenderE to find out why.
 func (l *LineChart) Render() string {
+	out, _ := l.RenderE()
+	return out
+}
+
+// RenderE generates the line chart as a multi-line string.
+// It returns ErrEmptyData, ErrInvalidData, or ErrInvalidDimensions
+// (possibly wrapped) when the chart cannot be drawn.
+func (l *LineChart) RenderE() (string, error) {
+	if l.err != nil {
+		return "", l.err
+	}
+	if err := l.opts.checkPoints(); err != nil {
+		return "", err
+	}
+
+	// A width or height of 0 is the terminal's; others are clamped
+	if opts := l.opts.sized(); opts != l.opts {
+		return (&LineChart{opts: opts}).RenderE()
+	}
+	if opts := l.opts.percentScaled(); opts != l.opts {
+		return (&LineChart{opts: opts}).RenderE()
+	}
+	if l.opts.noData() {
+		return l.opts.placeholder(l.opts.Width, l.opts.Height, l.shouldUseUnicode(), l.isColorEnabled()), nil
+	}
+
 	// Get all data series
 	allSeries := l.getAllSeries()
 	if len(allSeries) == 0 {
-		return ""
+		return "", ErrEmptyData
 	}
 
 	// Check for invalid values
-	for _, series := range allSeries {
-		if !internal.AllValid(series.Data) {
-			return ""
-		}
+	if err := validateSeries(allSeries); err != nil {
+		return "", err
 	}
 
 	// Render based on style
-	if l.opts.Style == StyleBraille {
-		return l.renderBraille(allSeries)
+	if l.opts.Style.subCell() {
+		return l.opts.postProcess(l.renderDots(allSeries, dotCellFor(l.opts.Style))), nil
 	}
-	return l.renderASCII(allSeries)
+	return l.opts.postProcess(l.renderASCII(allSeries)), nil
 }
 
 // getAllSeries returns all data series to render.
@@ -121,103 +241,118 @@ func (l *LineChart) renderASCII(allSeries []Series) string {
 	width := l.opts.Width
 	height := l.opts.Height
 
+	showXAxis := l.opts.ShowAxes && !l.opts.XAxis.Hidden
+
 	// Reserve space for title and axes
 	chartHeight := height
 	if l.opts.Title != "" {
 		chartHeight--
 	}
-	if l.opts.ShowAxes {
+	if showXAxis {
 		chartHeight -= 2 // Bottom axis and labels
 	}
 	if chartHeight < 3 {
-		chartHeight = 10
+		chartHeight = 3
 	}
 
-	// Calculate chart width (leave room for Y axis if showing)
-	chartWidth := width
-	yAxisWidth := 0
-	if l.opts.ShowAxes {
-		yAxisWidth = 8 // Space for Y axis labels
-		chartWidth -= yAxisWidth
+	theme := l.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
 	}
+
+	// Map the plotted series onto the Y axis and find its range
+	projected, globalMin, globalMax := l.projectSeries(l.opts.visibleSeries(allSeries, theme))
+
+	// Calculate chart width (leave room for Y axis if showing)
+	yLabels, yAxisWidth := yAxisLabels(l.opts, chartHeight, globalMin, globalMax)
+	chartWidth := width - yAxisWidth
 	if chartWidth < 10 {
-		chartWidth = 60
+		chartWidth = 10
 	}
 
-	// Find global min/max across all series
-	globalMin, globalMax := l.findGlobalMinMax(allSeries)
-	if globalMin == globalMax {
-		globalMax = globalMin + 1
+	// Staggered X axis labels take a row from the plot
+	useUnicode := l.shouldUseUnicode()
+	var ticks []xTick
+	if showXAxis {
+		ticks = l.xAxisTicks(allSeries)
+	}
+	if xLabelRows(ticks, chartWidth, useUnicode) > 1 && chartHeight > 3 {
+		chartHeight--
+		yLabels, yAxisWidth = yAxisLabels(l.opts, chartHeight, globalMin, globalMax)
+		chartWidth = internal.Max(width-yAxisWidth, 10)
 	}
 
 	// Get styling
-	useUnicode := l.shouldUseUnicode()
 	colorEnabled := l.isColorEnabled()
-	theme := l.opts.Theme
-	if theme == nil {
-		theme = DefaultTheme
-	}
 
 	// Create the chart grid
-	grid := make([][]rune, chartHeight)
-	colors := make([][]string, chartHeight)
-	for i := range grid {
-		grid[i] = make([]rune, chartWidth)
-		colors[i] = make([]string, chartWidth)
-		for j := range grid[i] {
-			grid[i][j] = ' '
+	cells := getGrid(chartWidth, chartHeight)
+	defer putGrid(cells)
+	grid, colors := cells.rows, cells.colorRows
+
+	// Shade confidence bands and filled areas, then draw each series over them
+	seriesColors := make([]string, len(projected))
+	for seriesIdx, series := range projected {
+		seriesColors[seriesIdx] = series.Color
+		if series.Color == "" {
+			seriesColors[seriesIdx] = theme.GetSeriesColor(seriesIdx)
 		}
 	}
-
-	// Render each series
-	for seriesIdx, series := range allSeries {
-		color := series.Color
-		if color == "" {
-			color = theme.GetSeriesColor(seriesIdx)
+	drawBandASCII(grid, colors, projected, chartWidth, chartHeight, globalMin, globalMax, useUnicode)
+	if areas := l.areaCells(projected, seriesColors, chartWidth, chartHeight, 1, globalMin, globalMax, useUnicode); areas != nil {
+		for y, row := range areas {
+			for x, area := range row {
+				if area.shade != 0 && grid[y][x] == ' ' {
+					grid[y][x], colors[y][x] = area.shade, area.color
+				}
+			}
 		}
-
-		l.renderSeriesASCII(grid, colors, series.Data, chartWidth, chartHeight, globalMin, globalMax, useUnicode, color)
+	}
+	for seriesIdx, series := range projected {
+		l.renderSeriesASCII(grid, colors, l.opts.downsample(series.Data, chartWidth), chartWidth, chartHeight, globalMin, globalMax, useUnicode, seriesColors[seriesIdx])
 	}
 
 	// Build result
-	var result strings.Builder
+	result := getBuffer()
+	defer putBuffer(result)
+	result.Grow(frameBytes(yAxisWidth+chartWidth, chartHeight))
 
 	// Render title if provided
 	if l.opts.Title != "" {
 		titleText := l.opts.Title
 		if colorEnabled {
-			titleText = Colorize(titleText, theme.Text, true)
+			titleText = Colorize(titleText, l.opts.titleColor(theme), true)
 		}
 		result.WriteString(titleText)
 		result.WriteString("\n")
 	}
 
 	// Render chart rows
+	run := newColorRun(result)
+	muted := ""
+	if colorEnabled {
+		muted = l.opts.axisColor(theme)
+	}
 	for row := 0; row < chartHeight; row++ {
 		// Y axis label
-		if l.opts.ShowAxes {
-			// Calculate value at this row
-			rowValue := globalMax - (float64(row)/float64(chartHeight-1))*(globalMax-globalMin)
-			label := fmt.Sprintf("%7.1f ", rowValue)
-			if colorEnabled {
-				label = Colorize(label, theme.Muted, true)
-			}
-			result.WriteString(label)
+		if yLabels != nil {
+			writeYLabel(run, yLabels[row], yAxisWidth, muted)
 		}
 
-		// Chart content
+		// Chart content, one color sequence per run of same-colored cells
 		for col := 0; col < chartWidth; col++ {
-			char := string(grid[row][col])
-			if colorEnabled && colors[row][col] != "" {
-				char = Colorize(char, colors[row][col], true)
+			color := ""
+			if colorEnabled {
+				color = colors[row][col]
 			}
-			result.WriteString(char)
+			run.writeRune(grid[row][col], color)
 		}
+		run.end()
 		result.WriteString("\n")
 	}
 
 	// Render X axis if showing axes
-	if l.opts.ShowAxes {
+	if showXAxis {
 		// Axis line
 		if yAxisWidth > 0 {
 			result.WriteString(strings.Repeat(" ", yAxisWidth))
@@ -227,43 +362,25 @@ func (l *LineChart) renderASCII(allSeries []Series) string {
 			axisLine = strings.Repeat("-", chartWidth)
 		}
 		if colorEnabled {
-			axisLine = Colorize(axisLine, theme.Muted, true)
+			axisLine = Colorize(axisLine, l.opts.axisColor(theme), true)
 		}
 		result.WriteString(axisLine)
 		result.WriteString("\n")
 
 		// X axis labels
-		if len(l.opts.Labels) > 0 {
-			if yAxisWidth > 0 {
-				result.WriteString(strings.Repeat(" ", yAxisWidth))
-			}
-			l.renderXAxisLabels(&result, chartWidth, colorEnabled, theme)
-			result.WriteString("\n")
+		if len(ticks) > 0 {
+			renderXAxisLabels(result, ticks, yAxisWidth, chartWidth, useUnicode, colorEnabled, l.opts.axisColor(theme))
 		}
 	}
 
 	// Render legend for multi-series
 	if len(allSeries) > 1 {
-		result.WriteString("\n")
-		for i, series := range allSeries {
-			color := series.Color
-			if color == "" {
-				color = theme.GetSeriesColor(i)
-			}
-			marker := "●"
-			if !useUnicode {
-				marker = "*"
-			}
-			if colorEnabled {
-				marker = Colorize(marker, color, true)
-			}
-			label := series.Label
-			if label == "" {
-				label = fmt.Sprintf("Series %d", i+1)
-			}
-			result.WriteString(fmt.Sprintf("%s %s  ", marker, label))
+		marker := "●"
+		if !useUnicode {
+			marker = "*"
 		}
 		result.WriteString("\n")
+		result.WriteString(chartLegend(l.opts, allSeries, marker, colorEnabled, theme).Render())
 	}
 
 	return result.String()
@@ -292,16 +409,22 @@ func (l *LineChart) renderSeriesASCII(grid [][]rune, colors [][]string, data []f
 		points[i] = [2]int{x, y}
 	}
 
-	// Draw lines between consecutive points
+	// Draw lines between consecutive points, leaving gaps at missing ones
 	for i := 0; i < len(points)-1; i++ {
+		if missingPoint(data[i]) || missingPoint(data[i+1]) {
+			continue
+		}
 		x1, y1 := points[i][0], points[i][1]
 		x2, y2 := points[i+1][0], points[i+1][1]
 
-		l.drawLine(grid, colors, x1, y1, x2, y2, useUnicode, color)
+		drawLine(grid, colors, x1, y1, x2, y2, useUnicode, color)
 	}
 
 	// Draw data points
-	for _, p := range points {
+	for i, p := range points {
+		if missingPoint(data[i]) {
+			continue
+		}
 		x, y := p[0], p[1]
 		if useUnicode {
 			grid[y][x] = lineDot
@@ -313,7 +436,7 @@ func (l *LineChart) renderSeriesASCII(grid [][]rune, colors [][]string, data []f
 }
 
 // drawLine draws a line between two points using Bresenham-style algorithm.
-func (l *LineChart) drawLine(grid [][]rune, colors [][]string, x1, y1, x2, y2 int, useUnicode bool, color string) {
+func drawLine(grid [][]rune, colors [][]string, x1, y1, x2, y2 int, useUnicode bool, color string) {
 	dx := internal.Abs(x2 - x1)
 	dy := internal.Abs(y2 - y1)
 
@@ -331,8 +454,9 @@ func (l *LineChart) drawLine(grid [][]rune, colors [][]string, x1, y1, x2, y2 in
 	x, y := x1, y1
 	for {
 		// Choose character based on direction
-		char := l.getLineChar(x, y, x1, y1, x2, y2, useUnicode)
-		if grid[y][x] == ' ' || grid[y][x] == lineHorizontal || grid[y][x] == asciiHorizontal {
+		char := getLineChar(x, y, x1, y1, x2, y2, useUnicode)
+		if c := grid[y][x]; c == ' ' || c == lineHorizontal || c == asciiHorizontal || isShade(c) {
+			// Lines draw over empty cells, flat segments, bands, and areas
 			grid[y][x] = char
 			colors[y][x] = color
 		}
@@ -354,7 +478,7 @@ func (l *LineChart) drawLine(grid [][]rune, colors [][]string, x1, y1, x2, y2 in
 }
 
 // getLineChar returns the appropriate character for a line segment.
-func (l *LineChart) getLineChar(x, y, x1, y1, x2, y2 int, useUnicode bool) rune {
+func getLineChar(x, y, x1, y1, x2, y2 int, useUnicode bool) rune {
 	dy := y2 - y1
 	dx := x2 - x1
 
@@ -389,212 +513,390 @@ func (l *LineChart) getLineChar(x, y, x1, y1, x2, y2 int, useUnicode bool) rune
 	return asciiUp
 }
 
-// renderXAxisLabels renders X axis labels.
-func (l *LineChart) renderXAxisLabels(result *strings.Builder, width int, colorEnabled bool, theme *Theme) {
+// xTick is a label placed at a fraction of the X axis width.
+type xTick struct {
+	pos   float64
+	label string
+}
+
+// xAxisTicks returns the X axis labels to draw. Labels set with WithLabels
+// are thinned to the configured tick count; without labels, index labels
+// are generated when a tick count is configured.
+func (l *LineChart) xAxisTicks(allSeries []Series) []xTick {
+	axis := l.opts.XAxis
 	labels := l.opts.Labels
+
 	if len(labels) == 0 {
-		return
+		points := 0
+		for _, series := range allSeries {
+			if len(series.Data) > points {
+				points = len(series.Data)
+			}
+		}
+		if axis.Ticks <= 0 || points == 0 {
+			return nil
+		}
+
+		ticks := axis.Ticks
+		if ticks > points {
+			ticks = points
+		}
+		result := make([]xTick, 0, ticks)
+		for k := 0; k < ticks; k++ {
+			idx := tickPosition(k, ticks, points)
+			result = append(result, xTick{
+				pos:   tickFraction(idx, points),
+				label: axis.format(float64(idx), "%.0f", l.opts.Locale),
+			})
+		}
+		return result
 	}
 
-	// Distribute labels across width
-	labelPositions := make([]int, len(labels))
-	for i := range labels {
-		labelPositions[i] = int(float64(i) / float64(len(labels)-1) * float64(width-1))
-		if len(labels) == 1 {
-			labelPositions[i] = width / 2
+	result := make([]xTick, 0, len(labels))
+	for i, label := range labels {
+		if axis.isTick(i, len(labels)) {
+			result = append(result, xTick{pos: tickFraction(i, len(labels)), label: label})
 		}
 	}
+	return result
+}
 
-	// Build label line
-	line := make([]byte, width)
-	for i := range line {
-		line[i] = ' '
+// tickFraction returns the position of item i of n as a fraction of the axis.
+func tickFraction(i, n int) float64 {
+	if n == 1 {
+		return 0.5
 	}
+	return float64(i) / float64(n-1)
+}
 
-	for i, label := range labels {
-		pos := labelPositions[i]
-		// Center the label around the position
-		start := pos - len(label)/2
-		if start < 0 {
-			start = 0
-		}
-		if start+len(label) > width {
-			start = width - len(label)
-		}
-		for j, c := range label {
-			if start+j < width {
-				line[start+j] = byte(c)
+// writeYLabel writes a y axis label right-aligned in an axis column of
+// axisWidth columns, including the space before the plot, as its own run.
+func writeYLabel(run *colorRun, label string, axisWidth int, color string) {
+	run.writeRepeat(' ', axisWidth-1-internal.StringWidth(label), color)
+	run.writeString(label, color)
+	run.writeRune(' ', color)
+	run.end()
+}
+
+// xLabel is a tick label placed on an X axis label line.
+type xLabel struct {
+	start int
+	text  string
+}
+
+// xLabelSpan returns the columns a tick label of labelWidth columns covers
+// when centered on its tick and kept within width columns, end exclusive.
+func xLabelSpan(pos float64, labelWidth, width int) (start, end int) {
+	start = int(pos*float64(width-1)) - labelWidth/2
+	if start+labelWidth > width {
+		start = width - labelWidth
+	}
+	if start < 0 {
+		start = 0
+	}
+	return start, start + labelWidth
+}
+
+// xLabelRows returns the number of lines the tick labels need within width
+// columns, once thinned by thinXTicks: 1 when each label is at least a column
+// apart from the next, or 2 when alternate labels must be staggered onto a
+// second line.
+func xLabelRows(ticks []xTick, width int, useUnicode bool) int {
+	return staggerRows(thinXTicks(ticks, width, useUnicode), width, useUnicode)
+}
+
+// thinXTicks returns every nth tick, starting with the first, for the
+// smallest n that lets each label be drawn whole, so crowded labels, such as
+// 60 daily labels on a 60-column chart, are thinned rather than truncated
+// into unreadable fragments. A label wider than the chart is still truncated.
+func thinXTicks(ticks []xTick, width int, useUnicode bool) []xTick {
+	for n := 1; n < len(ticks); n++ {
+		kept := ticks
+		if n > 1 {
+			kept = make([]xTick, 0, (len(ticks)+n-1)/n)
+			for i := 0; i < len(ticks); i += n {
+				kept = append(kept, ticks[i])
 			}
 		}
+		if xLabelsFit(kept, width, useUnicode) {
+			return kept
+		}
 	}
+	if len(ticks) > 1 {
+		return ticks[:1]
+	}
+	return ticks
+}
 
-	text := string(line)
-	if colorEnabled {
-		text = Colorize(text, theme.Muted, true)
+// xLabelsFit reports whether every tick label is drawn whole, or truncated
+// only to the chart width, when laid out within width columns.
+func xLabelsFit(ticks []xTick, width int, useUnicode bool) bool {
+	rows := staggerRows(ticks, width, useUnicode)
+	lines := placeXLabels(ticks, rows, width, useUnicode)
+	for row, line := range lines {
+		for k, label := range line {
+			if label.text != truncateLabel(ticks[row+k*rows].label, width, useUnicode) {
+				return false
+			}
+		}
+		if want := (len(ticks) - row + rows - 1) / rows; len(line) != want {
+			return false
+		}
 	}
-	result.WriteString(text)
+	return true
 }
 
-// renderBraille renders the line chart using high-resolution Braille patterns.
+// staggerRows returns the number of lines ticks need within width columns,
+// without thinning.
+func staggerRows(ticks []xTick, width int, useUnicode bool) int {
+	prevEnd := -1
+	for _, tick := range ticks {
+		label := truncateLabel(tick.label, width, useUnicode)
+		start, end := xLabelSpan(tick.pos, internal.StringWidth(label), width)
+		if start <= prevEnd {
+			return 2
+		}
+		prevEnd = end
+	}
+	return 1
+}
+
+// layoutXLabels places tick labels on the lines they need. Labels that would
+// touch a neighbour are staggered across two lines, alternating; if they
+// still do not fit, only every nth label is drawn (see thinXTicks).
+func layoutXLabels(ticks []xTick, width int, useUnicode bool) [][]xLabel {
+	ticks = thinXTicks(ticks, width, useUnicode)
+	return placeXLabels(ticks, staggerRows(ticks, width, useUnicode), width, useUnicode)
+}
+
+// placeXLabels places tick labels on rows lines, alternating. Labels that are
+// too wide for the space between their neighbours on a line are truncated, so
+// no label overwrites another.
+func placeXLabels(ticks []xTick, rows, width int, useUnicode bool) [][]xLabel {
+	lines := make([][]xLabel, rows)
+	for row := range lines {
+		var line []xTick
+		for i := row; i < len(ticks); i += rows {
+			line = append(line, ticks[i])
+		}
+
+		prevEnd := -1
+		center := func(t xTick) int { return int(t.pos * float64(width-1)) }
+		for i, tick := range line {
+			// Columns between this tick and its nearest neighbour on the line
+			room := width
+			if i > 0 {
+				room = internal.Min(room, center(tick)-center(line[i-1])-1)
+			}
+			if i < len(line)-1 {
+				room = internal.Min(room, center(line[i+1])-center(tick)-1)
+			}
+			label := truncateLabel(tick.label, internal.Max(room, 1), useUnicode)
+
+			start, _ := xLabelSpan(tick.pos, internal.StringWidth(label), width)
+			if start <= prevEnd {
+				start = prevEnd + 1
+			}
+			if start >= width {
+				break
+			}
+			label = truncateLabel(label, width-start, useUnicode)
+			lines[row] = append(lines[row], xLabel{start: start, text: label})
+			prevEnd = start + internal.StringWidth(label)
+		}
+	}
+	return lines
+}
+
+// renderXAxisLabels renders the X axis tick labels below a plot width columns
+// wide, indented by indent columns, one line per line of labels.
+func renderXAxisLabels(result *bytes.Buffer, ticks []xTick, indent, width int, useUnicode, colorEnabled bool, color string) {
+	for _, labels := range layoutXLabels(ticks, width, useUnicode) {
+		// Build the line one cell per column. Wide characters occupy their
+		// first column; the column after them holds an empty placeholder.
+		line := make([]string, width)
+		for i := range line {
+			line[i] = " "
+		}
+		for _, label := range labels {
+			col := label.start
+			for _, g := range internal.Graphemes(label.text) {
+				w := internal.StringWidth(g)
+				if w == 0 {
+					continue
+				}
+				line[col] = g
+				for ; w > 1; w-- {
+					col++
+					line[col] = ""
+				}
+				col++
+			}
+		}
+
+		text := strings.Join(line, "")
+		if colorEnabled {
+			text = Colorize(text, color, true)
+		}
+		result.WriteString(strings.Repeat(" ", indent))
+		result.WriteString(text)
+		result.WriteString("\n")
+	}
+}
+
+// renderDots renders the line chart at sub-cell resolution, drawing each
+// character cell's dots with Braille patterns, quadrant blocks, or sextants.
 //
 //nolint:gocyclo // Complex rendering logic
-func (l *LineChart) renderBraille(allSeries []Series) string {
+func (l *LineChart) renderDots(allSeries []Series, cell dotCell) string {
 	// Determine dimensions
 	width := l.opts.Width
 	height := l.opts.Height
 
+	showXAxis := l.opts.ShowAxes && !l.opts.XAxis.Hidden
+
 	// Reserve space for title
 	chartHeight := height
 	if l.opts.Title != "" {
 		chartHeight--
 	}
-	if l.opts.ShowAxes {
+	if showXAxis {
 		chartHeight -= 2
 	}
 	if chartHeight < 3 {
-		chartHeight = 10
+		chartHeight = 3
 	}
 
-	// Calculate chart width
-	chartWidth := width
-	yAxisWidth := 0
-	if l.opts.ShowAxes {
-		yAxisWidth = 8
-		chartWidth -= yAxisWidth
+	theme := l.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
 	}
+
+	// Map the plotted series onto the Y axis and find its range
+	projected, globalMin, globalMax := l.projectSeries(l.opts.visibleSeries(allSeries, theme))
+
+	// Calculate chart width
+	yLabels, yAxisWidth := yAxisLabels(l.opts, chartHeight, globalMin, globalMax)
+	chartWidth := width - yAxisWidth
 	if chartWidth < 10 {
-		chartWidth = 60
+		chartWidth = 10
 	}
 
-	// Braille resolution: each character is 2x4 dots
-	brailleWidth := chartWidth
-	brailleHeight := chartHeight * 4 // 4 vertical dots per character
-
-	// Find global min/max
-	globalMin, globalMax := l.findGlobalMinMax(allSeries)
-	if globalMin == globalMax {
-		globalMax = globalMin + 1
+	// Staggered X axis labels take a row from the plot
+	var ticks []xTick
+	if showXAxis {
+		ticks = l.xAxisTicks(allSeries)
+	}
+	if xLabelRows(ticks, chartWidth, true) > 1 && chartHeight > 3 {
+		chartHeight--
+		yLabels, yAxisWidth = yAxisLabels(l.opts, chartHeight, globalMin, globalMax)
+		chartWidth = internal.Max(width-yAxisWidth, 10)
 	}
 
+	// Sub-cell resolution, such as 2x4 dots per character for Braille
+	dotWidth := chartWidth * cell.cols
+	dotHeight := chartHeight * cell.rows
+
 	// Get styling
 	colorEnabled := l.isColorEnabled()
-	theme := l.opts.Theme
-	if theme == nil {
-		theme = DefaultTheme
-	}
-
-	// Create Braille dot grid
-	dotGrid := make([][]bool, brailleHeight)
-	for i := range dotGrid {
-		dotGrid[i] = make([]bool, brailleWidth*2) // 2 horizontal dots per char
-	}
 
-	// Create color grid for each character cell
-	colorGrid := make([][]string, chartHeight)
-	for i := range colorGrid {
-		colorGrid[i] = make([]string, chartWidth)
-	}
+	// Create the dot grid with a color for each character cell
+	dots := getDotGrid(dotWidth, dotHeight, chartWidth, chartHeight)
+	defer putDotGrid(dots)
+	dotGrid, colorGrid := dots.rows, dots.colorRows
 
 	// Render each series
-	for seriesIdx, series := range allSeries {
+	raster := make([]brailleSeries, len(projected))
+	seriesColors := make([]string, len(projected))
+	for seriesIdx, series := range projected {
 		color := series.Color
 		if color == "" {
 			color = theme.GetSeriesColor(seriesIdx)
 		}
-
-		l.renderSeriesBraille(dotGrid, colorGrid, series.Data, brailleWidth*2, brailleHeight, chartWidth, chartHeight, globalMin, globalMax, color)
+		raster[seriesIdx] = brailleSeries{data: l.opts.downsample(series.Data, dotWidth), color: color}
+		seriesColors[seriesIdx] = color
 	}
+	workers := brailleWorkers(len(raster), dotWidth*dotHeight)
+	l.rasterizeBraille(dots, raster, chartWidth, chartHeight, globalMin, globalMax, workers)
+	bands := bandCells(projected, chartWidth, chartHeight, cell.rows, globalMin, globalMax)
+	areas := l.areaCells(projected, seriesColors, chartWidth, chartHeight, cell.rows, globalMin, globalMax, true)
 
 	// Build result
-	var result strings.Builder
+	result := getBuffer()
+	defer putBuffer(result)
+	result.Grow(frameBytes(yAxisWidth+chartWidth, chartHeight))
 
 	// Render title if provided
 	if l.opts.Title != "" {
 		titleText := l.opts.Title
 		if colorEnabled {
-			titleText = Colorize(titleText, theme.Text, true)
+			titleText = Colorize(titleText, l.opts.titleColor(theme), true)
 		}
 		result.WriteString(titleText)
 		result.WriteString("\n")
 	}
 
-	// Convert dot grid to Braille characters
+	// Convert the dot grid to characters
+	run := newColorRun(result)
+	muted := ""
+	if colorEnabled {
+		muted = l.opts.axisColor(theme)
+	}
 	for row := 0; row < chartHeight; row++ {
 		// Y axis label
-		if l.opts.ShowAxes {
-			rowValue := globalMax - (float64(row)/float64(chartHeight-1))*(globalMax-globalMin)
-			label := fmt.Sprintf("%7.1f ", rowValue)
-			if colorEnabled {
-				label = Colorize(label, theme.Muted, true)
-			}
-			result.WriteString(label)
+		if yLabels != nil {
+			writeYLabel(run, yLabels[row], yAxisWidth, muted)
 		}
 
-		// Chart content
+		// Chart content, one color sequence per run of same-colored cells
 		for col := 0; col < chartWidth; col++ {
-			// Calculate Braille pattern for this cell
+			// Calculate the dot pattern for this cell
 			pattern := 0
-			for dotRow := 0; dotRow < 4; dotRow++ {
-				for dotCol := 0; dotCol < 2; dotCol++ {
-					gridRow := row*4 + dotRow
-					gridCol := col*2 + dotCol
-					if gridRow < brailleHeight && gridCol < brailleWidth*2 {
-						if dotGrid[gridRow][gridCol] {
-							pattern |= brailleDots[dotRow][dotCol]
-						}
+			for dotRow := 0; dotRow < cell.rows; dotRow++ {
+				for dotCol := 0; dotCol < cell.cols; dotCol++ {
+					if dotGrid[row*cell.rows+dotRow][col*cell.cols+dotCol] {
+						pattern |= cell.bits[dotRow][dotCol]
 					}
 				}
 			}
 
-			char := string(rune(brailleBase + pattern))
-			if colorEnabled && colorGrid[row][col] != "" {
-				char = Colorize(char, colorGrid[row][col], true)
+			// Confidence bands, then filled areas, shade the cells the lines leave empty
+			char, color := cell.glyph(pattern), colorGrid[row][col]
+			if pattern == 0 && bands != nil && bands[row][col] != "" {
+				char, color = bandShade, bands[row][col]
+			} else if pattern == 0 && areas != nil && areas[row][col].shade != 0 {
+				char, color = areas[row][col].shade, areas[row][col].color
+			}
+			if !colorEnabled {
+				color = ""
 			}
-			result.WriteString(char)
+			run.writeRune(char, color)
 		}
+		run.end()
 		result.WriteString("\n")
 	}
 
 	// Render X axis if showing axes
-	if l.opts.ShowAxes {
+	if showXAxis {
 		if yAxisWidth > 0 {
 			result.WriteString(strings.Repeat(" ", yAxisWidth))
 		}
 		axisLine := strings.Repeat("─", chartWidth)
 		if colorEnabled {
-			axisLine = Colorize(axisLine, theme.Muted, true)
+			axisLine = Colorize(axisLine, l.opts.axisColor(theme), true)
 		}
 		result.WriteString(axisLine)
 		result.WriteString("\n")
 
-		if len(l.opts.Labels) > 0 {
-			if yAxisWidth > 0 {
-				result.WriteString(strings.Repeat(" ", yAxisWidth))
-			}
-			l.renderXAxisLabels(&result, chartWidth, colorEnabled, theme)
-			result.WriteString("\n")
+		if len(ticks) > 0 {
+			renderXAxisLabels(result, ticks, yAxisWidth, chartWidth, true, colorEnabled, l.opts.axisColor(theme))
 		}
 	}
 
 	// Render legend for multi-series
 	if len(allSeries) > 1 {
 		result.WriteString("\n")
-		for i, series := range allSeries {
-			color := series.Color
-			if color == "" {
-				color = theme.GetSeriesColor(i)
-			}
-			marker := "●"
-			if colorEnabled {
-				marker = Colorize(marker, color, true)
-			}
-			label := series.Label
-			if label == "" {
-				label = fmt.Sprintf("Series %d", i+1)
-			}
-			result.WriteString(fmt.Sprintf("%s %s  ", marker, label))
-		}
-		result.WriteString("\n")
+		result.WriteString(chartLegend(l.opts, allSeries, "●", colorEnabled, theme).Render())
 	}
 
 	return result.String()
@@ -608,6 +910,9 @@ func (l *LineChart) renderSeriesBraille(dotGrid [][]bool, colorGrid [][]string,
 
 	// Map data points to dot coordinates
 	for i := 0; i < len(data)-1; i++ {
+		if missingPoint(data[i]) || missingPoint(data[i+1]) {
+			continue
+		}
 		// Start point
 		x1 := int(float64(i) / float64(len(data)-1) * float64(dotWidth-1))
 		y1 := int((maxVal - data[i]) / (maxVal - minVal) * float64(dotHeight-1))
@@ -621,7 +926,20 @@ func (l *LineChart) renderSeriesBraille(dotGrid [][]bool, colorGrid [][]string,
 		x2 = internal.ClampInt(x2, 0, dotWidth-1)
 
 		// Draw line between points using Bresenham
-		l.drawBrailleLine(dotGrid, colorGrid, x1, y1, x2, y2, charWidth, charHeight, color)
+		drawBrailleLine(dotGrid, colorGrid, x1, y1, x2, y2, charWidth, charHeight, color)
+	}
+
+	// Points between gaps have no segment to draw them
+	for i := 0; i < len(data) && len(data) > 1; i++ {
+		if missingPoint(data[i]) || (i > 0 && !missingPoint(data[i-1])) || (i < len(data)-1 && !missingPoint(data[i+1])) {
+			continue
+		}
+		x := int(float64(i) / float64(len(data)-1) * float64(dotWidth-1))
+		y := int((maxVal - data[i]) / (maxVal - minVal) * float64(dotHeight-1))
+		y = internal.ClampInt(y, 0, dotHeight-1)
+		x = internal.ClampInt(x, 0, dotWidth-1)
+		dotGrid[y][x] = true
+		colorGrid[y*charHeight/dotHeight][x*charWidth/dotWidth] = color
 	}
 
 	// Ensure single point is drawn
@@ -630,12 +948,88 @@ func (l *LineChart) renderSeriesBraille(dotGrid [][]bool, colorGrid [][]string,
 		y := int((maxVal - data[0]) / (maxVal - minVal) * float64(dotHeight-1))
 		y = internal.ClampInt(y, 0, dotHeight-1)
 		dotGrid[y][x] = true
-		colorGrid[y/4][x/2] = color
+		colorGrid[y*charHeight/dotHeight][x*charWidth/dotWidth] = color
 	}
 }
 
+// minParallelDots is the work, in dots times series, below which series are
+// rasterized one after another; smaller charts render faster without the
+// goroutines and the merge.
+const minParallelDots = 1 << 18
+
+// brailleSeries is a series ready to draw on the Braille dot grid.
+type brailleSeries struct {
+	data  []float64
+	color string
+}
+
+// brailleWorkers returns the number of goroutines to rasterize series series
+// onto a grid of dots dots with.
+func brailleWorkers(series, dots int) int {
+	workers := runtime.GOMAXPROCS(0)
+	if series < workers {
+		workers = series
+	}
+	if workers < 2 || series*dots < minParallelDots {
+		return 1
+	}
+	return workers
+}
+
+// rasterizeBraille draws the series onto dots, using up to workers
+// goroutines. Each worker draws a contiguous run of series onto its own grid,
+// and the grids are then merged in series order, so a cell shared by several
+// series takes the color of the last one, as when they are drawn in turn.
+func (l *LineChart) rasterizeBraille(dots *dotGrid, series []brailleSeries, charWidth, charHeight int, minVal, maxVal float64, workers int) {
+	dotWidth, dotHeight := len(dots.rows[0]), len(dots.rows)
+	draw := func(g *dotGrid, run []brailleSeries) {
+		for _, s := range run {
+			l.renderSeriesBraille(g.rows, g.colorRows, s.data, dotWidth, dotHeight, charWidth, charHeight, minVal, maxVal, s.color)
+		}
+	}
+	if workers < 2 {
+		draw(dots, series)
+		return
+	}
+
+	// The first run is drawn straight onto dots
+	grids := make([]*dotGrid, workers)
+	grids[0] = dots
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		run := series[w*len(series)/workers : (w+1)*len(series)/workers]
+		if w > 0 {
+			grids[w] = getDotGrid(dotWidth, dotHeight, charWidth, charHeight)
+			defer putDotGrid(grids[w])
+		}
+		wg.Add(1)
+		go func(g *dotGrid) {
+			defer wg.Done()
+			draw(g, run)
+		}(grids[w])
+	}
+	wg.Wait()
+
+	// Merge bands of character rows in parallel
+	band := (charHeight + workers - 1) / workers
+	for start := 0; start < charHeight; start += band {
+		end := start + band
+		if end > charHeight {
+			end = charHeight
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for _, g := range grids[1:] {
+				dots.merge(g, start, end)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+}
+
 // drawBrailleLine draws a line on the Braille dot grid.
-func (l *LineChart) drawBrailleLine(dotGrid [][]bool, colorGrid [][]string, x1, y1, x2, y2, charWidth, charHeight int, color string) {
+func drawBrailleLine(dotGrid [][]bool, colorGrid [][]string, x1, y1, x2, y2, charWidth, charHeight int, color string) {
 	dx := internal.Abs(x2 - x1)
 	dy := internal.Abs(y2 - y1)
 
@@ -655,8 +1049,8 @@ func (l *LineChart) drawBrailleLine(dotGrid [][]bool, colorGrid [][]string, x1,
 		if y >= 0 && y < len(dotGrid) && x >= 0 && x < len(dotGrid[0]) {
 			dotGrid[y][x] = true
 			// Set color for the character cell
-			charRow := y / 4
-			charCol := x / 2
+			charRow := y * charHeight / len(dotGrid)
+			charCol := x * charWidth / len(dotGrid[0])
 			if charRow < charHeight && charCol < charWidth {
 				colorGrid[charRow][charCol] = color
 			}
@@ -678,13 +1072,147 @@ func (l *LineChart) drawBrailleLine(dotGrid [][]bool, colorGrid [][]string, x1,
 	}
 }
 
-// findGlobalMinMax finds the min and max values across all series.
+// findGlobalMinMax finds the Y axis range across all series and their
+// confidence bands, honoring a range set with WithYAxis.
 func (l *LineChart) findGlobalMinMax(allSeries []Series) (float64, float64) {
-	var allData []float64
+	sets := make([][]float64, 0, len(allSeries))
 	for _, series := range allSeries {
-		allData = append(allData, series.Data...)
+		sets = append(sets, series.Data, series.Upper, series.Lower)
 	}
-	return internal.MinMax(allData)
+	return l.opts.YAxis.resolveRange(sets...)
+}
+
+// projectSeries resolves the Y axis range and maps every series into axis
+// space, where the renderers can treat values as linear, lining them up
+// with WithAlign and stacking them first with WithStacking. It returns the
+// projected series and the projected axis range.
+func (l *LineChart) projectSeries(allSeries []Series) ([]Series, float64, float64) {
+	allSeries = alignSeries(allSeries, l.opts.Align, math.NaN())
+	if l.opts.Stacked {
+		allSeries = stackSeries(allSeries)
+	}
+	if l.opts.SeparateScales {
+		return normalizeSeries(allSeries), 0, 1
+	}
+	axis := l.opts.YAxis
+
+	min, max := l.findGlobalMinMax(allSeries)
+	lo, hi := axis.project(min), axis.project(max)
+	if lo == hi {
+		hi = lo + 1
+	}
+	lo, hi = l.opts.yTickRange(lo, hi)
+
+	if axis.Scale != ScaleLog {
+		// Linear values are already in axis space
+		return allSeries, lo, hi
+	}
+
+	project := func(values []float64) []float64 {
+		if values == nil {
+			return nil
+		}
+		data := make([]float64, len(values))
+		for j, v := range values {
+			data[j] = axis.project(v)
+			if math.IsInf(data[j], -1) {
+				// Non-positive values on a log axis sit at its minimum
+				data[j] = lo
+			}
+		}
+		return data
+	}
+	projected := make([]Series, len(allSeries))
+	for i, series := range allSeries {
+		projected[i] = Series{
+			Label: series.Label,
+			Data:  project(series.Data),
+			Color: series.Color,
+			Upper: project(series.Upper),
+			Lower: project(series.Lower),
+		}
+	}
+	return projected, lo, hi
+}
+
+// normalizeSeries scales each series, with its confidence band, to the range
+// 0 to 1 between its own minimum and maximum. A flat series is drawn across
+// the middle.
+func normalizeSeries(allSeries []Series) []Series {
+	normalized := make([]Series, len(allSeries))
+	for i, series := range allSeries {
+		lo, hi := AxisConfig{}.resolveRange(series.Data, series.Upper, series.Lower)
+		scale := func(values []float64) []float64 {
+			if values == nil {
+				return nil
+			}
+			data := make([]float64, len(values))
+			for j, v := range values {
+				if hi > lo {
+					data[j] = (v - lo) / (hi - lo)
+				} else {
+					data[j] = 0.5
+				}
+			}
+			return data
+		}
+		normalized[i] = Series{
+			Label: series.Label,
+			Data:  scale(series.Data),
+			Color: series.Color,
+			Upper: scale(series.Upper),
+			Lower: scale(series.Lower),
+		}
+	}
+	return normalized
+}
+
+// yAxisLabels returns the Y axis label for each chart row and the width of the
+// label column, including the trailing space. Rows without a tick have an
+// empty label. It returns nil and 0 when the Y axis is not shown.
+func yAxisLabels(opts *Options, rows int, lo, hi float64) ([]string, int) {
+	axis := opts.YAxis
+	if !opts.ShowAxes || axis.Hidden || opts.SeparateScales {
+		return nil, 0
+	}
+
+	labels := make([]string, rows)
+	width := 7
+	if opts.YTicks > 0 && axis.Scale == ScaleLinear {
+		// Label the rows nearest each multiple of a round step
+		first, last, step := niceTicks(lo, hi, opts.YTicks, !axis.fixedRange())
+		def := fmt.Sprintf("%%.%df", stepDecimals(step))
+		for v := first; v <= last+step*1e-9; v += step {
+			row := int(math.Round((hi - v) / (hi - lo) * float64(rows-1)))
+			if row < 0 || row >= rows || labels[row] != "" {
+				continue
+			}
+			tick := math.Round(v/step) * step
+			if tick == 0 {
+				tick = 0 // Not -0
+			}
+			labels[row] = withUnit(axis.format(tick, def, opts.Locale), opts.YUnit)
+			if w := internal.StringWidth(labels[row]); w > width {
+				width = w
+			}
+		}
+		return labels, width + 1
+	}
+	if opts.YTicks > 0 {
+		axis.Ticks = opts.YTicks
+	}
+	for row := range labels {
+		if !axis.isTick(row, rows) {
+			continue
+		}
+		// Calculate value at this row
+		value := hi - (float64(row)/float64(rows-1))*(hi-lo)
+		labels[row] = withUnit(axis.format(axis.unproject(value), "%.1f", opts.Locale), opts.YUnit)
+		if w := internal.StringWidth(labels[row]); w > width {
+			width = w
+		}
+	}
+	return labels, width + 1
 }
 
 // shouldUseUnicode determines whether to use Unicode characters.
@@ -692,12 +1220,32 @@ func (l *LineChart) shouldUseUnicode() bool {
 	if l.opts.Style == StyleASCII {
 		return false
 	}
-	if l.opts.Style == StyleUnicode || l.opts.Style == StyleBraille {
+	if l.opts.Style == StyleUnicode || l.opts.Style.subCell() {
 		return true
 	}
 	return internal.SupportsUnicode()
 }
 
+// validateOptions reports options that line charts cannot honor.
+func (l *LineChart) validateOptions() error {
+	if err := l.opts.Validate(); err != nil {
+		return err
+	}
+	if l.opts.Direction == Vertical {
+		return conflict("line charts are always horizontal; remove WithDirection(Vertical)")
+	}
+	if l.opts.BarMode != BarModeGrouped {
+		return conflict("bar mode only applies to bar charts")
+	}
+	if l.opts.SeparateScales && (l.opts.YAxis.fixedRange() || l.opts.YAxis.Scale != ScaleLinear || l.opts.PercentAxis) {
+		return conflict("separate series scales cannot be combined with a fixed, log, or percent Y axis")
+	}
+	if l.opts.SeparateScales && l.opts.Stacked {
+		return conflict("stacked series share one scale; remove WithSharedScale(false) or WithStacking(true)")
+	}
+	return nil
+}
+
 // isColorEnabled determines whether colors should be used.
 func (l *LineChart) isColorEnabled() bool {
 	if l.opts.ColorEnabled != nil {
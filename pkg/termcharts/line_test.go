@@ -1,9 +1,15 @@
 package termcharts
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
 )
 
 func TestNewLineChart(t *testing.T) {
@@ -173,6 +179,63 @@ func TestLineChart_Render_BrailleMode(t *testing.T) {
 	}
 }
 
+func TestLineChart_Render_SubCellStyles(t *testing.T) {
+	tests := []struct {
+		name  string
+		style RenderStyle
+		want  string
+	}{
+		{
+			name:  "quadrant blocks",
+			style: StyleBlock2x2,
+			want: "       ▗▄▀\n" +
+				"     ▄▞▘  \n" +
+				"  ▗▞▀     \n" +
+				"▄▀▘       \n",
+		},
+		{
+			name:  "sextants",
+			style: StyleSextant,
+			want: "       🬞🬖🬅\n" +
+				"     🬭🬅🬀  \n" +
+				"  🬞🬖🬂     \n" +
+				"🬖🬅🬀       \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewLineChart(WithData([]float64{0, 1}), WithStyle(tt.style),
+				WithWidth(10), WithHeight(4), WithShowAxes(false), WithColor(false)).Render()
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSextantGlyph(t *testing.T) {
+	tests := []struct {
+		pattern int
+		want    rune
+	}{
+		{0, ' '},
+		{1, 0x1FB00},
+		{20, 0x1FB13},
+		{21, '▌'},
+		{22, 0x1FB14},
+		{42, '▐'},
+		{62, 0x1FB3B},
+		{63, '█'},
+	}
+
+	for _, tt := range tests {
+		if got := sextantGlyph(tt.pattern); got != tt.want {
+			t.Errorf("sextantGlyph(%d) = %U, want %U", tt.pattern, got, tt.want)
+		}
+	}
+}
+
 func TestLineChart_Render_InvalidData(t *testing.T) {
 	tests := []struct {
 		name string
@@ -300,6 +363,40 @@ func TestLineChart_Render_MultiSeries(t *testing.T) {
 	}
 }
 
+func TestLineChart_Render_SeparateScales(t *testing.T) {
+	for _, style := range []RenderStyle{StyleASCII, StyleBraille} {
+		t.Run(style.String(), func(t *testing.T) {
+			got := NewLineChart(
+				WithSeries([]Series{
+					{Label: "ms", Data: []float64{100, 300, 200, 500}},
+					{Label: "rps", Data: []float64{1, 1.5, 3, 2}},
+					{Label: "flat", Data: []float64{7, 7, 7, 7}},
+				}),
+				WithSharedScale(false), WithStyle(style), WithColor(false), WithWidth(40), WithHeight(10),
+			).Render()
+
+			// Each series spans the full height, and the value axis is hidden
+			want := NewLineChart(
+				WithSeries([]Series{
+					{Label: "ms", Data: []float64{0, 0.5, 0.25, 1}},
+					{Label: "rps", Data: []float64{0, 0.25, 1, 0.5}},
+					{Label: "flat", Data: []float64{0.5, 0.5, 0.5, 0.5}},
+				}),
+				WithYAxis(AxisConfig{Min: 0, Max: 1, Hidden: true}), WithStyle(style), WithColor(false), WithWidth(40), WithHeight(10),
+			).Render()
+			if got != want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+
+	_, err := NewLineChart(WithData([]float64{1, 2}), WithSharedScale(false), WithStrict(true),
+		WithYAxis(AxisConfig{Scale: ScaleLog})).RenderE()
+	if !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("RenderE() with a log axis error = %v, want %v", err, ErrConflictingOptions)
+	}
+}
+
 func TestLineChart_Render_MultiSeriesWithColor(t *testing.T) {
 	series := []Series{
 		{Label: "Sales", Data: []float64{10, 20, 15, 25}, Color: "red"},
@@ -504,3 +601,208 @@ func TestLineChart_getAllSeries(t *testing.T) {
 		}
 	})
 }
+
+func TestLineChart_Render_WideXAxisLabels(t *testing.T) {
+	line := NewLineChart(
+		WithData([]float64{1, 2, 3}),
+		WithLabels([]string{"一月", "二月", "三月"}),
+		WithShowAxes(true),
+		WithStyle(StyleUnicode),
+		WithColor(false),
+		WithWidth(30),
+		WithHeight(6),
+	)
+	lines := strings.Split(strings.TrimSuffix(line.Render(), "\n"), "\n")
+	axis := lines[len(lines)-2]
+	labels := lines[len(lines)-1]
+
+	for _, label := range []string{"一月", "二月", "三月"} {
+		if !strings.Contains(labels, label) {
+			t.Errorf("expected label %q in %q", label, labels)
+		}
+	}
+	// The last label ends flush with the axis line
+	if got, want := internal.StringWidth(labels), internal.StringWidth(axis); got != want {
+		t.Errorf("label line is %d columns wide, axis is %d", got, want)
+	}
+}
+
+func TestLineChart_Render_StaggeredXLabels(t *testing.T) {
+	labels := []string{"January", "February", "March", "April", "May", "June"}
+	opts := []LineOption{
+		WithData([]float64{1, 5, 2, 8, 3, 7}),
+		WithLabels(labels),
+		WithWidth(40),
+		WithHeight(10),
+		WithStyle(StyleASCII),
+		WithColor(false),
+	}
+
+	out := NewLineChart(opts...).Render()
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("got %d lines, want the chart height of 10:\n%s", len(lines), out)
+	}
+	// Overlapping labels alternate between two lines instead of overwriting each other
+	want := []string{
+		"        January   March        May      ",
+		"          February      April       June",
+	}
+	if got := lines[len(lines)-2:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("labels =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Labels that do not fit even when staggered are thinned, not truncated
+	out = NewLineChart(append(opts, WithData(make([]float64, 12)), WithLabels(append(labels, labels...)))...).Render()
+	lines = strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	for _, line := range lines[len(lines)-2:] {
+		if strings.Contains(line, ".") {
+			t.Errorf("expected whole labels in %q", line)
+		}
+	}
+}
+
+func TestThinXTicks(t *testing.T) {
+	daily := make([]xTick, 60)
+	for i := range daily {
+		daily[i] = xTick{pos: tickFraction(i, len(daily)), label: fmt.Sprintf("%02d", i+1)}
+	}
+
+	tests := []struct {
+		name  string
+		ticks []xTick
+		width int
+		want  []string
+	}{
+		{
+			name:  "labels that fit are kept",
+			ticks: []xTick{{pos: 0, label: "01"}, {pos: 0.5, label: "02"}, {pos: 1, label: "03"}},
+			width: 20,
+			want:  []string{"01", "02", "03"},
+		},
+		{
+			name:  "every nth label, staggered",
+			ticks: daily,
+			width: 60,
+			want:  []string{"01", "03", "05", "07", "09", "11", "13", "15", "17", "19", "21", "23", "25", "27", "29", "31", "33", "35", "37", "39", "41", "43", "45", "47", "49", "51", "53", "55", "57", "59"},
+		},
+		{
+			name:  "every nth label",
+			ticks: daily,
+			width: 30,
+			want:  []string{"01", "05", "09", "13", "17", "21", "25", "29", "33", "37", "41", "45", "49", "53", "57"},
+		},
+		{
+			name:  "label wider than the chart",
+			ticks: []xTick{{pos: 0, label: "first long label"}, {pos: 0.5, label: "second long label"}, {pos: 1, label: "third long label"}},
+			width: 10,
+			want:  []string{"first long label", "third long label"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tick := range thinXTicks(tt.ticks, tt.width, true) {
+				got = append(got, tick.label)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("thinXTicks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutXLabels(t *testing.T) {
+	ticks := []xTick{{pos: 0, label: "aaaa"}, {pos: 0.5, label: "bbbb"}, {pos: 1, label: "cccc"}}
+
+	// Labels with room keep one line
+	if got := layoutXLabels(ticks, 20, true); len(got) != 1 {
+		t.Errorf("layoutXLabels(20) = %d lines, want 1", len(got))
+	}
+
+	// Labels on a line never touch
+	for _, width := range []int{5, 8, 11} {
+		for _, line := range layoutXLabels(ticks, width, true) {
+			end := -1
+			for _, label := range line {
+				if label.start <= end || label.start+internal.StringWidth(label.text) > width {
+					t.Errorf("width %d: label %q at %d overlaps or overflows %v", width, label.text, label.start, line)
+				}
+				end = label.start + internal.StringWidth(label.text)
+			}
+		}
+	}
+}
+
+func TestLineChart_RasterizeBrailleParallel(t *testing.T) {
+	// Overlapping series drawn by several workers match drawing them in turn
+	series := make([]brailleSeries, 7)
+	for i := range series {
+		data := make([]float64, 300)
+		for j := range data {
+			data[j] = math.Sin(float64(j+i*7)/15) * float64(i+1)
+		}
+		series[i] = brailleSeries{data: data, color: []string{"red", "green", ""}[i%3]}
+	}
+
+	l := NewLineChart()
+	const width, height = 80, 12
+	render := func(workers int) *dotGrid {
+		g := getDotGrid(width*2, height*4, width, height)
+		l.rasterizeBraille(g, series, width, height, -7, 7, workers)
+		return g
+	}
+	want := render(1)
+	defer putDotGrid(want)
+	for _, workers := range []int{2, 3, 7} {
+		got := render(workers)
+		if !reflect.DeepEqual(got.dots, want.dots) {
+			t.Errorf("%d workers: dots differ from sequential rasterization", workers)
+		}
+		if !reflect.DeepEqual(got.colors, want.colors) {
+			t.Errorf("%d workers: colors differ from sequential rasterization", workers)
+		}
+		putDotGrid(got)
+	}
+}
+
+func TestBrailleWorkers(t *testing.T) {
+	if got := brailleWorkers(1, minParallelDots*2); got != 1 {
+		t.Errorf("brailleWorkers(1 series) = %d, want 1", got)
+	}
+	if got := brailleWorkers(8, 100); got != 1 {
+		t.Errorf("brailleWorkers(small grid) = %d, want 1", got)
+	}
+	if got := brailleWorkers(1000, minParallelDots); got > runtime.GOMAXPROCS(0) {
+		t.Errorf("brailleWorkers() = %d, want at most GOMAXPROCS (%d)", got, runtime.GOMAXPROCS(0))
+	}
+}
+
+func TestLineChart_Render_HiddenSeries(t *testing.T) {
+	series := []Series{
+		{Label: "cpu", Data: []float64{10, 40, 25}},
+		{Label: "spike", Data: []float64{0, 1000, 0}, Hidden: true},
+	}
+	opts := []LineOption{WithWidth(30), WithHeight(8), WithStyle(StyleASCII), WithColor(false)}
+
+	hidden := NewLineChart(append(opts, WithSeries(series))...).Render()
+	alone := NewLineChart(append(opts, WithSeries(series[:1]))...).Render()
+	if got, want := yAxisOf(hidden), yAxisOf(alone); !strings.HasPrefix(got, want) {
+		t.Errorf("hidden series should not change the y axis: got %q, want %q", got, want)
+	}
+	if !strings.Contains(hidden, "* cpu    spike") {
+		t.Errorf("hidden series should stay in the legend without a marker:\n%s", hidden)
+	}
+
+	// Toggling by label at runtime
+	chart := NewLineChart(append(opts, WithSeries([]Series{series[0], {Label: "spike", Data: series[1].Data}}))...)
+	chart.Update(WithHiddenSeries("spike"))
+	if got := chart.Render(); got != hidden {
+		t.Errorf("WithHiddenSeries render =\n%s\nwant\n%s", got, hidden)
+	}
+	chart.Update(WithHiddenSeries())
+	if got := chart.Render(); !strings.Contains(got, "1000") {
+		t.Errorf("shown series should extend the y axis:\n%s", got)
+	}
+}
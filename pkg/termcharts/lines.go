@@ -0,0 +1,93 @@
+package termcharts
+
+import (
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// TruncateLine shortens one line of rendered chart output to at most width
+// columns without corrupting its escape sequences or splitting a character.
+// A line cut while colored ends with a reset, so the color does not leak
+// into what the caller draws next. Use it to fit charts into panes narrower
+// than they were drawn for.
+//
+// Example:
+//
+//	for _, line := range chart.RenderLines() {
+//	    fmt.Println(termcharts.TruncateLine(line, paneWidth))
+//	}
+func TruncateLine(line string, width int) string {
+	return internal.TruncateANSI(line, width)
+}
+
+// WrapLine splits one line of rendered chart output into lines of at most
+// width columns without corrupting its escape sequences or splitting a
+// character. Each line is closed with a reset if it ends colored, and the
+// next one starts with the color in effect, so the lines can be drawn
+// anywhere on their own. A width below 1 returns the line unchanged.
+func WrapLine(line string, width int) []string {
+	return internal.WrapANSI(line, width)
+}
+
+// RenderLines renders chart and returns its output as lines, without line
+// endings, for callers that place a chart in table cells or panels. Lines
+// keep the chart's color escapes; each colored line ends its own escapes, so
+// lines can be moved or padded independently. It returns the error of a
+// ChartE that cannot be drawn.
+//
+// Example:
+//
+//	lines, err := termcharts.RenderLines(chart)
+//	for i, line := range lines {
+//	    table.SetCell(i, 1, line)
+//	}
+func RenderLines(chart Chart) ([]string, error) {
+	out, err := renderChart(chart)
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(out), nil
+}
+
+// splitLines splits chart output into lines, dropping the final line ending.
+// Empty output has no lines.
+func splitLines(out string) []string {
+	if out == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+}
+
+// RenderLines returns the bar chart as lines, or nil if it cannot be drawn.
+func (b *BarChart) RenderLines() []string { return splitLines(b.Render()) }
+
+// RenderLines returns the line chart as lines, or nil if it cannot be drawn.
+func (l *LineChart) RenderLines() []string { return splitLines(l.Render()) }
+
+// RenderLines returns the pie chart as lines, or nil if it cannot be drawn.
+func (p *PieChart) RenderLines() []string { return splitLines(p.Render()) }
+
+// RenderLines returns the sparkline as lines, or nil if it cannot be drawn.
+func (s *Sparkline) RenderLines() []string { return splitLines(s.Render()) }
+
+// RenderLines returns the big text as lines, or nil if it cannot be drawn.
+func (b *BigText) RenderLines() []string { return splitLines(b.Render()) }
+
+// RenderLines returns the histogram as lines, or nil if it cannot be drawn.
+func (h *Histogram) RenderLines() []string { return splitLines(h.Render()) }
+
+// RenderLines returns the box plot as lines, or nil if it cannot be drawn.
+func (b *BoxPlot) RenderLines() []string { return splitLines(b.Render()) }
+
+// RenderLines returns the comparison chart as lines, or nil if it cannot be drawn.
+func (c *ComparisonChart) RenderLines() []string { return splitLines(c.Render()) }
+
+// RenderLines returns the confusion matrix as lines, or nil if it cannot be drawn.
+func (m *ConfusionMatrixChart) RenderLines() []string { return splitLines(m.Render()) }
+
+// RenderLines returns the composed charts as lines, or nil if they cannot be drawn.
+func (c *ComposedChart) RenderLines() []string { return splitLines(c.Render()) }
+
+// RenderLines returns the grid of mini-charts as lines, or nil if it cannot be drawn.
+func (m *SmallMultiplesChart) RenderLines() []string { return splitLines(m.Render()) }
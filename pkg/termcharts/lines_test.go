@@ -0,0 +1,121 @@
+package termcharts
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+func TestTruncateLine_RenderedChart(t *testing.T) {
+	chart := NewBarChart(
+		WithData([]float64{10, 20}),
+		WithLabels([]string{"a", "b"}),
+		WithWidth(40),
+		WithStyle(StyleUnicode),
+		WithColor(true),
+	)
+	for _, line := range strings.Split(strings.TrimSuffix(chart.Render(), "\n"), "\n") {
+		got := TruncateLine(line, 10)
+		if w := internal.StringWidth(got); w > 10 {
+			t.Errorf("TruncateLine(%q) is %d columns wide", got, w)
+		}
+		if strings.Contains(got, "\033") && !strings.HasSuffix(got, colorReset) {
+			t.Errorf("TruncateLine(%q) should end with a reset", got)
+		}
+		if !strings.HasPrefix(internal.StripANSI(line), internal.StripANSI(got)) {
+			t.Errorf("TruncateLine(%q) = %q, want a prefix of the line", line, got)
+		}
+	}
+}
+
+func TestWrapLine_RenderedChart(t *testing.T) {
+	line := Spark([]float64{1, 2, 3, 4, 5, 6, 7, 8})
+	colored := Colorize(line, "green", true)
+
+	lines := WrapLine(colored, 3)
+	if len(lines) != 3 {
+		t.Fatalf("WrapLine() returned %d lines, want 3: %q", len(lines), lines)
+	}
+	var joined strings.Builder
+	for _, l := range lines {
+		if !strings.HasPrefix(l, colorGreen) || !strings.HasSuffix(l, colorReset) {
+			t.Errorf("wrapped line %q should be colored on its own", l)
+		}
+		joined.WriteString(internal.StripANSI(l))
+	}
+	if joined.String() != line {
+		t.Errorf("wrapped lines = %q, want %q", joined.String(), line)
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"trailing newline", "a\nb\n", []string{"a", "b"}},
+		{"no trailing newline", "▁▃█", []string{"▁▃█"}},
+		{"blank line kept", "a\n\nb\n", []string{"a", "", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitLines(tt.out); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitLines(%q) = %q, want %q", tt.out, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderLines(t *testing.T) {
+	data := []float64{3, 1, 4, 1, 5}
+	opts := []Option{WithData(data), WithWidth(30), WithHeight(6), WithColor(true)}
+	series := []Series{{Label: "a", Data: data}, {Label: "b", Data: []float64{2, 7, 1, 8, 2}}}
+
+	charts := map[string]interface {
+		Chart
+		RenderLines() []string
+	}{
+		"bar":        NewBarChart(Combine(opts...)),
+		"line":       NewLineChart(Combine(opts...)),
+		"sparkline":  NewSparkline(Combine(opts...)),
+		"box plot":   NewBoxPlot(Combine(opts...)),
+		"comparison": NewComparison(series[0], series[1], Combine(opts...)),
+	}
+	for name, chart := range charts {
+		t.Run(name, func(t *testing.T) {
+			lines := chart.RenderLines()
+			if len(lines) == 0 {
+				t.Fatal("RenderLines() returned no lines")
+			}
+			if got, want := strings.Join(lines, "\n"), strings.TrimSuffix(chart.Render(), "\n"); got != want {
+				t.Errorf("RenderLines() joined =\n%s\nwant the output of Render()\n%s", got, want)
+			}
+			for i, line := range lines {
+				if strings.Contains(line, "\n") {
+					t.Errorf("line %d contains a newline: %q", i, line)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderLines_Errors(t *testing.T) {
+	if lines := NewBarChart(WithData(nil)).RenderLines(); lines != nil {
+		t.Errorf("RenderLines() = %q for a chart that cannot be drawn, want nil", lines)
+	}
+
+	lines, err := RenderLines(NewPieChart(WithData([]float64{0, 0})))
+	if !errors.Is(err, ErrInvalidData) || lines != nil {
+		t.Errorf("RenderLines() = %q, %v, want nil and the chart's error", lines, err)
+	}
+
+	lines, err = RenderLines(staticChart("one\ntwo\n"))
+	if err != nil || !reflect.DeepEqual(lines, []string{"one", "two"}) {
+		t.Errorf("RenderLines() = %q, %v, want the lines of Render()", lines, err)
+	}
+}
@@ -0,0 +1,416 @@
+package termcharts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// LiveRenderer redraws successive chart frames in place on a terminal.
+// It keeps the previously drawn frame and, on each Draw, skips the lines that
+// did not change and rewrites only what changed on the others, using cursor
+// positioning. This greatly reduces flicker and the number of bytes written,
+// which matters for animated output over SSH.
+//
+// Lines containing wide characters, such as CJK labels, are always rewritten
+// in full, since cell positions no longer match terminal columns.
+//
+// Example:
+//
+//	live := termcharts.NewLiveRenderer(os.Stdout)
+//	for data := range updates {
+//	    chart := termcharts.NewSparkline(termcharts.WithData(data))
+//	    live.Draw(chart.Render())
+//	}
+type LiveRenderer struct {
+	// Diff selects how changed lines are updated (zero = DiffCells).
+	Diff DiffMode
+	// Cache, if set, keeps the renders of Run, so ticks whose data did not
+	// change skip drawing the chart (nil = render every tick).
+	Cache *RenderCache
+	// Highlight draws the cells that changed since the previous frame in
+	// reverse video until the next frame, so movement stands out on an
+	// otherwise static dashboard. Blank cells are not highlighted.
+	Highlight bool
+
+	w     io.Writer
+	prev  [][]internal.Cell // The frame on the terminal, highlights included
+	last  [][]internal.Cell // The frame last passed to Draw
+	drawn bool
+}
+
+// highlightStyle is the escape sequence that marks changed cells.
+const highlightStyle = "\033[7m"
+
+// DiffMode selects how a LiveRenderer updates the lines of a frame that
+// changed. Unchanged lines are skipped in every mode.
+type DiffMode int
+
+const (
+	// DiffCells rewrites only the changed cells of each changed line.
+	// It writes the fewest bytes.
+	DiffCells DiffMode = iota
+	// DiffLines rewrites each changed line in full. It writes more bytes
+	// than DiffCells but fewer cursor movements, which avoids visible
+	// tearing on terminals that draw escape sequences slowly.
+	DiffLines
+)
+
+// mergeGap is the number of unchanged cells between two changed spans below
+// which the spans are rewritten as one, since a cursor move costs about as
+// many bytes as the cells it would skip.
+const mergeGap = 4
+
+// Updatable is a chart whose options can be changed after it is created.
+// All built-in charts implement it; their Update methods take the chart's
+// typed options, and Apply the options shared by all charts.
+type Updatable interface {
+	Chart
+	// Apply applies opts to the chart before its next Render.
+	Apply(opts ...Option)
+}
+
+// DataSource returns the latest data for a live chart.
+type DataSource func() ([]float64, error)
+
+// NewLiveRenderer creates a LiveRenderer that writes frames to w.
+func NewLiveRenderer(w io.Writer) *LiveRenderer {
+	return &LiveRenderer{w: w}
+}
+
+// Draw writes frame to the terminal. The first frame is written as-is;
+// subsequent frames overwrite the previous one, updating only changed lines.
+// After Draw the cursor rests at the start of the line below the frame.
+func (r *LiveRenderer) Draw(frame string) error {
+	next := splitFrame(frame)
+	last := next
+	if r.Highlight && r.drawn {
+		next = highlightChanges(r.last, next)
+	}
+
+	var buf bytes.Buffer
+	if !r.drawn {
+		for _, line := range next {
+			writeCells(&buf, line)
+			buf.WriteString("\n")
+		}
+	} else {
+		r.writeDiff(&buf, next)
+	}
+
+	r.prev = next
+	r.last = last
+	r.drawn = true
+
+	if buf.Len() == 0 {
+		return nil
+	}
+	_, err := r.w.Write(buf.Bytes())
+	return err
+}
+
+// Reset forgets the previously drawn frame so the next Draw starts a fresh
+// frame at the current cursor position.
+func (r *LiveRenderer) Reset() {
+	r.prev = nil
+	r.last = nil
+	r.drawn = false
+}
+
+// RunLive redraws chart on stdout every interval with data from source until
+// ctx is cancelled. See LiveRenderer.Run.
+//
+// Example:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	spark := termcharts.NewSparkline(termcharts.WithWidth(40))
+//	err := termcharts.RunLive(ctx, spark, readLoad, time.Second)
+func RunLive(ctx context.Context, chart Updatable, source DataSource, interval time.Duration) error {
+	return NewLiveRenderer(os.Stdout).Run(ctx, chart, source, interval)
+}
+
+// Run draws chart with data from source immediately and then on every tick
+// of interval, updating the frame in place, until ctx is cancelled.
+// The cursor is hidden while running; on exit the cursor is shown again,
+// colors are reset, and the cursor is left below the last frame.
+//
+// Run returns nil when ctx is cancelled. It stops early and returns the
+// error if source fails or the chart cannot be rendered.
+func (r *LiveRenderer) Run(ctx context.Context, chart Updatable, source DataSource, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: live interval %v must be positive", ErrInvalidOption, interval)
+	}
+
+	return r.withHiddenCursor(func() error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err := r.drawFrom(chart, source); err != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// withHiddenCursor runs fn with the terminal cursor hidden, then shows the
+// cursor and resets colors, even if fn fails.
+func (r *LiveRenderer) withHiddenCursor(fn func() error) (err error) {
+	if _, err := io.WriteString(r.w, cursorHide); err != nil {
+		return err
+	}
+	defer func() {
+		if _, werr := io.WriteString(r.w, colorReset+cursorShow); err == nil {
+			err = werr
+		}
+	}()
+	return fn()
+}
+
+// drawFrom updates chart with the latest data from source and draws it.
+func (r *LiveRenderer) drawFrom(chart Updatable, source DataSource) error {
+	data, err := source()
+	if err != nil {
+		return err
+	}
+	chart.Apply(WithData(data))
+
+	render := renderChart
+	if r.Cache != nil {
+		render = r.Cache.Render
+	}
+	frame, err := render(chart)
+	if err != nil {
+		return err
+	}
+	return r.Draw(frame)
+}
+
+// writeDiff emits the escape sequences and cells needed to turn the
+// previously drawn frame into next.
+func (r *LiveRenderer) writeDiff(buf *bytes.Buffer, next [][]internal.Cell) {
+	if framesEqual(r.prev, next) {
+		return
+	}
+
+	prevRows := len(r.prev)
+	nextRows := len(next)
+
+	// The cursor starts on the line below the old frame. Unchanged lines are
+	// skipped with a single cursor move instead of being rewritten.
+	cur := prevRows
+	for row := 0; row < nextRows; row++ {
+		var old []internal.Cell
+		if row < prevRows {
+			old = r.prev[row]
+			if cellsEqual(old, next[row]) {
+				continue
+			}
+		}
+		moveToRow(buf, cur, row)
+		if r.Diff == DiffLines || !singleWidth(old) || !singleWidth(next[row]) {
+			writeRow(buf, old, next[row])
+		} else {
+			writeRowDiff(buf, old, next[row])
+		}
+		buf.WriteString("\n")
+		cur = row + 1
+	}
+	moveToRow(buf, cur, nextRows)
+
+	// Clear rows left over from a taller previous frame, then return
+	if prevRows > nextRows {
+		for row := nextRows; row < prevRows; row++ {
+			buf.WriteString("\033[2K")
+			if row < prevRows-1 {
+				buf.WriteString("\n")
+			}
+		}
+		buf.WriteString("\r")
+		if up := prevRows - 1 - nextRows; up > 0 {
+			fmt.Fprintf(buf, "\033[%dA", up)
+		}
+	}
+}
+
+// highlightChanges returns next with the cells that differ from old, other
+// than blanks, drawn in reverse video. Rows without changes are shared with
+// next.
+func highlightChanges(old, next [][]internal.Cell) [][]internal.Cell {
+	marked := make([][]internal.Cell, len(next))
+	for row, cells := range next {
+		marked[row] = cells
+		var prev []internal.Cell
+		if row < len(old) {
+			prev = old[row]
+		}
+		copied := false
+		for i, c := range cells {
+			if c.Rune == ' ' || (i < len(prev) && prev[i] == c) {
+				continue
+			}
+			if !copied {
+				marked[row] = append([]internal.Cell(nil), cells...)
+				copied = true
+			}
+			marked[row][i].Style += highlightStyle
+		}
+	}
+	return marked
+}
+
+// framesEqual reports whether two frames have identical cells.
+func framesEqual(a, b [][]internal.Cell) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !cellsEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// cellsEqual reports whether two rows have identical cells.
+func cellsEqual(a, b []internal.Cell) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// singleWidth reports whether every cell of a row occupies one terminal
+// column, so cell indexes can be used as cursor columns.
+func singleWidth(cells []internal.Cell) bool {
+	for _, c := range cells {
+		if c.Rune >= utf8.RuneSelf && cellWidth(c.Rune) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// moveToRow moves the cursor from the start of row from to the start of
+// row to. Rows below the cursor must already exist on the terminal.
+func moveToRow(buf *bytes.Buffer, from, to int) {
+	switch {
+	case to < from:
+		fmt.Fprintf(buf, "\033[%dA\r", from-to)
+	case to > from:
+		fmt.Fprintf(buf, "\033[%dB", to-from)
+	}
+}
+
+// writeRow rewrites a whole row, erasing whatever remains of the old row.
+// The cursor is at column 0 of the row on entry and is left at its end.
+func writeRow(buf *bytes.Buffer, old, next []internal.Cell) {
+	writeCells(buf, next)
+	if len(old) > 0 {
+		buf.WriteString("\033[K")
+	}
+}
+
+// writeRowDiff rewrites the spans of a row that differ between old and next.
+// The cursor is at column 0 of the row on entry and is left somewhere on the row.
+func writeRowDiff(buf *bytes.Buffer, old, next []internal.Cell) {
+	col := 0
+	for i := 0; i < len(next); {
+		if i < len(old) && old[i] == next[i] {
+			i++
+			continue
+		}
+
+		// Extend the span while cells differ or unchanged gaps are short
+		end := i + 1
+		for j := end; j < len(next); j++ {
+			if j < len(old) && old[j] == next[j] {
+				continue
+			}
+			if j-end >= mergeGap {
+				break
+			}
+			end = j + 1
+		}
+
+		moveToColumn(buf, col, i)
+		writeCells(buf, next[i:end])
+		col = end
+		i = end
+	}
+
+	// Erase trailing cells from a longer previous row
+	if len(old) > len(next) {
+		moveToColumn(buf, col, len(next))
+		buf.WriteString("\033[K")
+	}
+}
+
+// moveToColumn moves the cursor from column from to column to on the current row.
+func moveToColumn(buf *bytes.Buffer, from, to int) {
+	switch {
+	case to == from:
+	case to == 0:
+		buf.WriteString("\r")
+	case to > from:
+		fmt.Fprintf(buf, "\033[%dC", to-from)
+	default:
+		fmt.Fprintf(buf, "\033[%dG", to+1)
+	}
+}
+
+// writeCells writes cells, emitting a style change only where it differs
+// from the previous cell and resetting at the end of the run.
+func writeCells(buf *bytes.Buffer, cells []internal.Cell) {
+	style := ""
+	for _, c := range cells {
+		if c.Style != style {
+			if style != "" {
+				buf.WriteString(colorReset)
+			}
+			buf.WriteString(c.Style)
+			style = c.Style
+		}
+		buf.WriteRune(c.Rune)
+	}
+	if style != "" {
+		buf.WriteString(colorReset)
+	}
+}
+
+// splitFrame splits rendered chart output into rows of cells.
+// A single trailing newline does not produce an extra empty row.
+func splitFrame(frame string) [][]internal.Cell {
+	frame = strings.TrimSuffix(frame, "\n")
+	if frame == "" {
+		return nil
+	}
+	lines := strings.Split(frame, "\n")
+	rows := make([][]internal.Cell, len(lines))
+	for i, line := range lines {
+		rows[i] = internal.ParseCells(line)
+	}
+	return rows
+}
@@ -0,0 +1,363 @@
+package termcharts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLiveRenderer_FirstFrame(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+
+	if err := live.Draw("abc\ndef\n"); err != nil {
+		t.Fatalf("Draw returned error: %v", err)
+	}
+
+	if buf.String() != "abc\ndef\n" {
+		t.Errorf("first frame = %q, want %q", buf.String(), "abc\ndef\n")
+	}
+}
+
+func TestLiveRenderer_UnchangedFrame(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	_ = live.Draw("abc\ndef\n")
+	buf.Reset()
+
+	_ = live.Draw("abc\ndef\n")
+
+	if buf.Len() != 0 {
+		t.Errorf("identical frame should write nothing, got %q", buf.String())
+	}
+}
+
+func TestLiveRenderer_ChangedCell(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	_ = live.Draw("abcdefghij\nklmnopqrst\n")
+	buf.Reset()
+
+	_ = live.Draw("abcdefghij\nklmnopqrsX\n")
+	out := buf.String()
+
+	// Cursor moves up to the changed line, then only the changed cell is written
+	if !strings.HasPrefix(out, "\033[1A\r") {
+		t.Errorf("expected cursor-up to the changed line, got %q", out)
+	}
+	if strings.Contains(out, "abcdefghij") || strings.Contains(out, "klmnop") {
+		t.Errorf("unchanged cells should not be rewritten, got %q", out)
+	}
+	if !strings.Contains(out, "\033[9CX") {
+		t.Errorf("expected column move followed by changed cell, got %q", out)
+	}
+	if !strings.HasSuffix(out, "\n") {
+		t.Errorf("cursor should end below the frame, got %q", out)
+	}
+}
+
+func TestLiveRenderer_ColoredCells(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	_ = live.Draw(Colorize("█", "red", true) + Colorize("█", "red", true) + "\n")
+	buf.Reset()
+
+	_ = live.Draw(Colorize("█", "red", true) + Colorize("█", "blue", true) + "\n")
+	out := buf.String()
+
+	if !strings.Contains(out, colorBlue+"█"+colorReset) {
+		t.Errorf("changed cell should be redrawn with its color, got %q", out)
+	}
+	if strings.Contains(out, colorRed) {
+		t.Errorf("unchanged red cell should not be rewritten, got %q", out)
+	}
+}
+
+func TestLiveRenderer_ShrinkingFrame(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	_ = live.Draw("one\ntwo\nthree\n")
+	buf.Reset()
+
+	_ = live.Draw("one\n")
+	out := buf.String()
+
+	if strings.Count(out, "\033[2K") != 2 {
+		t.Errorf("expected two stale rows to be cleared, got %q", out)
+	}
+	// After clearing rows 1-2 the cursor must return to row 1
+	if !strings.HasSuffix(out, "\r\033[1A") {
+		t.Errorf("cursor should return below the new frame, got %q", out)
+	}
+}
+
+func TestLiveRenderer_ShorterRow(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	_ = live.Draw("abcdef\n")
+	buf.Reset()
+
+	_ = live.Draw("abc\n")
+	out := buf.String()
+
+	if !strings.Contains(out, "\033[3C\033[K") {
+		t.Errorf("expected trailing cells to be erased, got %q", out)
+	}
+}
+
+func TestLiveRenderer_SkipsUnchangedLines(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	_ = live.Draw("aaa\nbbb\nccc\nddd\n")
+	buf.Reset()
+
+	_ = live.Draw("aaa\nbXb\nccc\nddd\n")
+
+	// Up to line 1, rewrite the changed cell, then down past the unchanged
+	// lines to the line below the frame
+	want := "\033[3A\r\033[1CX\n\033[2B"
+	if got := buf.String(); got != want {
+		t.Errorf("diff = %q, want %q", got, want)
+	}
+}
+
+func TestLiveRenderer_DiffLines(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	live.Diff = DiffLines
+	_ = live.Draw("aaa\nbbbbbb\nccc\n")
+	buf.Reset()
+
+	_ = live.Draw("aaa\nbXb\nccc\n")
+
+	// The changed line is rewritten in full and the rest of the old line erased
+	want := "\033[2A\rbXb\033[K\n\033[1B"
+	if got := buf.String(); got != want {
+		t.Errorf("diff = %q, want %q", got, want)
+	}
+}
+
+func TestLiveRenderer_WideCharacters(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	_ = live.Draw("東京 1\n")
+	buf.Reset()
+
+	_ = live.Draw("東京 2\n")
+
+	// Cell indexes do not match columns after a wide character, so the
+	// line is rewritten rather than patched with a cursor move
+	want := "\033[1A\r東京 2\033[K\n"
+	if got := buf.String(); got != want {
+		t.Errorf("diff = %q, want %q", got, want)
+	}
+}
+
+func TestLiveRenderer_GrowingFrame(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	_ = live.Draw("one\n")
+	buf.Reset()
+
+	_ = live.Draw("one\ntwo\n")
+
+	// New lines below the old frame are written with newlines so the
+	// terminal scrolls if needed
+	want := "two\n"
+	if got := buf.String(); got != want {
+		t.Errorf("diff = %q, want %q", got, want)
+	}
+}
+
+func TestLiveRenderer_Reset(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	_ = live.Draw("abc\n")
+	live.Reset()
+	buf.Reset()
+
+	_ = live.Draw("abc\n")
+
+	if buf.String() != "abc\n" {
+		t.Errorf("frame after Reset should be written in full, got %q", buf.String())
+	}
+}
+
+func TestLiveRenderer_Run(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	frames := [][]float64{{1, 2, 3}, {3, 2, 1}, {1, 2, 3}}
+	calls := 0
+	source := func() ([]float64, error) {
+		data := frames[calls]
+		calls++
+		if calls == len(frames) {
+			cancel()
+		}
+		return data, nil
+	}
+
+	chart := NewSparkline(WithColor(false), WithStyle(StyleASCII))
+	if err := live.Run(ctx, chart, source, time.Millisecond); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if calls != len(frames) {
+		t.Errorf("source called %d times, want %d", calls, len(frames))
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, cursorHide+"_=@\n") {
+		t.Errorf("output should hide the cursor and draw the first frame, got %q", out)
+	}
+	if !strings.HasSuffix(out, colorReset+cursorShow) {
+		t.Errorf("output should restore the terminal on exit, got %q", out)
+	}
+}
+
+func TestLiveRenderer_RunCache(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	live.Cache = NewRenderCache(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	frames := [][]float64{{1, 2, 3}, {1, 2, 3}, {3, 2, 1}, {1, 2, 3}}
+	calls := 0
+	source := func() ([]float64, error) {
+		data := frames[calls]
+		calls++
+		if calls == len(frames) {
+			cancel()
+		}
+		return data, nil
+	}
+
+	renders := 0
+	count := func(lines []string) []string {
+		renders++
+		return lines
+	}
+	chart := NewSparkline(WithColor(false), WithStyle(StyleASCII), WithPostProcessor(count))
+	if err := live.Run(ctx, chart, source, time.Millisecond); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if renders != 2 {
+		t.Errorf("chart rendered %d times for 2 distinct frames, want 2", renders)
+	}
+}
+
+func TestLiveRenderer_RunErrors(t *testing.T) {
+	chart := NewSparkline(WithColor(false))
+	errSource := errors.New("source failed")
+
+	tests := []struct {
+		name     string
+		source   DataSource
+		interval time.Duration
+		wantErr  error
+	}{
+		{
+			name:     "invalid interval",
+			source:   func() ([]float64, error) { return []float64{1}, nil },
+			interval: 0,
+			wantErr:  ErrInvalidOption,
+		},
+		{
+			name:     "source error",
+			source:   func() ([]float64, error) { return nil, errSource },
+			interval: time.Millisecond,
+			wantErr:  errSource,
+		},
+		{
+			name:     "render error",
+			source:   func() ([]float64, error) { return nil, nil },
+			interval: time.Millisecond,
+			wantErr:  ErrEmptyData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := NewLiveRenderer(&buf).Run(context.Background(), chart, tt.source, tt.interval)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+			if buf.Len() > 0 && !strings.HasSuffix(buf.String(), cursorShow) {
+				t.Errorf("cursor not restored after error, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestLiveRenderer_Highlight(t *testing.T) {
+	var buf bytes.Buffer
+	live := NewLiveRenderer(&buf)
+	live.Highlight = true
+
+	_ = live.Draw("ab c\n")
+	if buf.String() != "ab c\n" {
+		t.Errorf("first frame should not be highlighted, got %q", buf.String())
+	}
+
+	// Changed cells are drawn in reverse video; a cell cleared to a blank is not
+	buf.Reset()
+	_ = live.Draw("aX  \n")
+	if out := buf.String(); !strings.Contains(out, highlightStyle+"X"+colorReset) {
+		t.Errorf("changed cell should be highlighted, got %q", out)
+	}
+	if out := buf.String(); strings.Contains(out, highlightStyle+" ") || strings.Contains(out, highlightStyle+"a") {
+		t.Errorf("blank and unchanged cells should not be highlighted, got %q", out)
+	}
+
+	// The next frame clears the highlight, even when nothing else changed
+	buf.Reset()
+	_ = live.Draw("aX  \n")
+	if out := buf.String(); !strings.Contains(out, "X") || strings.Contains(out, highlightStyle) {
+		t.Errorf("highlight should be cleared on the next frame, got %q", out)
+	}
+
+	// Then identical frames write nothing
+	buf.Reset()
+	_ = live.Draw("aX  \n")
+	if buf.Len() != 0 {
+		t.Errorf("identical frame should write nothing, got %q", buf.String())
+	}
+}
+
+func TestHighlightChanges_KeepsColor(t *testing.T) {
+	old := splitFrame(Colorize("█", "red", true) + "\n")
+	next := splitFrame(Colorize("█", "blue", true) + "\n")
+	marked := highlightChanges(old, next)
+	if got := marked[0][0].Style; got != colorBlue+highlightStyle {
+		t.Errorf("highlighted style = %q, want the cell's color and reverse video", got)
+	}
+	if next[0][0].Style != colorBlue {
+		t.Errorf("highlightChanges modified its input: %q", next[0][0].Style)
+	}
+}
+
+func TestUpdatable_Apply(t *testing.T) {
+	charts := []Updatable{
+		NewBarChart(), NewHistogram(), NewBoxPlot(), NewLineChart(), NewPieChart(), NewSparkline(), NewBigText(),
+		NewComparison(Series{}, Series{}), NewConfusionMatrix(nil, nil), Compose(nil), SmallMultiples(nil, nil),
+	}
+	for _, chart := range charts {
+		chart.Apply(WithWidth(30), WithColor(false))
+	}
+
+	bar := NewBarChart(WithSeries([]Series{{Label: "a", Data: []float64{1, 2}}, {Label: "b", Data: []float64{3, 4}}}), WithColor(false))
+	bar.Update(WithBarMode(BarModeStacked))
+	want := NewBarChart(WithSeries([]Series{{Label: "a", Data: []float64{1, 2}}, {Label: "b", Data: []float64{3, 4}}}), WithColor(false),
+		WithBarMode(BarModeStacked)).Render()
+	if got := bar.Render(); got != want {
+		t.Errorf("Render() after Update(WithBarMode) =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package termcharts
+
+import (
+	"sort"
+	"strings"
+)
+
+// numberFormat describes how a locale writes numbers.
+type numberFormat struct {
+	// decimal separates the integer and fractional parts.
+	decimal string
+	// group separates groups of three integer digits.
+	group string
+	// minGroup is the number of digits the leading group needs before
+	// grouping applies, e.g. 2 for locales that write 4-digit numbers ungrouped.
+	minGroup int
+}
+
+// locales maps lowercase locale tags to their number formats. Language-only
+// tags select the most common region.
+var locales = map[string]numberFormat{
+	"en":    {decimal: ".", group: ","},
+	"en-us": {decimal: ".", group: ","},
+	"en-gb": {decimal: ".", group: ","},
+	"de":    {decimal: ",", group: "."},
+	"de-de": {decimal: ",", group: "."},
+	"de-at": {decimal: ",", group: "\u00a0"},
+	"de-ch": {decimal: ".", group: "\u2019"},
+	"fr":    {decimal: ",", group: "\u202f"},
+	"fr-fr": {decimal: ",", group: "\u202f"},
+	"fr-ca": {decimal: ",", group: "\u00a0"},
+	"es":    {decimal: ",", group: ".", minGroup: 2},
+	"es-es": {decimal: ",", group: ".", minGroup: 2},
+	"es-mx": {decimal: ".", group: ","},
+	"it":    {decimal: ",", group: "."},
+	"it-it": {decimal: ",", group: "."},
+	"pt":    {decimal: ",", group: "."},
+	"pt-br": {decimal: ",", group: "."},
+	"pt-pt": {decimal: ",", group: "\u00a0"},
+	"nl":    {decimal: ",", group: "."},
+	"nl-nl": {decimal: ",", group: "."},
+	"da":    {decimal: ",", group: "."},
+	"da-dk": {decimal: ",", group: "."},
+	"sv":    {decimal: ",", group: "\u00a0"},
+	"sv-se": {decimal: ",", group: "\u00a0"},
+	"nb":    {decimal: ",", group: "\u00a0"},
+	"nb-no": {decimal: ",", group: "\u00a0"},
+	"fi":    {decimal: ",", group: "\u00a0"},
+	"fi-fi": {decimal: ",", group: "\u00a0"},
+	"pl":    {decimal: ",", group: "\u00a0", minGroup: 2},
+	"pl-pl": {decimal: ",", group: "\u00a0", minGroup: 2},
+	"ru":    {decimal: ",", group: "\u00a0"},
+	"ru-ru": {decimal: ",", group: "\u00a0"},
+	"ja":    {decimal: ".", group: ","},
+	"ja-jp": {decimal: ".", group: ","},
+	"zh":    {decimal: ".", group: ","},
+	"zh-cn": {decimal: ".", group: ","},
+	"ko":    {decimal: ".", group: ","},
+	"ko-kr": {decimal: ".", group: ","},
+}
+
+// WithLocale formats axis labels, values, legends, and text summaries the way
+// the given locale writes numbers, with its thousands separator and decimal
+// mark. Tags such as "de-DE", "de_DE.UTF-8", and "de" are accepted; see
+// Locales for the supported tags. An empty tag restores the default, which
+// writes numbers without grouping. Unknown tags are ignored unless WithStrict
+// is set, in which case the chart fails with ErrInvalidOption.
+//
+// Formatters set with AxisConfig.Format, Legend.Format, or WithValueFormat
+// take precedence over the locale.
+//
+// Example:
+//
+//	chart := termcharts.NewBarChart(
+//	    termcharts.WithData([]float64{1250.5, 980, 2210.75}),
+//	    termcharts.WithShowValues(true),
+//	    termcharts.WithLocale("de-DE"), // 1.250,5  980,0  2.210,8
+//	)
+func WithLocale(tag string) Option {
+	return func(o *Options) {
+		o.Locale = tag
+	}
+}
+
+// Locales returns the supported locale tags, sorted.
+func Locales() []string {
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// LocaleSupported reports whether WithLocale recognizes tag.
+func LocaleSupported(tag string) bool {
+	_, ok := lookupLocale(tag)
+	return ok
+}
+
+// lookupLocale returns the number format for tag. Tags are matched without
+// regard to case, underscores may separate language and region, and encoding
+// or modifier suffixes ("de_DE.UTF-8", "de_DE@euro") are ignored. A tag
+// whose region is unknown falls back to its language.
+func lookupLocale(tag string) (numberFormat, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if nf, ok := locales[tag]; ok {
+		return nf, true
+	}
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		nf, ok := locales[tag[:i]]
+		return nf, ok
+	}
+	return numberFormat{}, false
+}
+
+// localizeNumber rewrites the first number in s, as formatted by fmt, in the
+// number format of locale tag. Surrounding text such as padding, signs, and
+// units is kept. s is returned unchanged for an empty or unknown tag.
+func localizeNumber(s, tag string) string {
+	if tag == "" {
+		return s
+	}
+	nf, ok := lookupLocale(tag)
+	if !ok {
+		return s
+	}
+
+	start := strings.IndexAny(s, "0123456789")
+	if start < 0 {
+		return s
+	}
+	end := start
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	integer := s[start:end]
+
+	fraction := ""
+	rest := s[end:]
+	if len(rest) > 1 && rest[0] == '.' && rest[1] >= '0' && rest[1] <= '9' {
+		n := 1
+		for n < len(rest) && rest[n] >= '0' && rest[n] <= '9' {
+			n++
+		}
+		fraction = nf.decimal + rest[1:n]
+		rest = rest[n:]
+	}
+
+	return s[:start] + groupDigits(integer, nf) + fraction + rest
+}
+
+// groupDigits inserts the group separator of nf between groups of three digits.
+func groupDigits(digits string, nf numberFormat) string {
+	minGroup := nf.minGroup
+	if minGroup < 1 {
+		minGroup = 1
+	}
+	if len(digits) < 3+minGroup {
+		return digits
+	}
+
+	var b strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		b.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if i > 0 {
+			b.WriteString(nf.group)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,168 @@
+package termcharts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLocalizeNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		tag      string
+		expected string
+	}{
+		{name: "default", s: "1234567.5", tag: "", expected: "1234567.5"},
+		{name: "en-US", s: "1234567.5", tag: "en-US", expected: "1,234,567.5"},
+		{name: "de-DE", s: "1234567.5", tag: "de-DE", expected: "1.234.567,5"},
+		{name: "fr-FR", s: "1234.5", tag: "fr-FR", expected: "1 234,5"},
+		{name: "de-CH", s: "1234.5", tag: "de-CH", expected: "1’234.5"},
+		{name: "short number", s: "980.0", tag: "de-DE", expected: "980,0"},
+		{name: "es-ES leaves four digits ungrouped", s: "1234.5", tag: "es-ES", expected: "1234,5"},
+		{name: "es-ES groups five digits", s: "12345.5", tag: "es-ES", expected: "12.345,5"},
+		{name: "negative", s: "-1234.0", tag: "de-DE", expected: "-1.234,0"},
+		{name: "padding and units kept", s: "  5.0%", tag: "de-DE", expected: "  5,0%"},
+		{name: "posix tag", s: "1234.5", tag: "de_DE.UTF-8", expected: "1.234,5"},
+		{name: "language fallback", s: "1234.5", tag: "de-LU", expected: "1.234,5"},
+		{name: "unknown locale", s: "1234.5", tag: "xx-YY", expected: "1234.5"},
+		{name: "no number", s: "NaN", tag: "de-DE", expected: "NaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := localizeNumber(tt.s, tt.tag); got != tt.expected {
+				t.Errorf("localizeNumber(%q, %q) = %q, want %q", tt.s, tt.tag, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLocaleSupported(t *testing.T) {
+	for _, tag := range Locales() {
+		if !LocaleSupported(tag) {
+			t.Errorf("LocaleSupported(%q) = false for a listed locale", tag)
+		}
+	}
+	if LocaleSupported("xx-YY") {
+		t.Error("LocaleSupported(\"xx-YY\") = true, want false")
+	}
+}
+
+func TestWithLocale(t *testing.T) {
+	data := []float64{1250.5, 980, 2210.75}
+	labels := []string{"A", "B", "C"}
+
+	tests := []struct {
+		name     string
+		chart    Chart
+		contains []string
+	}{
+		{
+			name: "bar values",
+			chart: NewBarChart(
+				WithData(data),
+				WithLabels(labels),
+				WithShowValues(true),
+				WithColor(false),
+				WithLocale("de-DE"),
+			),
+			contains: []string{"1.250,5", "980,0", "2.210,8"},
+		},
+		{
+			name: "line axis labels",
+			chart: NewLineChart(
+				WithData(data),
+				WithShowAxes(true),
+				WithColor(false),
+				WithHeight(6),
+				WithLocale("de-DE"),
+			),
+			contains: []string{"2.210,8", "980,0"},
+		},
+		{
+			name: "pie legend",
+			chart: NewPieChart(
+				WithData([]float64{1, 3}),
+				WithLabels([]string{"A", "B"}),
+				WithShowValues(true),
+				WithColor(false),
+				WithLocale("fr-FR"),
+			),
+			contains: []string{"25,0%", "75,0%", "[3,0]"},
+		},
+		{
+			name: "legend values",
+			chart: NewLineChart(
+				WithSeries([]Series{{Label: "a", Data: data}, {Label: "b", Data: data}}),
+				WithLegend(Legend{Values: LegendMax}),
+				WithColor(false),
+				WithLocale("de-DE"),
+			),
+			contains: []string{"a (max 2.210,8)"},
+		},
+		{
+			name: "text summary",
+			chart: NewSparkline(
+				WithData(data),
+				WithTextSummaryMode(TextSummaryOnly),
+				WithLocale("de-DE"),
+			),
+			contains: []string{"min 980,0, max 2.210,8, last 2.210,8"},
+		},
+		{
+			name: "KPI value",
+			chart: NewBigText(
+				WithData([]float64{1180, 1250.5}),
+				WithStyle(StyleASCII),
+				WithColor(false),
+				WithWidth(5),
+				WithLocale("de-DE"),
+			),
+			contains: []string{"1.250,5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.chart.Render()
+			for _, want := range tt.contains {
+				if !strings.Contains(result, want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, result)
+				}
+			}
+		})
+	}
+}
+
+func TestWithLocale_AxisFormatTakesPrecedence(t *testing.T) {
+	bar := NewBarChart(
+		WithData([]float64{1250.5}),
+		WithShowValues(true),
+		WithColor(false),
+		WithYAxis(AxisConfig{Format: func(v float64) string { return "custom" }}),
+		WithLocale("de-DE"),
+	)
+	if result := bar.Render(); !strings.Contains(result, "custom") {
+		t.Errorf("expected axis formatter to be used, got:\n%s", result)
+	}
+}
+
+func TestWithLocale_Strict(t *testing.T) {
+	bar := NewBarChart(
+		WithData([]float64{1, 2}),
+		WithLocale("xx-YY"),
+		WithStrict(true),
+	)
+	if _, err := bar.RenderE(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("RenderE() error = %v, want ErrInvalidOption", err)
+	}
+
+	lenient := NewBarChart(
+		WithData([]float64{1, 2}),
+		WithLocale("xx-YY"),
+	)
+	if _, err := lenient.RenderE(); err != nil {
+		t.Errorf("RenderE() without strict mode returned %v", err)
+	}
+}
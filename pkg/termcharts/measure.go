@@ -0,0 +1,102 @@
+package termcharts
+
+// Measurer is implemented by charts that can report the space they use,
+// so layout engines can pack charts tightly instead of over-allocating.
+type Measurer interface {
+	// Measure returns the width in terminal columns and the height in rows
+	// the chart uses when given at most maxWidth columns and maxHeight rows.
+	Measure(maxWidth, maxHeight int) (width, height int)
+}
+
+// withSize returns a copy of o sized to maxWidth and maxHeight.
+// A maximum of 0 keeps the configured size.
+func (o *Options) withSize(maxWidth, maxHeight int) *Options {
+	sized := *o
+	if maxWidth > 0 {
+		sized.Width = maxWidth
+	}
+	if maxHeight > 0 {
+		sized.Height = maxHeight
+	}
+	return &sized
+}
+
+// measure renders chart and returns the width of its widest line and its
+// number of lines, or 0, 0 if it cannot be drawn.
+func measure(chart ChartE) (int, int) {
+	out, err := chart.RenderE()
+	if err != nil {
+		return 0, 0
+	}
+	frame := NewFrame(out)
+	return frame.Width(), frame.Height()
+}
+
+// Measure returns the width and height the bar chart uses when given at most
+// maxWidth columns and maxHeight rows, including its title, axes, and legend.
+// A maximum of 0 uses the configured size. The result can exceed the maximum
+// when the chart cannot shrink that far. It returns 0, 0 if the chart cannot
+// be drawn.
+func (b *BarChart) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&BarChart{opts: b.opts.withSize(maxWidth, maxHeight), err: b.err})
+}
+
+// Measure returns the width and height the line chart uses when given at most
+// maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (l *LineChart) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&LineChart{opts: l.opts.withSize(maxWidth, maxHeight), err: l.err})
+}
+
+// Measure returns the width and height the pie chart uses when given at most
+// maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (p *PieChart) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&PieChart{opts: p.opts.withSize(maxWidth, maxHeight), err: p.err})
+}
+
+// Measure returns the width and height the sparkline uses when given at most
+// maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (s *Sparkline) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&Sparkline{opts: s.opts.withSize(maxWidth, maxHeight), err: s.err})
+}
+
+// Measure returns the width and height the KPI panel uses when given at most
+// maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (b *BigText) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&BigText{opts: b.opts.withSize(maxWidth, maxHeight), err: b.err})
+}
+
+// Measure returns the width and height the composed chart uses when given at
+// most maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (c *ComposedChart) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&ComposedChart{opts: c.opts.withSize(maxWidth, maxHeight), layers: c.layers, err: c.err})
+}
+
+// Measure returns the width and height the histogram uses when given at most
+// maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (h *Histogram) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&Histogram{opts: h.opts.withSize(maxWidth, maxHeight), err: h.err})
+}
+
+// Measure returns the width and height the box plot uses when given at most
+// maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (b *BoxPlot) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&BoxPlot{opts: b.opts.withSize(maxWidth, maxHeight), err: b.err})
+}
+
+// Measure returns the width and height the comparison chart uses when given
+// at most maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (c *ComparisonChart) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&ComparisonChart{opts: c.opts.withSize(maxWidth, maxHeight), left: c.left, right: c.right})
+}
+
+// Measure returns the width and height the confusion matrix uses when given
+// at most maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (m *ConfusionMatrixChart) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&ConfusionMatrixChart{opts: m.opts.withSize(maxWidth, maxHeight), labels: m.labels, matrix: m.matrix})
+}
+
+// Measure returns the width and height the grid of mini-charts uses when
+// given at most maxWidth columns and maxHeight rows. See BarChart.Measure.
+func (m *SmallMultiplesChart) Measure(maxWidth, maxHeight int) (int, int) {
+	return measure(&SmallMultiplesChart{opts: m.opts.withSize(maxWidth, maxHeight), series: m.series, factory: m.factory})
+}
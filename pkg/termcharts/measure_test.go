@@ -0,0 +1,94 @@
+package termcharts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMeasure(t *testing.T) {
+	data := []float64{10, 25, 15, 30}
+	labels := []string{"Q1", "Q2", "Q3", "Q4"}
+
+	tests := []struct {
+		name  string
+		chart interface {
+			Measurer
+			Chart
+		}
+	}{
+		{name: "bar", chart: NewBarChart(WithData(data), WithLabels(labels), WithTitle("Sales"), WithColor(true))},
+		{name: "line", chart: NewLineChart(WithData(data), WithLabels(labels), WithColor(false))},
+		{name: "pie", chart: NewPieChart(WithData(data), WithLabels(labels), WithColor(false))},
+		{name: "sparkline", chart: NewSparkline(WithData(data), WithTextSummary(true))},
+		{name: "kpi", chart: NewBigText(WithData(data), WithTitle("Users"), WithShowSparkline(true))},
+		{name: "composed", chart: Compose([]Layer{
+			{Kind: LayerBar, Series: Series{Label: "a", Data: data}},
+			{Kind: LayerLine, Series: Series{Label: "b", Data: data}},
+		}, WithColor(false))},
+		{name: "histogram", chart: NewHistogram(WithData(data), WithBins(3), WithColor(false))},
+		{name: "box plot", chart: NewBoxPlot(WithData(data), WithColor(false))},
+		{name: "comparison", chart: NewComparison(
+			Series{Label: "before", Data: data}, Series{Label: "after", Data: data}, WithLabels(labels), WithColor(false))},
+		{name: "confusion matrix", chart: NewConfusionMatrix([]string{"a", "b"}, [][]float64{{9, 1}, {5, 5}}, WithColor(false))},
+		{name: "small multiples", chart: SmallMultiples([]Series{{Label: "a", Data: data}, {Label: "b", Data: data}},
+			func(opts ...Option) Chart { return NewSparkline(Combine(opts...)) }, WithColor(false))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := tt.chart.Measure(50, 14)
+
+			// Measuring matches rendering at that size
+			sized := tt.chart
+			if u, ok := sized.(Updatable); ok {
+				u.Apply(WithWidth(50), WithHeight(14))
+			}
+			frame := NewFrame(sized.Render())
+			if width != frame.Width() || height != frame.Height() {
+				t.Errorf("Measure(50, 14) = %d, %d, rendered %d, %d", width, height, frame.Width(), frame.Height())
+			}
+			if width == 0 || height == 0 {
+				t.Errorf("Measure(50, 14) = %d, %d, want a non-empty size", width, height)
+			}
+		})
+	}
+}
+
+func TestMeasure_WithinMaximum(t *testing.T) {
+	bar := NewBarChart(
+		WithData([]float64{10, 25, 15, 30}),
+		WithLabels([]string{"Q1", "Q2", "Q3", "Q4"}),
+		WithTitle("Quarterly Sales"),
+		WithStyle(StyleUnicode),
+	)
+
+	width, height := bar.Measure(40, 10)
+	if width > 40 || height > 10 {
+		t.Errorf("Measure(40, 10) = %d, %d, want at most 40, 10", width, height)
+	}
+	// Title plus one row per bar
+	if height != 5 {
+		t.Errorf("height = %d, want 5", height)
+	}
+
+	// Measuring does not change the chart
+	if got := bar.Render(); strings.Count(got, "\n") != 5 || bar.opts.Width != 80 {
+		t.Error("Measure should not modify the chart's options")
+	}
+}
+
+func TestMeasure_ZeroUsesConfiguredSize(t *testing.T) {
+	line := NewLineChart(WithData([]float64{1, 2, 3}), WithWidth(30), WithHeight(8))
+	want := NewFrame(line.Render())
+	width, height := line.Measure(0, 0)
+	if width != want.Width() || height != want.Height() {
+		t.Errorf("Measure(0, 0) = %d, %d, want %d, %d", width, height, want.Width(), want.Height())
+	}
+}
+
+func TestMeasure_InvalidChart(t *testing.T) {
+	width, height := NewBarChart().Measure(40, 10)
+	if width != 0 || height != 0 {
+		t.Errorf("Measure() of empty chart = %d, %d, want 0, 0", width, height)
+	}
+}
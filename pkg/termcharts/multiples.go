@@ -0,0 +1,286 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// Layout of small multiples: the narrowest mini-chart worth drawing, and the
+// spaces between columns of mini-charts.
+const (
+	minMultipleWidth = 20
+	multiplesGap     = 2
+)
+
+// SmallMultiplesChart draws one mini-chart per series in a grid. Every
+// mini-chart is the same chart type and shares one value range, so the series
+// can be compared at a glance, and a single legend is drawn below the grid.
+type SmallMultiplesChart struct {
+	opts    *Options
+	series  []Series
+	factory ChartFactory
+}
+
+// MultiplesOption configures a grid of small multiples. Every Option is a
+// MultiplesOption, as are the axis options, which set the value range every
+// mini-chart shares, and the series options, which set the grid's legend.
+type MultiplesOption interface {
+	applyMultiples(*Options)
+}
+
+func (f Option) applyMultiples(o *Options)       { f(o) }
+func (f axisOption) applyMultiples(o *Options)   { f(o) }
+func (f seriesOption) applyMultiples(o *Options) { f(o) }
+
+// SmallMultiples creates a grid of mini-charts, one per series, each built
+// by factory and drawn below its series label. The width and height set the
+// size of the whole grid; columns are added while each mini-chart stays at
+// least 20 columns wide. The value axis of every mini-chart covers the data
+// of all visible series. Hidden series get no mini-chart but stay in the
+// legend. Other options, such as the style, theme, and labels, apply to
+// every mini-chart.
+//
+// Options of one chart type, such as WithBraille, go to the mini-charts by
+// wrapping a constructor:
+//
+//	grid := termcharts.SmallMultiples(series, func(opts ...termcharts.Option) termcharts.Chart {
+//	    return termcharts.NewLineChart(termcharts.Combine(opts...), termcharts.WithBraille())
+//	}, termcharts.WithWidth(100), termcharts.WithHeight(30))
+//	fmt.Print(grid.Render())
+func SmallMultiples(series []Series, factory ChartFactory, opts ...MultiplesOption) *SmallMultiplesChart {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyMultiples(options)
+	}
+	return &SmallMultiplesChart{
+		opts:    options,
+		series:  series,
+		factory: factory,
+	}
+}
+
+// Update applies opts to the grid, replacing the options they set.
+// Series and the chart factory are not changed.
+func (m *SmallMultiplesChart) Update(opts ...MultiplesOption) {
+	for _, opt := range opts {
+		opt.applyMultiples(m.opts)
+	}
+}
+
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the grid Updatable.
+func (m *SmallMultiplesChart) Apply(opts ...Option) { m.Update(Combine(opts...)) }
+
+// Render generates the grid as a multi-line string.
+// It returns an empty string if the grid cannot be drawn; use RenderE to find out why.
+func (m *SmallMultiplesChart) Render() string {
+	out, _ := m.RenderE()
+	return out
+}
+
+// RenderE generates the grid as a multi-line string. It returns
+// ErrEmptyData, ErrInvalidData, ErrInvalidDimensions, or ErrInvalidOption
+// (possibly wrapped) when the grid or one of its mini-charts cannot be drawn.
+func (m *SmallMultiplesChart) RenderE() (string, error) {
+	if m.factory == nil {
+		return "", fmt.Errorf("%w: small multiples need a chart factory", ErrInvalidOption)
+	}
+	if err := m.opts.checkPoints(seriesData(m.series)...); err != nil {
+		return "", err
+	}
+	// A width or height of 0 is the terminal's; others are clamped
+	if opts := m.opts.sized(); opts != m.opts {
+		return (&SmallMultiplesChart{opts: opts, series: m.series, factory: m.factory}).RenderE()
+	}
+	if opts := m.opts.percentScaled(seriesData(m.series)...); opts != m.opts {
+		return (&SmallMultiplesChart{opts: opts, series: m.series, factory: m.factory}).RenderE()
+	}
+	if m.opts.EmptyMessage != "" && seriesEmpty(m.series) {
+		return m.opts.placeholder(m.opts.Width, m.opts.Height, m.shouldUseUnicode(), m.isColorEnabled()), nil
+	}
+	if err := validateSeries(m.series); err != nil {
+		return "", err
+	}
+
+	out, err := m.render()
+	if err != nil {
+		return "", err
+	}
+
+	// Summaries describe the series
+	opts := *m.opts
+	opts.Series = m.series
+	return opts.postProcess(out), nil
+}
+
+// render draws the title, the grid of mini-charts, and the legend.
+func (m *SmallMultiplesChart) render() (string, error) {
+	colorEnabled := m.isColorEnabled()
+	useUnicode := m.shouldUseUnicode()
+	theme := m.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	visible := m.opts.visibleSeries(m.series, theme)
+
+	var result strings.Builder
+	if m.opts.Title != "" {
+		title := fitTitle(m.opts.Title, m.opts.Width, useUnicode)
+		if colorEnabled {
+			title = Colorize(title, m.opts.titleColor(theme), true)
+		}
+		result.WriteString(title)
+		result.WriteString("\n")
+	}
+
+	marker := "●"
+	if !useUnicode {
+		marker = "*"
+	}
+	legend := chartLegend(m.opts, m.series, marker, colorEnabled, theme).Render()
+
+	if len(visible) > 0 {
+		// Share the rest of the height between the rows of mini-charts, each
+		// below a label line and followed by a blank line
+		columns, cellWidth := multiplesColumns(len(visible), m.opts.Width)
+		rows := (len(visible) + columns - 1) / columns
+		avail := m.opts.Height - strings.Count(result.String(), "\n") - strings.Count(legend, "\n") - rows
+		cellHeight := internal.Max(avail/rows-1, minHeight)
+
+		cell := m.cellOptions(visible, cellWidth, cellHeight, colorEnabled)
+		for row := 0; row < rows; row++ {
+			var cells [][]string
+			for i := row * columns; i < len(visible) && i < (row+1)*columns; i++ {
+				lines, err := m.renderCell(visible[i], cell)
+				if err != nil {
+					return "", err
+				}
+				cells = append(cells, lines)
+			}
+			writeCellRow(&result, cells, cellWidth)
+			result.WriteString("\n")
+		}
+	}
+
+	result.WriteString(legend)
+	return result.String(), nil
+}
+
+// cellOptions returns the options shared by every mini-chart: the grid's
+// options without its title, series, legend, and output hooks, sized to a
+// cell and colored like the grid, with a value axis covering all visible
+// series.
+func (m *SmallMultiplesChart) cellOptions(visible []Series, width, height int, colorEnabled bool) Options {
+	cell := *m.opts
+	cell.Width, cell.Height = width, height
+	cell.ColorEnabled = &colorEnabled
+	cell.Title = ""
+	cell.Series, cell.HiddenSeries = nil, nil
+	cell.ShowLegend, cell.Legend = false, nil
+	cell.PostProcessors, cell.Insets = nil, nil
+	cell.TextSummary = TextSummaryOff
+	cell.EmptyMessage = ""
+
+	if !cell.YAxis.fixedRange() {
+		sets := make([][]float64, len(visible))
+		for i, s := range visible {
+			sets[i] = s.Data
+		}
+		lo, hi := cell.YAxis.resolveRange(sets...)
+		if hi <= lo {
+			// A flat range is widened so it still counts as fixed
+			hi = lo + 1
+		}
+		cell.YAxis.Min, cell.YAxis.Max = lo, hi
+	}
+	return cell
+}
+
+// renderCell draws the mini-chart of one series in the series' color below
+// its label and returns its lines.
+func (m *SmallMultiplesChart) renderCell(s Series, cell Options) ([]string, error) {
+	theme := cell.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	cell.Theme = theme.WithPrimary(s.Color).WithSeriesPalette(s.Color)
+	cell.Data = s.Data
+
+	chart := m.factory(func(o *Options) { *o = cell })
+	var out string
+	if ce, ok := chart.(ChartE); ok {
+		var err error
+		if out, err = ce.RenderE(); err != nil {
+			return nil, fmt.Errorf("series %q: %w", s.Label, err)
+		}
+	} else {
+		out = chart.Render()
+	}
+
+	// Every chart type gets the same label line, including those without titles
+	label := truncateLabel(s.Label, cell.Width, m.shouldUseUnicode())
+	if *cell.ColorEnabled {
+		label = Colorize(label, m.opts.titleColor(theme), true)
+	}
+	return append([]string{label}, strings.Split(strings.TrimSuffix(out, "\n"), "\n")...), nil
+}
+
+// writeCellRow writes a row of mini-charts side by side, padding each to
+// width columns and shorter ones with blank lines.
+func writeCellRow(result *strings.Builder, cells [][]string, width int) {
+	height := 0
+	for _, lines := range cells {
+		height = internal.Max(height, len(lines))
+	}
+	gap := strings.Repeat(" ", multiplesGap)
+	for line := 0; line < height; line++ {
+		var row strings.Builder
+		for i, lines := range cells {
+			if i > 0 {
+				row.WriteString(gap)
+			}
+			text := ""
+			if line < len(lines) {
+				text = lines[line]
+			}
+			row.WriteString(text)
+			if pad := width - internal.StringWidth(text); pad > 0 {
+				row.WriteString(strings.Repeat(" ", pad))
+			}
+		}
+		result.WriteString(strings.TrimRight(row.String(), " "))
+		result.WriteString("\n")
+	}
+}
+
+// multiplesColumns returns the number of columns of a grid of n mini-charts
+// within width columns, close to square, and the width of each mini-chart.
+func multiplesColumns(n, width int) (int, int) {
+	columns := int(math.Ceil(math.Sqrt(float64(n))))
+	cellWidth := func(c int) int { return (width - multiplesGap*(c-1)) / c }
+	for columns > 1 && cellWidth(columns) < minMultipleWidth {
+		columns--
+	}
+	return columns, cellWidth(columns)
+}
+
+// shouldUseUnicode determines whether to use Unicode characters based on style.
+func (m *SmallMultiplesChart) shouldUseUnicode() bool {
+	if m.opts.Style == StyleASCII {
+		return false
+	} else if m.opts.Style == StyleUnicode || m.opts.Style.subCell() {
+		return true
+	}
+	return internal.SupportsUnicode()
+}
+
+// isColorEnabled determines whether colors should be used.
+func (m *SmallMultiplesChart) isColorEnabled() bool {
+	if m.opts.ColorEnabled != nil {
+		return *m.opts.ColorEnabled
+	}
+	return internal.SupportsColor()
+}
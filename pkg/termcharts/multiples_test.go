@@ -0,0 +1,107 @@
+package termcharts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSmallMultiples(t *testing.T) {
+	series := []Series{
+		{Label: "web", Data: []float64{1, 5, 3, 8}},
+		{Label: "db", Data: []float64{2, 3, 2, 4}},
+		{Label: "cache", Data: []float64{9, 7, 6, 5}},
+	}
+	spark := func(opts ...Option) Chart { return NewSparkline(Combine(opts...)) }
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{
+			// Every sparkline is scaled to the range 1..9 of all series
+			name: "shared scale",
+			opts: []Option{WithWidth(50)},
+			expected: "web                       db\n" +
+				"▁▄▂▇                      ▁▂▁▃\n" +
+				"\n" +
+				"cache\n" +
+				"█▆▅▄\n" +
+				"\n" +
+				"● web  ● db  ● cache  \n",
+		},
+		{
+			name: "one column when narrow",
+			opts: []Option{WithWidth(30), WithTitle("Load")},
+			expected: "Load\n" +
+				"web\n▁▄▂▇\n\n" +
+				"db\n▁▂▁▃\n\n" +
+				"cache\n█▆▅▄\n\n" +
+				"● web  ● db  ● cache  \n",
+		},
+		{
+			name: "hidden series stay in the legend",
+			opts: []Option{WithWidth(50), WithHiddenSeries("cache")},
+			expected: "web                       db\n" +
+				"▁▅▃█                      ▂▃▂▄\n" +
+				"\n" +
+				"● web  ● db    cache  \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithHeight(20), WithStyle(StyleUnicode), WithColor(false)}, tt.opts...)
+			got, err := SmallMultiples(series, spark, Combine(opts...)).RenderE()
+			if err != nil {
+				t.Fatalf("RenderE() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("RenderE() =\n%s\nwant:\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSmallMultiples_LineCells(t *testing.T) {
+	series := []Series{
+		{Label: "low", Data: []float64{1, 2, 3}},
+		{Label: "high", Data: []float64{50, 80, 100}},
+	}
+	line := func(opts ...Option) Chart { return NewLineChart(Combine(opts...)) }
+	out := SmallMultiples(series, line, WithWidth(60), WithHeight(12), WithStyle(StyleASCII), WithColor(false)).Render()
+
+	// Both mini-charts share one row, and their Y axes run up to the largest value
+	lines := strings.Split(out, "\n")
+	if !strings.HasPrefix(lines[0], "low") || !strings.Contains(lines[0], "high") {
+		t.Errorf("first line = %q, want both labels", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); len(fields) < 2 || fields[0] != "100.0" || fields[1] != "100.0" {
+		t.Errorf("top row = %q, want both Y axes to start at 100.0", lines[1])
+	}
+	if strings.Count(out, "low") != 2 {
+		t.Errorf("want one legend below the grid, got:\n%s", out)
+	}
+	for i, line := range lines {
+		if w := len(line); w > 60 {
+			t.Errorf("line %d is %d columns wide, want at most 60: %q", i, w, line)
+		}
+	}
+}
+
+func TestSmallMultiples_Errors(t *testing.T) {
+	spark := func(opts ...Option) Chart { return NewSparkline(Combine(opts...)) }
+	series := []Series{{Label: "a", Data: []float64{1, 2}}}
+
+	if _, err := SmallMultiples(series, nil).RenderE(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("nil factory: error = %v, want ErrInvalidOption", err)
+	}
+	if _, err := SmallMultiples(nil, spark, WithWidth(40), WithHeight(10)).RenderE(); !errors.Is(err, ErrEmptyData) {
+		t.Errorf("no series: error = %v, want ErrEmptyData", err)
+	}
+	bar := func(opts ...Option) Chart { return NewBarChart(Combine(opts...)) }
+	if _, err := SmallMultiples(series, bar, WithWidth(40), WithHeight(10), WithStyle(StyleBraille), WithStrict(true)).RenderE(); !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("braille bar cells: error = %v, want ErrConflictingOptions from the mini-chart", err)
+	}
+}
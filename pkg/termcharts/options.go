@@ -1,9 +1,17 @@
 package termcharts
 
+import (
+	"fmt"
+	"math"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
 // Options holds configuration for chart rendering.
 // Options are set using functional options via With* functions.
 type Options struct {
-	// Width is the maximum chart width in terminal columns (0 = auto-detect).
+	// Width is the maximum chart width in terminal columns (0 = auto-detect;
+	// no limit for sparklines).
 	Width int
 	// Height is the maximum chart height in terminal rows (0 = auto-detect).
 	Height int
@@ -15,6 +23,8 @@ type Options struct {
 	Labels []string
 	// Series contains multiple data series for multi-series charts.
 	Series []Series
+	// HiddenSeries are the labels of series left out of the plot (see Series.Hidden).
+	HiddenSeries []string
 	// ColorEnabled controls whether to use ANSI colors (auto-detected if nil).
 	ColorEnabled *bool
 	// Style specifies the rendering mode (ASCII, Unicode, or Braille).
@@ -27,15 +37,190 @@ type Options struct {
 	ShowAxes bool
 	// Theme specifies the color theme to use.
 	Theme *Theme
+	// ColorByLabel colors series by a hash of their labels instead of their positions.
+	ColorByLabel bool
 	// BarMode specifies how multiple series are displayed (grouped or stacked).
 	BarMode BarMode
+	// MinBar draws positive values too small to fill a cell of a bar chart as a one-cell marker.
+	MinBar bool
+	// TopN keeps the bar chart categories with the largest values (0 = all).
+	TopN int
+	// Page and PageSize draw one page of bar chart categories (Page 0 = all), pages counted from 1.
+	Page, PageSize int
+	// GroupDelimiter groups bar chart categories by the label text before it, with a header and subtotal per group ("" = no groups).
+	GroupDelimiter string
+	// LabelWrap wraps horizontal bar chart labels wider than it over two lines (0 = no wrapping).
+	LabelWrap int
+	// Emphasize offsets pie slice Emphasis from the center and highlights it and its legend entry.
+	Emphasize bool
+	// Emphasis is the index of the emphasized pie slice, counted from 0.
+	Emphasis int
 	// ShowLegend controls whether to display a legend for multi-series charts.
 	ShowLegend bool
+	// Legend customizes the legend of multi-series charts (nil = defaults).
+	Legend *Legend
+	// ShowSparkline controls whether KPI panels embed a sparkline of their data.
+	ShowSparkline bool
+	// ValueFormat formats the value shown by KPI panels (nil = default).
+	ValueFormat func(float64) string
+	// SparkStats controls whether sparklines are followed by their min, max, and last values.
+	SparkStats bool
+	// SparkTrend controls whether sparklines are followed by a trend arrow.
+	SparkTrend bool
+	// Thresholds color sparkline characters by value: each takes the color of the highest threshold at or below it.
+	Thresholds map[float64]string
+	// ColorScale colors sparkline characters by value and confusion matrix cells by row share (nil = theme colors).
+	ColorScale *ColorScale
+	// ColorBar draws a color bar mapping colors back to values below charts that color cells by value.
+	ColorBar bool
+	// SparkMaxColor and SparkMinColor highlight the highest and lowest points of sparklines (empty = no highlight).
+	SparkMaxColor string
+	SparkMinColor string
+	// Baseline selects the value sparklines are scaled from (default BaselineMin).
+	Baseline Baseline
+	// SparkOverlay draws the series of a sparkline over each other in one row of Braille dots.
+	SparkOverlay bool
+	// Strict makes chart constructors validate options; invalid charts fail to render.
+	Strict bool
+	// XAxis configures the horizontal axis.
+	XAxis AxisConfig
+	// YAxis configures the value axis.
+	YAxis AxisConfig
+	// Stacked draws each series of a line chart on top of the series before it.
+	Stacked bool
+	// Fill shades the region under each line of a line chart.
+	Fill bool
+	// SeparateScales scales each series of a line chart on its own, hiding the value axis.
+	SeparateScales bool
+	// Bins is the number of bins a histogram sorts its samples into (0 = chosen by BinRule).
+	Bins int
+	// BinRule chooses the number of bins of a histogram when Bins is 0.
+	BinRule BinRule
+	// Cumulative draws each bar of a histogram as the running total of the bins up to it.
+	Cumulative bool
+	// Density overlays a kernel density estimate of the samples on a histogram.
+	Density bool
+	// Align lines up series of different lengths by their first or last points.
+	Align SeriesAlign
+	// PercentAxis labels the value axis and values as percentages, from 0 to 100%.
+	PercentAxis bool
+	// PostProcessors transform the rendered output lines, in order.
+	PostProcessors []PostProcessor
+	// Insets are small charts drawn inside the chart, in order.
+	Insets []Inset
+	// TextSummary controls whether a text description of the data is included.
+	TextSummary TextSummaryMode
+	// Locale selects the number format of displayed values, e.g. "de-DE" (empty = default).
+	Locale string
+	// MaxPoints caps the points drawn per series (0 = two per plot column, negative = no cap).
+	MaxPoints int
+	// Limits guard against input too large to chart (zero fields = defaults).
+	Limits Limits
+	// YTicks is the number of round-numbered value axis labels (0 = one label per row or YAxis.Ticks).
+	YTicks int
+	// YUnit is appended to value axis labels and displayed values, e.g. "ms" (empty = none).
+	YUnit string
+	// TitleStyle is the style of the title (zero = theme Text color).
+	TitleStyle Style
+	// AxisStyle is the style of axes and their labels (zero = theme Muted color).
+	AxisStyle Style
+	// LegendStyle is the style of legend labels (zero = terminal default).
+	LegendStyle Style
+	// FillChar is the character bars are filled with (0 = '█', or '#' in ASCII mode).
+	FillChar rune
+	// SparkChars are the characters sparklines are drawn with, lowest first (nil = the style's).
+	SparkChars []rune
+	// EmptyMessage is shown in a placeholder box when the chart has no data (empty = render nothing).
+	EmptyMessage string
 }
 
 // Option is a function that configures chart Options using the functional options pattern.
+// Options apply to every chart type, so an Option can be passed to any constructor.
 type Option func(*Options)
 
+// BarOption configures a bar chart. Every Option is a BarOption; options that
+// only make sense for bar charts, such as WithBarMode, are BarOptions only.
+type BarOption interface {
+	SpecOption
+	applyBar(*Options)
+}
+
+// LineOption configures a line chart. Every Option is a LineOption; options
+// that only make sense for line charts, such as WithBraille, are LineOptions only.
+type LineOption interface {
+	SpecOption
+	applyLine(*Options)
+}
+
+// PieOption configures a pie chart. Every Option is a PieOption; options
+// that only make sense for pie charts, such as WithEmphasis, are PieOptions only.
+type PieOption interface {
+	SpecOption
+	applyPie(*Options)
+}
+
+// SparklineOption configures a sparkline. Every Option is a SparklineOption.
+type SparklineOption interface {
+	SpecOption
+	applySparkline(*Options)
+}
+
+// BigTextOption configures a KPI panel. Every Option is a BigTextOption.
+type BigTextOption interface {
+	SpecOption
+	applyBigText(*Options)
+}
+
+// ComposeOption configures a composed chart. Every Option is a ComposeOption.
+type ComposeOption interface {
+	applyCompose(*Options)
+}
+
+func (f Option) applyBar(o *Options)       { f(o) }
+func (f Option) applyLine(o *Options)      { f(o) }
+func (f Option) applyPie(o *Options)       { f(o) }
+func (f Option) applySparkline(o *Options) { f(o) }
+func (f Option) applyBigText(o *Options)   { f(o) }
+func (f Option) applyCompose(o *Options)   { f(o) }
+
+// barOption is an option that only applies to bar charts.
+type barOption func(*Options)
+
+func (f barOption) applyBar(o *Options) { f(o) }
+
+// lineOption is an option that only applies to line charts.
+type lineOption func(*Options)
+
+func (f lineOption) applyLine(o *Options) { f(o) }
+
+// pieOption is an option that only applies to pie charts.
+type pieOption func(*Options)
+
+func (f pieOption) applyPie(o *Options) { f(o) }
+
+// sparklineOption is an option that only applies to sparklines.
+type sparklineOption func(*Options)
+
+func (f sparklineOption) applySparkline(o *Options) { f(o) }
+
+// bigTextOption is an option that only applies to KPI panels.
+type bigTextOption func(*Options)
+
+func (f bigTextOption) applyBigText(o *Options) { f(o) }
+
+// Combine merges several options into one. It is useful for passing a
+// []Option built up at runtime to a constructor that takes typed options:
+//
+//	opts := []termcharts.Option{termcharts.WithData(data)}
+//	chart := termcharts.NewBarChart(termcharts.Combine(opts...), termcharts.WithBarMode(mode))
+func Combine(opts ...Option) Option {
+	return func(o *Options) {
+		for _, opt := range opts {
+			opt(o)
+		}
+	}
+}
+
 // NewOptions creates a new Options struct with sensible defaults.
 func NewOptions(opts ...Option) *Options {
 	// Default options
@@ -56,6 +241,39 @@ func NewOptions(opts ...Option) *Options {
 	return o
 }
 
+// Charts are drawn at least this size, so a pathological width or height
+// cannot break the layout. The largest sizes are set by Limits, so one cannot
+// allocate a huge grid either.
+const (
+	minWidth  = 8
+	minHeight = 3
+)
+
+// sized returns o with a width or height of 0 replaced by the terminal's,
+// falling back to 80x24 when the size cannot be detected, and both clamped
+// between the minimums above and the limits. It returns o itself when no
+// change is needed.
+func (o *Options) sized() *Options {
+	width, height := o.Width, o.Height
+	if width == 0 || height == 0 {
+		size := internal.GetTerminalSize()
+		if width == 0 {
+			width = size.Width
+		}
+		if height == 0 {
+			height = size.Height
+		}
+	}
+	width = clampSize(width, minWidth, o.maxWidth())
+	height = clampSize(height, minHeight, o.maxHeight())
+	if width == o.Width && height == o.Height {
+		return o
+	}
+	sized := *o
+	sized.Width, sized.Height = width, height
+	return &sized
+}
+
 // WithData sets the primary data series for the chart.
 func WithData(data []float64) Option {
 	return func(o *Options) {
@@ -78,8 +296,25 @@ func WithSeries(series []Series) Option {
 	}
 }
 
+// WithHiddenSeries leaves the series with the given labels out of the plot,
+// as if each had Series.Hidden set, and shows the others. It replaces the
+// labels set by an earlier WithHiddenSeries, so a live display can toggle
+// series by passing the current set to Update between frames.
+//
+// Example:
+//
+//	chart.Update(termcharts.WithHiddenSeries("Memory", "Disk"))
+func WithHiddenSeries(labels ...string) Option {
+	return func(o *Options) {
+		o.HiddenSeries = labels
+	}
+}
+
 // WithWidth sets the maximum chart width in terminal columns.
-// Use 0 to auto-detect terminal width.
+// Use 0 to auto-detect terminal width. Sparklines treat 0 as no limit.
+// Other widths are clamped to between 8 and 1000 columns when rendering
+// (up to 1000 characters for sparklines); Options.Validate reports negative
+// and oversized widths.
 func WithWidth(width int) Option {
 	return func(o *Options) {
 		o.Width = width
@@ -87,7 +322,9 @@ func WithWidth(width int) Option {
 }
 
 // WithHeight sets the maximum chart height in terminal rows.
-// Use 0 to auto-detect terminal height.
+// Use 0 to auto-detect terminal height. Other heights are clamped to
+// between 3 and 500 rows when rendering; Options.Validate reports negative
+// and oversized heights.
 func WithHeight(height int) Option {
 	return func(o *Options) {
 		o.Height = height
@@ -117,11 +354,11 @@ func WithStyle(style RenderStyle) Option {
 	}
 }
 
-// WithDirection sets the chart orientation (horizontal or vertical).
-func WithDirection(dir Direction) Option {
-	return func(o *Options) {
+// WithDirection sets the bar chart orientation (horizontal or vertical).
+func WithDirection(dir Direction) BarOption {
+	return barOption(func(o *Options) {
 		o.Direction = dir
-	}
+	})
 }
 
 // WithShowValues controls whether numeric values are displayed on the chart.
@@ -145,17 +382,209 @@ func WithTheme(theme *Theme) Option {
 	}
 }
 
+// WithColorByLabel colors series without a color of their own by a hash of
+// their labels instead of their positions, so a metric keeps its color across
+// the charts of a dashboard and between runs, however the series are ordered
+// or filtered. Pie slices are colored by their labels. Series without a label
+// are colored by position. Different labels can share a color; give a series
+// a Color of its own to tell it apart.
+func WithColorByLabel(byLabel bool) Option {
+	return func(o *Options) {
+		o.ColorByLabel = byLabel
+	}
+}
+
+// seriesColor returns the theme color of the series at index i with label:
+// picked by the label with WithColorByLabel, or by the index.
+func (o *Options) seriesColor(theme *Theme, i int, label string) string {
+	if o.ColorByLabel && label != "" {
+		return theme.GetLabelColor(label)
+	}
+	return theme.GetSeriesColor(i)
+}
+
 // WithBarMode sets how multiple series are displayed in bar charts.
 // Use BarModeGrouped for side-by-side bars or BarModeStacked for stacked bars.
-func WithBarMode(mode BarMode) Option {
-	return func(o *Options) {
+func WithBarMode(mode BarMode) BarOption {
+	return barOption(func(o *Options) {
 		o.BarMode = mode
-	}
+	})
 }
 
-// WithShowLegend controls whether a legend is displayed for multi-series charts.
-func WithShowLegend(show bool) Option {
-	return func(o *Options) {
+// WithMinBar draws every positive value of a bar chart as at least one cell,
+// so small values do not vanish beside large ones. A bar too short to fill a
+// cell is drawn as a thin marker, '▏' at the start of a horizontal bar or '▁'
+// at the bottom of a vertical one ('|' and '_' in ASCII mode), which tells a
+// tiny value apart from zero, drawn as nothing, and from a bar of one cell.
+// It applies to single and grouped bars; with WithStrict, combining it with
+// BarModeStacked returns ErrConflictingOptions.
+//
+// Example:
+//
+//	chart := termcharts.NewBarChart(
+//	    termcharts.WithData([]float64{12000, 3, 0}),
+//	    termcharts.WithMinBar(true),
+//	)
+func WithMinBar(min bool) BarOption {
+	return barOption(func(o *Options) {
+		o.MinBar = min
+	})
+}
+
+// WithShowLegend controls whether a legend is displayed for multi-series bar charts.
+func WithShowLegend(show bool) BarOption {
+	return barOption(func(o *Options) {
 		o.ShowLegend = show
+	})
+}
+
+// WithBraille renders a line chart using high-resolution Braille patterns.
+// It is equivalent to WithStyle(StyleBraille), which other chart types do not support.
+func WithBraille() LineOption {
+	return lineOption(func(o *Options) {
+		o.Style = StyleBraille
+	})
+}
+
+// WithSharedScale controls whether the series of a line chart share one
+// value axis (the default). With false, each series is scaled between its
+// own minimum and maximum, so the shapes of unrelated metrics, such as
+// latency in milliseconds and throughput in requests per second, can be
+// compared. The value axis is hidden, since no one scale applies; legends
+// and text summaries still show the real values.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithSeries([]termcharts.Series{latency, throughput}),
+//	    termcharts.WithSharedScale(false),
+//	)
+func WithSharedScale(shared bool) LineOption {
+	return lineOption(func(o *Options) {
+		o.SeparateScales = !shared
+	})
+}
+
+// WithShowSparkline controls whether a KPI panel embeds a sparkline of its data.
+func WithShowSparkline(show bool) BigTextOption {
+	return bigTextOption(func(o *Options) {
+		o.ShowSparkline = show
+	})
+}
+
+// WithSparkStats controls whether a sparkline is followed by the minimum,
+// maximum, and last values of its data, e.g. "▁▄█▃ min 1.2  max 9.8  last 4.1",
+// formatted for the chart's locale. The stats are drawn after the sparkline's
+// width.
+func WithSparkStats(show bool) SparklineOption {
+	return sparklineOption(func(o *Options) {
+		o.SparkStats = show
+	})
+}
+
+// WithSparkTrend controls whether a sparkline ends with an arrow showing
+// whether its last value rose (▲) or fell (▼) from the one before, or "=" if
+// it did not change, followed by the percent change, e.g. "▲ +12.5%".
+// See Trend. With WithSparkStats, the trend follows the stats.
+func WithSparkTrend(show bool) SparklineOption {
+	return sparklineOption(func(o *Options) {
+		o.SparkTrend = show
+	})
+}
+
+// WithValueFormat sets how a KPI panel formats its value, e.g. to add units.
+// Only digits and the characters . , - + % : and space are drawn large.
+func WithValueFormat(format func(float64) string) BigTextOption {
+	return bigTextOption(func(o *Options) {
+		o.ValueFormat = format
+	})
+}
+
+// WithStrict enables strict mode. In strict mode, chart constructors call
+// Validate along with chart-specific checks, and a chart with invalid options
+// renders nothing; RenderE returns the validation error.
+func WithStrict(strict bool) Option {
+	return func(o *Options) {
+		o.Strict = strict
+	}
+}
+
+// Validate checks the options for values that cannot produce a sensible chart.
+// It returns the first problem found, wrapping ErrInvalidDimensions,
+// ErrInvalidOption, ErrLabelMismatch, or ErrConflictingOptions.
+func (o *Options) Validate() error {
+	if err := validateDimensions(o); err != nil {
+		return err
+	}
+
+	if o.Style < StyleAuto || o.Style > StyleSextant {
+		return fmt.Errorf("%w: unknown render style %d", ErrInvalidOption, o.Style)
 	}
+	if o.Direction != Horizontal && o.Direction != Vertical {
+		return fmt.Errorf("%w: unknown direction %d", ErrInvalidOption, o.Direction)
+	}
+	if o.BarMode != BarModeGrouped && o.BarMode != BarModeStacked {
+		return fmt.Errorf("%w: unknown bar mode %d", ErrInvalidOption, o.BarMode)
+	}
+	if o.Baseline != BaselineMin && o.Baseline != BaselineZero {
+		return fmt.Errorf("%w: unknown baseline %d", ErrInvalidOption, o.Baseline)
+	}
+	if o.LabelWrap < 0 {
+		return fmt.Errorf("%w: label wrap width must not be negative, got %d", ErrInvalidOption, o.LabelWrap)
+	}
+	if o.Bins < 0 {
+		return fmt.Errorf("%w: bins must not be negative, got %d", ErrInvalidOption, o.Bins)
+	}
+	if o.BinRule != BinSturges && o.BinRule != BinFreedmanDiaconis {
+		return fmt.Errorf("%w: unknown bin rule %d", ErrInvalidOption, o.BinRule)
+	}
+	if o.Align < AlignStretch || o.Align > AlignRight {
+		return fmt.Errorf("%w: unknown series alignment %d", ErrInvalidOption, o.Align)
+	}
+	for t := range o.Thresholds {
+		if math.IsNaN(t) {
+			return fmt.Errorf("%w: threshold is NaN", ErrInvalidOption)
+		}
+	}
+
+	if err := o.validateCharsets(); err != nil {
+		return err
+	}
+
+	if _, ok := lookupLocale(o.Locale); o.Locale != "" && !ok {
+		return fmt.Errorf("%w: unknown locale %q", ErrInvalidOption, o.Locale)
+	}
+
+	if err := o.XAxis.validate("X"); err != nil {
+		return err
+	}
+	if err := o.YAxis.validate("Y"); err != nil {
+		return err
+	}
+	if o.YTicks < 0 {
+		return fmt.Errorf("%w: Y tick count %d is negative", ErrInvalidOption, o.YTicks)
+	}
+
+	if len(o.Data) > 0 && len(o.Series) > 0 {
+		return fmt.Errorf("%w: both Data and Series are set; Data is ignored, remove WithData or WithSeries", ErrConflictingOptions)
+	}
+
+	if len(o.Labels) > 0 {
+		points := len(o.Data)
+		for _, s := range o.Series {
+			if len(s.Data) > points {
+				points = len(s.Data)
+			}
+		}
+		if len(o.Labels) != points {
+			return fmt.Errorf("%w: got %d labels for %d data points", ErrLabelMismatch, len(o.Labels), points)
+		}
+	}
+
+	return nil
+}
+
+// conflict returns an ErrConflictingOptions error with the given explanation.
+func conflict(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{ErrConflictingOptions}, args...)...)
 }
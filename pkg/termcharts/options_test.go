@@ -1,7 +1,13 @@
 package termcharts
 
 import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
 	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
 )
 
 func TestNewOptions(t *testing.T) {
@@ -94,6 +100,37 @@ func TestWithHeight(t *testing.T) {
 	}
 }
 
+func TestRender_AutoSize(t *testing.T) {
+	// Tests do not run in a terminal, so the size comes from the environment
+	t.Setenv("COLUMNS", "50")
+	t.Setenv("LINES", "9")
+	internal.ResetDetection()
+	defer internal.ResetDetection()
+
+	charts := map[string]Chart{
+		"bar": NewBarChart(WithData([]float64{1, 2, 3}), WithWidth(0), WithColor(false)),
+		"line": NewLineChart(WithData([]float64{1, 5, 2, 8}), WithWidth(0), WithHeight(0),
+			WithColor(false)),
+	}
+	for name, chart := range charts {
+		frame, err := RenderFrame(chart)
+		if err != nil {
+			t.Fatalf("%s: RenderFrame() error = %v", name, err)
+		}
+		if frame.Width() > 50 || frame.Width() < 40 {
+			t.Errorf("%s: width = %d, want the terminal's 50 columns", name, frame.Width())
+		}
+	}
+	if frame, _ := RenderFrame(charts["line"]); frame.Height() > 9 {
+		t.Errorf("line: height = %d, want at most the terminal's 9 rows", frame.Height())
+	}
+
+	// Sparklines treat a width of 0 as no limit
+	if got := NewSparkline(WithData(make([]float64, 120)), WithWidth(0)).Render(); len([]rune(got)) != 120 {
+		t.Errorf("sparkline with width 0 has %d characters, want 120", len([]rune(got)))
+	}
+}
+
 func TestWithTitle(t *testing.T) {
 	opts := NewOptions(WithTitle("Test Chart"))
 
@@ -189,7 +226,7 @@ func TestWithDirection(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			opts := NewOptions(WithDirection(tt.direction))
+			opts := NewBarChart(WithDirection(tt.direction)).opts
 
 			if opts.Direction != tt.expected {
 				t.Errorf("Direction = %v, want %v", opts.Direction, tt.expected)
@@ -276,8 +313,52 @@ func TestWithTheme(t *testing.T) {
 	}
 }
 
+func TestWithColorByLabel(t *testing.T) {
+	// By position, cpu and memory would be the first and second series colors
+	cpu, memory := DefaultTheme.GetLabelColor("cpu"), DefaultTheme.GetLabelColor("memory")
+	if cpu == DefaultTheme.GetSeriesColor(0) || memory == DefaultTheme.GetSeriesColor(1) {
+		t.Fatal("test labels should not hash to their positions' colors")
+	}
+	series := []Series{{Label: "cpu", Data: []float64{3, 5}}, {Label: "memory", Data: []float64{4, 2}}}
+	reversed := []Series{series[1], series[0]}
+
+	charts := map[string][]Chart{
+		"bar": {
+			NewBarChart(WithSeries(series), WithShowLegend(true), WithColorByLabel(true), WithColor(true)),
+			NewBarChart(WithSeries(reversed), WithShowLegend(true), WithColorByLabel(true), WithColor(true)),
+		},
+		"line": {
+			NewLineChart(WithSeries(series), WithColorByLabel(true), WithColor(true), WithWidth(30), WithHeight(8)),
+			NewLineChart(WithSeries(reversed), WithColorByLabel(true), WithColor(true), WithWidth(30), WithHeight(8)),
+		},
+		"pie": {
+			NewPieChart(WithData([]float64{3, 4}), WithLabels([]string{"cpu", "memory"}), WithColorByLabel(true), WithColor(true)),
+			NewPieChart(WithData([]float64{4, 3}), WithLabels([]string{"memory", "cpu"}), WithColorByLabel(true), WithColor(true)),
+		},
+	}
+	for name, pair := range charts {
+		t.Run(name, func(t *testing.T) {
+			for _, chart := range pair {
+				out := chart.Render()
+				for label, color := range map[string]string{"cpu": cpu, "memory": memory} {
+					// The legend marker of each series is in the series' color
+					if !regexp.MustCompile(regexp.QuoteMeta(colorCode(color)) + `\S+` + regexp.QuoteMeta(colorReset) + ` +\S*` + label).MatchString(out) {
+						t.Errorf("%s should be colored %s whatever its position:\n%q", label, color, out)
+					}
+				}
+			}
+		})
+	}
+
+	// Series with a color of their own keep it
+	own := NewBarChart(WithSeries([]Series{{Label: "cpu", Data: []float64{3}, Color: "green"}}), WithColorByLabel(true), WithColor(true)).Render()
+	if !strings.Contains(own, colorCode("green")) {
+		t.Errorf("a series' own color should win: %q", own)
+	}
+}
+
 func TestMultipleOptions(t *testing.T) {
-	opts := NewOptions(
+	opts := NewBarChart(
 		WithData([]float64{1, 2, 3}),
 		WithWidth(100),
 		WithHeight(20),
@@ -285,7 +366,7 @@ func TestMultipleOptions(t *testing.T) {
 		WithStyle(StyleUnicode),
 		WithDirection(Vertical),
 		WithShowValues(true),
-	)
+	).opts
 
 	if len(opts.Data) != 3 {
 		t.Errorf("len(Data) = %v, want %v", len(opts.Data), 3)
@@ -315,3 +396,169 @@ func TestMultipleOptions(t *testing.T) {
 		t.Errorf("ShowValues = %v, want %v", opts.ShowValues, true)
 	}
 }
+
+func TestWithStrict(t *testing.T) {
+	opts := NewOptions()
+	if opts.Strict {
+		t.Error("default Strict = true, want false")
+	}
+
+	opts = NewOptions(WithStrict(true))
+	if !opts.Strict {
+		t.Error("WithStrict(true) did not enable strict mode")
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{
+			name: "defaults",
+			opts: nil,
+		},
+		{
+			name: "matching labels",
+			opts: []Option{WithData([]float64{1, 2}), WithLabels([]string{"A", "B"})},
+		},
+		{
+			name: "labels match longest series",
+			opts: []Option{
+				WithSeries([]Series{{Data: []float64{1}}, {Data: []float64{1, 2}}}),
+				WithLabels([]string{"A", "B"}),
+			},
+		},
+		{
+			name:    "negative width",
+			opts:    []Option{WithWidth(-1)},
+			wantErr: ErrInvalidDimensions,
+		},
+		{
+			name:    "negative height",
+			opts:    []Option{WithHeight(-5)},
+			wantErr: ErrInvalidDimensions,
+		},
+		{
+			name:    "enormous width",
+			opts:    []Option{WithWidth(1 << 40)},
+			wantErr: ErrInvalidDimensions,
+		},
+		{
+			name:    "unknown style",
+			opts:    []Option{WithStyle(RenderStyle(42))},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "NaN threshold",
+			opts:    []Option{func(o *Options) { o.Thresholds = map[float64]string{math.NaN(): "red"} }},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "unknown baseline",
+			opts:    []Option{func(o *Options) { o.Baseline = Baseline(7) }},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "unknown series alignment",
+			opts:    []Option{func(o *Options) { o.Align = SeriesAlign(5) }},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "unknown direction",
+			opts:    []Option{func(o *Options) { o.Direction = Direction(7) }},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "unknown bar mode",
+			opts:    []Option{func(o *Options) { o.BarMode = BarMode(3) }},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "negative axis ticks",
+			opts:    []Option{func(o *Options) { o.YAxis.Ticks = -1 }},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "negative y ticks",
+			opts:    []Option{func(o *Options) { o.YTicks = -1 }},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "axis max below min",
+			opts:    []Option{func(o *Options) { o.XAxis = AxisConfig{Min: 10, Max: 5} }},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "log axis with non-positive min",
+			opts:    []Option{func(o *Options) { o.YAxis = AxisConfig{Min: 0, Max: 10, Scale: ScaleLog} }},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "too few labels",
+			opts:    []Option{WithData([]float64{1, 2, 3}), WithLabels([]string{"A"})},
+			wantErr: ErrLabelMismatch,
+		},
+		{
+			name:    "too many labels",
+			opts:    []Option{WithData([]float64{1}), WithLabels([]string{"A", "B"})},
+			wantErr: ErrLabelMismatch,
+		},
+		{
+			name: "data and series",
+			opts: []Option{
+				WithData([]float64{1}),
+				WithSeries([]Series{{Data: []float64{1}}}),
+			},
+			wantErr: ErrConflictingOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewOptions(tt.opts...).Validate()
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTypedOptions(t *testing.T) {
+	bar := NewBarChart(
+		WithData([]float64{1, 2}),
+		WithBarMode(BarModeStacked),
+		WithShowLegend(true),
+		WithDirection(Vertical),
+	)
+	if bar.opts.BarMode != BarModeStacked || !bar.opts.ShowLegend || bar.opts.Direction != Vertical {
+		t.Errorf("bar options not applied: %+v", bar.opts)
+	}
+
+	line := NewLineChart(WithData([]float64{1, 2}), WithBraille())
+	if line.opts.Style != StyleBraille {
+		t.Errorf("WithBraille() Style = %v, want %v", line.opts.Style, StyleBraille)
+	}
+}
+
+func TestCombine(t *testing.T) {
+	shared := []Option{WithTitle("Combined"), WithWidth(40)}
+	bar := NewBarChart(Combine(shared...), WithBarMode(BarModeStacked))
+
+	if bar.opts.Title != "Combined" {
+		t.Errorf("Title = %v, want %v", bar.opts.Title, "Combined")
+	}
+	if bar.opts.Width != 40 {
+		t.Errorf("Width = %v, want %v", bar.opts.Width, 40)
+	}
+	if bar.opts.BarMode != BarModeStacked {
+		t.Errorf("BarMode = %v, want %v", bar.opts.BarMode, BarModeStacked)
+	}
+}
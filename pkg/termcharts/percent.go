@@ -0,0 +1,98 @@
+package termcharts
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// WithPercentAxis treats data as percentages. The value axis runs from 0 to
+// 100%, or from -100% when there are negative values, and axis labels and
+// displayed values are formatted as percentages, such as "42%" or "12.5%".
+// Data whose values all lie within [-1, 1] is read as fractions, so 0.42 is
+// 42%; other data is read as percentages already. A range or formatter set
+// with WithYAxis takes precedence.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithData([]float64{0.12, 0.35, 0.61, 0.58}),
+//	    termcharts.WithPercentAxis(true),
+//	)
+func WithPercentAxis(percent bool) AxisOption {
+	return axisOption(func(o *Options) {
+		o.PercentAxis = percent
+	})
+}
+
+// percentScaled returns the options with a percent axis resolved into the Y
+// axis range and formatter, for the data of the chart and extra data sets,
+// or o itself when WithPercentAxis is not set.
+func (o *Options) percentScaled(extra ...[]float64) *Options {
+	if !o.PercentAxis {
+		return o
+	}
+
+	sets := append([][]float64{o.Data}, extra...)
+	for _, s := range o.Series {
+		sets = append(sets, s.Data)
+	}
+	lo, hi, found := 0.0, 0.0, false
+	for _, data := range sets {
+		if len(data) == 0 {
+			continue
+		}
+		min, max := internal.MinMax(data)
+		if !found || min < lo {
+			lo = min
+		}
+		if !found || max > hi {
+			hi = max
+		}
+		found = true
+	}
+
+	// Fractions are scaled to percentages for display
+	full, factor := 100.0, 1.0
+	if found && lo >= -1 && hi <= 1 {
+		full, factor = 1, 100
+	}
+
+	scaled := *o
+	scaled.PercentAxis = false
+	if !scaled.YAxis.fixedRange() {
+		scaled.YAxis.Min, scaled.YAxis.Max = 0, full
+		if lo < 0 {
+			scaled.YAxis.Min = -full
+		}
+	}
+	if scaled.YAxis.Format == nil {
+		locale := o.Locale
+		scaled.YAxis.Format = func(v float64) string {
+			return localizeNumber(formatPercent(v*factor), locale)
+		}
+	}
+	return &scaled
+}
+
+// seriesData returns the data of each series.
+func seriesData(series []Series) [][]float64 {
+	sets := make([][]float64, len(series))
+	for i, s := range series {
+		sets[i] = s.Data
+	}
+	return sets
+}
+
+// formatPercent formats p as a percentage with at most one decimal place,
+// e.g. "42%" or "12.5%".
+func formatPercent(p float64) string {
+	s := strconv.FormatFloat(math.Round(p*10)/10, 'f', 1, 64)
+	s = strings.TrimSuffix(s, ".0")
+	if s == "-0" {
+		s = "0"
+	}
+	return s + "%"
+}
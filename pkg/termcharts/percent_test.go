@@ -0,0 +1,115 @@
+package termcharts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithPercentAxis(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		yAxis   AxisConfig
+		wantMin float64
+		wantMax float64
+		values  map[float64]string
+	}{
+		{
+			name:    "fractions",
+			opts:    []Option{WithData([]float64{0.1, 0.45})},
+			wantMax: 1,
+			values:  map[float64]string{0: "0%", 0.5: "50%", 0.125: "12.5%", 1: "100%"},
+		},
+		{
+			name:    "percentages",
+			opts:    []Option{WithData([]float64{12, 85})},
+			wantMax: 100,
+			values:  map[float64]string{0: "0%", 50: "50%", 12.34: "12.3%"},
+		},
+		{
+			name:    "negative fractions",
+			opts:    []Option{WithData([]float64{-0.2, 0.3})},
+			wantMin: -1,
+			wantMax: 1,
+			values:  map[float64]string{-0.25: "-25%", -0.0001: "0%"},
+		},
+		{
+			name:    "series",
+			opts:    []Option{WithSeries([]Series{{Data: []float64{0.5}}, {Data: []float64{40}}})},
+			wantMax: 100,
+			values:  map[float64]string{40: "40%"},
+		},
+		{
+			name:    "fixed range kept",
+			opts:    []Option{WithData([]float64{0.5})},
+			yAxis:   AxisConfig{Min: 0.25, Max: 0.75},
+			wantMin: 0.25,
+			wantMax: 0.75,
+			values:  map[float64]string{0.5: "50%"},
+		},
+		{
+			name:    "localized",
+			opts:    []Option{WithData([]float64{0.5}), WithLocale("de-DE")},
+			wantMax: 1,
+			values:  map[float64]string{0.125: "12,5%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions(tt.opts...)
+			opts.YAxis = tt.yAxis
+			WithPercentAxis(true).(axisOption)(opts)
+			got := opts.percentScaled()
+			if got.YAxis.Min != tt.wantMin || got.YAxis.Max != tt.wantMax {
+				t.Errorf("range = [%g, %g], want [%g, %g]", got.YAxis.Min, got.YAxis.Max, tt.wantMin, tt.wantMax)
+			}
+			for v, want := range tt.values {
+				if s := got.YAxis.format(v, "%g", ""); s != want {
+					t.Errorf("format(%g) = %q, want %q", v, s, want)
+				}
+			}
+		})
+	}
+
+	if opts := NewOptions(WithData([]float64{1})); opts.percentScaled() != opts {
+		t.Error("percentScaled() without WithPercentAxis should return the options unchanged")
+	}
+}
+
+func TestWithPercentAxis_Charts(t *testing.T) {
+	data := []float64{0.125, 0.5}
+	tests := []struct {
+		name  string
+		chart Chart
+		want  []string
+	}{
+		{
+			name:  "bar values",
+			chart: NewBarChart(WithData(data), WithLabels([]string{"a", "b"}), WithShowValues(true), WithPercentAxis(true), WithWidth(30)),
+			want:  []string{"a  ##                  12.5%", "b  #########           50%\n"},
+		},
+		{
+			name:  "line axis",
+			chart: NewLineChart(WithData(data), WithPercentAxis(true), WithYTicks(3), WithWidth(30), WithHeight(6)),
+			want:  []string{"100%", "50%", "0%"},
+		},
+		{
+			name:  "sparkline stats",
+			chart: NewSparkline(WithData(data), WithPercentAxis(true), WithSparkStats(true)),
+			want:  []string{"min 12.5%  max 50%  last 50%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.chart.(Updatable).Apply(WithColor(false), WithStyle(StyleASCII))
+			got := tt.chart.Render()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Render() =\n%s\nwant it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
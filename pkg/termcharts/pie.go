@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/neilpeterson/termcharts/internal"
 )
@@ -13,6 +14,7 @@ import (
 // rendered using different characters for each slice.
 type PieChart struct {
 	opts *Options
+	err  error
 }
 
 // Slice represents a single slice of the pie chart.
@@ -41,29 +43,89 @@ var legendCharsASCII = []rune{'*', 'o', '#', 'x', '+', '@', '=', '~', '%', '&'}
 //	    termcharts.WithLabels([]string{"Chrome", "Firefox", "Safari", "Edge", "Other"}),
 //	)
 //	fmt.Println(pie.Render())
-func NewPieChart(opts ...Option) *PieChart {
-	options := NewOptions(opts...)
-	return &PieChart{
+func NewPieChart(opts ...PieOption) *PieChart {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applyPie(options)
+	}
+	p := &PieChart{
 		opts: options,
 	}
+	if options.Strict {
+		p.err = p.validateOptions()
+	}
+	return p
+}
+
+// WithEmphasis calls attention to one slice of a pie chart, counted from 0:
+// the slice is drawn offset from the center, and with color it and its
+// legend entry are drawn in bold. A negative index emphasizes no slice.
+//
+// Example:
+//
+//	pie := termcharts.NewPieChart(
+//	    termcharts.WithData([]float64{30, 25, 20, 15, 10}),
+//	    termcharts.WithLabels([]string{"Chrome", "Firefox", "Safari", "Edge", "Other"}),
+//	    termcharts.WithEmphasis(2),
+//	)
+func WithEmphasis(index int) PieOption {
+	return pieOption(func(o *Options) {
+		o.Emphasize, o.Emphasis = index >= 0, index
+	})
+}
+
+// Update applies opts to the pie chart, replacing the options they set.
+// The next Render draws the pie chart with the new options; with WithStrict
+// they are validated again. Use it to feed new data into a live display.
+func (p *PieChart) Update(opts ...PieOption) {
+	for _, opt := range opts {
+		opt.applyPie(p.opts)
+	}
+	p.err = nil
+	if p.opts.Strict {
+		p.err = p.validateOptions()
+	}
 }
 
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the pie chart Updatable.
+func (p *PieChart) Apply(opts ...Option) { p.Update(Combine(opts...)) }
+
 // Render generates the pie chart as a multi-line string.
+// It returns an empty string if the chart cannot be drawn; use RenderE to find out why.
 func (p *PieChart) Render() string {
-	// Validate data
-	if len(p.opts.Data) == 0 {
-		return ""
+	out, _ := p.RenderE()
+	return out
+}
+
+// RenderE generates the pie chart as a multi-line string.
+// It returns ErrEmptyData, ErrInvalidData, or ErrInvalidDimensions
+// (possibly wrapped) when the chart cannot be drawn.
+func (p *PieChart) RenderE() (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+	if err := p.opts.checkPoints(); err != nil {
+		return "", err
+	}
+
+	// A width or height of 0 is the terminal's; others are clamped
+	if opts := p.opts.sized(); opts != p.opts {
+		return (&PieChart{opts: opts}).RenderE()
+	}
+	if p.opts.noData() {
+		return p.opts.placeholder(p.opts.Width, p.opts.Height, p.shouldUseUnicode(), p.isColorEnabled()), nil
 	}
 
-	// Check for invalid values
-	if !internal.AllValid(p.opts.Data) {
-		return ""
+	// Validate data
+	if err := validateData(p.opts.Data); err != nil {
+		return "", err
 	}
 
 	// Calculate total and slices
 	slices := p.calculateSlices()
 	if len(slices) == 0 {
-		return ""
+		return "", fmt.Errorf("%w: pie chart needs at least one positive value", ErrInvalidData)
 	}
 
 	// Get rendering settings
@@ -79,7 +141,7 @@ func (p *PieChart) Render() string {
 	if p.opts.Title != "" {
 		titleText := p.opts.Title
 		if colorEnabled {
-			titleText = Colorize(titleText, theme.Text, true)
+			titleText = Colorize(titleText, p.opts.titleColor(theme), true)
 		}
 		result.WriteString(titleText)
 		result.WriteString("\n\n")
@@ -89,7 +151,7 @@ func (p *PieChart) Render() string {
 	pieWithLegend := p.renderCircularPieWithLegend(slices, colorEnabled, theme)
 	result.WriteString(pieWithLegend)
 
-	return result.String()
+	return p.opts.postProcess(result.String()), nil
 }
 
 // calculateSlices calculates the slice data including percentages.
@@ -149,47 +211,38 @@ func (p *PieChart) renderCircularPieWithLegend(slices []Slice, colorEnabled bool
 	radius := 6
 	aspectRatio := 2.0 // Terminal chars are ~2x taller than wide
 
+	// An emphasized slice is drawn one row out from the center, so the pie
+	// gets a margin for it
+	emphasis, margin := -1, 0
+	if p.opts.Emphasize && p.opts.Emphasis < len(slices) {
+		emphasis, margin = p.opts.Emphasis, 1
+	}
+
 	// Calculate cumulative angles for each slice (starting at top, going clockwise)
 	angles := make([]float64, len(slices)+1)
 	angles[0] = -math.Pi / 2 // Start at 12 o'clock
 	for i, slice := range slices {
 		angles[i+1] = angles[i] + (slice.Percentage/100)*2*math.Pi
 	}
+	var offsetX, offsetY float64
+	if emphasis >= 0 {
+		mid := (angles[emphasis] + angles[emphasis+1]) / 2
+		offsetX, offsetY = math.Cos(mid), math.Sin(mid)
+	}
 
-	// Build pie chart rows
-	pieRows := make([]string, 0)
-	for y := -radius; y <= radius; y++ {
-		var row strings.Builder
-		for x := -int(float64(radius) * aspectRatio); x <= int(float64(radius)*aspectRatio); x++ {
-			// Calculate actual position accounting for aspect ratio
-			actualX := float64(x) / aspectRatio
-			actualY := float64(y)
-
-			// Calculate distance from center
-			distance := math.Sqrt(actualX*actualX + actualY*actualY)
-
-			// Check if point is inside the circle
-			if distance <= float64(radius)-0.5 {
-				// Calculate angle of this point
-				angle := math.Atan2(actualY, actualX)
-
-				// Find which slice this angle belongs to
-				sliceIndex := p.findSliceForAngle(angle, angles, len(slices))
-
-				// Apply color if enabled - use uniform character with different colors
-				if colorEnabled {
-					color := theme.GetSeriesColor(sliceIndex)
-					row.WriteString(Colorize(string(pChar), color, true))
-				} else {
-					// Without colors, use different characters to distinguish slices
-					char := lChars[sliceIndex%len(lChars)]
-					row.WriteString(string(char))
-				}
-			} else {
-				row.WriteString(" ")
-			}
+	// sliceAt returns the slice at a point relative to the center, or -1
+	// outside the circle
+	sliceAt := func(x, y float64) int {
+		if math.Sqrt(x*x+y*y) > float64(radius)-0.5 {
+			return -1
+		}
+		return p.findSliceForAngle(math.Atan2(y, x), angles, len(slices))
+	}
+	sliceColor := func(i int) string {
+		if i == emphasis {
+			return "bold " + p.opts.seriesColor(theme, i, slices[i].Label)
 		}
-		pieRows = append(pieRows, row.String())
+		return p.opts.seriesColor(theme, i, slices[i].Label)
 	}
 
 	// Build legend entries
@@ -199,38 +252,78 @@ func (p *PieChart) renderCircularPieWithLegend(slices []Slice, colorEnabled bool
 
 		if colorEnabled {
 			// With colors: use uniform char with slice color
-			color := theme.GetSeriesColor(i)
-			entry.WriteString(Colorize(string(pChar), color, true))
+			run := newColorRun(&entry)
+			run.writeRune(pChar, sliceColor(i))
+			run.end()
 		} else {
 			// Without colors: use different chars to match pie
-			char := lChars[i%len(lChars)]
-			entry.WriteString(string(char))
+			entry.WriteRune(lChars[i%len(lChars)])
 		}
 		entry.WriteString(" ")
 
 		// Format: symbol label percentage [value]
+		percent := localizeNumber(fmt.Sprintf("%5.1f", slice.Percentage), p.opts.Locale)
+		text := fmt.Sprintf("%s %s%%", internal.FillRight(slice.Label, 8), percent)
 		if p.opts.ShowValues {
-			entry.WriteString(fmt.Sprintf("%-8s %5.1f%% [%.1f]", slice.Label, slice.Percentage, slice.Value))
-		} else {
-			entry.WriteString(fmt.Sprintf("%-8s %5.1f%%", slice.Label, slice.Percentage))
+			text += fmt.Sprintf(" [%s]", localizeNumber(fmt.Sprintf("%.1f", slice.Value), p.opts.Locale))
+		}
+		style := p.opts.LegendStyle
+		if i == emphasis {
+			style.Bold = true
 		}
+		entry.WriteString(style.Render(text, colorEnabled))
 
 		legendEntries[i] = entry.String()
 	}
 
-	// Combine pie chart and legend side by side
-	var result strings.Builder
-	legendStartRow := (len(pieRows) - len(legendEntries)) / 2
+	// Draw the pie and legend side by side into one buffer, sized for the
+	// widest multi-byte characters so it never has to grow
+	pieRows := 2*(radius+margin) + 1
+	halfCols := int(float64(radius)*aspectRatio) + int(aspectRatio)*margin
+	pieCols := 2*halfCols + 1
+	legendStartRow := (pieRows - len(legendEntries)) / 2
 	if legendStartRow < 0 {
 		legendStartRow = 0
 	}
+	legendSize := 0
+	for _, entry := range legendEntries {
+		legendSize += len(entry)
+	}
+
+	var result strings.Builder
+	result.Grow(pieRows*(pieCols*utf8.UTFMax+len("   \n")) + legendSize)
+	run := newColorRun(&result)
+	for y := -radius - margin; y <= radius+margin; y++ {
+		for x := -halfCols; x <= halfCols; x++ {
+			// Calculate actual position accounting for aspect ratio
+			actualX := float64(x) / aspectRatio
+			actualY := float64(y)
+
+			// The emphasized slice is drawn from its offset position, and
+			// left empty where it would have been
+			sliceIndex := -1
+			if emphasis >= 0 && sliceAt(actualX-offsetX, actualY-offsetY) == emphasis {
+				sliceIndex = emphasis
+			} else if i := sliceAt(actualX, actualY); i != emphasis {
+				sliceIndex = i
+			}
 
-	for i, pieRow := range pieRows {
-		result.WriteString(pieRow)
+			switch {
+			case sliceIndex < 0:
+				run.writeRune(' ', "")
+			case colorEnabled:
+				// Uniform character with a different color per slice
+				run.writeRune(pChar, sliceColor(sliceIndex))
+			default:
+				// Without colors, use different characters to distinguish slices
+				run.writeRune(lChars[sliceIndex%len(lChars)], "")
+			}
+		}
+		run.end()
 		result.WriteString("   ") // Gap between pie and legend
 
 		// Add legend entry if available for this row
-		legendIdx := i - legendStartRow
+		legendIdx := y + radius + margin - legendStartRow
 		if legendIdx >= 0 && legendIdx < len(legendEntries) {
 			result.WriteString(legendEntries[legendIdx])
 		}
@@ -273,6 +366,29 @@ func (p *PieChart) shouldUseUnicode() bool {
 	return internal.SupportsUnicode()
 }
 
+// validateOptions reports options that pie charts cannot honor.
+func (p *PieChart) validateOptions() error {
+	if err := p.opts.Validate(); err != nil {
+		return err
+	}
+	if p.opts.Style.subCell() {
+		return conflict("%s style is only supported by line charts", p.opts.Style)
+	}
+	if len(p.opts.Series) > 0 {
+		return conflict("pie charts display a single data set; use WithData instead of WithSeries")
+	}
+	if p.opts.Direction == Vertical {
+		return conflict("pie charts have no direction; remove WithDirection(Vertical)")
+	}
+	if p.opts.BarMode != BarModeGrouped {
+		return conflict("bar mode only applies to bar charts")
+	}
+	if p.opts.Emphasize && p.opts.Emphasis >= len(p.opts.Data) {
+		return fmt.Errorf("%w: emphasized slice %d is out of range for %d values", ErrInvalidOption, p.opts.Emphasis, len(p.opts.Data))
+	}
+	return nil
+}
+
 // isColorEnabled determines whether colors should be used.
 func (p *PieChart) isColorEnabled() bool {
 	if p.opts.ColorEnabled != nil {
@@ -1,6 +1,7 @@
 package termcharts
 
 import (
+	"errors"
 	"math"
 	"strings"
 	"testing"
@@ -380,3 +381,37 @@ func TestPieChart_ManySlices(t *testing.T) {
 		t.Error("expected slices to show 10.0%")
 	}
 }
+
+func TestPieChart_Render_WithEmphasis(t *testing.T) {
+	data := []float64{30, 25, 20, 15, 10}
+	plain := NewPieChart(WithData(data), WithStyle(StyleASCII), WithColor(false)).Render()
+
+	// The offset slice needs a row above and below the pie
+	emphasized := NewPieChart(WithData(data), WithStyle(StyleASCII), WithColor(false), WithEmphasis(2)).Render()
+	if got, want := strings.Count(emphasized, "\n"), strings.Count(plain, "\n")+2; got != want {
+		t.Errorf("emphasized pie has %d lines, want %d:\n%s", got, want, emphasized)
+	}
+	if !strings.Contains(emphasized, "# Item 3") {
+		t.Errorf("emphasized pie is missing its legend entry:\n%s", emphasized)
+	}
+
+	if got := NewPieChart(WithData(data), WithStyle(StyleASCII), WithColor(false), WithEmphasis(-1)).Render(); got != plain {
+		t.Errorf("negative emphasis =\n%s\nwant the plain pie\n%s", got, plain)
+	}
+
+	colored := NewPieChart(WithData([]float64{30, 70}), WithStyle(StyleASCII), WithColor(true), WithEmphasis(0)).Render()
+	for _, want := range []string{
+		Colorize("*", "bold "+DefaultTheme.GetSeriesColor(0), true),
+		Colorize("Item 1    30.0%", "bold", true),
+		"Item 2    70.0%\n",
+	} {
+		if !strings.Contains(colored, want) {
+			t.Errorf("colored pie = %q, want it to contain %q", colored, want)
+		}
+	}
+
+	strict := NewPieChart(WithData(data), WithEmphasis(5), WithStrict(true))
+	if _, err := strict.RenderE(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("RenderE() error = %v, want ErrInvalidOption", err)
+	}
+}
@@ -0,0 +1,97 @@
+package termcharts
+
+import (
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// WithEmptyMessage makes a chart with no data render a placeholder instead
+// of an empty string: a box of the chart's width and height with the title in
+// its top border and msg centered inside. Dashboards use it to keep a stable
+// layout while a metric is missing. Sparklines, which are a single line, draw
+// msg centered in their width instead of a box. RenderE returns the
+// placeholder without an error.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithData(latency),
+//	    termcharts.WithTitle("p99 latency"),
+//	    termcharts.WithEmptyMessage("no data"),
+//	)
+func WithEmptyMessage(msg string) Option {
+	return func(o *Options) {
+		o.EmptyMessage = msg
+	}
+}
+
+// noData reports whether o has an empty message set and no data to draw.
+func (o *Options) noData() bool {
+	return o.EmptyMessage != "" && len(o.Data) == 0 && seriesEmpty(o.Series)
+}
+
+// seriesEmpty reports whether none of series has data.
+func seriesEmpty(series []Series) bool {
+	for _, s := range series {
+		if len(s.Data) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// placeholder draws the empty-data placeholder, width by height, and runs
+// the post-processors over it. With fewer than three rows it is a single
+// line holding the message, padded to width if one is set.
+func (o *Options) placeholder(width, height int, useUnicode, colorEnabled bool) string {
+	color := ""
+	if colorEnabled {
+		theme := o.Theme
+		if theme == nil {
+			theme = DefaultTheme
+		}
+		color = theme.Muted
+	}
+
+	if height < 3 || width < 2 {
+		if width <= 0 {
+			return o.postProcessors(o.EmptyMessage)
+		}
+		line := internal.Center(truncateLabel(o.EmptyMessage, width, useUnicode), width)
+		return o.postProcessors(line)
+	}
+
+	tl, tr, bl, br, h, v := "┌", "┐", "└", "┘", "─", "│"
+	if !useUnicode {
+		tl, tr, bl, br, h, v = "+", "+", "+", "+", "-", "|"
+	}
+	inner := width - 2
+
+	var result strings.Builder
+	top := strings.Repeat(h, inner)
+	if o.Title != "" && inner >= 4 {
+		title := " " + truncateLabel(o.Title, inner-3, useUnicode) + " "
+		top = h + title + strings.Repeat(h, inner-1-internal.StringWidth(title))
+	}
+	result.WriteString(Colorize(tl+top+tr, color, colorEnabled))
+	result.WriteString("\n")
+
+	// The message sits on the middle row, or just above the middle
+	side := Colorize(v, color, colorEnabled)
+	msgRow := (height - 3) / 2
+	for row := 0; row < height-2; row++ {
+		text := ""
+		if row == msgRow {
+			text = truncateLabel(o.EmptyMessage, inner, useUnicode)
+		}
+		result.WriteString(side)
+		result.WriteString(internal.Center(text, inner))
+		result.WriteString(side)
+		result.WriteString("\n")
+	}
+
+	result.WriteString(Colorize(bl+strings.Repeat(h, inner)+br, color, colorEnabled))
+	result.WriteString("\n")
+	return o.postProcessors(result.String())
+}
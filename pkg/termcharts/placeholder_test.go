@@ -0,0 +1,92 @@
+package termcharts
+
+import "testing"
+
+func TestWithEmptyMessage(t *testing.T) {
+	empty := WithEmptyMessage("no data")
+	tests := []struct {
+		name     string
+		chart    ChartE
+		expected string
+	}{
+		{
+			name:  "line chart with title",
+			chart: NewLineChart(empty, WithTitle("p99 latency"), WithWidth(24), WithHeight(5), WithStyle(StyleUnicode), WithColor(false)),
+			expected: "┌─ p99 latency ────────┐\n" +
+				"│                      │\n" +
+				"│       no data        │\n" +
+				"│                      │\n" +
+				"└──────────────────────┘\n",
+		},
+		{
+			name:  "ascii bar chart",
+			chart: NewBarChart(empty, WithWidth(12), WithHeight(3), WithStyle(StyleASCII), WithColor(false)),
+			expected: "+----------+\n" +
+				"| no data  |\n" +
+				"+----------+\n",
+		},
+		{
+			name:  "multi-series chart without data",
+			chart: NewBarChart(empty, WithSeries([]Series{{Label: "a"}}), WithWidth(9), WithHeight(3), WithStyle(StyleASCII), WithColor(false)),
+			expected: "+-------+\n" +
+				"|no data|\n" +
+				"+-------+\n",
+		},
+		{
+			name:     "message truncated",
+			chart:    NewPieChart(empty, WithWidth(8), WithHeight(3), WithStyle(StyleUnicode), WithColor(false)),
+			expected: "┌──────┐\n│no da…│\n└──────┘\n",
+		},
+		{
+			name:     "composed chart",
+			chart:    Compose([]Layer{{Kind: LayerLine}}, empty, WithWidth(9), WithHeight(3), WithStyle(StyleASCII), WithColor(false)),
+			expected: "+-------+\n|no data|\n+-------+\n",
+		},
+		{
+			name:     "sparkline",
+			chart:    NewSparkline(empty, WithWidth(11)),
+			expected: "  no data  ",
+		},
+		{
+			name: "post-processed",
+			chart: NewBigText(empty, WithWidth(9), WithHeight(3), WithStyle(StyleASCII), WithColor(false),
+				WithPostProcessor(func(lines []string) []string {
+					for i := range lines {
+						lines[i] = "> " + lines[i]
+					}
+					return lines
+				})),
+			expected: "> +-------+\n> |no data|\n> +-------+\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.chart.RenderE()
+			if err != nil {
+				t.Fatalf("RenderE() error = %v", err)
+			}
+			if out != tt.expected {
+				t.Errorf("RenderE() = %q, want %q", out, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithEmptyMessage_ColoredBorder(t *testing.T) {
+	out := NewLineChart(WithEmptyMessage("no data"), WithWidth(10), WithHeight(3), WithStyle(StyleASCII), WithColor(true)).Render()
+	expected := Colorize("+--------+", DefaultTheme.Muted, true) + "\n" +
+		Colorize("|", DefaultTheme.Muted, true) + "no data " + Colorize("|", DefaultTheme.Muted, true) + "\n" +
+		Colorize("+--------+", DefaultTheme.Muted, true) + "\n"
+	if out != expected {
+		t.Errorf("Render() = %q, want %q", out, expected)
+	}
+}
+
+func TestWithEmptyMessage_DataRendersChart(t *testing.T) {
+	opts := []LineOption{WithData([]float64{1, 2, 3}), WithWidth(20), WithHeight(6), WithColor(false)}
+	want := NewLineChart(opts...).Render()
+	if got := NewLineChart(append(opts, WithEmptyMessage("no data"))...).Render(); got != want {
+		t.Errorf("Render() with data = %q, want the chart %q", got, want)
+	}
+}
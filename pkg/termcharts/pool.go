@@ -0,0 +1,170 @@
+package termcharts
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Renders in watch loops redraw the same chart many times a second. The
+// pools below let each render reuse the output buffer and cell grids of an
+// earlier one instead of allocating them again.
+
+// maxPooledBuffer is the largest buffer capacity kept for reuse, so one huge
+// render does not pin its memory for the life of the process.
+const maxPooledBuffer = 1 << 20
+
+// bufferPool holds output buffers.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// getBuffer returns an empty output buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns buf to the pool. buf must not be used afterwards.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// frameBytes estimates the output size of a chart drawn on width by height
+// cells, for pre-sizing buffers. Chart glyphs such as blocks, box drawing
+// lines, and braille take three bytes each; the extra rows cover titles and
+// axis labels.
+func frameBytes(width, height int) int {
+	return (height + 3) * (width*3 + 1)
+}
+
+// cellGrid is a character grid with a color per cell, backed by two
+// contiguous arrays so it can be reused between renders.
+type cellGrid struct {
+	runes  []rune
+	colors []string
+	// rows and colorRows index the backing arrays by row.
+	rows      [][]rune
+	colorRows [][]string
+}
+
+// gridPool holds cell grids.
+var gridPool = sync.Pool{
+	New: func() interface{} { return new(cellGrid) },
+}
+
+// getGrid returns a grid of width by height blank cells from the pool.
+func getGrid(width, height int) *cellGrid {
+	g := gridPool.Get().(*cellGrid)
+	n := width * height
+	if cap(g.runes) < n {
+		g.runes = make([]rune, n)
+		g.colors = make([]string, n)
+	}
+	g.runes, g.colors = g.runes[:n], g.colors[:n]
+	for i := range g.runes {
+		g.runes[i] = ' '
+	}
+
+	if cap(g.rows) < height {
+		g.rows = make([][]rune, height)
+		g.colorRows = make([][]string, height)
+	}
+	g.rows, g.colorRows = g.rows[:height], g.colorRows[:height]
+	for y := 0; y < height; y++ {
+		g.rows[y] = g.runes[y*width : (y+1)*width : (y+1)*width]
+		g.colorRows[y] = g.colors[y*width : (y+1)*width : (y+1)*width]
+	}
+	return g
+}
+
+// putGrid returns g to the pool. Its rows must not be used afterwards.
+func putGrid(g *cellGrid) {
+	// Drop color references so the pool does not keep strings alive
+	for i := range g.colors {
+		g.colors[i] = ""
+	}
+	gridPool.Put(g)
+}
+
+// dotGrid is a Braille dot grid with a color per character cell.
+type dotGrid struct {
+	dots   []bool
+	colors []string
+	// rows and colorRows index the backing arrays by dot row and character row.
+	rows      [][]bool
+	colorRows [][]string
+}
+
+// dotGridPool holds Braille dot grids.
+var dotGridPool = sync.Pool{
+	New: func() interface{} { return new(dotGrid) },
+}
+
+// getDotGrid returns a clear grid of dotWidth by dotHeight dots, colored per
+// character cell of charWidth by charHeight, from the pool.
+func getDotGrid(dotWidth, dotHeight, charWidth, charHeight int) *dotGrid {
+	g := dotGridPool.Get().(*dotGrid)
+
+	n := dotWidth * dotHeight
+	if cap(g.dots) < n {
+		g.dots = make([]bool, n)
+	}
+	g.dots = g.dots[:n]
+	for i := range g.dots {
+		g.dots[i] = false
+	}
+	if cap(g.rows) < dotHeight {
+		g.rows = make([][]bool, dotHeight)
+	}
+	g.rows = g.rows[:dotHeight]
+	for y := range g.rows {
+		g.rows[y] = g.dots[y*dotWidth : (y+1)*dotWidth : (y+1)*dotWidth]
+	}
+
+	m := charWidth * charHeight
+	if cap(g.colors) < m {
+		g.colors = make([]string, m)
+	}
+	g.colors = g.colors[:m]
+	if cap(g.colorRows) < charHeight {
+		g.colorRows = make([][]string, charHeight)
+	}
+	g.colorRows = g.colorRows[:charHeight]
+	for y := range g.colorRows {
+		g.colorRows[y] = g.colors[y*charWidth : (y+1)*charWidth : (y+1)*charWidth]
+	}
+	return g
+}
+
+// putDotGrid returns g to the pool. Its rows must not be used afterwards.
+func putDotGrid(g *dotGrid) {
+	for i := range g.colors {
+		g.colors[i] = ""
+	}
+	dotGridPool.Put(g)
+}
+
+// merge draws the dots of src over g in character rows start to end. Cells
+// src drew on take its color.
+func (g *dotGrid) merge(src *dotGrid, start, end int) {
+	for row := start; row < end; row++ {
+		for col := range g.colorRows[row] {
+			drawn := false
+			for dotRow := row * 4; dotRow < row*4+4; dotRow++ {
+				for dotCol := col * 2; dotCol < col*2+2; dotCol++ {
+					if src.rows[dotRow][dotCol] {
+						g.rows[dotRow][dotCol] = true
+						drawn = true
+					}
+				}
+			}
+			if drawn {
+				g.colorRows[row][col] = src.colorRows[row][col]
+			}
+		}
+	}
+}
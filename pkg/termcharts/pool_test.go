@@ -0,0 +1,77 @@
+package termcharts
+
+import (
+	"testing"
+)
+
+func TestGetGrid_ReusedGridIsBlank(t *testing.T) {
+	g := getGrid(4, 3)
+	g.rows[1][2] = 'x'
+	g.colorRows[2][3] = "red"
+	putGrid(g)
+
+	for _, size := range [][2]int{{4, 3}, {2, 5}, {6, 1}} {
+		g := getGrid(size[0], size[1])
+		if len(g.rows) != size[1] {
+			t.Fatalf("getGrid(%d, %d) has %d rows", size[0], size[1], len(g.rows))
+		}
+		for y, row := range g.rows {
+			if len(row) != size[0] || len(g.colorRows[y]) != size[0] {
+				t.Fatalf("getGrid(%d, %d) row %d has width %d", size[0], size[1], y, len(row))
+			}
+			for x, r := range row {
+				if r != ' ' || g.colorRows[y][x] != "" {
+					t.Errorf("getGrid(%d, %d) cell (%d, %d) = %q %q, want blank", size[0], size[1], x, y, r, g.colorRows[y][x])
+				}
+			}
+		}
+		g.rows[0][0] = 'y'
+		putGrid(g)
+	}
+}
+
+func TestGetDotGrid_ReusedGridIsClear(t *testing.T) {
+	g := getDotGrid(4, 8, 2, 2)
+	g.rows[7][3] = true
+	g.colorRows[1][1] = "blue"
+	putDotGrid(g)
+
+	g = getDotGrid(4, 8, 2, 2)
+	defer putDotGrid(g)
+	for y, row := range g.rows {
+		for x, dot := range row {
+			if dot {
+				t.Errorf("dot (%d, %d) is set in a reused grid", x, y)
+			}
+		}
+	}
+	for y, row := range g.colorRows {
+		for x, color := range row {
+			if color != "" {
+				t.Errorf("cell (%d, %d) has color %q in a reused grid", x, y, color)
+			}
+		}
+	}
+}
+
+func TestRender_RepeatedRendersMatch(t *testing.T) {
+	charts := map[string]Chart{
+		"line":    NewLineChart(WithData([]float64{1, 5, 2, 8}), WithColor(true), WithStyle(StyleUnicode)),
+		"braille": NewLineChart(WithData([]float64{1, 5, 2, 8}), WithColor(true), WithStyle(StyleBraille)),
+		"bar":     NewBarChart(WithData([]float64{1, 5, 2, 8}), WithColor(true), WithShowValues(true)),
+		"compose": Compose([]Layer{
+			{Kind: LayerBar, Series: Series{Data: []float64{1, 5, 2, 8}}},
+			{Kind: LayerLine, Series: Series{Data: []float64{2, 3, 4, 5}}},
+		}, WithColor(true)),
+	}
+	for name, chart := range charts {
+		t.Run(name, func(t *testing.T) {
+			first := chart.Render()
+			// A render of another chart in between reuses the pooled buffers
+			NewLineChart(WithData([]float64{9, 1, 9, 1}), WithStyle(StyleBraille), WithColor(true)).Render()
+			if second := chart.Render(); second != first {
+				t.Errorf("second render differs:\n%s\nfirst:\n%s", second, first)
+			}
+		})
+	}
+}
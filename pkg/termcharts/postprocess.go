@@ -0,0 +1,57 @@
+package termcharts
+
+import "strings"
+
+// PostProcessor transforms the lines of a rendered chart before it is
+// returned. Lines do not include their trailing newlines.
+type PostProcessor func(lines []string) []string
+
+// WithPostProcessor adds a post-processor to the chart's output hook chain.
+// Post-processors run in the order they are added, each receiving the lines
+// returned by the previous one, after the chart is fully rendered. Use them to
+// indent output, add a watermark, or inject annotations.
+//
+// Example:
+//
+//	termcharts.WithPostProcessor(func(lines []string) []string {
+//	    for i := range lines {
+//	        lines[i] = "  " + lines[i]
+//	    }
+//	    return lines
+//	})
+func WithPostProcessor(fn PostProcessor) Option {
+	return func(o *Options) {
+		o.PostProcessors = append(o.PostProcessors, fn)
+	}
+}
+
+// postProcess draws insets, adds the text summary, and runs the
+// post-processor chain over rendered output, keeping a trailing newline
+// if the output had one.
+func (o *Options) postProcess(out string) string {
+	out = o.drawInsets(out)
+	out = o.applyTextSummary(out)
+	return o.postProcessors(out)
+}
+
+// postProcessors runs the post-processor chain over out, keeping a trailing
+// newline if out had one.
+func (o *Options) postProcessors(out string) string {
+	if len(o.PostProcessors) == 0 {
+		return out
+	}
+
+	trailing := strings.HasSuffix(out, "\n")
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	for _, fn := range o.PostProcessors {
+		if fn != nil {
+			lines = fn(lines)
+		}
+	}
+
+	result := strings.Join(lines, "\n")
+	if trailing && len(lines) > 0 {
+		result += "\n"
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package termcharts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithPostProcessor(t *testing.T) {
+	indent := func(lines []string) []string {
+		for i := range lines {
+			lines[i] = "  " + lines[i]
+		}
+		return lines
+	}
+	watermark := func(lines []string) []string {
+		return append(lines, "(c) example")
+	}
+
+	tests := []struct {
+		name     string
+		chart    Chart
+		expected string
+	}{
+		{
+			name: "sparkline without trailing newline",
+			chart: NewSparkline(
+				WithData([]float64{1, 2, 3}),
+				WithStyle(StyleASCII),
+				WithColor(false),
+				WithPostProcessor(indent),
+			),
+			expected: "  _=@",
+		},
+		{
+			name: "processors run in order",
+			chart: NewSparkline(
+				WithData([]float64{1, 2, 3}),
+				WithStyle(StyleASCII),
+				WithColor(false),
+				WithPostProcessor(watermark),
+				WithPostProcessor(indent),
+			),
+			expected: "  _=@\n  (c) example",
+		},
+		{
+			name: "trailing newline kept",
+			chart: NewBigText(
+				WithData([]float64{1}),
+				WithStyle(StyleASCII),
+				WithColor(false),
+				WithPostProcessor(watermark),
+			),
+			expected: " #\n##\n #\n #\n###\n(c) example\n",
+		},
+		{
+			name: "nil processor skipped",
+			chart: NewSparkline(
+				WithData([]float64{1, 2, 3}),
+				WithStyle(StyleASCII),
+				WithColor(false),
+				WithPostProcessor(nil),
+			),
+			expected: "_=@",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.chart.Render(); got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithPostProcessor_AllCharts(t *testing.T) {
+	marker := func(lines []string) []string {
+		return append([]string{"#marker"}, lines...)
+	}
+	data := WithData([]float64{1, 2, 3})
+
+	charts := map[string]Chart{
+		"bar":   NewBarChart(data, WithPostProcessor(marker)),
+		"line":  NewLineChart(data, WithPostProcessor(marker)),
+		"pie":   NewPieChart(data, WithPostProcessor(marker)),
+		"spark": NewSparkline(data, WithPostProcessor(marker)),
+		"kpi":   NewBigText(data, WithPostProcessor(marker)),
+	}
+	for name, chart := range charts {
+		if out := chart.Render(); !strings.HasPrefix(out, "#marker\n") {
+			t.Errorf("%s chart output not post-processed: %q", name, out)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package termcharts
+
+import "github.com/neilpeterson/termcharts/internal"
+
+// Reducer reduces a stream of values to the points a chart needs, as the
+// values arrive, instead of collecting them into a slice first. It keeps the
+// first and last values and the minimum and maximum of evenly sized buckets,
+// the same points WithMaxPoints draws, so charting a file with millions of
+// rows uses memory proportional to the chart width rather than the file.
+//
+// Example:
+//
+//	reducer := termcharts.NewReducer(8 * width)
+//	for scanner.Scan() {
+//	    v, _ := strconv.ParseFloat(scanner.Text(), 64)
+//	    reducer.Add(v)
+//	}
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithData(reducer.Points()),
+//	    termcharts.WithWidth(width),
+//	)
+type Reducer struct {
+	r *internal.Reducer
+}
+
+// NewReducer creates a Reducer that keeps at most maxPoints points.
+// A few points per chart column, such as eight times the width, leave the
+// chart's own downsampling enough points to place the minimum and maximum
+// of each column. Values below 2 are treated as 2.
+func NewReducer(maxPoints int) *Reducer {
+	return &Reducer{r: internal.NewReducer(maxPoints)}
+}
+
+// Add appends v to the stream.
+func (r *Reducer) Add(v float64) {
+	r.r.Add(v)
+}
+
+// Count returns the number of values added so far.
+func (r *Reducer) Count() int {
+	return r.r.Count()
+}
+
+// Points returns the reduced data, ready for WithData. A stream of at most
+// maxPoints values is returned unchanged.
+func (r *Reducer) Points() []float64 {
+	return r.r.Points()
+}
+
+// ReduceFunc calls next until it reports false and returns the values it
+// produced, reduced to at most maxPoints points by a Reducer.
+//
+// Example:
+//
+//	i := 0
+//	data := termcharts.ReduceFunc(func() (float64, bool) {
+//	    if i == len(samples) {
+//	        return 0, false
+//	    }
+//	    i++
+//	    return samples[i-1], true
+//	}, 120)
+func ReduceFunc(next func() (float64, bool), maxPoints int) []float64 {
+	r := NewReducer(maxPoints)
+	for {
+		v, ok := next()
+		if !ok {
+			return r.Points()
+		}
+		r.Add(v)
+	}
+}
@@ -0,0 +1,82 @@
+package termcharts
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReduceFunc(t *testing.T) {
+	const n = 500000
+	i := 0
+	data := ReduceFunc(func() (float64, bool) {
+		if i == n {
+			return 0, false
+		}
+		i++
+		if i == 250000 {
+			return 500, true
+		}
+		return float64(i % 10), true
+	}, 120)
+
+	if len(data) > 120 {
+		t.Fatalf("ReduceFunc returned %d points, want at most 120", len(data))
+	}
+	if i != n {
+		t.Errorf("ReduceFunc consumed %d values, want %d", i, n)
+	}
+	found := false
+	for _, v := range data {
+		if v == 500 {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("ReduceFunc dropped the spike")
+	}
+}
+
+func TestReducer_ShortStreamUnchanged(t *testing.T) {
+	r := NewReducer(10)
+	for _, v := range []float64{4, 8, 15, 16, 23, 42} {
+		r.Add(v)
+	}
+	if r.Count() != 6 {
+		t.Errorf("Count() = %d, want 6", r.Count())
+	}
+	if got, want := r.Points(), []float64{4, 8, 15, 16, 23, 42}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Points() = %v, want %v", got, want)
+	}
+}
+
+func TestReducer_LineChartRange(t *testing.T) {
+	// A chart of the reduced stream spans the same value range as the full data
+	data := make([]float64, 100000)
+	for i := range data {
+		data[i] = float64(i%97) - 10
+	}
+	r := NewReducer(2 * 60)
+	for _, v := range data {
+		r.Add(v)
+	}
+
+	opts := []LineOption{WithWidth(60), WithHeight(10), WithStyle(StyleASCII), WithColor(false)}
+	full := NewLineChart(append(opts, WithData(data))...).Render()
+	reduced := NewLineChart(append(opts, WithData(r.Points()))...).Render()
+	if got, want := yAxisOf(reduced), yAxisOf(full); got != want {
+		t.Errorf("reduced y axis = %q, want %q", got, want)
+	}
+}
+
+// yAxisOf returns the y axis labels of a rendered chart, the first field of
+// each line.
+func yAxisOf(out string) string {
+	var labels []string
+	for _, line := range strings.Split(out, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			labels = append(labels, fields[0])
+		}
+	}
+	return strings.Join(labels, " ")
+}
@@ -0,0 +1,78 @@
+package termcharts
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ChartFactory creates a chart from a set of options.
+// Factories are registered with RegisterChart so that tools such as the
+// termcharts CLI can build chart types they were not compiled against.
+type ChartFactory func(opts ...Option) Chart
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]ChartFactory)
+)
+
+func init() {
+	RegisterChart("kpi", func(opts ...Option) Chart { return NewBigText(Combine(opts...)) })
+	RegisterChart("bar", func(opts ...Option) Chart { return NewBarChart(Combine(opts...)) })
+	RegisterChart("line", func(opts ...Option) Chart { return NewLineChart(Combine(opts...)) })
+	RegisterChart("pie", func(opts ...Option) Chart { return NewPieChart(Combine(opts...)) })
+	RegisterChart("histogram", func(opts ...Option) Chart { return NewHistogram(Combine(opts...)) })
+	RegisterChart("box", func(opts ...Option) Chart { return NewBoxPlot(Combine(opts...)) })
+	RegisterChart("spark", func(opts ...Option) Chart { return NewSparkline(Combine(opts...)) })
+}
+
+// RegisterChart makes a chart type available under the given name.
+// It is intended to be called from an init function of the package that
+// defines the chart. RegisterChart panics if name is empty, factory is nil,
+// or a chart is already registered under name.
+//
+// Example:
+//
+//	func init() {
+//	    termcharts.RegisterChart("gauge", func(opts ...termcharts.Option) termcharts.Chart {
+//	        return NewGauge(opts...)
+//	    })
+//	}
+func RegisterChart(name string, factory ChartFactory) {
+	if name == "" {
+		panic("termcharts: RegisterChart name is empty")
+	}
+	if factory == nil {
+		panic("termcharts: RegisterChart factory is nil for " + name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("termcharts: RegisterChart called twice for %q", name))
+	}
+	registry[name] = factory
+}
+
+// LookupChart returns the factory registered under name.
+func LookupChart(name string) (ChartFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	factory, ok := registry[name]
+	return factory, ok
+}
+
+// RegisteredCharts returns the names of all registered chart types in sorted order.
+func RegisteredCharts() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,72 @@
+package termcharts
+
+import (
+	"testing"
+)
+
+func TestRegisteredCharts_Builtins(t *testing.T) {
+	for _, name := range []string{"bar", "line", "pie", "spark", "kpi", "histogram", "box"} {
+		factory, ok := LookupChart(name)
+		if !ok {
+			t.Errorf("LookupChart(%q) not found", name)
+			continue
+		}
+		chart := factory(WithData([]float64{1, 2, 3}), WithColor(false))
+		if chart.Render() == "" {
+			t.Errorf("chart %q rendered empty output", name)
+		}
+	}
+}
+
+func TestRegisterChart(t *testing.T) {
+	RegisterChart("test-registry", func(opts ...Option) Chart {
+		return NewSparkline(Combine(opts...))
+	})
+
+	if _, ok := LookupChart("test-registry"); !ok {
+		t.Fatal("registered chart not found")
+	}
+
+	names := RegisteredCharts()
+	found := false
+	for i, name := range names {
+		if name == "test-registry" {
+			found = true
+		}
+		if i > 0 && names[i-1] > name {
+			t.Errorf("RegisteredCharts() not sorted: %v", names)
+		}
+	}
+	if !found {
+		t.Errorf("RegisteredCharts() = %v, missing test-registry", names)
+	}
+
+	if _, ok := LookupChart("does-not-exist"); ok {
+		t.Error("LookupChart() found an unregistered chart")
+	}
+}
+
+func TestRegisterChart_Panics(t *testing.T) {
+	factory := func(opts ...Option) Chart { return NewBarChart(Combine(opts...)) }
+
+	tests := []struct {
+		name    string
+		chart   string
+		factory ChartFactory
+	}{
+		{name: "empty name", chart: "", factory: factory},
+		{name: "nil factory", chart: "nil-factory", factory: nil},
+		{name: "duplicate", chart: "bar", factory: factory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("RegisterChart() did not panic")
+				}
+			}()
+			RegisterChart(tt.chart, tt.factory)
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package termcharts
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// Cell is one character of a rendered chart and the style it is drawn in.
+type Cell struct {
+	// Rune is the character drawn in the cell.
+	Rune rune
+	// Style holds the colors and attributes of the cell (zero = terminal default).
+	Style Style
+	// Point is the data point the cell draws, for hosts that show the value
+	// under the mouse (nil = none). Sparklines set it on each character.
+	Point *DataPoint
+}
+
+// DataPoint identifies the value a cell of a Frame draws.
+type DataPoint struct {
+	// Index is the position of the value in the chart's data.
+	Index int
+	// Value is the value, as passed to the chart.
+	Value float64
+}
+
+// Frame is a rendered chart as rows of styled cells. It is the cell buffer
+// that Renderer backends draw, and can be handed directly to TUI libraries
+// that paint their own cells. Wide characters such as CJK text occupy a
+// single cell that spans two terminal columns.
+type Frame struct {
+	// Rows holds the cells of each line of the chart, top to bottom.
+	Rows [][]Cell
+}
+
+// Renderer draws a Frame to an output backend such as a terminal, a cell
+// buffer, or an image format. Charts lay out their cells once; every backend
+// draws the same Frame, so a new output format does not need its own version
+// of each chart type.
+type Renderer interface {
+	// Render draws frame to w.
+	Render(w io.Writer, frame *Frame) error
+}
+
+// RendererFunc adapts an ordinary function to the Renderer interface.
+type RendererFunc func(w io.Writer, frame *Frame) error
+
+// Render calls f(w, frame).
+func (f RendererFunc) Render(w io.Writer, frame *Frame) error {
+	return f(w, frame)
+}
+
+// NewFrame converts rendered chart output, text with ANSI color escapes as
+// returned by Render, into a Frame. A trailing newline does not produce an
+// extra empty row.
+func NewFrame(out string) *Frame {
+	rows := splitFrame(out)
+	frame := &Frame{Rows: make([][]Cell, len(rows))}
+	for i, row := range rows {
+		cells := make([]Cell, len(row))
+		for j, c := range row {
+			cells[j] = Cell{Rune: c.Rune, Style: parseSGR(c.Style)}
+		}
+		frame.Rows[i] = cells
+	}
+	return frame
+}
+
+// RenderFrame renders chart and returns its cells. Cells of sparklines carry
+// the data point they draw in Point. It returns the chart's error when chart
+// is a ChartE that cannot be drawn.
+func RenderFrame(chart Chart) (*Frame, error) {
+	out, err := renderChart(chart)
+	if err != nil {
+		return nil, err
+	}
+	frame := NewFrame(out)
+	if a, ok := chart.(annotator); ok {
+		a.annotate(frame)
+	}
+	return frame, nil
+}
+
+// annotator is a chart that attaches the data points it draws to the cells
+// of its rendered frame.
+type annotator interface {
+	annotate(frame *Frame)
+}
+
+// PointAt returns the data point drawn at terminal column x of row y, such
+// as the cell under the mouse, counting wide characters as two columns. It
+// reports false where no data point is drawn.
+func (f *Frame) PointAt(x, y int) (DataPoint, bool) {
+	if y < 0 || y >= len(f.Rows) || x < 0 {
+		return DataPoint{}, false
+	}
+	col := 0
+	for _, c := range f.Rows[y] {
+		col += cellWidth(c.Rune)
+		if x < col {
+			if c.Point == nil {
+				return DataPoint{}, false
+			}
+			return *c.Point, true
+		}
+	}
+	return DataPoint{}, false
+}
+
+// StyledSpan is a run of text drawn in one style.
+type StyledSpan struct {
+	// Text is the text of the run.
+	Text string
+	// Style holds the colors and attributes of the run (zero = terminal default).
+	Style Style
+}
+
+// StyledLine is one line of a rendered chart as runs of text, each drawn in
+// a single style. Neighboring spans differ in style.
+type StyledLine []StyledSpan
+
+// String returns the line as plain text.
+func (l StyledLine) String() string {
+	var b strings.Builder
+	for _, span := range l {
+		b.WriteString(span.Text)
+	}
+	return b.String()
+}
+
+// Lines returns the rows of the frame as styled lines, joining neighboring
+// cells of the same style into one span. Host applications can re-map the
+// colors of each span, e.g. from a theme's "blue" to a color of their own
+// palette, and draw the text with their own styling.
+func (f *Frame) Lines() []StyledLine {
+	lines := make([]StyledLine, len(f.Rows))
+	for i, row := range f.Rows {
+		line := StyledLine{}
+		var text strings.Builder
+		for j, c := range row {
+			if j > 0 && c.Style != row[j-1].Style {
+				line = append(line, StyledSpan{Text: text.String(), Style: row[j-1].Style})
+				text.Reset()
+			}
+			text.WriteRune(c.Rune)
+		}
+		if len(row) > 0 {
+			line = append(line, StyledSpan{Text: text.String(), Style: row[len(row)-1].Style})
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// RenderStyled renders chart and returns its lines as runs of styled text,
+// for host applications that draw charts with their own colors rather than
+// through ANSI escapes. Charts drawn without color, e.g. with
+// WithColor(false), have unstyled spans. It returns the chart's error when
+// chart is a ChartE that cannot be drawn.
+//
+// Example:
+//
+//	lines, err := termcharts.RenderStyled(chart)
+//	for _, line := range lines {
+//	    for _, span := range line {
+//	        draw(span.Text, palette[span.Style.Fg])
+//	    }
+//	}
+func RenderStyled(chart Chart) ([]StyledLine, error) {
+	frame, err := RenderFrame(chart)
+	if err != nil {
+		return nil, err
+	}
+	return frame.Lines(), nil
+}
+
+// RenderWith renders chart and draws it to w with renderer r.
+//
+// Example:
+//
+//	err := termcharts.RenderWith(file, chart, &termcharts.SVGRenderer{})
+func RenderWith(w io.Writer, chart Chart, r Renderer) error {
+	frame, err := RenderFrame(chart)
+	if err != nil {
+		return err
+	}
+	return r.Render(w, frame)
+}
+
+// Width returns the width of the widest row in terminal columns.
+func (f *Frame) Width() int {
+	width := 0
+	for _, row := range f.Rows {
+		width = internal.Max(width, rowWidth(row))
+	}
+	return width
+}
+
+// Height returns the number of rows.
+func (f *Frame) Height() int {
+	return len(f.Rows)
+}
+
+// String returns the frame as plain text, one line per row.
+func (f *Frame) String() string {
+	var b strings.Builder
+	for _, row := range f.Rows {
+		for _, c := range row {
+			b.WriteRune(c.Rune)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// rowWidth returns the width of a row of cells in terminal columns.
+func rowWidth(row []Cell) int {
+	width := 0
+	for _, c := range row {
+		width += cellWidth(c.Rune)
+	}
+	return width
+}
+
+// cellWidth returns the number of terminal columns r occupies.
+func cellWidth(r rune) int {
+	return internal.RuneWidth(r)
+}
+
+// ANSIRenderer draws frames as terminal text, with ANSI escape sequences
+// for styled cells when Color is set. It draws the same text and colors as a
+// chart's Render method.
+type ANSIRenderer struct {
+	// Color enables ANSI colors and attributes.
+	Color bool
+}
+
+// Render draws frame to w as lines of text.
+func (r *ANSIRenderer) Render(w io.Writer, frame *Frame) error {
+	var buf bytes.Buffer
+	for _, row := range frame.Rows {
+		if !r.Color {
+			for _, c := range row {
+				buf.WriteRune(c.Rune)
+			}
+		} else {
+			cells := make([]internal.Cell, len(row))
+			for i, c := range row {
+				cells[i] = internal.Cell{Rune: c.Rune, Style: c.Style.sequence()}
+			}
+			writeCells(&buf, cells)
+		}
+		buf.WriteString("\n")
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// SVG renderer defaults.
+const (
+	defaultSVGFontSize   = 14
+	defaultSVGFontFamily = "monospace"
+	defaultSVGForeground = "#e5e5e5"
+)
+
+// SVGRenderer draws frames as a standalone SVG document, one text element
+// per run of identically styled cells, for embedding charts in web pages and
+// reports. Cells are laid out on a monospace grid, so the image matches the
+// terminal rendering.
+type SVGRenderer struct {
+	// FontSize is the text size in pixels (0 = 14).
+	FontSize float64
+	// FontFamily is the CSS font family (empty = "monospace").
+	FontFamily string
+	// Background fills the image behind the chart (empty = transparent).
+	Background string
+	// Foreground colors unstyled text (empty = light gray).
+	Foreground string
+}
+
+// Render draws frame to w as an SVG document.
+func (r *SVGRenderer) Render(w io.Writer, frame *Frame) error {
+	fontSize := r.FontSize
+	if fontSize <= 0 {
+		fontSize = defaultSVGFontSize
+	}
+	family := r.FontFamily
+	if family == "" {
+		family = defaultSVGFontFamily
+	}
+	foreground := r.Foreground
+	if foreground == "" {
+		foreground = defaultSVGForeground
+	}
+
+	// Monospace cells are about 0.6em wide and 1.2em tall
+	cellW := fontSize * 0.6
+	cellH := fontSize * 1.2
+	width := float64(frame.Width()) * cellW
+	height := float64(frame.Height()) * cellH
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n",
+		svgNum(width), svgNum(height), svgNum(width), svgNum(height))
+	if r.Background != "" {
+		fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgColor(r.Background, foreground))
+	}
+	fmt.Fprintf(&buf, `<g font-family="%s" font-size="%s" fill="%s" xml:space="preserve">`+"\n",
+		html.EscapeString(family), svgNum(fontSize), svgColor(foreground, foreground))
+
+	for y, row := range frame.Rows {
+		baseline := float64(y)*cellH + fontSize
+		col := 0
+		for start := 0; start < len(row); {
+			// Collect a run of cells with the same style
+			end := start
+			runWidth := 0
+			for end < len(row) && row[end].Style == row[start].Style {
+				runWidth += cellWidth(row[end].Rune)
+				end++
+			}
+			text := make([]rune, 0, end-start)
+			for _, c := range row[start:end] {
+				text = append(text, c.Rune)
+			}
+			style := row[start].Style
+
+			x := float64(col) * cellW
+			fg, bg := style.Fg, style.Bg
+			if style.Reverse {
+				fg, bg = bg, fg
+				if fg == "" {
+					fg = r.Background
+				}
+				if fg == "" {
+					fg = "black"
+				}
+				if bg == "" {
+					bg = foreground
+				}
+			}
+			if bg != "" {
+				fmt.Fprintf(&buf, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+					svgNum(x), svgNum(float64(y)*cellH), svgNum(float64(runWidth)*cellW), svgNum(cellH), svgColor(bg, foreground))
+			}
+			if strings.TrimSpace(string(text)) != "" {
+				fmt.Fprintf(&buf, `<text x="%s" y="%s"%s>%s</text>`+"\n",
+					svgNum(x), svgNum(baseline), svgTextAttrs(style, fg, foreground), html.EscapeString(string(text)))
+			}
+
+			col += runWidth
+			start = end
+		}
+	}
+
+	buf.WriteString("</g>\n</svg>\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// svgTextAttrs returns the attributes of a text element drawn in style with
+// foreground color fg.
+func svgTextAttrs(style Style, fg, foreground string) string {
+	var attrs strings.Builder
+	if fg != "" {
+		fmt.Fprintf(&attrs, ` fill="%s"`, svgColor(fg, foreground))
+	}
+	if style.Bold {
+		attrs.WriteString(` font-weight="bold"`)
+	}
+	if style.Faint {
+		attrs.WriteString(` fill-opacity="0.6"`)
+	}
+	if style.Underline {
+		attrs.WriteString(` text-decoration="underline"`)
+	}
+	return attrs.String()
+}
+
+// svgColor returns color as a hex value, or fallback if it is unknown.
+func svgColor(color, fallback string) string {
+	rgb, ok := colorToRGB(color)
+	if !ok {
+		return fallback
+	}
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+}
+
+// svgNum formats a coordinate without trailing zeros.
+func svgNum(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// sgrColors maps SGR foreground codes to palette names; background codes are
+// the foreground code plus 10.
+var sgrColors = map[int]string{
+	30: "black",
+	31: "red",
+	32: "green",
+	33: "yellow",
+	34: "blue",
+	35: "magenta",
+	36: "cyan",
+	37: "white",
+	90: "gray",
+}
+
+// parseSGR decodes one or more SGR escape sequences into a Style.
+// Unsupported parameters are ignored.
+func parseSGR(seq string) Style {
+	var s Style
+	for _, part := range strings.Split(seq, "\033[") {
+		part = strings.TrimSuffix(part, "m")
+		if part == "" {
+			continue
+		}
+		params := strings.Split(part, ";")
+		for i := 0; i < len(params); i++ {
+			n, err := strconv.Atoi(params[i])
+			if err != nil {
+				continue
+			}
+			switch {
+			case n == 0:
+				s = Style{}
+			case n == 1:
+				s.Bold = true
+			case n == 2:
+				s.Faint = true
+			case n == 4:
+				s.Underline = true
+			case n == 7:
+				s.Reverse = true
+			case (n == 38 || n == 48) && i+4 < len(params) && params[i+1] == "2":
+				color := hexParams(params[i+2 : i+5])
+				if n == 38 {
+					s.Fg = color
+				} else {
+					s.Bg = color
+				}
+				i += 4
+			case sgrColors[n] != "":
+				s.Fg = sgrColors[n]
+			case sgrColors[n-10] != "":
+				s.Bg = sgrColors[n-10]
+			}
+		}
+	}
+	return s
+}
+
+// hexParams formats three decimal SGR color components as a hex color.
+func hexParams(rgb []string) string {
+	var b strings.Builder
+	b.WriteString("#")
+	for _, p := range rgb {
+		n, _ := strconv.Atoi(p)
+		fmt.Fprintf(&b, "%02x", internal.ClampInt(n, 0, 255))
+	}
+	return b.String()
+}
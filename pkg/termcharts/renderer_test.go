@@ -0,0 +1,283 @@
+package termcharts
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+func TestParseSGR(t *testing.T) {
+	tests := []struct {
+		name     string
+		seq      string
+		expected Style
+	}{
+		{name: "empty", seq: "", expected: Style{}},
+		{name: "palette foreground", seq: "\033[34m", expected: Style{Fg: "blue"}},
+		{name: "bright gray", seq: "\033[90m", expected: Style{Fg: "gray"}},
+		{name: "background", seq: "\033[41m", expected: Style{Bg: "red"}},
+		{name: "hex colors", seq: "\033[38;2;255;136;0;48;2;0;0;16m", expected: Style{Fg: "#ff8800", Bg: "#000010"}},
+		{name: "attributes", seq: "\033[1;2;4;7m", expected: Style{Bold: true, Faint: true, Underline: true, Reverse: true}},
+		{name: "accumulated sequences", seq: "\033[1m\033[32m", expected: Style{Fg: "green", Bold: true}},
+		{name: "reset clears", seq: "\033[1m\033[0m\033[33m", expected: Style{Fg: "yellow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSGR(tt.seq); got != tt.expected {
+				t.Errorf("parseSGR(%q) = %+v, want %+v", tt.seq, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSGR_RoundTrip(t *testing.T) {
+	styles := []Style{
+		{Fg: "red"},
+		{Fg: "#123456", Bg: "white", Bold: true},
+		{Bg: "#abcdef", Underline: true, Reverse: true},
+	}
+	for _, s := range styles {
+		if got := parseSGR(s.sequence()); got != s {
+			t.Errorf("parseSGR(%q) = %+v, want %+v", s.sequence(), got, s)
+		}
+	}
+}
+
+func TestNewFrame(t *testing.T) {
+	out := "ab" + Colorize("東", "red", true) + "\n" + Colorize("x", "bold #00ff00", true) + "\n"
+	frame := NewFrame(out)
+
+	if frame.Height() != 2 {
+		t.Fatalf("Height() = %d, want 2", frame.Height())
+	}
+	if frame.Width() != 4 {
+		t.Errorf("Width() = %d, want 4", frame.Width())
+	}
+	if got := frame.Rows[0][2]; got != (Cell{Rune: '東', Style: Style{Fg: "red"}}) {
+		t.Errorf("cell = %+v, want red 東", got)
+	}
+	if got := frame.Rows[1][0].Style; got != (Style{Fg: "#00ff00", Bold: true}) {
+		t.Errorf("style = %+v, want bold #00ff00", got)
+	}
+	if got := frame.String(); got != "ab東\nx\n" {
+		t.Errorf("String() = %q, want %q", got, "ab東\nx\n")
+	}
+}
+
+func TestFrame_Lines(t *testing.T) {
+	out := "ab" + Colorize("東西", "red", true) + Colorize("c", "bold red", true) + "\n\n" + Colorize("x", "blue", true) + "y\n"
+	got := NewFrame(out).Lines()
+	want := []StyledLine{
+		{{Text: "ab"}, {Text: "東西", Style: Style{Fg: "red"}}, {Text: "c", Style: Style{Fg: "red", Bold: true}}},
+		{},
+		{{Text: "x", Style: Style{Fg: "blue"}}, {Text: "y"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines() = %+v, want %+v", got, want)
+	}
+	if got[0].String() != "ab東西c" {
+		t.Errorf("String() = %q, want %q", got[0].String(), "ab東西c")
+	}
+}
+
+func TestRenderStyled(t *testing.T) {
+	lines, err := RenderStyled(NewBarChart(
+		WithData([]float64{1, 2}), WithLabels([]string{"a", "b"}), WithColor(true), WithWidth(20),
+	))
+	if err != nil {
+		t.Fatalf("RenderStyled() error = %v", err)
+	}
+
+	// The bars keep the theme's color, to be re-mapped by the host
+	bar := lines[1][len(lines[1])-1]
+	if bar.Style.Fg != DefaultTheme.Primary || strings.Trim(bar.Text, "█") != "" {
+		t.Errorf("bar span = %+v, want a bar in %q", bar, DefaultTheme.Primary)
+	}
+
+	if _, err := RenderStyled(NewBarChart()); !errors.Is(err, ErrEmptyData) {
+		t.Errorf("RenderStyled() error = %v, want ErrEmptyData", err)
+	}
+}
+
+func TestRenderFrame_SparklinePoints(t *testing.T) {
+	points := func(row []Cell) []DataPoint {
+		var got []DataPoint
+		for _, c := range row {
+			if c.Point != nil {
+				got = append(got, *c.Point)
+			}
+		}
+		return got
+	}
+	tests := []struct {
+		name  string
+		chart Chart
+		want  []DataPoint
+	}{
+		{
+			name:  "every value",
+			chart: NewSparkline(WithData([]float64{1, 5, 3}), WithColor(true)),
+			want:  []DataPoint{{0, 1}, {1, 5}, {2, 3}},
+		},
+		{
+			// Each character draws one sampled value
+			name:  "sampled",
+			chart: NewSparkline(WithData([]float64{1, 5, 3, 8}), WithWidth(2), WithColor(false)),
+			want:  []DataPoint{{0, 1}, {2, 3}},
+		},
+		{
+			// Stats after the sparkline carry no points
+			name:  "with stats",
+			chart: NewSparkline(WithData([]float64{2, 4}), WithSparkStats(true), WithColor(false)),
+			want:  []DataPoint{{0, 2}, {1, 4}},
+		},
+		{
+			// Points hold the values passed in, not the percentages drawn
+			name:  "percent axis",
+			chart: NewSparkline(WithData([]float64{0.25, 0.5}), WithPercentAxis(true), WithColor(false)),
+			want:  []DataPoint{{0, 0.25}, {1, 0.5}},
+		},
+		{
+			name:  "bar chart",
+			chart: NewBarChart(WithData([]float64{1, 2}), WithColor(false), WithWidth(20)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, err := RenderFrame(tt.chart)
+			if err != nil {
+				t.Fatalf("RenderFrame() error = %v", err)
+			}
+			var got []DataPoint
+			for _, row := range frame.Rows {
+				got = append(got, points(row)...)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("points = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrame_PointAt(t *testing.T) {
+	frame := &Frame{Rows: [][]Cell{{
+		{Rune: '東'},
+		{Rune: '▁', Point: &DataPoint{Index: 0, Value: 1}},
+		{Rune: '█', Point: &DataPoint{Index: 1, Value: 9}},
+	}}}
+	tests := []struct {
+		x, y int
+		want DataPoint
+		ok   bool
+	}{
+		{x: 1, y: 0}, // the wide character spans two columns
+		{x: 2, y: 0, want: DataPoint{Index: 0, Value: 1}, ok: true},
+		{x: 3, y: 0, want: DataPoint{Index: 1, Value: 9}, ok: true},
+		{x: 4, y: 0},
+		{x: 2, y: 1},
+		{x: -1, y: 0},
+	}
+	for _, tt := range tests {
+		if got, ok := frame.PointAt(tt.x, tt.y); got != tt.want || ok != tt.ok {
+			t.Errorf("PointAt(%d, %d) = %+v, %t, want %+v, %t", tt.x, tt.y, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestANSIRenderer(t *testing.T) {
+	bar := NewBarChart(
+		WithData([]float64{10, 25, 15}),
+		WithLabels([]string{"A", "B", "C"}),
+		WithStyle(StyleUnicode),
+		WithColor(true),
+		WithWidth(30),
+	)
+	out := bar.Render()
+
+	for _, color := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := RenderWith(&buf, bar, &ANSIRenderer{Color: color}); err != nil {
+			t.Fatalf("RenderWith() error = %v", err)
+		}
+		if got, want := internal.StripANSI(buf.String()), internal.StripANSI(out); got != want {
+			t.Errorf("text = %q, want %q", got, want)
+		}
+		if hasColor := strings.Contains(buf.String(), "\033["); hasColor != color {
+			t.Errorf("Color = %v, output has escapes = %v", color, hasColor)
+		}
+		if color && !framesMatch(NewFrame(buf.String()), NewFrame(out)) {
+			t.Error("colored output styles differ from Render")
+		}
+	}
+}
+
+// framesMatch reports whether two frames have the same cells.
+func framesMatch(a, b *Frame) bool {
+	if len(a.Rows) != len(b.Rows) {
+		return false
+	}
+	for i := range a.Rows {
+		if len(a.Rows[i]) != len(b.Rows[i]) {
+			return false
+		}
+		for j := range a.Rows[i] {
+			if a.Rows[i][j] != b.Rows[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSVGRenderer(t *testing.T) {
+	frame := NewFrame("<" + Colorize("██", "red", true) + Colorize(" ", "on blue", true) + "\n")
+
+	var buf bytes.Buffer
+	r := &SVGRenderer{FontSize: 10, Background: "black"}
+	if err := r.Render(&buf, frame); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	svg := buf.String()
+
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="24" height="12" viewBox="0 0 24 12">`,
+		`<rect width="100%" height="100%" fill="#000000"/>`,
+		`<text x="0" y="10">&lt;</text>`,
+		`<text x="6" y="10" fill="#cd0000">██</text>`,
+		`<rect x="18" y="0" width="6" height="12" fill="#0000ee"/>`,
+		`</svg>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("expected SVG to contain %q, got:\n%s", want, svg)
+		}
+	}
+}
+
+func TestRenderWith(t *testing.T) {
+	t.Run("chart error", func(t *testing.T) {
+		err := RenderWith(io.Discard, NewBarChart(), &ANSIRenderer{})
+		if !errors.Is(err, ErrEmptyData) {
+			t.Errorf("RenderWith() error = %v, want ErrEmptyData", err)
+		}
+	})
+
+	t.Run("custom renderer", func(t *testing.T) {
+		var rows int
+		r := RendererFunc(func(w io.Writer, frame *Frame) error {
+			rows = frame.Height()
+			return nil
+		})
+		if err := RenderWith(io.Discard, NewSparkline(WithData([]float64{1, 2, 3})), r); err != nil {
+			t.Fatalf("RenderWith() error = %v", err)
+		}
+		if rows != 1 {
+			t.Errorf("renderer got %d rows, want 1", rows)
+		}
+	})
+}
@@ -1,7 +1,9 @@
 package termcharts
 
 import (
+	"math"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/neilpeterson/termcharts/internal"
 )
@@ -9,8 +11,73 @@ import (
 // Sparkline represents a compact, inline chart showing data trends.
 // Sparklines use Unicode block characters (▁▂▃▄▅▆▇█) to visualize
 // data in a single line, perfect for dashboards and monitoring.
+// With WithSeries, a sparkline draws one labeled line per series, or
+// overlays them in Braille with WithSparkOverlay.
 type Sparkline struct {
 	opts *Options
+	err  error
+}
+
+// Baseline selects the value a sparkline's lowest level stands for.
+type Baseline int
+
+const (
+	// BaselineMin scales a sparkline between the minimum and maximum of its
+	// data, so small fluctuations fill the full height. This is the default.
+	BaselineMin Baseline = iota
+	// BaselineZero scales a sparkline from zero, so heights are proportional
+	// to the values and sparklines of different data compare honestly.
+	BaselineZero
+)
+
+// WithThresholds colors sparkline characters by the value they draw: each
+// takes the color of the highest threshold at or below its value, so a
+// metric reads as ok, warning, or critical at a glance whatever the scale.
+// Values below every threshold keep the default intensity colors; use
+// math.Inf(-1) as a threshold to color them too. Colors are palette names,
+// hex values, or style specs as accepted by Colorize. Thresholds need color
+// to be enabled. They are a banded ColorScale; WithColorScale takes their
+// place when both are set.
+//
+// Example:
+//
+//	termcharts.WithThresholds(map[float64]string{
+//	    0:  "green",
+//	    70: "yellow",
+//	    90: "red",
+//	})
+func WithThresholds(thresholds map[float64]string) SparklineOption {
+	return sparklineOption(func(o *Options) {
+		o.Thresholds = thresholds
+	})
+}
+
+// WithSparkExtremes draws the highest point of a sparkline in maxColor and
+// the lowest in minColor, so anomalies stand out in dense dashboards, e.g.
+// WithSparkExtremes("red", "blue"). Colors are palette names, hex values, or
+// style specs as accepted by Colorize; "" leaves that point in its usual
+// color. When a sparkline samples its data, the extremes are those of the
+// drawn points. Highlights take precedence over WithThresholds and need color
+// to be enabled.
+func WithSparkExtremes(maxColor, minColor string) SparklineOption {
+	return sparklineOption(func(o *Options) {
+		o.SparkMaxColor, o.SparkMinColor = maxColor, minColor
+	})
+}
+
+// WithBaseline sets the value a sparkline is scaled from. It does not apply
+// to a fixed range set with WithYAxis or to a log scale, which has no zero.
+//
+// Example:
+//
+//	termcharts.NewSparkline(
+//	    termcharts.WithData([]float64{98, 99, 97, 100}),
+//	    termcharts.WithBaseline(termcharts.BaselineZero),
+//	)
+func WithBaseline(baseline Baseline) SparklineOption {
+	return sparklineOption(func(o *Options) {
+		o.Baseline = baseline
+	})
 }
 
 // Unicode block characters for sparkline rendering (8 levels).
@@ -28,30 +95,174 @@ var sparkCharsASCII = []rune{'_', '.', '-', '=', '+', '*', '#', '@'}
 //	    termcharts.WithData([]float64{1, 5, 2, 8, 3, 7}),
 //	)
 //	fmt.Println(spark.Render())
-func NewSparkline(opts ...Option) *Sparkline {
-	options := NewOptions(opts...)
-	return &Sparkline{
+func NewSparkline(opts ...SparklineOption) *Sparkline {
+	options := NewOptions()
+	for _, opt := range opts {
+		opt.applySparkline(options)
+	}
+	s := &Sparkline{
 		opts: options,
 	}
+	if options.Strict {
+		s.err = s.validateOptions()
+	}
+	return s
+}
+
+// Update applies opts to the sparkline, replacing the options they set.
+// The next Render draws the sparkline with the new options; with WithStrict
+// they are validated again. Use it to feed new data into a live display.
+func (s *Sparkline) Update(opts ...SparklineOption) {
+	for _, opt := range opts {
+		opt.applySparkline(s.opts)
+	}
+	s.err = nil
+	if s.opts.Strict {
+		s.err = s.validateOptions()
+	}
 }
 
+// Apply applies opts, options shared by all charts, like Update. It makes
+// the sparkline Updatable.
+func (s *Sparkline) Apply(opts ...Option) { s.Update(Combine(opts...)) }
+
 // Render generates the sparkline as a single-line string.
 // Each data point is represented by a single character, with height
 // proportional to the value relative to the min/max in the dataset.
+// It returns an empty string if the chart cannot be drawn; use RenderE to find out why.
 func (s *Sparkline) Render() string {
-	// Validate data
-	if len(s.opts.Data) == 0 {
-		return ""
+	out, _ := s.RenderE()
+	return out
+}
+
+// RenderE generates the sparkline as a single-line string.
+// It returns ErrEmptyData, ErrInvalidData, or ErrInvalidDimensions
+// (possibly wrapped) when the sparkline cannot be drawn.
+func (s *Sparkline) RenderE() (string, error) {
+	if opts := s.opts.percentScaled(); opts != s.opts {
+		return (&Sparkline{opts: opts, err: s.err}).RenderE()
+	}
+	if s.err == nil && s.opts.noData() {
+		return s.opts.placeholder(s.opts.Width, 1, s.opts.Style != StyleASCII, false), nil
+	}
+	if err := s.check(); err != nil {
+		return "", err
+	}
+	if len(s.opts.Series) > 0 {
+		return s.opts.postProcess(s.renderSeries()), nil
+	}
+
+	// Sparkline glyphs take three bytes
+	out := s.appendSpark(make([]byte, 0, s.columns()*3))
+	if s.opts.SparkStats || s.opts.SparkTrend {
+		out = append(out, s.stats()...)
+	}
+	return s.opts.postProcess(string(out)), nil
+}
+
+// stats returns the text drawn after the sparkline by WithSparkStats and
+// WithSparkTrend, with a leading space, e.g. " min 1.2  max 9.8  last 4.1 ▼ -58.2%".
+func (s *Sparkline) stats() string {
+	data := s.opts.Data
+	last := data[len(data)-1]
+	colorEnabled := s.opts.ColorEnabled != nil && *s.opts.ColorEnabled
+
+	var parts []string
+	if s.opts.SparkStats {
+		min, max := internal.MinMax(data)
+		text := "min " + s.formatStat(min) + "  max " + s.formatStat(max) + "  last " + s.formatStat(last)
+		if colorEnabled {
+			theme := s.opts.Theme
+			if theme == nil {
+				theme = DefaultTheme
+			}
+			text = Colorize(text, theme.Muted, true)
+		}
+		parts = append(parts, text)
 	}
 
-	// Check for invalid values
-	if !internal.AllValid(s.opts.Data) {
-		return ""
+	// The trend compares the last two values
+	if s.opts.SparkTrend {
+		useUnicode := !(s.opts.Style == StyleASCII || (s.opts.Style == StyleAuto && !internal.SupportsUnicode()))
+		prev := last
+		if len(data) > 1 {
+			prev = data[len(data)-2]
+		}
+		parts = append(parts, formatTrend(prev, last, useUnicode, colorEnabled, s.opts.Locale))
 	}
+	return " " + strings.Join(parts, " ")
+}
 
-	// Determine character set based on style
+// formatStat formats a value shown by WithSparkStats.
+func (s *Sparkline) formatStat(v float64) string {
+	return withUnit(s.opts.YAxis.format(v, "%.1f", s.opts.Locale), s.opts.YUnit)
+}
+
+// AppendRender appends the sparkline to dst and returns the extended buffer,
+// like Render but without building a string. A caller that reuses its buffer
+// renders without allocating, which suits logging and metrics paths that
+// draw sparklines thousands of times per second. Insets, text summaries,
+// post-processors, and stats set with WithSparkStats or WithSparkTrend still
+// go through Render and allocate. It appends nothing, or the placeholder set
+// with WithEmptyMessage, if the chart cannot be drawn; use RenderE to find
+// out why.
+//
+// Example:
+//
+//	buf := make([]byte, 0, 256)
+//	for range ticker.C {
+//	    spark.Update(termcharts.WithData(window))
+//	    buf = spark.AppendRender(buf[:0])
+//	    logger.Write(buf)
+//	}
+func (s *Sparkline) AppendRender(dst []byte) []byte {
+	if opts := s.opts.percentScaled(); opts != s.opts {
+		return (&Sparkline{opts: opts, err: s.err}).AppendRender(dst)
+	}
+	if s.check() != nil {
+		return append(dst, s.Render()...)
+	}
+	if len(s.opts.Insets) > 0 || len(s.opts.PostProcessors) > 0 || s.opts.SparkStats || s.opts.SparkTrend || len(s.opts.Series) > 0 ||
+		s.opts.TextSummary == TextSummaryAppend || s.opts.TextSummary == TextSummaryOnly {
+		return append(dst, s.Render()...)
+	}
+	return s.appendSpark(dst)
+}
+
+// check reports why the sparkline cannot be drawn, if it cannot.
+func (s *Sparkline) check() error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.opts.checkPoints(); err != nil {
+		return err
+	}
+	if len(s.opts.Series) > 0 {
+		return validateSeries(s.opts.Series)
+	}
+	return validateData(s.opts.Data)
+}
+
+// columns returns the number of characters in the sparkline. A width other
+// than 0 is clamped to between 1 character and the width limit.
+func (s *Sparkline) columns() int {
+	if s.opts.Width != 0 {
+		if width := clampSize(s.opts.Width, 1, s.opts.maxWidth()); len(s.opts.Data) > width {
+			return width
+		}
+	}
+	return len(s.opts.Data)
+}
+
+// appendSpark appends the sparkline characters to dst. It works on the data
+// in place, scaling and sampling one value per column, so it does not
+// allocate beyond growing dst.
+func (s *Sparkline) appendSpark(dst []byte) []byte {
+	// Determine character set based on style, unless one is set
 	chars := sparkChars
-	if s.opts.Style == StyleASCII {
+	if len(s.opts.SparkChars) >= 2 {
+		chars = s.opts.SparkChars
+	} else if s.opts.Style == StyleASCII {
 		chars = sparkCharsASCII
 	} else if s.opts.Style == StyleAuto {
 		// Auto-detect Unicode support
@@ -60,20 +271,34 @@ func (s *Sparkline) Render() string {
 		}
 	}
 
-	// Normalize data to 0-1 range
-	normalized, _, _ := internal.Normalize(s.opts.Data)
+	// Scale values to the range [0, 1], honoring the range and scale set
+	// with WithYAxis. Values outside a fixed range are clamped.
+	data := s.opts.Data
+	axis := s.opts.YAxis
+	lo, hi := s.scaleRange(data)
 
-	var result strings.Builder
+	// Sample every Nth value when the data is wider than the sparkline
+	columns := s.columns()
+	step := float64(len(data)) / float64(columns)
 
-	// Apply width limit if specified
-	data := normalized
-	if s.opts.Width > 0 && len(normalized) > s.opts.Width {
-		// Sample data to fit width
-		data = sampleData(normalized, s.opts.Width)
+	colorEnabled := s.opts.ColorEnabled != nil && *s.opts.ColorEnabled
+	var scale *ColorScale
+	if colorEnabled {
+		scale = s.opts.valueScale()
+	}
+	maxCol, minCol := -1, -1
+	if colorEnabled && (s.opts.SparkMaxColor != "" || s.opts.SparkMinColor != "") {
+		maxCol, minCol = extremeColumns(data, columns, step)
 	}
 
-	// Map each value to a character
-	for _, val := range data {
+	current := ""
+	for i := 0; i < columns; i++ {
+		index := sampleIndex(i, step, len(data))
+		val := 0.5
+		if hi != lo {
+			val = internal.Clamp((axis.project(data[index])-lo)/(hi-lo), 0, 1)
+		}
+
 		// Map 0-1 to character index (0-7)
 		level := int(val * float64(len(chars)-1))
 		if level < 0 {
@@ -83,18 +308,191 @@ func (s *Sparkline) Render() string {
 			level = len(chars) - 1
 		}
 
-		char := chars[level]
-
-		// Apply color if enabled
-		if s.opts.ColorEnabled != nil && *s.opts.ColorEnabled {
+		// Apply color if enabled; neighbouring characters of the same color
+		// share one escape sequence
+		if colorEnabled {
 			color := s.getColorForLevel(level, len(chars))
-			result.WriteString(Colorize(string(char), color, true))
-		} else {
-			result.WriteRune(char)
+			if scale != nil {
+				if c := scale.Color(data[index]); c != "" {
+					color = c
+				}
+			}
+			if i == maxCol && s.opts.SparkMaxColor != "" {
+				color = s.opts.SparkMaxColor
+			} else if i == minCol && s.opts.SparkMinColor != "" {
+				color = s.opts.SparkMinColor
+			}
+			dst = appendColorChange(dst, current, color)
+			current = color
 		}
+		dst = utf8.AppendRune(dst, chars[level])
+	}
+	return appendColorChange(dst, current, "")
+}
+
+// scaleRange returns the projected values the lowest and highest levels of
+// a sparkline of data stand for, honoring WithYAxis and WithBaseline.
+func (s *Sparkline) scaleRange(data []float64) (lo, hi float64) {
+	axis := s.opts.YAxis
+	min, max := axis.resolveRange(data)
+	if s.opts.Baseline == BaselineZero && !axis.fixedRange() && axis.Scale != ScaleLog {
+		min, max = math.Min(min, 0), math.Max(max, 0)
 	}
+	return axis.project(min), axis.project(max)
+}
 
-	return result.String()
+// annotate attaches the value each character of the sparkline draws to its
+// cell in frame. The sparkline is found as a run of its characters in a row,
+// so stats, text summaries, and post-processors that keep the characters do
+// not move the points onto other cells. Each series of stacked sparklines is
+// looked for below the one before; overlaid series share their cells and are
+// not annotated.
+func (s *Sparkline) annotate(frame *Frame) {
+	opts := *s.opts.percentScaled()
+	if s.check() != nil {
+		return
+	}
+	if len(opts.Series) == 0 {
+		annotateSpark(frame.Rows, &opts)
+		return
+	}
+	if (&Sparkline{opts: &opts}).overlaid() {
+		return
+	}
+	rows := frame.Rows
+	for _, series := range opts.visibleSeries(opts.Series, DefaultTheme) {
+		if len(series.Data) == 0 {
+			continue
+		}
+		row := opts
+		row.Data, row.Series = series.Data, nil
+		found := annotateSpark(rows, &row)
+		if found < 0 {
+			return
+		}
+		rows = rows[found+1:]
+	}
+}
+
+// annotateSpark attaches the values of the sparkline drawn with opts to the
+// first of rows that holds it, and returns the index of that row, or -1.
+func annotateSpark(rows [][]Cell, opts *Options) int {
+	if len(opts.Data) == 0 {
+		return -1
+	}
+	plain := *opts
+	off := false
+	plain.ColorEnabled = &off
+	spark := []rune(string((&Sparkline{opts: &plain}).appendSpark(nil)))
+
+	step := float64(len(opts.Data)) / float64(len(spark))
+	for r, row := range rows {
+		start := findRunes(row, spark)
+		if start < 0 {
+			continue
+		}
+		for i := range spark {
+			index := sampleIndex(i, step, len(opts.Data))
+			row[start+i].Point = &DataPoint{Index: index, Value: opts.Data[index]}
+		}
+		return r
+	}
+	return -1
+}
+
+// findRunes returns the index of the cell of row where runes first appear,
+// or -1.
+func findRunes(row []Cell, runes []rune) int {
+	for start := 0; start+len(runes) <= len(row); start++ {
+		match := true
+		for i, r := range runes {
+			if row[start+i].Rune != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			return start
+		}
+	}
+	return -1
+}
+
+// valueScale returns the scale sparkline characters are colored by: the one
+// set with WithColorScale, a banded scale of the thresholds, or nil.
+func (o *Options) valueScale() *ColorScale {
+	if o.ColorScale != nil {
+		return o.ColorScale
+	}
+	if len(o.Thresholds) == 0 {
+		return nil
+	}
+	stops := make([]ColorStop, 0, len(o.Thresholds))
+	for t, c := range o.Thresholds {
+		stops = append(stops, ColorStop{Value: t, Color: c})
+	}
+	return NewBandedColorScale(stops...)
+}
+
+// sampleIndex returns the index of the value drawn in column i of a
+// sparkline of n values, sampled every step values.
+func sampleIndex(i int, step float64, n int) int {
+	index := int(float64(i) * step)
+	if index >= n {
+		index = n - 1
+	}
+	return index
+}
+
+// extremeColumns returns the first columns that draw the largest and the
+// smallest of the sampled values, or -1 for both when they are all equal.
+func extremeColumns(data []float64, columns int, step float64) (maxCol, minCol int) {
+	maxCol, minCol = 0, 0
+	for i := 1; i < columns; i++ {
+		v := data[sampleIndex(i, step, len(data))]
+		if v > data[sampleIndex(maxCol, step, len(data))] {
+			maxCol = i
+		}
+		if v < data[sampleIndex(minCol, step, len(data))] {
+			minCol = i
+		}
+	}
+	if maxCol == minCol {
+		return -1, -1
+	}
+	return maxCol, minCol
+}
+
+// appendColorChange appends the escape sequences that switch the text color
+// from prev to next, where "" is uncolored, the way a colorRun does.
+func appendColorChange(dst []byte, prev, next string) []byte {
+	if prev == next {
+		return dst
+	}
+	if colorCode(prev) != "" {
+		dst = append(dst, colorReset...)
+	}
+	return append(dst, colorCode(next)...)
+}
+
+// validateOptions reports options that sparklines cannot honor.
+func (s *Sparkline) validateOptions() error {
+	if err := s.opts.Validate(); err != nil {
+		return err
+	}
+	if s.opts.Style.subCell() {
+		return conflict("%s style is only supported by line charts", s.opts.Style)
+	}
+	if s.opts.SparkOverlay && s.opts.Style == StyleASCII {
+		return conflict("overlaid sparklines are drawn in Braille; remove WithStyle(StyleASCII) or WithSparkOverlay")
+	}
+	if s.opts.Direction == Vertical {
+		return conflict("sparklines are always horizontal; remove WithDirection(Vertical)")
+	}
+	if s.opts.BarMode != BarModeGrouped {
+		return conflict("bar mode only applies to bar charts")
+	}
+	return nil
 }
 
 // getColorForLevel returns a color based on the value level.
@@ -116,27 +514,6 @@ func (s *Sparkline) getColorForLevel(level, maxLevel int) string {
 	}
 }
 
-// sampleData reduces the data to the target width by sampling.
-// Uses simple downsampling - takes every Nth value.
-func sampleData(data []float64, targetWidth int) []float64 {
-	if len(data) <= targetWidth {
-		return data
-	}
-
-	result := make([]float64, targetWidth)
-	step := float64(len(data)) / float64(targetWidth)
-
-	for i := 0; i < targetWidth; i++ {
-		index := int(float64(i) * step)
-		if index >= len(data) {
-			index = len(data) - 1
-		}
-		result[i] = data[index]
-	}
-
-	return result
-}
-
 // Spark is a convenience function that creates and renders a sparkline in one call.
 // This is the simplest way to generate a sparkline from data.
 //
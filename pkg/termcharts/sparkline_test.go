@@ -1,9 +1,12 @@
 package termcharts
 
 import (
+	"errors"
 	"math"
 	"strings"
 	"testing"
+
+	"github.com/neilpeterson/termcharts/internal"
 )
 
 func TestNewSparkline(t *testing.T) {
@@ -283,3 +286,344 @@ func TestSparkline_Render_CharacterMapping(t *testing.T) {
 		t.Errorf("Expected max character %c, got %c", sparkChars[len(sparkChars)-1], runes[1])
 	}
 }
+
+func TestSparkline_Update(t *testing.T) {
+	spark := NewSparkline(WithData([]float64{1, 2, 3}), WithStyle(StyleASCII), WithColor(false))
+	spark.Update(WithData([]float64{3, 2, 1}))
+
+	if got := spark.Render(); got != "@=_" {
+		t.Errorf("Render() after Update = %q, want %q", got, "@=_")
+	}
+
+	strict := NewSparkline(WithStrict(true), WithData([]float64{1}))
+	strict.Update(WithStyle(StyleBraille))
+	if _, err := strict.RenderE(); !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("RenderE() error = %v, want %v", err, ErrConflictingOptions)
+	}
+	strict.Update(WithStyle(StyleASCII))
+	if _, err := strict.RenderE(); err != nil {
+		t.Errorf("RenderE() error = %v after fixing options", err)
+	}
+}
+
+func TestSparkline_Render_Stats(t *testing.T) {
+	data := WithData([]float64{1.2, 5, 9.8, 4.1})
+	tests := []struct {
+		name     string
+		opts     []SparklineOption
+		expected string
+	}{
+		{
+			name:     "stats",
+			opts:     []SparklineOption{WithSparkStats(true)},
+			expected: "▁▄█▃ min 1.2  max 9.8  last 4.1",
+		},
+		{
+			name:     "stats and trend",
+			opts:     []SparklineOption{WithSparkStats(true), WithSparkTrend(true)},
+			expected: "▁▄█▃ min 1.2  max 9.8  last 4.1 ▼ -58.2%",
+		},
+		{
+			name:     "rising trend only",
+			opts:     []SparklineOption{WithData([]float64{3, 1, 2}), WithSparkTrend(true)},
+			expected: "█▁▄ ▲ +100.0%",
+		},
+		{
+			name:     "flat trend",
+			opts:     []SparklineOption{WithData([]float64{2, 2}), WithSparkTrend(true)},
+			expected: "▄▄ = +0.0%",
+		},
+		{
+			name:     "ascii trend",
+			opts:     []SparklineOption{WithStyle(StyleASCII), WithSparkTrend(true)},
+			expected: "_=@- v -58.2%",
+		},
+		{
+			name:     "localized",
+			opts:     []SparklineOption{WithSparkStats(true), WithLocale("de-DE")},
+			expected: "▁▄█▃ min 1,2  max 9,8  last 4,1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]SparklineOption{data, WithStyle(StyleUnicode), WithColor(false)}, tt.opts...)
+			if got := NewSparkline(opts...).Render(); got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	colored := NewSparkline(data, WithStyle(StyleUnicode), WithColor(true), WithSparkTrend(true)).Render()
+	if !strings.HasSuffix(colored, Colorize("▼ -58.2%", "red", true)) {
+		t.Errorf("Render() = %q, want a red falling arrow and percent change", colored)
+	}
+}
+
+func TestSparkline_Render_Baseline(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		opts     []SparklineOption
+		expected string
+	}{
+		{name: "min baseline", data: []float64{98, 99, 97, 100}, expected: "▃▅▁█"},
+		{name: "zero baseline", data: []float64{98, 99, 97, 100}, opts: []SparklineOption{WithBaseline(BaselineZero)}, expected: "▇▇▇█"},
+		{name: "zero baseline negative", data: []float64{-5, -2, -8}, opts: []SparklineOption{WithBaseline(BaselineZero)}, expected: "▃▆▁"},
+		{name: "zero baseline spanning zero", data: []float64{-3, 0, 3}, opts: []SparklineOption{WithBaseline(BaselineZero)}, expected: "▁▄█"},
+		{
+			name: "fixed range wins", data: []float64{98, 99, 97, 100},
+			opts:     []SparklineOption{WithBaseline(BaselineZero), WithYAxis(AxisConfig{Min: 96, Max: 100})},
+			expected: "▄▆▂█",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]SparklineOption{WithData(tt.data), WithStyle(StyleUnicode), WithColor(false)}, tt.opts...)
+			if got := NewSparkline(opts...).Render(); got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSparkline_Render_Extremes(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		opts     []SparklineOption
+		expected string
+	}{
+		{
+			name:     "max and min",
+			data:     []float64{3, 9, 1, 5},
+			opts:     []SparklineOption{WithSparkExtremes("red", "blue")},
+			expected: "\x1b[90m▂\x1b[0m\x1b[31m█\x1b[0m\x1b[34m▁▄\x1b[0m",
+		},
+		{
+			name:     "max only",
+			data:     []float64{3, 9, 1, 5},
+			opts:     []SparklineOption{WithSparkExtremes("red", "")},
+			expected: "\x1b[90m▂\x1b[0m\x1b[31m█\x1b[0m\x1b[90m▁\x1b[0m\x1b[34m▄\x1b[0m",
+		},
+		{
+			name:     "first of equal extremes",
+			data:     []float64{9, 1, 9, 1},
+			opts:     []SparklineOption{WithSparkExtremes("red", "cyan")},
+			expected: "\x1b[31m█\x1b[0m\x1b[36m▁\x1b[0m\x1b[33m█\x1b[0m\x1b[90m▁\x1b[0m",
+		},
+		{
+			name:     "flat data",
+			data:     []float64{4, 4, 4},
+			opts:     []SparklineOption{WithSparkExtremes("red", "blue")},
+			expected: "\x1b[34m▄▄▄\x1b[0m",
+		},
+		{
+			name:     "color disabled",
+			data:     []float64{3, 9, 1, 5},
+			opts:     []SparklineOption{WithSparkExtremes("red", "blue"), WithColor(false)},
+			expected: "▂█▁▄",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]SparklineOption{WithData(tt.data), WithStyle(StyleUnicode), WithColor(true)}, tt.opts...)
+			if got := NewSparkline(opts...).Render(); got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSparkline_Render_Thresholds(t *testing.T) {
+	thresholds := map[float64]string{0: "green", 70: "yellow", 90: "red"}
+	tests := []struct {
+		name     string
+		data     []float64
+		opts     []SparklineOption
+		expected string
+	}{
+		{
+			name:     "ok warn crit",
+			data:     []float64{10, 75, 95, 50},
+			opts:     []SparklineOption{WithThresholds(thresholds)},
+			expected: "\x1b[32m▁\x1b[0m\x1b[33m▆\x1b[0m\x1b[31m█\x1b[0m\x1b[32m▄\x1b[0m",
+		},
+		{
+			name:     "below every threshold keeps intensity color",
+			data:     []float64{-10, 75},
+			opts:     []SparklineOption{WithThresholds(thresholds)},
+			expected: "\x1b[90m▁\x1b[0m\x1b[33m█\x1b[0m",
+		},
+		{
+			name:     "value on a threshold",
+			data:     []float64{70, 90},
+			opts:     []SparklineOption{WithThresholds(thresholds)},
+			expected: "\x1b[33m▁\x1b[0m\x1b[31m█\x1b[0m",
+		},
+		{
+			name:     "extremes win",
+			data:     []float64{10, 75, 95, 50},
+			opts:     []SparklineOption{WithThresholds(thresholds), WithSparkExtremes("magenta", "")},
+			expected: "\x1b[32m▁\x1b[0m\x1b[33m▆\x1b[0m\x1b[35m█\x1b[0m\x1b[32m▄\x1b[0m",
+		},
+		{
+			name:     "color disabled",
+			data:     []float64{10, 75, 95, 50},
+			opts:     []SparklineOption{WithThresholds(thresholds), WithColor(false)},
+			expected: "▁▆█▄",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]SparklineOption{WithData(tt.data), WithStyle(StyleUnicode), WithColor(true)}, tt.opts...)
+			if got := NewSparkline(opts...).Render(); got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSparkline_AppendRender(t *testing.T) {
+	data := benchData(100)
+	tests := []struct {
+		name string
+		opts []SparklineOption
+	}{
+		{name: "unicode", opts: []SparklineOption{WithStyle(StyleUnicode), WithColor(false)}},
+		{name: "ascii sampled", opts: []SparklineOption{WithStyle(StyleASCII), WithColor(false), WithWidth(30)}},
+		{name: "color", opts: []SparklineOption{WithStyle(StyleUnicode), WithColor(true)}},
+		{name: "log axis", opts: []SparklineOption{WithStyle(StyleUnicode), WithColor(false), WithYAxis(AxisConfig{Scale: ScaleLog})}},
+		{name: "post-processed", opts: []SparklineOption{WithStyle(StyleASCII), WithColor(false), WithPostProcessor(func(lines []string) []string {
+			return append(lines, "done")
+		})}},
+		{name: "stats", opts: []SparklineOption{WithStyle(StyleASCII), WithColor(false), WithSparkStats(true), WithSparkTrend(true)}},
+		{name: "thresholds", opts: []SparklineOption{WithStyle(StyleUnicode), WithColor(true), WithThresholds(map[float64]string{0: "green", 50: "red"})}},
+		{name: "extremes", opts: []SparklineOption{WithStyle(StyleUnicode), WithColor(true), WithWidth(30), WithSparkExtremes("red", "blue")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spark := NewSparkline(append(tt.opts, WithData(data))...)
+			got := spark.AppendRender([]byte("> "))
+			if want := "> " + spark.Render(); string(got) != want {
+				t.Errorf("AppendRender() = %q, want %q", got, want)
+			}
+		})
+	}
+
+	if got := NewSparkline().AppendRender([]byte("x")); string(got) != "x" {
+		t.Errorf("AppendRender() with no data = %q, want the buffer unchanged", got)
+	}
+}
+
+func TestSparkline_AppendRenderAllocs(t *testing.T) {
+	for _, color := range []bool{false, true} {
+		spark := NewSparkline(WithData(benchData(200)), WithWidth(60), WithStyle(StyleUnicode), WithColor(color))
+		buf := make([]byte, 0, 1024)
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = spark.AppendRender(buf[:0])
+		})
+		if allocs != 0 {
+			t.Errorf("AppendRender() with color %v made %v allocations, want 0", color, allocs)
+		}
+	}
+}
+
+func TestSparkline_Render_Series(t *testing.T) {
+	series := []Series{
+		{Label: "cpu", Data: []float64{1, 3, 5, 7, 5, 3, 1, 2}},
+		{Label: "memory", Data: []float64{8, 7, 6, 5, 4}},
+	}
+	tests := []struct {
+		name string
+		opts []SparklineOption
+		want string
+	}{
+		{
+			name: "stacked",
+			want: "cpu    ▁▃▅█▅▃▁▂\n" +
+				"memory █▆▄▂▁\n",
+		},
+		{
+			name: "stats aligned",
+			opts: []SparklineOption{WithSparkStats(true)},
+			want: "cpu    ▁▃▅█▅▃▁▂ min 1.0  max 7.0  last 2.0\n" +
+				"memory █▆▄▂▁    min 4.0  max 8.0  last 4.0\n",
+		},
+		{
+			name: "sampled to width",
+			opts: []SparklineOption{WithWidth(12)},
+			want: "cpu    ▁▃█▅▁\n" +
+				"memory █▆▄▂▁\n",
+		},
+		{
+			name: "labels truncated to width",
+			opts: []SparklineOption{WithWidth(7)},
+			want: "cpu ▁▅▃\n" +
+				"me… █▆▂\n",
+		},
+		{
+			// Both series share one scale, two points per character
+			name: "overlay",
+			opts: []SparklineOption{WithSparkOverlay(true)},
+			want: "⡩⠚⠦⣀\n● cpu  ● memory  \n",
+		},
+		{
+			name: "overlay needs Unicode",
+			opts: []SparklineOption{WithSparkOverlay(true), WithStyle(StyleASCII)},
+			want: "cpu    _-+@+-_.\n" +
+				"memory @*=._\n",
+		},
+		{
+			name: "hidden series",
+			opts: []SparklineOption{WithHiddenSeries("cpu")},
+			want: "memory █▆▄▂▁\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]SparklineOption{WithSeries(series), WithStyle(StyleUnicode), WithColor(false)}, tt.opts...)
+			got, err := NewSparkline(opts...).RenderE()
+			if err != nil {
+				t.Fatalf("RenderE() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderE() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	// Labels and sparklines share the width
+	long := []Series{{Label: "requests per second", Data: benchData(200)}, {Label: "p99", Data: benchData(50)}}
+	for _, style := range []RenderStyle{StyleASCII, StyleUnicode} {
+		for width := 1; width <= 100; width++ {
+			out := NewSparkline(WithSeries(long), WithWidth(width), WithStyle(style), WithColor(false)).Render()
+			for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+				if got := internal.StringWidth(line); got > width {
+					t.Fatalf("width %d: line %q is %d columns wide", width, line, got)
+				}
+			}
+		}
+	}
+
+	// Each stacked sparkline carries the points of its own series
+	frame, err := RenderFrame(NewSparkline(WithSeries(series), WithStyle(StyleUnicode)))
+	if err != nil {
+		t.Fatalf("RenderFrame() error = %v", err)
+	}
+	if p := frame.Rows[1][7].Point; p == nil || *p != (DataPoint{Index: 0, Value: 8}) {
+		t.Errorf("first point of the second series = %v, want {0 8}", p)
+	}
+
+	strict := NewSparkline(WithStrict(true), WithSeries(series), WithSparkOverlay(true), WithStyle(StyleASCII))
+	if _, err := strict.RenderE(); !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("RenderE() error = %v, want %v", err, ErrConflictingOptions)
+	}
+	if _, err := NewSparkline(WithStrict(true), WithSeries(series)).RenderE(); err != nil {
+		t.Errorf("RenderE() error = %v for a strict sparkline of series", err)
+	}
+}
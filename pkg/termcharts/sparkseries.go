@@ -0,0 +1,163 @@
+package termcharts
+
+import (
+	"strings"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// WithSparkOverlay draws the series of a sparkline set with WithSeries over
+// each other in a single row of Braille dots, followed by a legend, instead
+// of one sparkline per series. Each character holds two points of every
+// series at four levels. The series share one scale, so their values compare
+// directly, and each is drawn in its color; a character holding points of
+// several series takes the color of the last. Overlaid sparklines need
+// Unicode: with StyleASCII the series are stacked.
+//
+// Example:
+//
+//	termcharts.NewSparkline(
+//	    termcharts.WithSeries([]termcharts.Series{
+//	        {Label: "p50", Data: p50},
+//	        {Label: "p99", Data: p99},
+//	    }),
+//	    termcharts.WithSparkOverlay(true),
+//	)
+func WithSparkOverlay(overlay bool) SparklineOption {
+	return sparklineOption(func(o *Options) {
+		o.SparkOverlay = overlay
+	})
+}
+
+// overlaid reports whether the series of the sparkline are drawn over each
+// other in Braille.
+func (s *Sparkline) overlaid() bool {
+	return s.opts.SparkOverlay &&
+		!(s.opts.Style == StyleASCII || (s.opts.Style == StyleAuto && !internal.SupportsUnicode()))
+}
+
+// renderSeries draws the visible series of the sparkline, stacked one per
+// line or overlaid in Braille.
+func (s *Sparkline) renderSeries() string {
+	theme := s.opts.Theme
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	colorEnabled := s.opts.ColorEnabled != nil && *s.opts.ColorEnabled
+	series := s.opts.visibleSeries(s.opts.Series, theme)
+	if s.overlaid() {
+		return s.renderOverlay(series, colorEnabled, theme)
+	}
+	return s.renderStacked(series, colorEnabled)
+}
+
+// renderStacked draws one sparkline per series, each scaled to its own data
+// like a sparkline of that data alone. Labels are padded to the widest, and
+// shorter sparklines are padded to the widest, so the sparklines and their
+// stats start in the same columns on every line. With a width, the labels
+// and the space after them are part of it: labels take at most half of it,
+// truncated to fit, and the sparklines the rest.
+func (s *Sparkline) renderStacked(series []Series, colorEnabled bool) string {
+	useUnicode := !(s.opts.Style == StyleASCII || (s.opts.Style == StyleAuto && !internal.SupportsUnicode()))
+	labelWidth := 0
+	for _, sr := range series {
+		labelWidth = internal.Max(labelWidth, internal.StringWidth(sr.Label))
+	}
+	sparkWidth := s.opts.Width
+	if sparkWidth != 0 {
+		width := clampSize(s.opts.Width, 1, s.opts.maxWidth())
+		labelWidth = internal.Min(labelWidth, internal.Min(width/2, width-2))
+		sparkWidth = width
+		if labelWidth > 0 {
+			sparkWidth -= labelWidth + 1
+		}
+	}
+
+	rows := make([]*Sparkline, len(series))
+	columns := 0
+	for i, sr := range series {
+		opts := *s.opts
+		opts.Data, opts.Series, opts.Width = sr.Data, nil, sparkWidth
+		rows[i] = &Sparkline{opts: &opts}
+		columns = internal.Max(columns, rows[i].columns())
+	}
+
+	var b strings.Builder
+	for i, sr := range series {
+		if labelWidth > 0 {
+			text := truncateLabel(sr.Label, labelWidth, useUnicode)
+			label := text
+			if colorEnabled {
+				label = Colorize(label, sr.Color, true)
+			}
+			b.WriteString(label)
+			b.WriteString(strings.Repeat(" ", labelWidth-internal.StringWidth(text)+1))
+		}
+		row := rows[i]
+		if len(sr.Data) > 0 {
+			b.Write(row.appendSpark(nil))
+		}
+		if s.opts.SparkStats || s.opts.SparkTrend {
+			b.WriteString(strings.Repeat(" ", columns-row.columns()))
+			if len(sr.Data) > 0 {
+				b.WriteString(row.stats())
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderOverlay draws every series in one row of Braille dots on a shared
+// scale, followed by a legend of the series.
+func (s *Sparkline) renderOverlay(series []Series, colorEnabled bool, theme *Theme) string {
+	points := 0
+	var all []float64
+	for _, sr := range series {
+		points = internal.Max(points, len(sr.Data))
+		all = append(all, sr.Data...)
+	}
+	cell := brailleCell
+	columns := (points + cell.cols - 1) / cell.cols
+	if s.opts.Width != 0 {
+		columns = internal.Min(columns, clampSize(s.opts.Width, 1, s.opts.maxWidth()))
+	}
+
+	// Each series is sampled to at most one point per dot column, on the
+	// scale of all series
+	axis := s.opts.YAxis
+	lo, hi := s.scaleRange(all)
+	patterns := make([]int, columns)
+	colors := make([]string, columns)
+	for _, sr := range series {
+		if len(sr.Data) == 0 {
+			continue
+		}
+		count := internal.Min(len(sr.Data), columns*cell.cols)
+		step := float64(len(sr.Data)) / float64(count)
+		for i := 0; i < count; i++ {
+			val := 0.5
+			if hi != lo {
+				val = internal.Clamp((axis.project(sr.Data[sampleIndex(i, step, len(sr.Data))])-lo)/(hi-lo), 0, 1)
+			}
+			dotRow := cell.rows - 1 - int(val*float64(cell.rows-1)+0.5)
+			patterns[i/cell.cols] |= cell.bits[dotRow][i%cell.cols]
+			colors[i/cell.cols] = sr.Color
+		}
+	}
+
+	result := getBuffer()
+	defer putBuffer(result)
+	run := newColorRun(result)
+	for col, pattern := range patterns {
+		color := ""
+		if colorEnabled {
+			color = colors[col]
+		}
+		run.writeRune(cell.glyph(pattern), color)
+	}
+	run.end()
+	result.WriteString("\n")
+	result.WriteString(chartLegend(s.opts, s.opts.Series, "●", colorEnabled, theme).Render())
+	return result.String()
+}
@@ -0,0 +1,278 @@
+package termcharts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Spec is a serializable chart specification: the type of a chart, its data,
+// and how it is drawn. Specs are written as JSON, so other tools can generate
+// charts without building options in Go or argument lists for the CLI.
+// FromSpec decodes a spec and builds its chart.
+//
+// Example spec:
+//
+//	{
+//	    "type": "bar",
+//	    "title": "Regional Sales",
+//	    "data": [120, 98, 145],
+//	    "labels": ["North", "South", "East"],
+//	    "showValues": true,
+//	    "theme": "dark"
+//	}
+type Spec struct {
+	// Type is the registered name of the chart type, such as "bar", "line",
+	// "pie", "histogram", "box", "spark", or "kpi" (see RegisteredCharts).
+	Type string `json:"type"`
+	// Title is the chart title.
+	Title string `json:"title,omitempty"`
+	// Data holds the values of a single-series chart.
+	Data []float64 `json:"data,omitempty"`
+	// Series holds the series of a multi-series chart.
+	Series []SpecSeries `json:"series,omitempty"`
+	// Labels names the data points or categories.
+	Labels []string `json:"labels,omitempty"`
+	// Width and Height size the chart (0 = the chart's default).
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+	// Style is the character set: auto, ascii, unicode, braille, block, or
+	// sextant (empty = auto).
+	Style string `json:"style,omitempty"`
+	// Theme is the color theme: default, dark, light, or mono (empty = default).
+	Theme string `json:"theme,omitempty"`
+	// Color turns colors on or off (nil = detected from the terminal).
+	Color *bool `json:"color,omitempty"`
+	// ColorByLabel colors series and slices by their labels, so they keep
+	// their colors across charts.
+	ColorByLabel bool `json:"colorByLabel,omitempty"`
+	// ShowValues displays the numeric values.
+	ShowValues bool `json:"showValues,omitempty"`
+	// Locale formats numbers the way a locale writes them, e.g. "de-DE".
+	Locale string `json:"locale,omitempty"`
+	// Direction is horizontal or vertical, for bar charts, histograms, and
+	// box plots (empty = horizontal).
+	Direction string `json:"direction,omitempty"`
+	// BarMode is grouped or stacked, for multi-series bar charts
+	// (empty = grouped).
+	BarMode string `json:"barMode,omitempty"`
+	// ShowLegend displays a legend on multi-series bar charts.
+	ShowLegend bool `json:"showLegend,omitempty"`
+}
+
+// SpecSeries is a data series of a Spec. Its fields are those of Series.
+type SpecSeries struct {
+	Label  string    `json:"label,omitempty"`
+	Data   []float64 `json:"data"`
+	Color  string    `json:"color,omitempty"`
+	Hidden bool      `json:"hidden,omitempty"`
+	Upper  []float64 `json:"upper,omitempty"`
+	Lower  []float64 `json:"lower,omitempty"`
+}
+
+// SpecOption configures a chart built from a Spec. Every Option is a
+// SpecOption, and so are the typed options of the built-in chart types a spec
+// can name. Since the chart type is only known once the spec is read, a typed
+// option for another chart type than the spec's is an error wrapping
+// ErrInvalidSpec, where passing it to the wrong constructor would not compile.
+type SpecOption interface {
+	// specOption returns the option as an Option, and whether it applies to
+	// charts of the registered type chart.
+	specOption(chart string) (Option, bool)
+}
+
+// specBar reports whether chart is a chart type that takes BarOptions.
+func specBar(chart string) bool {
+	return chart == "bar" || chart == "histogram" || chart == "box"
+}
+
+func (f Option) specOption(string) (Option, bool)                { return f, true }
+func (f barOption) specOption(chart string) (Option, bool)       { return Option(f), specBar(chart) }
+func (f fillOption) specOption(chart string) (Option, bool)      { return Option(f), specBar(chart) }
+func (f lineOption) specOption(chart string) (Option, bool)      { return Option(f), chart == "line" }
+func (f plotOption) specOption(chart string) (Option, bool)      { return Option(f), chart == "line" }
+func (f pieOption) specOption(chart string) (Option, bool)       { return Option(f), chart == "pie" }
+func (f sparklineOption) specOption(chart string) (Option, bool) { return Option(f), chart == "spark" }
+func (f bigTextOption) specOption(chart string) (Option, bool)   { return Option(f), chart == "kpi" }
+func (f seriesOption) specOption(chart string) (Option, bool) {
+	return Option(f), specBar(chart) || chart == "line"
+}
+func (f alignOption) specOption(chart string) (Option, bool) {
+	return Option(f), specBar(chart) || chart == "line"
+}
+func (f axisOption) specOption(chart string) (Option, bool) {
+	return Option(f), specBar(chart) || chart == "line" || chart == "spark"
+}
+
+// specThemes are the themes a Spec names.
+var specThemes = map[string]*Theme{
+	"default":    DefaultTheme,
+	"dark":       DarkTheme,
+	"light":      LightTheme,
+	"mono":       MonochromeTheme,
+	"monochrome": MonochromeTheme,
+}
+
+// FromSpec builds the chart described by data, a Spec encoded as JSON.
+// Options in opts are applied after those of the spec, so callers can add
+// settings a spec cannot hold, such as WithLimits for untrusted specs, or
+// WithBraille for a spec of a line chart.
+//
+// Unknown fields, unknown chart types, and invalid values return an error
+// wrapping ErrInvalidSpec. Errors in the data, such as an empty data set,
+// are reported when the chart is rendered.
+//
+// Example:
+//
+//	chart, err := termcharts.FromSpec([]byte(`{"type": "line", "data": [3, 1, 4, 1, 5]}`))
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Print(chart.Render())
+func FromSpec(data []byte, opts ...SpecOption) (Chart, error) {
+	var spec Spec
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: unexpected data after the spec", ErrInvalidSpec)
+	}
+	return spec.Chart(opts...)
+}
+
+// Chart builds the chart the spec describes, applying opts after the
+// options of the spec. It returns an error wrapping ErrInvalidSpec for an
+// unknown chart type, an invalid value, or a typed option of another chart
+// type.
+func (s Spec) Chart(opts ...SpecOption) (Chart, error) {
+	if s.Type == "" {
+		return nil, fmt.Errorf("%w: missing chart type (registered: %s)", ErrInvalidSpec, strings.Join(RegisteredCharts(), ", "))
+	}
+	factory, ok := LookupChart(s.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown chart type %q (registered: %s)", ErrInvalidSpec, s.Type, strings.Join(RegisteredCharts(), ", "))
+	}
+	specOpts, err := s.options()
+	if err != nil {
+		return nil, err
+	}
+	for i, opt := range opts {
+		option, ok := opt.specOption(s.Type)
+		if !ok {
+			return nil, fmt.Errorf("%w: option %d does not apply to %q charts", ErrInvalidSpec, i+1, s.Type)
+		}
+		specOpts = append(specOpts, option)
+	}
+	return factory(specOpts...), nil
+}
+
+// options returns the options the spec describes.
+func (s Spec) options() ([]Option, error) {
+	var opts []Option
+	if s.Data != nil {
+		opts = append(opts, WithData(s.Data))
+	}
+	if s.Series != nil {
+		series := make([]Series, len(s.Series))
+		for i, ss := range s.Series {
+			series[i] = Series{Label: ss.Label, Data: ss.Data, Color: ss.Color, Hidden: ss.Hidden, Upper: ss.Upper, Lower: ss.Lower}
+		}
+		opts = append(opts, WithSeries(series))
+	}
+	if s.Labels != nil {
+		opts = append(opts, WithLabels(s.Labels))
+	}
+	if s.Title != "" {
+		opts = append(opts, WithTitle(s.Title))
+	}
+	if s.Width != 0 {
+		opts = append(opts, WithWidth(s.Width))
+	}
+	if s.Height != 0 {
+		opts = append(opts, WithHeight(s.Height))
+	}
+	if s.Style != "" {
+		style, ok := specStyle(s.Style)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid style %q (use auto, ascii, unicode, braille, block, or sextant)", ErrInvalidSpec, s.Style)
+		}
+		opts = append(opts, WithStyle(style))
+	}
+	if s.Theme != "" {
+		theme, ok := specThemes[strings.ToLower(s.Theme)]
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid theme %q (use default, dark, light, or mono)", ErrInvalidSpec, s.Theme)
+		}
+		opts = append(opts, WithTheme(theme))
+	}
+	if s.Color != nil {
+		opts = append(opts, WithColor(*s.Color))
+	}
+	if s.ColorByLabel {
+		opts = append(opts, WithColorByLabel(true))
+	}
+	if s.ShowValues {
+		opts = append(opts, WithShowValues(true))
+	}
+	if s.Locale != "" {
+		if !LocaleSupported(s.Locale) {
+			return nil, fmt.Errorf("%w: unsupported locale %q (supported: %s)", ErrInvalidSpec, s.Locale, strings.Join(Locales(), ", "))
+		}
+		opts = append(opts, WithLocale(s.Locale))
+	}
+
+	// Bar settings apply to bar charts only
+	bar := s.Type == "bar"
+	if s.Direction != "" {
+		if !bar && s.Type != "histogram" && s.Type != "box" {
+			return nil, fmt.Errorf("%w: direction applies to bar charts, histograms, and box plots, not %q", ErrInvalidSpec, s.Type)
+		}
+		var dir Direction
+		switch strings.ToLower(s.Direction) {
+		case "horizontal":
+			dir = Horizontal
+		case "vertical":
+			dir = Vertical
+		default:
+			return nil, fmt.Errorf("%w: invalid direction %q (use horizontal or vertical)", ErrInvalidSpec, s.Direction)
+		}
+		opts = append(opts, func(o *Options) { o.Direction = dir })
+	}
+	if s.BarMode != "" {
+		if !bar {
+			return nil, fmt.Errorf("%w: barMode applies to bar charts, not %q", ErrInvalidSpec, s.Type)
+		}
+		var mode BarMode
+		switch strings.ToLower(s.BarMode) {
+		case "grouped":
+			mode = BarModeGrouped
+		case "stacked":
+			mode = BarModeStacked
+		default:
+			return nil, fmt.Errorf("%w: invalid barMode %q (use grouped or stacked)", ErrInvalidSpec, s.BarMode)
+		}
+		opts = append(opts, func(o *Options) { o.BarMode = mode })
+	}
+	if s.ShowLegend {
+		if !bar {
+			return nil, fmt.Errorf("%w: showLegend applies to bar charts, not %q", ErrInvalidSpec, s.Type)
+		}
+		opts = append(opts, func(o *Options) { o.ShowLegend = true })
+	}
+	return opts, nil
+}
+
+// specStyle returns the RenderStyle named name, as written by its String method.
+func specStyle(name string) (RenderStyle, bool) {
+	for _, style := range []RenderStyle{StyleAuto, StyleASCII, StyleUnicode, StyleBraille, StyleBlock2x2, StyleSextant} {
+		if strings.EqualFold(style.String(), name) {
+			return style, true
+		}
+	}
+	return StyleAuto, false
+}
@@ -0,0 +1,139 @@
+package termcharts
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestFromSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want Chart
+	}{
+		{
+			name: "bar",
+			spec: `{"type": "bar", "title": "Sales", "data": [120, 98, 145], "labels": ["North", "South", "East"],
+				"showValues": true, "width": 40, "style": "ascii", "color": false}`,
+			want: NewBarChart(WithTitle("Sales"), WithData([]float64{120, 98, 145}), WithLabels([]string{"North", "South", "East"}),
+				WithShowValues(true), WithWidth(40), WithStyle(StyleASCII), WithColor(false)),
+		},
+		{
+			name: "stacked bar series",
+			spec: `{"type": "bar", "series": [{"label": "a", "data": [1, 2]}, {"label": "b", "data": [3, 4]}],
+				"barMode": "stacked", "direction": "vertical", "showLegend": true, "height": 8, "color": false}`,
+			want: NewBarChart(WithSeries([]Series{{Label: "a", Data: []float64{1, 2}}, {Label: "b", Data: []float64{3, 4}}}),
+				WithBarMode(BarModeStacked), WithDirection(Vertical), WithShowLegend(true), WithHeight(8), WithColor(false)),
+		},
+		{
+			name: "line with theme and locale",
+			spec: `{"type": "line", "data": [1250.5, 980, 2210.75], "theme": "dark", "locale": "de-DE", "width": 30, "height": 6, "color": true}`,
+			want: NewLineChart(WithData([]float64{1250.5, 980, 2210.75}), WithTheme(DarkTheme), WithLocale("de-DE"),
+				WithWidth(30), WithHeight(6), WithColor(true)),
+		},
+		{
+			name: "pie colored by label",
+			spec: `{"type": "pie", "data": [3, 4], "labels": ["cpu", "memory"], "colorByLabel": true, "color": true}`,
+			want: NewPieChart(WithData([]float64{3, 4}), WithLabels([]string{"cpu", "memory"}), WithColorByLabel(true), WithColor(true)),
+		},
+		{
+			name: "vertical box plot",
+			spec: `{"type": "box", "series": [{"label": "api", "data": [2, 3, 4, 20]}], "direction": "vertical", "height": 8, "color": false}`,
+			want: NewBoxPlot(WithSeries([]Series{{Label: "api", Data: []float64{2, 3, 4, 20}}}), WithDirection(Vertical), WithHeight(8), WithColor(false)),
+		},
+		{
+			name: "sparkline",
+			spec: `{"type": "spark", "data": [1, 5, 3], "style": "unicode"}`,
+			want: NewSparkline(WithData([]float64{1, 5, 3}), WithStyle(StyleUnicode)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart, err := FromSpec([]byte(tt.spec))
+			if err != nil {
+				t.Fatalf("FromSpec() error = %v", err)
+			}
+			if got, want := chart.Render(), tt.want.Render(); got != want {
+				t.Errorf("FromSpec() renders\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestFromSpec_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"invalid JSON", `{"type": "bar",`},
+		{"unknown field", `{"type": "bar", "data": [1], "colour": true}`},
+		{"trailing data", `{"type": "bar", "data": [1]} {}`},
+		{"missing type", `{"data": [1, 2]}`},
+		{"unknown type", `{"type": "radar", "data": [1, 2]}`},
+		{"invalid style", `{"type": "bar", "data": [1], "style": "fancy"}`},
+		{"invalid theme", `{"type": "bar", "data": [1], "theme": "neon"}`},
+		{"unsupported locale", `{"type": "bar", "data": [1], "locale": "xx-YY"}`},
+		{"invalid direction", `{"type": "bar", "data": [1], "direction": "diagonal"}`},
+		{"direction on a line chart", `{"type": "line", "data": [1], "direction": "vertical"}`},
+		{"bar mode on a pie chart", `{"type": "pie", "data": [1], "barMode": "stacked"}`},
+		{"legend on a sparkline", `{"type": "spark", "data": [1], "showLegend": true}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart, err := FromSpec([]byte(tt.spec))
+			if !errors.Is(err, ErrInvalidSpec) {
+				t.Errorf("FromSpec() error = %v, want ErrInvalidSpec", err)
+			}
+			if chart != nil {
+				t.Errorf("FromSpec() chart = %T, want nil", chart)
+			}
+		})
+	}
+}
+
+func TestFromSpec_Options(t *testing.T) {
+	chart, err := FromSpec([]byte(`{"type": "bar", "data": [1, 2, 3]}`), WithLimits(Limits{MaxPoints: 2}))
+	if err != nil {
+		t.Fatalf("FromSpec() error = %v", err)
+	}
+	if _, err := chart.(ChartE).RenderE(); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("RenderE() error = %v, want ErrLimitExceeded from the options after the spec", err)
+	}
+}
+
+func TestSpec_RoundTrip(t *testing.T) {
+	color := false
+	spec := Spec{Type: "pie", Data: []float64{3, 2, 1}, Labels: []string{"a", "b", "c"}, Color: &color}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chart, err := FromSpec(data)
+	if err != nil {
+		t.Fatalf("FromSpec(%s) error = %v", data, err)
+	}
+	want := NewPieChart(WithData(spec.Data), WithLabels(spec.Labels), WithColor(false)).Render()
+	if got := chart.Render(); got != want {
+		t.Errorf("decoded spec renders\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFromSpec_TypedOptions(t *testing.T) {
+	line := `{"type": "line", "data": [1, 5, 3, 4], "width": 20, "height": 6, "color": false}`
+	chart, err := FromSpec([]byte(line), WithBraille())
+	if err != nil {
+		t.Fatalf("FromSpec() error = %v", err)
+	}
+	want := NewLineChart(WithData([]float64{1, 5, 3, 4}), WithWidth(20), WithHeight(6), WithColor(false), WithBraille()).Render()
+	if got := chart.Render(); got != want {
+		t.Errorf("FromSpec() with WithBraille renders\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := FromSpec([]byte(`{"type": "bar", "data": [1, 2]}`), WithBraille()); !errors.Is(err, ErrInvalidSpec) {
+		t.Errorf("FromSpec() bar with WithBraille error = %v, want ErrInvalidSpec", err)
+	}
+	if _, err := FromSpec([]byte(`{"type": "pie", "data": [1, 2]}`), WithBarMode(BarModeStacked)); !errors.Is(err, ErrInvalidSpec) {
+		t.Errorf("FromSpec() pie with WithBarMode error = %v, want ErrInvalidSpec", err)
+	}
+}
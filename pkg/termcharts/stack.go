@@ -0,0 +1,60 @@
+package termcharts
+
+import "github.com/neilpeterson/termcharts/internal"
+
+// WithStacking stacks the series of a line chart: each series is drawn at its
+// values plus those of the series before it, so the top line shows the total
+// at each point. Hidden series are left out of the stack. Legends and text
+// summaries still show each series' own values.
+//
+// Example:
+//
+//	chart := termcharts.NewLineChart(
+//	    termcharts.WithSeries([]termcharts.Series{api, web, batch}),
+//	    termcharts.WithStacking(true),
+//	)
+func WithStacking(stack bool) LineOption {
+	return lineOption(func(o *Options) {
+		o.Stacked = stack
+	})
+}
+
+// stackSeries returns the series with each one's values, and confidence band,
+// raised by the running total of the series before it at each point. Series
+// shorter than others add nothing past their end, and missing points add
+// nothing where they are.
+func stackSeries(allSeries []Series) []Series {
+	stacked := make([]Series, len(allSeries))
+	var base []float64
+	for i, series := range allSeries {
+		raise := func(values []float64) []float64 {
+			if values == nil {
+				return nil
+			}
+			data := make([]float64, len(values))
+			for j, v := range values {
+				data[j] = v
+				if j < len(base) && !missingPoint(base[j]) {
+					data[j] += base[j]
+				}
+			}
+			return data
+		}
+		stacked[i] = Series{
+			Label: series.Label,
+			Data:  raise(series.Data),
+			Color: series.Color,
+			Upper: raise(series.Upper),
+			Lower: raise(series.Lower),
+		}
+		next := make([]float64, internal.Max(len(base), len(stacked[i].Data)))
+		copy(next, base)
+		for j, v := range stacked[i].Data {
+			if !missingPoint(v) {
+				next[j] = v
+			}
+		}
+		base = next
+	}
+	return stacked
+}
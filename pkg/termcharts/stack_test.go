@@ -0,0 +1,52 @@
+package termcharts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStackSeries(t *testing.T) {
+	got := stackSeries([]Series{
+		{Label: "a", Data: []float64{1, 2, 3}, Upper: []float64{2, 3, 4}},
+		{Label: "b", Data: []float64{10, 20}},
+		{Label: "c", Data: []float64{100, 100, 100, 100}, Color: "red"},
+	})
+	want := []Series{
+		{Label: "a", Data: []float64{1, 2, 3}, Upper: []float64{2, 3, 4}},
+		{Label: "b", Data: []float64{11, 22}},
+		{Label: "c", Data: []float64{111, 122, 103, 100}, Color: "red"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stackSeries() = %v, want %v", got, want)
+	}
+}
+
+func TestLineChart_Render_Stacked(t *testing.T) {
+	for _, style := range []RenderStyle{StyleASCII, StyleBraille} {
+		t.Run(style.String(), func(t *testing.T) {
+			got := NewLineChart(
+				WithSeries([]Series{
+					{Label: "api", Data: []float64{3, 5, 4, 6}},
+					{Label: "web", Data: []float64{2, 2, 3, 1}},
+					{Label: "off", Data: []float64{9, 9, 9, 9}},
+				}),
+				WithHiddenSeries("off"),
+				WithStacking(true), WithStyle(style), WithColor(false), WithWidth(40), WithHeight(10),
+			).Render()
+
+			// Hidden series are left out of the stack
+			want := NewLineChart(
+				WithSeries([]Series{
+					{Label: "api", Data: []float64{3, 5, 4, 6}},
+					{Label: "web", Data: []float64{5, 7, 7, 7}},
+					{Label: "off", Data: []float64{9, 9, 9, 9}},
+				}),
+				WithHiddenSeries("off"),
+				WithStyle(style), WithColor(false), WithWidth(40), WithHeight(10),
+			).Render()
+			if got != want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
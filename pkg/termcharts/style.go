@@ -1,6 +1,10 @@
 package termcharts
 
-import "fmt"
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+)
 
 // RenderStyle specifies the character set used for rendering charts.
 type RenderStyle int
@@ -8,12 +12,18 @@ type RenderStyle int
 const (
 	// StyleAuto automatically selects the best rendering style based on terminal capabilities.
 	StyleAuto RenderStyle = iota
-	// StyleASCII uses only basic ASCII characters for maximum compatibility.
+	// StyleASCII uses only 7-bit ASCII characters for maximum compatibility.
 	StyleASCII
 	// StyleUnicode uses Unicode block characters for higher fidelity.
 	StyleUnicode
 	// StyleBraille uses Unicode Braille patterns for highest resolution (line charts).
 	StyleBraille
+	// StyleBlock2x2 uses Unicode quadrant blocks, 2x2 sub-cells per character,
+	// for double resolution in fonts where Braille dots look faint (line charts).
+	StyleBlock2x2
+	// StyleSextant uses Unicode sextant blocks, 2x3 sub-cells per character
+	// (line charts). Sextants need a font with Unicode 13 block symbols.
+	StyleSextant
 )
 
 // String returns the string representation of the RenderStyle.
@@ -27,13 +37,23 @@ func (s RenderStyle) String() string {
 		return "unicode"
 	case StyleBraille:
 		return "braille"
+	case StyleBlock2x2:
+		return "block"
+	case StyleSextant:
+		return "sextant"
 	default:
 		return "unknown"
 	}
 }
 
+// subCell reports whether the style draws with sub-cell dots, which only
+// line charts support.
+func (s RenderStyle) subCell() bool {
+	return s == StyleBraille || s == StyleBlock2x2 || s == StyleSextant
+}
+
 // Theme defines colors for chart elements.
-// Colors are specified as ANSI color codes or hex values.
+// Colors are specified as ANSI color names, hex values, or style specs (see Style).
 type Theme struct {
 	// Primary is the primary chart color.
 	Primary string
@@ -129,19 +149,144 @@ var colorMap = map[string]string{
 	"brown":   colorRed,  // Alias for red
 }
 
+// colorRGB maps color names to the RGB values of the standard xterm palette.
+// Aliases resolve to the same value as the color they render as.
+var colorRGB = map[string][3]uint8{
+	"black":   {0, 0, 0},
+	"red":     {205, 0, 0},
+	"green":   {0, 205, 0},
+	"yellow":  {205, 205, 0},
+	"orange":  {205, 205, 0},
+	"blue":    {0, 0, 238},
+	"magenta": {205, 0, 205},
+	"purple":  {205, 0, 205},
+	"cyan":    {0, 205, 205},
+	"white":   {229, 229, 229},
+	"gray":    {127, 127, 127},
+	"grey":    {127, 127, 127},
+	"brown":   {205, 0, 0},
+}
+
 // Colorize wraps text with ANSI color codes.
+// The color may be a name from the standard palette, a hex value such as
+// "#ff8800" or "#f80", which is rendered using 24-bit color, an index into
+// the 256-color palette such as "208", or a style spec
+// such as "bold red on black" (see Style). Unknown colors leave text unstyled.
 // If colorEnabled is false, returns the text unchanged.
 func Colorize(text, color string, colorEnabled bool) string {
 	if !colorEnabled || color == "" {
 		return text
 	}
 
-	code, ok := colorMap[color]
-	if !ok {
+	code := colorCode(color)
+	if code == "" {
 		return text
 	}
 
-	return fmt.Sprintf("%s%s%s", code, text, colorReset)
+	return code + text + colorReset
+}
+
+// colorCode returns the escape sequence that starts color, a palette name or
+// style spec, or "" if color is empty or invalid.
+func colorCode(color string) string {
+	if code, ok := colorMap[color]; ok {
+		return code
+	}
+
+	colorCodesMu.RLock()
+	code, ok := colorCodes[color]
+	colorCodesMu.RUnlock()
+	if ok {
+		return code
+	}
+
+	if style, err := ParseStyle(color); err == nil {
+		code = style.sequence()
+	}
+	colorCodesMu.Lock()
+	if len(colorCodes) < maxColorCodes {
+		colorCodes[color] = code
+	}
+	colorCodesMu.Unlock()
+	return code
+}
+
+// maxColorCodes bounds colorCodes, so programs that generate colors, such as
+// gradients, do not grow it without limit. Colors past the bound are parsed
+// on every use.
+const maxColorCodes = 1024
+
+// colorCodes caches the escape sequences of hex colors and style specs, which
+// would otherwise be parsed for every colored cell. Invalid colors are cached
+// as "". Palette names are looked up in colorMap instead.
+var (
+	colorCodesMu sync.RWMutex
+	colorCodes   = make(map[string]string)
+)
+
+// runWriter is the subset of strings.Builder and bytes.Buffer that colorRun
+// writes to.
+type runWriter interface {
+	WriteString(s string) (int, error)
+	WriteRune(r rune) (int, error)
+}
+
+// colorRun writes characters to w, emitting one escape sequence per run of
+// same-colored characters instead of wrapping each one in escape and reset.
+// Call end before writing uncolored text or a newline, so every line leaves
+// the terminal in its default state.
+type colorRun struct {
+	w     runWriter
+	color string
+	open  bool
+}
+
+// newColorRun returns a colorRun that writes to w.
+func newColorRun(w runWriter) *colorRun {
+	return &colorRun{w: w}
+}
+
+// writeRune writes r in color ("" = uncolored).
+func (c *colorRun) writeRune(r rune, color string) {
+	c.setColor(color)
+	c.w.WriteRune(r)
+}
+
+// writeRepeat writes n copies of r in color ("" = uncolored).
+func (c *colorRun) writeRepeat(r rune, n int, color string) {
+	c.setColor(color)
+	for i := 0; i < n; i++ {
+		c.w.WriteRune(r)
+	}
+}
+
+// writeString writes s in color ("" = uncolored).
+func (c *colorRun) writeString(s, color string) {
+	c.setColor(color)
+	c.w.WriteString(s)
+}
+
+// setColor switches the run to color, writing a reset and new sequence only
+// when the color changes.
+func (c *colorRun) setColor(color string) {
+	if color == c.color && (c.open || color == "") {
+		return
+	}
+	c.end()
+	c.color = color
+	if code := colorCode(color); code != "" {
+		c.w.WriteString(code)
+		c.open = true
+	}
+}
+
+// end closes the current run with a reset, if one is open.
+func (c *colorRun) end() {
+	if c.open {
+		c.w.WriteString(colorReset)
+		c.open = false
+	}
+	c.color = ""
 }
 
 // GetSeriesColor returns the color for a data series at the given index.
@@ -152,3 +297,66 @@ func (t *Theme) GetSeriesColor(index int) string {
 	}
 	return t.Series[index%len(t.Series)]
 }
+
+// GetLabelColor returns the series color picked by a hash of label, so a
+// series keeps its color on every chart drawn with the theme, whatever its
+// position. Different labels can share a color.
+func (t *Theme) GetLabelColor(label string) string {
+	if len(t.Series) == 0 {
+		return t.Primary
+	}
+	h := fnv.New32a()
+	h.Write([]byte(label))
+	return t.Series[h.Sum32()%uint32(len(t.Series))]
+}
+
+// parseHexColor parses a "#rrggbb" or "#rgb" color.
+func parseHexColor(color string) ([3]uint8, bool) {
+	var rgb [3]uint8
+	if len(color) == 0 || color[0] != '#' {
+		return rgb, false
+	}
+
+	hex := color[1:]
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return rgb, false
+	}
+
+	for i := range rgb {
+		v, err := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return rgb, false
+		}
+		rgb[i] = uint8(v)
+	}
+	return rgb, true
+}
+
+// colorToRGB resolves a named, hex, or 256-color palette color to RGB.
+func colorToRGB(color string) ([3]uint8, bool) {
+	if rgb, ok := colorRGB[color]; ok {
+		return rgb, true
+	}
+	if n, ok := paletteIndex(color); ok {
+		return xtermRGB(n), true
+	}
+	return parseHexColor(color)
+}
+
+// paletteIndex parses a 256-color palette index, "0" to "255".
+func paletteIndex(color string) (int, bool) {
+	if len(color) == 0 || len(color) > 3 {
+		return 0, false
+	}
+	n := 0
+	for _, c := range color {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, n <= 255
+}
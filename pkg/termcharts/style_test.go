@@ -31,6 +31,16 @@ func TestRenderStyle_String(t *testing.T) {
 			style:    StyleBraille,
 			expected: "braille",
 		},
+		{
+			name:     "StyleBlock2x2",
+			style:    StyleBlock2x2,
+			expected: "block",
+		},
+		{
+			name:     "StyleSextant",
+			style:    StyleSextant,
+			expected: "sextant",
+		},
 		{
 			name:     "unknown style",
 			style:    RenderStyle(999),
@@ -154,6 +164,27 @@ func TestColorize(t *testing.T) {
 			colorEnabled: true,
 			expectColor:  true,
 		},
+		{
+			name:         "hex color",
+			text:         "test",
+			color:        "#ff8800",
+			colorEnabled: true,
+			expectColor:  true,
+		},
+		{
+			name:         "short hex color",
+			text:         "test",
+			color:        "#f80",
+			colorEnabled: true,
+			expectColor:  true,
+		},
+		{
+			name:         "malformed hex color",
+			text:         "test",
+			color:        "#ff88zz",
+			colorEnabled: true,
+			expectColor:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -244,6 +275,27 @@ func TestTheme_GetSeriesColor(t *testing.T) {
 	}
 }
 
+func TestTheme_GetLabelColor(t *testing.T) {
+	theme := &Theme{Primary: "white", Series: []string{"red", "green", "blue"}}
+	labels := []string{"cpu", "memory", "disk", "network", "api", "web", "batch"}
+
+	seen := make(map[string]bool)
+	for _, label := range labels {
+		color := theme.GetLabelColor(label)
+		if color != theme.GetLabelColor(label) {
+			t.Errorf("GetLabelColor(%q) is not stable", label)
+		}
+		seen[color] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("GetLabelColor() gave every label the same color: %v", seen)
+	}
+
+	if got := (&Theme{Primary: "white"}).GetLabelColor("cpu"); got != "white" {
+		t.Errorf("GetLabelColor() without series colors = %q, want the primary color", got)
+	}
+}
+
 func TestPredefinedThemes(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -313,3 +365,266 @@ func TestColorConstants(t *testing.T) {
 		t.Errorf("colorRed = %q, want %q", colorRed, "\033[31m")
 	}
 }
+
+func TestColorize_Hex(t *testing.T) {
+	want := "\033[38;2;255;136;0mtest" + colorReset
+	for _, color := range []string{"#ff8800", "#f80", "#FF8800"} {
+		if got := Colorize("test", color, true); got != want {
+			t.Errorf("Colorize(%q) = %q, want %q", color, got, want)
+		}
+	}
+}
+
+func TestColorCode_Cached(t *testing.T) {
+	for _, color := range []string{"#1e90ff", "bold red on black", "not-a-color"} {
+		want := ""
+		if style, err := ParseStyle(color); err == nil {
+			want = style.sequence()
+		}
+		for i := 0; i < 2; i++ {
+			if got := colorCode(color); got != want {
+				t.Errorf("colorCode(%q) call %d = %q, want %q", color, i+1, got, want)
+			}
+		}
+		if allocs := testing.AllocsPerRun(10, func() { colorCode(color) }); allocs != 0 {
+			t.Errorf("colorCode(%q) made %v allocations once cached, want 0", color, allocs)
+		}
+	}
+}
+
+func BenchmarkColorize_Hex(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Colorize("█", "#ff8800", true)
+	}
+}
+
+func TestColorRun(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(run *colorRun)
+		want  string
+	}{
+		{
+			name: "single run",
+			write: func(run *colorRun) {
+				for i := 0; i < 3; i++ {
+					run.writeRune('█', "red")
+				}
+			},
+			want: colorRed + "███" + colorReset,
+		},
+		{
+			name: "color change",
+			write: func(run *colorRun) {
+				run.writeRune('█', "red")
+				run.writeRune('█', "red")
+				run.writeRune('█', "blue")
+			},
+			want: colorRed + "██" + colorReset + colorBlue + "█" + colorReset,
+		},
+		{
+			name: "uncolored gap",
+			write: func(run *colorRun) {
+				run.writeRune('█', "red")
+				run.writeString("  ", "")
+				run.writeRune('█', "red")
+			},
+			want: colorRed + "█" + colorReset + "  " + colorRed + "█" + colorReset,
+		},
+		{
+			name: "repeat",
+			write: func(run *colorRun) {
+				run.writeRepeat('█', 3, "red")
+				run.writeRepeat(' ', 0, "red")
+				run.writeRepeat(' ', -1, "")
+			},
+			want: colorRed + "███" + colorReset,
+		},
+		{
+			name: "style spec",
+			write: func(run *colorRun) {
+				run.writeString("ab", "bold red")
+			},
+			want: "\033[1;31mab" + colorReset,
+		},
+		{
+			name: "invalid color",
+			write: func(run *colorRun) {
+				run.writeString("ab", "nope")
+			},
+			want: "ab",
+		},
+		{
+			name:  "empty",
+			write: func(run *colorRun) {},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			run := newColorRun(&b)
+			tt.write(run)
+			run.end()
+			if got := b.String(); got != tt.want {
+				t.Errorf("colorRun wrote %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_ColorRunsCoalesced(t *testing.T) {
+	tests := []struct {
+		name  string
+		chart Chart
+		color string
+	}{
+		{
+			name: "horizontal bar",
+			chart: NewBarChart(
+				WithData([]float64{10, 20}),
+				WithWidth(40),
+				WithStyle(StyleUnicode),
+				WithColor(true),
+			),
+			color: DefaultTheme.Primary,
+		},
+		{
+			name: "vertical bar",
+			chart: NewBarChart(
+				WithData([]float64{10, 20}),
+				WithDirection(Vertical),
+				WithHeight(6),
+				WithStyle(StyleUnicode),
+				WithColor(true),
+			),
+			color: DefaultTheme.Primary,
+		},
+		{
+			name: "line",
+			chart: NewLineChart(
+				WithData([]float64{5, 5, 5, 5, 5, 5, 5, 5}),
+				WithWidth(30),
+				WithHeight(5),
+				WithStyle(StyleASCII),
+				WithColor(true),
+			),
+			color: DefaultTheme.GetSeriesColor(0),
+		},
+		{
+			name: "sparkline",
+			chart: NewSparkline(
+				WithData([]float64{1, 1, 1, 1, 1, 1}),
+				WithStyle(StyleUnicode),
+				WithColor(true),
+			),
+			color: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := tt.chart.Render()
+			frame := NewFrame(out)
+			for i, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+				// Each line must leave the terminal in its default state
+				if idx := strings.LastIndex(line, "\033["); idx >= 0 && !strings.HasPrefix(line[idx:], colorReset) {
+					t.Errorf("line %d leaves a color set: %q", i, line)
+				}
+				// Neighbouring cells of the same color share one sequence, so
+				// a line never has more sequences than style changes
+				changes := 0
+				prev := Style{}
+				for _, c := range frame.Rows[i] {
+					if c.Style != prev && c.Style != (Style{}) {
+						changes++
+					}
+					prev = c.Style
+				}
+				if got := strings.Count(line, colorReset); got > changes {
+					t.Errorf("line %d has %d resets for %d color runs: %q", i, got, changes, line)
+				}
+			}
+			if tt.color != "" && !strings.Contains(out, colorCode(tt.color)) {
+				t.Errorf("output is missing color %q", tt.color)
+			}
+		})
+	}
+}
+
+func TestRender_ASCIIStyle(t *testing.T) {
+	data := []float64{3, 1, 4, 1, 5, 9, 2, 6}
+	labels := []string{"us/east", "us/west", "eu/west", "eu/north", "ap", "sa", "af", "a-label-too-long-to-fit"}
+	series := []Series{
+		{Label: "api", Data: data, Upper: []float64{4, 2, 5, 2, 6, 10, 3, 7}, Lower: data},
+		{Label: "web", Data: []float64{2, 2, 3}},
+		{Label: "off", Data: []float64{1, 1, 1}, Hidden: true},
+	}
+	scale := NewColorScale(ColorStop{Value: 0, Color: "green"}, ColorStop{Value: 1, Color: "red"})
+	line := func(opts ...LineOption) Chart {
+		return NewLineChart(append([]LineOption{WithSeries(series), WithLabels(labels), WithXAxis(AxisConfig{Ticks: 8})}, opts...)...)
+	}
+	bar := func(opts ...BarOption) Chart {
+		return NewBarChart(append([]BarOption{WithData(data), WithLabels(labels), WithShowValues(true)}, opts...)...)
+	}
+
+	// Every chart path, drawn in ASCII, keeps to 7-bit characters: its
+	// lines, markers, shades, ellipses, and trend arrows all fall back
+	charts := map[string]Chart{
+		"line":                 line(),
+		"line stacked":         line(WithStacking(true), WithLegendStats(LegendCurrent|LegendMax)),
+		"line separate scales": line(WithSharedScale(false)),
+		"line log":             line(WithYAxis(AxisConfig{Scale: ScaleLog})),
+		"line inset":           line(WithInset(Inset{Chart: NewSparkline(WithData(data), WithStyle(StyleASCII), WithWidth(8))})),
+		"line summary":         line(WithTextSummary(true)),
+		"bar":                  bar(WithFillChar('=')),
+		"bar negative":         bar(WithData([]float64{3, -2, 5})),
+		"bar vertical":         bar(WithDirection(Vertical), WithData([]float64{3, -2, 5})),
+		"bar grouped":          bar(WithSeries(series), WithShowLegend(true), WithLegendStats(LegendMax)),
+		"bar stacked":          bar(WithSeries(series), WithShowLegend(true), WithBarMode(BarModeStacked)),
+		"bar vertical stacked": bar(WithSeries(series), WithShowLegend(true), WithBarMode(BarModeStacked), WithDirection(Vertical)),
+		"bar categories":       bar(WithGroupDelimiter("/"), WithPage(1, 5)),
+		"bar color scale":      bar(WithColorScale(scale), WithColorBar(true), WithPercentAxis(true)),
+		"bar empty":            bar(WithData(nil), WithEmptyMessage("Waiting for data")),
+		"histogram":            NewHistogram(WithData(data), WithShowValues(true)),
+		"box plot":             NewBoxPlot(WithSeries(series), WithShowValues(true)),
+		"box plot vertical":    NewBoxPlot(WithSeries(series), WithDirection(Vertical)),
+		"pie":                  NewPieChart(WithData(data), WithLabels(labels), WithEmphasis(2)),
+		"sparkline":            NewSparkline(WithData(data), WithSparkStats(true), WithSparkTrend(true), WithSparkExtremes("green", "red"), WithThresholds(map[float64]string{4: "red"})),
+		"big text":             NewBigText(WithData(data), WithShowSparkline(true)),
+		"comparison":           NewComparison(series[0], series[1], WithLabels(labels), WithShowValues(true)),
+		"confusion matrix":     NewConfusionMatrix([]string{"cat", "dog"}, [][]float64{{5, 1}, {2, 7}}, WithColorBar(true)),
+		"composed": Compose([]Layer{
+			{Kind: LayerBar, Series: series[0]},
+			{Kind: LayerLine, Series: series[1]},
+			{Kind: LayerScatter, Series: Series{Label: "points", Data: data}},
+		}, WithLabels(labels)),
+		"small multiples": SmallMultiples(series, func(opts ...Option) Chart {
+			return NewLineChart(Combine(opts...))
+		}, WithHeight(20)),
+	}
+
+	for name, chart := range charts {
+		for _, color := range []bool{false, true} {
+			chart.(Updatable).Apply(
+				WithStyle(StyleASCII), WithColor(color), WithWidth(40), WithHeight(12),
+				WithTitle("A title much too long for forty columns"),
+			)
+			out := chart.Render()
+			if out == "" {
+				t.Errorf("%s: empty output", name)
+				continue
+			}
+			for i, line := range strings.Split(out, "\n") {
+				for j := 0; j < len(line); j++ {
+					if line[j] > 127 {
+						t.Errorf("%s (color %v): line %d has non-ASCII byte at %d: %q", name, color, i, j, line)
+						break
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// TextSummaryMode controls whether a chart includes a text description of its data.
+type TextSummaryMode int
+
+const (
+	// TextSummaryOff renders the chart only.
+	TextSummaryOff TextSummaryMode = iota
+	// TextSummaryAppend renders the chart followed by its text summary.
+	TextSummaryAppend
+	// TextSummaryOnly renders the text summary in place of the chart.
+	TextSummaryOnly
+)
+
+// String returns the string representation of the TextSummaryMode.
+func (m TextSummaryMode) String() string {
+	switch m {
+	case TextSummaryOff:
+		return "off"
+	case TextSummaryAppend:
+		return "append"
+	case TextSummaryOnly:
+		return "only"
+	default:
+		return unknownString
+	}
+}
+
+// summaryTopCategories is the number of largest labeled values listed in a summary.
+const summaryTopCategories = 3
+
+// WithTextSummary appends a concise text description of the chart's data:
+// the number of values, minimum, maximum, last value, trend, and top
+// categories when labels are set. The description keeps chart output
+// meaningful to screen readers and in logs.
+//
+// Example output:
+//
+//	Summary: 4 values, min 10.0 (Q1), max 30.0 (Q4), last 30.0, trend rising; top Q4 30.0, Q2 25.0, Q3 15.0
+func WithTextSummary(show bool) Option {
+	return func(o *Options) {
+		o.TextSummary = TextSummaryOff
+		if show {
+			o.TextSummary = TextSummaryAppend
+		}
+	}
+}
+
+// WithTextSummaryMode sets whether the text summary is appended to the chart
+// or replaces it. See WithTextSummary.
+func WithTextSummaryMode(mode TextSummaryMode) Option {
+	return func(o *Options) {
+		o.TextSummary = mode
+	}
+}
+
+// applyTextSummary appends the text summary to rendered output or replaces
+// the output with it, according to the summary mode.
+func (o *Options) applyTextSummary(out string) string {
+	if o.TextSummary != TextSummaryAppend && o.TextSummary != TextSummaryOnly {
+		return out
+	}
+
+	summary := describeSeries(o.summarySeries(), o.Labels, o.Locale, o.YUnit)
+	if summary == "" {
+		return out
+	}
+
+	trailing := strings.HasSuffix(out, "\n")
+	if o.TextSummary == TextSummaryOnly {
+		if trailing {
+			return summary + "\n"
+		}
+		return summary
+	}
+	if trailing {
+		return out + summary + "\n"
+	}
+	return out + "\n" + summary
+}
+
+// summarySeries returns the data series a summary describes.
+func (o *Options) summarySeries() []Series {
+	if len(o.Series) > 0 {
+		return o.Series
+	}
+	if len(o.Data) > 0 {
+		return []Series{{Data: o.Data}}
+	}
+	return nil
+}
+
+// describeSeries returns one summary line per series, joined by newlines.
+// Single-series summaries are headed "Summary:", multi-series summaries
+// name each series. Numbers are written in the format of locale, followed
+// by unit if one is set.
+func describeSeries(series []Series, labels []string, locale, unit string) string {
+	lines := make([]string, 0, len(series))
+	for i, s := range series {
+		desc := describeData(s.Data, labels, locale, unit)
+		if desc == "" {
+			continue
+		}
+		heading := "Summary"
+		if len(series) > 1 {
+			label := s.Label
+			if label == "" {
+				label = fmt.Sprintf("Series %d", i+1)
+			}
+			heading = fmt.Sprintf("Summary (%s)", label)
+		}
+		lines = append(lines, heading+": "+desc)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// describeData describes the range, trend, and largest labeled values of data.
+func describeData(data []float64, labels []string, locale, unit string) string {
+	_, min, max, ok := seriesStats(data)
+	if !ok {
+		return ""
+	}
+
+	num := func(v float64) string {
+		return withUnit(localizeNumber(fmt.Sprintf("%.1f", v), locale), unit)
+	}
+	label := func(i int) string {
+		if i < len(labels) && labels[i] != "" {
+			return " (" + labels[i] + ")"
+		}
+		return ""
+	}
+	minIdx, maxIdx := -1, -1
+	for i, v := range data {
+		if v == min && minIdx < 0 {
+			minIdx = i
+		}
+		if v == max && maxIdx < 0 {
+			maxIdx = i
+		}
+	}
+
+	noun := "values"
+	if len(data) == 1 {
+		noun = "value"
+	}
+	parts := []string{
+		fmt.Sprintf("%d %s", len(data), noun),
+		"min " + num(min) + label(minIdx),
+		"max " + num(max) + label(maxIdx),
+		"last " + num(data[len(data)-1]),
+	}
+	if len(data) > 1 {
+		parts = append(parts, "trend "+describeTrend(data, max-min))
+	}
+	desc := strings.Join(parts, ", ")
+
+	if top := topCategories(data, labels, num); top != "" {
+		desc += "; top " + top
+	}
+	return desc
+}
+
+// describeTrend classifies the linear trend of data as rising, falling, or
+// flat. A fitted change smaller than 5% of the data's range counts as flat.
+func describeTrend(data []float64, spread float64) string {
+	trend := TrendLine(data)
+	change := trend[len(trend)-1] - trend[0]
+	switch {
+	case spread == 0 || math.Abs(change) < 0.05*spread:
+		return "flat"
+	case change > 0:
+		return "rising"
+	default:
+		return "falling"
+	}
+}
+
+// topCategories lists the largest labeled values, largest first, formatting
+// values with num. It returns an empty string when fewer than two values are labeled.
+func topCategories(data []float64, labels []string, num func(float64) string) string {
+	n := len(data)
+	if len(labels) < n {
+		n = len(labels)
+	}
+	if n < 2 {
+		return ""
+	}
+
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return data[idx[a]] > data[idx[b]]
+	})
+	if len(idx) > summaryTopCategories {
+		idx = idx[:summaryTopCategories]
+	}
+
+	entries := make([]string, len(idx))
+	for i, j := range idx {
+		entries[i] = labels[j] + " " + num(data[j])
+	}
+	return strings.Join(entries, ", ")
+}
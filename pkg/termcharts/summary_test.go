@@ -0,0 +1,91 @@
+package termcharts
+
+import (
+	"testing"
+)
+
+func TestWithTextSummary(t *testing.T) {
+	data := []float64{10, 25, 15, 30}
+	labels := []string{"Q1", "Q2", "Q3", "Q4"}
+	summary := "Summary: 4 values, min 10.0 (Q1), max 30.0 (Q4), last 30.0, trend rising; top Q4 30.0, Q2 25.0, Q3 15.0"
+
+	tests := []struct {
+		name     string
+		chart    Chart
+		expected string
+	}{
+		{
+			name: "appended to sparkline",
+			chart: NewSparkline(
+				WithData(data),
+				WithLabels(labels),
+				WithStyle(StyleASCII),
+				WithColor(false),
+				WithTextSummary(true),
+			),
+			expected: "_*.@\n" + summary,
+		},
+		{
+			name: "replaces chart",
+			chart: NewBarChart(
+				WithData(data),
+				WithLabels(labels),
+				WithTextSummaryMode(TextSummaryOnly),
+			),
+			expected: summary + "\n",
+		},
+		{
+			name: "disabled",
+			chart: NewSparkline(
+				WithData(data),
+				WithStyle(StyleASCII),
+				WithColor(false),
+				WithTextSummary(true),
+				WithTextSummary(false),
+			),
+			expected: "_*.@",
+		},
+		{
+			name: "one line per series",
+			chart: NewLineChart(
+				WithSeries([]Series{
+					{Label: "CPU", Data: []float64{5, 4, 1}},
+					{Data: []float64{2, 2}},
+				}),
+				WithTextSummaryMode(TextSummaryOnly),
+			),
+			expected: "Summary (CPU): 3 values, min 1.0, max 5.0, last 1.0, trend falling\n" +
+				"Summary (Series 2): 2 values, min 2.0, max 2.0, last 2.0, trend flat\n",
+		},
+		{
+			name: "composed chart layers",
+			chart: Compose(
+				[]Layer{{Kind: LayerBar, Series: Series{Label: "Actual", Data: []float64{7}}}},
+				WithTextSummaryMode(TextSummaryOnly),
+			),
+			expected: "Summary: 1 value, min 7.0, max 7.0, last 7.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.chart.Render(); got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTextSummaryMode_String(t *testing.T) {
+	tests := map[TextSummaryMode]string{
+		TextSummaryOff:      "off",
+		TextSummaryAppend:   "append",
+		TextSummaryOnly:     "only",
+		TextSummaryMode(99): "unknown",
+	}
+	for mode, expected := range tests {
+		if got := mode.String(); got != expected {
+			t.Errorf("TextSummaryMode(%d).String() = %q, want %q", mode, got, expected)
+		}
+	}
+}
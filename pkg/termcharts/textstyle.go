@@ -0,0 +1,196 @@
+package termcharts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Style describes how text is drawn: foreground and background colors plus
+// attributes. Wherever termcharts takes a color string, such as Theme fields,
+// Series.Color, and Colorize, a style spec from String can be used instead,
+// and a plain color name or hex value is a style with only a foreground.
+//
+// Example:
+//
+//	title := termcharts.Style{Fg: "white", Bg: "#1e3a5f", Bold: true}
+//	theme := termcharts.NewTheme().WithText(title.String())
+type Style struct {
+	// Fg is the foreground color: a palette name, palette index, or hex value (empty = terminal default).
+	Fg string
+	// Bg is the background color: a palette name, palette index, or hex value (empty = terminal default).
+	Bg string
+	// Bold draws text in bold or increased intensity.
+	Bold bool
+	// Faint draws text with decreased intensity.
+	Faint bool
+	// Underline underlines text.
+	Underline bool
+	// Reverse swaps the foreground and background colors.
+	Reverse bool
+}
+
+// String returns the style spec, e.g. "bold underline red on #202020".
+// Attributes come first, then the foreground, then "on" and the background.
+func (s Style) String() string {
+	var parts []string
+	if s.Bold {
+		parts = append(parts, "bold")
+	}
+	if s.Faint {
+		parts = append(parts, "faint")
+	}
+	if s.Underline {
+		parts = append(parts, "underline")
+	}
+	if s.Reverse {
+		parts = append(parts, "reverse")
+	}
+	if s.Fg != "" {
+		parts = append(parts, s.Fg)
+	}
+	if s.Bg != "" {
+		parts = append(parts, "on", s.Bg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Render draws text in the style. If colorEnabled is false, returns the text unchanged.
+func (s Style) Render(text string, colorEnabled bool) string {
+	return Colorize(text, s.String(), colorEnabled)
+}
+
+// ParseStyle parses a style spec such as "bold red", "on blue", or
+// "underline #ff8800 on black". Tokens are separated by spaces; the
+// attributes bold, faint, underline, and reverse may appear in any order,
+// and a color after "on" is the background. It returns ErrInvalidOption
+// (wrapped) for unknown colors or malformed specs.
+func ParseStyle(spec string) (Style, error) {
+	var s Style
+	tokens := strings.Fields(spec)
+	for i := 0; i < len(tokens); i++ {
+		switch tok := tokens[i]; tok {
+		case "bold":
+			s.Bold = true
+		case "faint":
+			s.Faint = true
+		case "underline":
+			s.Underline = true
+		case "reverse":
+			s.Reverse = true
+		case "on":
+			if i+1 >= len(tokens) || s.Bg != "" {
+				return Style{}, fmt.Errorf("%w: style %q needs one background color after \"on\"", ErrInvalidOption, spec)
+			}
+			i++
+			if _, ok := colorToRGB(tokens[i]); !ok {
+				return Style{}, fmt.Errorf("%w: unknown background color %q", ErrInvalidOption, tokens[i])
+			}
+			s.Bg = tokens[i]
+		default:
+			if _, ok := colorToRGB(tok); !ok {
+				return Style{}, fmt.Errorf("%w: unknown color %q", ErrInvalidOption, tok)
+			}
+			if s.Fg != "" {
+				return Style{}, fmt.Errorf("%w: style %q has more than one foreground color", ErrInvalidOption, spec)
+			}
+			s.Fg = tok
+		}
+	}
+	return s, nil
+}
+
+// sequence returns the SGR escape sequence that starts the style,
+// or an empty string for the default style.
+func (s Style) sequence() string {
+	var params []string
+	if s.Bold {
+		params = append(params, "1")
+	}
+	if s.Faint {
+		params = append(params, "2")
+	}
+	if s.Underline {
+		params = append(params, "4")
+	}
+	if s.Reverse {
+		params = append(params, "7")
+	}
+	if s.Fg != "" {
+		params = append(params, colorParams(s.Fg, false))
+	}
+	if s.Bg != "" {
+		params = append(params, colorParams(s.Bg, true))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\033[" + strings.Join(params, ";") + "m"
+}
+
+// colorParams returns the SGR parameters selecting a color as the foreground
+// or background. Palette names use the standard codes, palette indexes the
+// 256-color codes, and hex values 24-bit color.
+func colorParams(color string, background bool) string {
+	if code, ok := colorMap[color]; ok {
+		n, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(code, "\033["), "m"))
+		if background {
+			n += 10
+		}
+		return strconv.Itoa(n)
+	}
+
+	mode := 38
+	if background {
+		mode = 48
+	}
+	if n, ok := paletteIndex(color); ok {
+		return fmt.Sprintf("%d;5;%d", mode, n)
+	}
+	rgb, _ := parseHexColor(color)
+	return fmt.Sprintf("%d;2;%d;%d;%d", mode, rgb[0], rgb[1], rgb[2])
+}
+
+// WithTitleStyle sets the style of the chart title, overriding the theme's
+// Text color. The zero Style keeps the theme's.
+//
+// Example:
+//
+//	termcharts.WithTitleStyle(termcharts.Style{Fg: "cyan", Bold: true})
+func WithTitleStyle(style Style) Option {
+	return func(o *Options) {
+		o.TitleStyle = style
+	}
+}
+
+// WithAxisStyle sets the style of axis lines and their tick and category
+// labels, overriding the theme's Muted color. The zero Style keeps the theme's.
+func WithAxisStyle(style Style) Option {
+	return func(o *Options) {
+		o.AxisStyle = style
+	}
+}
+
+// WithLegendStyle sets the style of legend labels; markers keep their series
+// colors. The zero Style draws labels in the terminal's default color.
+func WithLegendStyle(style Style) Option {
+	return func(o *Options) {
+		o.LegendStyle = style
+	}
+}
+
+// titleColor returns the style spec chart titles are drawn in.
+func (o *Options) titleColor(theme *Theme) string {
+	if o.TitleStyle != (Style{}) {
+		return o.TitleStyle.String()
+	}
+	return theme.Text
+}
+
+// axisColor returns the style spec axes and their labels are drawn in.
+func (o *Options) axisColor(theme *Theme) string {
+	if o.AxisStyle != (Style{}) {
+		return o.AxisStyle.String()
+	}
+	return theme.Muted
+}
@@ -0,0 +1,135 @@
+package termcharts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected Style
+		wantErr  bool
+	}{
+		{name: "color name", spec: "red", expected: Style{Fg: "red"}},
+		{name: "hex color", spec: "#ff8800", expected: Style{Fg: "#ff8800"}},
+		{name: "attributes", spec: "bold underline", expected: Style{Bold: true, Underline: true}},
+		{name: "background only", spec: "on blue", expected: Style{Bg: "blue"}},
+		{
+			name:     "full spec",
+			spec:     "faint reverse white on #202020",
+			expected: Style{Fg: "white", Bg: "#202020", Faint: true, Reverse: true},
+		},
+		{name: "empty", spec: "", expected: Style{}},
+		{name: "unknown color", spec: "bold chartreuse", wantErr: true},
+		{name: "missing background", spec: "red on", wantErr: true},
+		{name: "two foregrounds", spec: "red blue", wantErr: true},
+		{name: "two backgrounds", spec: "on red on blue", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStyle(tt.spec)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidOption) {
+					t.Errorf("ParseStyle(%q) error = %v, want %v", tt.spec, err, ErrInvalidOption)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseStyle(%q) unexpected error: %v", tt.spec, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseStyle(%q) = %+v, want %+v", tt.spec, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStyle_String(t *testing.T) {
+	style := Style{Fg: "red", Bg: "#202020", Bold: true, Underline: true}
+	if got, want := style.String(), "bold underline red on #202020"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	parsed, err := ParseStyle(style.String())
+	if err != nil || parsed != style {
+		t.Errorf("ParseStyle(String()) = %+v, %v; want %+v", parsed, err, style)
+	}
+}
+
+func TestStyle_Render(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		expected string
+	}{
+		{name: "foreground", style: Style{Fg: "red"}, expected: "\033[31mtest" + colorReset},
+		{name: "bold on background", style: Style{Fg: "red", Bg: "blue", Bold: true}, expected: "\033[1;31;44mtest" + colorReset},
+		{name: "gray background", style: Style{Bg: "gray"}, expected: "\033[100mtest" + colorReset},
+		{name: "hex colors", style: Style{Fg: "#ff8800", Bg: "#000"}, expected: "\033[38;2;255;136;0;48;2;0;0;0mtest" + colorReset},
+		{name: "attributes", style: Style{Faint: true, Underline: true, Reverse: true}, expected: "\033[2;4;7mtest" + colorReset},
+		{name: "default style", style: Style{}, expected: "test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Render("test", true); got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if got := (Style{Fg: "red", Bold: true}).Render("test", false); got != "test" {
+		t.Errorf("Render() with color disabled = %q, want %q", got, "test")
+	}
+}
+
+func TestTheme_DarkenStyle(t *testing.T) {
+	theme := NewTheme().WithPrimary("bold #ffffff on #000000").Darken(0.5)
+	if got, want := theme.Primary, "bold #808080 on #000000"; got != want {
+		t.Errorf("Darken style = %q, want %q", got, want)
+	}
+}
+
+func TestWithTextStyles(t *testing.T) {
+	title := Style{Fg: "cyan", Bold: true}
+	axis := Style{Fg: "yellow", Faint: true}
+	legend := Style{Underline: true}
+	series := WithSeries([]Series{{Label: "cpu", Data: []float64{1, 3, 2}}, {Label: "mem", Data: []float64{2, 1, 3}}})
+	styles := []Option{WithTitle("Load"), WithTitleStyle(title), WithAxisStyle(axis), WithLegendStyle(legend), WithColor(true)}
+
+	charts := map[string]Chart{
+		"line":    NewLineChart(series, WithLabels([]string{"a", "b", "c"}), WithWidth(40), WithHeight(10), Combine(styles...)),
+		"bar":     NewBarChart(series, WithShowLegend(true), WithLabels([]string{"a", "b", "c"}), WithWidth(40), Combine(styles...)),
+		"compose": Compose([]Layer{{Kind: LayerLine, Series: Series{Label: "cpu", Data: []float64{1, 3, 2}}}}, WithLabels([]string{"a", "b", "c"}), WithWidth(40), WithHeight(10), Combine(styles...)),
+	}
+	for name, chart := range charts {
+		out := chart.Render()
+		if !strings.HasPrefix(out, title.Render("Load", true)) {
+			t.Errorf("%s: title not drawn in its style: %q", name, strings.SplitN(out, "\n", 2)[0])
+		}
+		if !strings.Contains(out, axis.sequence()) {
+			t.Errorf("%s: axis style missing from %q", name, out)
+		}
+		if strings.Contains(out, colorCode(DefaultTheme.Muted)) {
+			t.Errorf("%s: theme muted color still used in %q", name, out)
+		}
+		if name != "compose" && !strings.Contains(out, legend.Render("cpu", true)) {
+			t.Errorf("%s: legend label not drawn in its style: %q", name, out)
+		}
+	}
+
+	// Without styles, titles and axes keep the theme's colors
+	out := NewLineChart(WithData([]float64{1, 2}), WithTitle("Load"), WithColor(true), WithWidth(30), WithHeight(8)).Render()
+	if !strings.HasPrefix(out, Colorize("Load", DefaultTheme.Text, true)) || !strings.Contains(out, colorCode(DefaultTheme.Muted)) {
+		t.Errorf("default styles not applied: %q", out)
+	}
+
+	pie := NewPieChart(WithData([]float64{1, 2}), WithLabels([]string{"a", "b"}), WithLegendStyle(legend), WithColor(true)).Render()
+	if !strings.Contains(pie, legend.sequence()+"a ") {
+		t.Errorf("pie legend not drawn in its style: %q", pie)
+	}
+}
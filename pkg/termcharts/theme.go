@@ -0,0 +1,153 @@
+package termcharts
+
+import "fmt"
+
+// NewTheme returns a copy of DefaultTheme to use as the starting point
+// for a custom theme. The With* methods return modified copies, so themes
+// can be built fluently and derived from each other without side effects.
+//
+// Example:
+//
+//	theme := termcharts.NewTheme().
+//	    WithPrimary("#1e90ff").
+//	    WithSeriesPalette("#e41a1c", "#377eb8", "#4daf4a")
+func NewTheme() *Theme {
+	return DefaultTheme.clone()
+}
+
+// clone returns a deep copy of the theme.
+func (t *Theme) clone() *Theme {
+	c := *t
+	c.Series = append([]string(nil), t.Series...)
+	return &c
+}
+
+// WithPrimary returns a copy of the theme with the given primary color.
+func (t *Theme) WithPrimary(color string) *Theme {
+	c := t.clone()
+	c.Primary = color
+	return c
+}
+
+// WithSecondary returns a copy of the theme with the given secondary color.
+func (t *Theme) WithSecondary(color string) *Theme {
+	c := t.clone()
+	c.Secondary = color
+	return c
+}
+
+// WithAccent returns a copy of the theme with the given accent color.
+func (t *Theme) WithAccent(color string) *Theme {
+	c := t.clone()
+	c.Accent = color
+	return c
+}
+
+// WithMuted returns a copy of the theme with the given color for axes and labels.
+func (t *Theme) WithMuted(color string) *Theme {
+	c := t.clone()
+	c.Muted = color
+	return c
+}
+
+// WithText returns a copy of the theme with the given text color.
+func (t *Theme) WithText(color string) *Theme {
+	c := t.clone()
+	c.Text = color
+	return c
+}
+
+// WithBackground returns a copy of the theme with the given background color.
+func (t *Theme) WithBackground(color string) *Theme {
+	c := t.clone()
+	c.Background = color
+	return c
+}
+
+// WithSeriesPalette returns a copy of the theme that uses colors for data series.
+func (t *Theme) WithSeriesPalette(colors ...string) *Theme {
+	c := t.clone()
+	c.Series = append([]string(nil), colors...)
+	return c
+}
+
+// WithPalette returns a copy of the theme built around a palette: colors are
+// used for data series, and the first three also become the primary,
+// secondary, and accent colors.
+func (t *Theme) WithPalette(colors ...string) *Theme {
+	c := t.WithSeriesPalette(colors...)
+	if len(colors) > 0 {
+		c.Primary = colors[0]
+	}
+	if len(colors) > 1 {
+		c.Secondary = colors[1]
+	}
+	if len(colors) > 2 {
+		c.Accent = colors[2]
+	}
+	return c
+}
+
+// Darken returns a copy of the theme with every color moved toward black by
+// amount, from 0 (unchanged) to 1 (black). Colors are converted to hex values;
+// colors that cannot be resolved are kept as-is.
+func (t *Theme) Darken(amount float64) *Theme {
+	return t.mapColors(func(color string) string {
+		return blendColor(color, [3]uint8{0, 0, 0}, amount)
+	})
+}
+
+// Lighten returns a copy of the theme with every color moved toward white by
+// amount, from 0 (unchanged) to 1 (white). Colors are converted to hex values;
+// colors that cannot be resolved are kept as-is.
+func (t *Theme) Lighten(amount float64) *Theme {
+	return t.mapColors(func(color string) string {
+		return blendColor(color, [3]uint8{255, 255, 255}, amount)
+	})
+}
+
+// mapColors returns a copy of the theme with fn applied to every color.
+func (t *Theme) mapColors(fn func(string) string) *Theme {
+	c := t.clone()
+	c.Primary = fn(c.Primary)
+	c.Secondary = fn(c.Secondary)
+	c.Accent = fn(c.Accent)
+	c.Muted = fn(c.Muted)
+	c.Background = fn(c.Background)
+	c.Text = fn(c.Text)
+	for i, color := range c.Series {
+		c.Series[i] = fn(color)
+	}
+	return c
+}
+
+// blendColor mixes color with target by amount and returns the result as hex.
+// For a style spec, both of its colors are blended and its attributes kept.
+func blendColor(color string, target [3]uint8, amount float64) string {
+	rgb, ok := colorToRGB(color)
+	if !ok {
+		style, err := ParseStyle(color)
+		if err != nil || color == "" {
+			return color
+		}
+		if style.Fg != "" {
+			style.Fg = blendColor(style.Fg, target, amount)
+		}
+		if style.Bg != "" {
+			style.Bg = blendColor(style.Bg, target, amount)
+		}
+		return style.String()
+	}
+
+	if amount < 0 {
+		amount = 0
+	} else if amount > 1 {
+		amount = 1
+	}
+
+	for i := range rgb {
+		v := float64(rgb[i]) + (float64(target[i])-float64(rgb[i]))*amount
+		rgb[i] = uint8(v + 0.5)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+}
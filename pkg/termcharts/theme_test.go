@@ -0,0 +1,155 @@
+package termcharts
+
+import (
+	"testing"
+)
+
+func TestNewTheme(t *testing.T) {
+	theme := NewTheme()
+
+	if theme == DefaultTheme {
+		t.Fatal("NewTheme() should return a copy, not DefaultTheme itself")
+	}
+	if theme.Primary != DefaultTheme.Primary {
+		t.Errorf("Primary = %v, want %v", theme.Primary, DefaultTheme.Primary)
+	}
+
+	theme.Series[0] = "changed"
+	if DefaultTheme.Series[0] == "changed" {
+		t.Error("modifying NewTheme() series changed DefaultTheme")
+	}
+}
+
+func TestTheme_Builder(t *testing.T) {
+	theme := NewTheme().
+		WithPrimary("cyan").
+		WithSecondary("magenta").
+		WithAccent("#ff8800").
+		WithMuted("grey").
+		WithText("white").
+		WithBackground("black").
+		WithSeriesPalette("red", "green")
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"Primary", theme.Primary, "cyan"},
+		{"Secondary", theme.Secondary, "magenta"},
+		{"Accent", theme.Accent, "#ff8800"},
+		{"Muted", theme.Muted, "grey"},
+		{"Text", theme.Text, "white"},
+		{"Background", theme.Background, "black"},
+		{"Series[1]", theme.GetSeriesColor(1), "green"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.expected)
+		}
+	}
+
+	_ = DarkTheme.WithPrimary("red")
+	if DarkTheme.Primary != "cyan" {
+		t.Error("With* methods should not modify the receiver")
+	}
+}
+
+func TestTheme_WithPalette(t *testing.T) {
+	tests := []struct {
+		name      string
+		palette   []string
+		primary   string
+		secondary string
+		accent    string
+	}{
+		{
+			name:      "full palette",
+			palette:   []string{"red", "green", "blue", "cyan"},
+			primary:   "red",
+			secondary: "green",
+			accent:    "blue",
+		},
+		{
+			name:      "single color keeps other roles",
+			palette:   []string{"red"},
+			primary:   "red",
+			secondary: DefaultTheme.Secondary,
+			accent:    DefaultTheme.Accent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme := NewTheme().WithPalette(tt.palette...)
+			if theme.Primary != tt.primary {
+				t.Errorf("Primary = %v, want %v", theme.Primary, tt.primary)
+			}
+			if theme.Secondary != tt.secondary {
+				t.Errorf("Secondary = %v, want %v", theme.Secondary, tt.secondary)
+			}
+			if theme.Accent != tt.accent {
+				t.Errorf("Accent = %v, want %v", theme.Accent, tt.accent)
+			}
+			if len(theme.Series) != len(tt.palette) {
+				t.Errorf("len(Series) = %v, want %v", len(theme.Series), len(tt.palette))
+			}
+		})
+	}
+}
+
+func TestTheme_DarkenLighten(t *testing.T) {
+	tests := []struct {
+		name     string
+		theme    *Theme
+		expected string
+	}{
+		{
+			name:     "darken hex by half",
+			theme:    NewTheme().WithPrimary("#808080").Darken(0.5),
+			expected: "#404040",
+		},
+		{
+			name:     "lighten hex by half",
+			theme:    NewTheme().WithPrimary("#000000").Lighten(0.5),
+			expected: "#808080",
+		},
+		{
+			name:     "darken named color",
+			theme:    NewTheme().WithPrimary("red").Darken(1),
+			expected: "#000000",
+		},
+		{
+			name:     "lighten clamps amount",
+			theme:    NewTheme().WithPrimary("blue").Lighten(2),
+			expected: "#ffffff",
+		},
+		{
+			name:     "zero amount converts to hex",
+			theme:    NewTheme().WithPrimary("#f80").Darken(0),
+			expected: "#ff8800",
+		},
+		{
+			name:     "unknown color is kept",
+			theme:    NewTheme().WithPrimary("chartreuse").Darken(0.5),
+			expected: "chartreuse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.theme.Primary != tt.expected {
+				t.Errorf("Primary = %v, want %v", tt.theme.Primary, tt.expected)
+			}
+		})
+	}
+
+	dark := DefaultTheme.Darken(0.2)
+	if dark.Background != "" {
+		t.Errorf("empty Background = %q after Darken, want empty", dark.Background)
+	}
+	if DefaultTheme.Series[0] != "red" {
+		t.Error("Darken should not modify the receiver")
+	}
+}
@@ -0,0 +1,59 @@
+package termcharts
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/neilpeterson/termcharts/internal"
+)
+
+// Trend returns an arrow and the percent change from old to new, such as
+// "▲ +12.5%" or "▼ -3.1%", for pairing with sparklines and KPI panels.
+// A rise is drawn in green and a fall in red when colors are enabled.
+// When old is zero the change is shown as a difference, e.g. "▲ +5".
+// Trend honors WithColor, WithStyle, and WithLocale.
+//
+// Example:
+//
+//	fmt.Println("Revenue", termcharts.Trend(lastMonth, thisMonth))
+func Trend(old, new float64, opts ...Option) string {
+	o := NewOptions(opts...)
+	useUnicode := o.Style == StyleUnicode || (o.Style == StyleAuto && internal.SupportsUnicode())
+	colorEnabled := internal.SupportsColor()
+	if o.ColorEnabled != nil {
+		colorEnabled = *o.ColorEnabled
+	}
+	return formatTrend(old, new, useUnicode, colorEnabled, o.Locale)
+}
+
+// formatTrend renders the change from previous to current, e.g. "▲ +4.2%".
+// The change is shown as a percentage unless previous is zero.
+func formatTrend(previous, current float64, useUnicode, colorEnabled bool, locale string) string {
+	diff := current - previous
+
+	up, down, flat := "▲", "▼", "="
+	if !useUnicode {
+		up, down = "^", "v"
+	}
+
+	var arrow, color string
+	switch {
+	case diff > 0:
+		arrow, color = up, "green"
+	case diff < 0:
+		arrow, color = down, "red"
+	default:
+		arrow = flat
+	}
+
+	change := fmt.Sprintf("%+.1f%%", diff/math.Abs(previous)*100)
+	if previous == 0 {
+		change = fmt.Sprintf("%+g", diff)
+	}
+
+	text := arrow + " " + localizeNumber(change, locale)
+	if colorEnabled && color != "" {
+		text = Colorize(text, color, true)
+	}
+	return text
+}
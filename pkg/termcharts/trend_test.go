@@ -0,0 +1,32 @@
+package termcharts
+
+import "testing"
+
+func TestTrend(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new float64
+		opts     []Option
+		expected string
+	}{
+		{name: "rise", old: 80, new: 90, expected: "▲ +12.5%"},
+		{name: "fall", old: 32, new: 31, expected: "▼ -3.1%"},
+		{name: "unchanged", old: 5, new: 5, expected: "= +0.0%"},
+		{name: "from zero", old: 0, new: 5, expected: "▲ +5"},
+		{name: "negative base", old: -10, new: -5, expected: "▲ +50.0%"},
+		{name: "ascii", old: 32, new: 31, opts: []Option{WithStyle(StyleASCII)}, expected: "v -3.1%"},
+		{name: "localized", old: 80, new: 90, opts: []Option{WithLocale("de-DE")}, expected: "▲ +12,5%"},
+		{name: "colored rise", old: 80, new: 90, opts: []Option{WithColor(true)}, expected: colorGreen + "▲ +12.5%" + colorReset},
+		{name: "colored fall", old: 32, new: 31, opts: []Option{WithColor(true)}, expected: colorRed + "▼ -3.1%" + colorReset},
+		{name: "unchanged is not colored", old: 5, new: 5, opts: []Option{WithColor(true)}, expected: "= +0.0%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithStyle(StyleUnicode), WithColor(false)}, tt.opts...)
+			if got := Trend(tt.old, tt.new, opts...); got != tt.expected {
+				t.Errorf("Trend(%v, %v) = %q, want %q", tt.old, tt.new, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package termcharts
+
+import (
+	"bytes"
+	"io"
+)
+
+// ChartWriter is a Chart that writes its output to an io.Writer, so callers
+// can send a chart to stdout or a file and learn why it could not be drawn
+// or written. All chart types implement it; Render remains the convenience
+// form that returns the output as a string.
+//
+// Horizontal bar charts and histograms, whose rows grow with their
+// categories, are written in chunks as their rows are drawn, so a chart of
+// many categories is never held in memory whole. Other charts are bounded by
+// their width and height and are written once drawn. A chart with insets,
+// post-processors, or a text summary is drawn in full first, since those
+// change the whole output.
+type ChartWriter interface {
+	Chart
+	// RenderTo writes the chart to w. It returns the error RenderE would,
+	// without writing anything, when the chart cannot be drawn, and the
+	// error of w when the write fails.
+	RenderTo(w io.Writer) error
+}
+
+// RenderTo writes chart to w: with the chart's RenderTo method when it is a
+// ChartWriter, and otherwise with RenderE or Render. It returns the error of
+// a chart that cannot be drawn, or of a write that fails.
+//
+// Example:
+//
+//	if err := termcharts.RenderTo(os.Stdout, chart); err != nil {
+//	    return err
+//	}
+func RenderTo(w io.Writer, chart Chart) error {
+	if c, ok := chart.(ChartWriter); ok {
+		return c.RenderTo(w)
+	}
+	return writeChart(w, chart)
+}
+
+// writeChart renders chart and writes its output to w in a single write.
+// Nothing is written when the chart cannot be drawn.
+func writeChart(w io.Writer, chart Chart) error {
+	out, err := renderChart(chart)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
+
+// streamChunk is the number of bytes of drawn rows a rowStream collects
+// before writing them, so rows reach the writer in a few large writes.
+const streamChunk = 32 << 10
+
+// rowStream writes the rows of a chart to w as they are drawn. The first
+// error of w is kept, and later rows are discarded.
+type rowStream struct {
+	w   io.Writer
+	err error
+}
+
+// flush writes the rows collected in buf to the stream once they reach
+// streamChunk bytes, and empties buf. buf must end with a complete row. A
+// nil stream keeps the rows in buf.
+func (s *rowStream) flush(buf *bytes.Buffer) {
+	if s == nil || buf.Len() < streamChunk {
+		return
+	}
+	if s.err == nil {
+		_, s.err = s.w.Write(buf.Bytes())
+	}
+	buf.Reset()
+}
+
+// finish writes rest, the output drawn since the last flush, and returns the
+// error of the render or of the stream. A render that fails writes nothing,
+// since charts report errors before drawing rows.
+func (s *rowStream) finish(rest string, err error) error {
+	if err != nil {
+		return err
+	}
+	if s.err != nil {
+		return s.err
+	}
+	_, err = io.WriteString(s.w, rest)
+	return err
+}
+
+// streamable reports whether rows can be written as they are drawn: insets,
+// post-processors, and text summaries need the whole output.
+func (o *Options) streamable() bool {
+	return len(o.Insets) == 0 && len(o.PostProcessors) == 0 && o.TextSummary == TextSummaryOff
+}
+
+// RenderTo writes the bar chart to w, returning the error of RenderE or of w.
+// Horizontal rows are written as they are drawn.
+func (b *BarChart) RenderTo(w io.Writer) error {
+	if !b.opts.streamable() {
+		return writeChart(w, b)
+	}
+	out := &rowStream{w: w}
+	return out.finish((&BarChart{opts: b.opts, err: b.err, footer: b.footer, headers: b.headers, out: out}).RenderE())
+}
+
+// RenderTo writes the line chart to w, returning the error of RenderE or of w.
+func (l *LineChart) RenderTo(w io.Writer) error { return writeChart(w, l) }
+
+// RenderTo writes the pie chart to w, returning the error of RenderE or of w.
+func (p *PieChart) RenderTo(w io.Writer) error { return writeChart(w, p) }
+
+// RenderTo writes the sparkline to w, returning the error of RenderE or of w.
+func (s *Sparkline) RenderTo(w io.Writer) error { return writeChart(w, s) }
+
+// RenderTo writes the big text to w, returning the error of RenderE or of w.
+func (b *BigText) RenderTo(w io.Writer) error { return writeChart(w, b) }
+
+// RenderTo writes the histogram to w, returning the error of RenderE or of w.
+// Horizontal rows are written as they are drawn.
+func (h *Histogram) RenderTo(w io.Writer) error {
+	if !h.opts.streamable() {
+		return writeChart(w, h)
+	}
+	out := &rowStream{w: w}
+	return out.finish(h.render(out))
+}
+
+// RenderTo writes the box plot to w, returning the error of RenderE or of w.
+func (b *BoxPlot) RenderTo(w io.Writer) error { return writeChart(w, b) }
+
+// RenderTo writes the comparison chart to w, returning the error of RenderE or of w.
+func (c *ComparisonChart) RenderTo(w io.Writer) error { return writeChart(w, c) }
+
+// RenderTo writes the confusion matrix to w, returning the error of RenderE or of w.
+func (m *ConfusionMatrixChart) RenderTo(w io.Writer) error { return writeChart(w, m) }
+
+// RenderTo writes the composed charts to w, returning the error of RenderE or of w.
+func (c *ComposedChart) RenderTo(w io.Writer) error { return writeChart(w, c) }
+
+// RenderTo writes the grid of mini-charts to w, returning the error of RenderE or of w.
+func (m *SmallMultiplesChart) RenderTo(w io.Writer) error { return writeChart(w, m) }
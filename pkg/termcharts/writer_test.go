@@ -0,0 +1,142 @@
+package termcharts
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// failingWriter is a writer whose writes fail.
+type failingWriter struct{}
+
+var errWrite = errors.New("disk full")
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errWrite }
+
+func TestRenderTo(t *testing.T) {
+	data := []float64{3, 1, 4, 1, 5}
+	opts := []Option{WithData(data), WithWidth(30), WithHeight(6), WithColor(false)}
+	series := []Series{{Label: "a", Data: data}, {Label: "b", Data: []float64{2, 7, 1, 8, 2}}}
+	spark := func(opts ...Option) Chart { return NewSparkline(Combine(opts...)) }
+
+	charts := map[string]ChartWriter{
+		"bar":        NewBarChart(Combine(opts...)),
+		"line":       NewLineChart(Combine(opts...)),
+		"pie":        NewPieChart(Combine(opts...)),
+		"sparkline":  NewSparkline(Combine(opts...)),
+		"big text":   NewBigText(Combine(opts...)),
+		"histogram":  NewHistogram(Combine(opts...)),
+		"box plot":   NewBoxPlot(Combine(opts...)),
+		"comparison": NewComparison(series[0], series[1], Combine(opts...)),
+		"confusion":  NewConfusionMatrix([]string{"a", "b"}, [][]float64{{5, 1}, {2, 7}}, WithColor(false)),
+		"composed":   Compose([]Layer{{Kind: LayerLine, Series: series[0]}}, Combine(opts...)),
+		"multiples":  SmallMultiples(series, spark, Combine(opts...)),
+	}
+	for name, chart := range charts {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := chart.RenderTo(&buf); err != nil {
+				t.Fatalf("RenderTo() error = %v", err)
+			}
+			if buf.Len() == 0 || buf.String() != chart.Render() {
+				t.Errorf("RenderTo() wrote\n%s\nwant the output of Render()\n%s", buf.String(), chart.Render())
+			}
+		})
+	}
+}
+
+func TestRenderTo_Errors(t *testing.T) {
+	t.Run("chart cannot be drawn", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := NewBarChart(WithData([]float64{})).RenderTo(&buf)
+		if !errors.Is(err, ErrEmptyData) {
+			t.Errorf("RenderTo() error = %v, want ErrEmptyData", err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("RenderTo() wrote %q for a chart that cannot be drawn", buf.String())
+		}
+	})
+	t.Run("write fails", func(t *testing.T) {
+		err := NewLineChart(WithData([]float64{1, 2, 3})).RenderTo(failingWriter{})
+		if !errors.Is(err, errWrite) {
+			t.Errorf("RenderTo() error = %v, want the writer's error", err)
+		}
+	})
+}
+
+// countingWriter is a writer that counts its writes.
+type countingWriter struct {
+	buf    bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.buf.Write(p)
+}
+
+func TestRenderTo_Streams(t *testing.T) {
+	data := make([]float64, 5000)
+	labels := make([]string, len(data))
+	for i := range data {
+		data[i] = float64(i%97 + 1)
+		labels[i] = fmt.Sprintf("item %d", i)
+	}
+	samples := make([]float64, 20000)
+	for i := range samples {
+		samples[i] = float64(i % 1000)
+	}
+	opts := []Option{WithWidth(60), WithHeight(len(data)), WithColor(false)}
+
+	tests := []struct {
+		name      string
+		chart     ChartWriter
+		minWrites int
+	}{
+		{"bar chart of many categories", NewBarChart(Combine(append(opts, WithData(data), WithLabels(labels))...)), 2},
+		{"stacked bar chart", NewBarChart(Combine(append(opts, WithSeries([]Series{{Label: "a", Data: data}, {Label: "b", Data: data}}), WithLabels(labels))...), WithBarMode(BarModeStacked)), 2},
+		{"histogram of many bins", NewHistogram(Combine(append(opts, WithData(samples))...), WithBins(5000)), 2},
+		{"post-processed bar chart", NewBarChart(Combine(append(opts, WithData(data), WithPostProcessor(func(lines []string) []string { return lines }))...)), 1},
+		{"short bar chart", NewBarChart(WithData([]float64{1, 2, 3}), WithColor(false)), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w countingWriter
+			if err := tt.chart.RenderTo(&w); err != nil {
+				t.Fatalf("RenderTo() error = %v", err)
+			}
+			if w.buf.String() != tt.chart.Render() {
+				t.Errorf("RenderTo() did not write the output of Render()")
+			}
+			if tt.minWrites == 1 && w.writes != 1 {
+				t.Errorf("RenderTo() wrote %d times, want once", w.writes)
+			}
+			if w.writes < tt.minWrites {
+				t.Errorf("RenderTo() wrote %d times, want at least %d", w.writes, tt.minWrites)
+			}
+		})
+	}
+
+	t.Run("write fails", func(t *testing.T) {
+		err := NewBarChart(Combine(append(opts, WithData(data))...)).RenderTo(failingWriter{})
+		if !errors.Is(err, errWrite) {
+			t.Errorf("RenderTo() error = %v, want the writer's error", err)
+		}
+	})
+}
+
+func TestRenderTo_Chart(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderTo(&buf, staticChart("custom\n")); err != nil {
+		t.Fatalf("RenderTo() error = %v", err)
+	}
+	if got := buf.String(); got != "custom\n" {
+		t.Errorf("RenderTo() wrote %q, want the output of Render()", got)
+	}
+
+	err := RenderTo(&buf, NewPieChart(WithData([]float64{0, 0})))
+	if !errors.Is(err, ErrInvalidData) {
+		t.Errorf("RenderTo() error = %v, want the chart's error", err)
+	}
+}
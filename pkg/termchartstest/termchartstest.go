@@ -0,0 +1,149 @@
+// Package termchartstest provides helpers for testing code that renders
+// termcharts charts.
+//
+// Chart output normally depends on the terminal: the rendering style and
+// color support are auto-detected. Deterministic fixes those settings so
+// output is the same on every machine, and golden-file helpers compare
+// rendered charts with snapshots stored under testdata.
+//
+// Basic usage:
+//
+//	func TestDashboard(t *testing.T) {
+//	    chart := termcharts.NewBarChart(
+//	        termchartstest.Deterministic(),
+//	        termcharts.WithData([]float64{10, 20, 30}),
+//	    )
+//	    termchartstest.AssertGolden(t, "dashboard", termchartstest.Render(t, chart))
+//	}
+//
+// Run the tests with TERMCHARTS_UPDATE_GOLDEN=1 to create or update the
+// golden files.
+package termchartstest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+)
+
+// UpdateEnv is the environment variable that makes AssertGolden write the
+// rendered output to the golden file instead of comparing against it.
+const UpdateEnv = "TERMCHARTS_UPDATE_GOLDEN"
+
+// Default chart size used by Deterministic.
+const (
+	DefaultWidth  = 80
+	DefaultHeight = 24
+)
+
+// Deterministic returns an option that fixes every setting termcharts
+// would otherwise detect from the terminal: an 80x24 size, Unicode style,
+// and no color. Pass it first so later options can override any of them.
+func Deterministic() termcharts.Option {
+	return termcharts.Combine(
+		termcharts.WithWidth(DefaultWidth),
+		termcharts.WithHeight(DefaultHeight),
+		termcharts.WithStyle(termcharts.StyleUnicode),
+		termcharts.WithColor(false),
+	)
+}
+
+// Render renders chart, failing the test if it cannot be drawn.
+// Charts that implement termcharts.ChartE report their render error.
+func Render(t testing.TB, chart termcharts.Chart) string {
+	t.Helper()
+
+	if ce, ok := chart.(termcharts.ChartE); ok {
+		out, err := ce.RenderE()
+		if err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+		return out
+	}
+	return chart.Render()
+}
+
+// Golden compares rendered output with golden files in a directory.
+type Golden struct {
+	// Dir is the directory holding the golden files (empty = "testdata").
+	Dir string
+	// Update writes output to the golden files instead of comparing.
+	Update bool
+}
+
+// AssertGolden compares got with testdata/<name>.golden, or updates the file
+// when the TERMCHARTS_UPDATE_GOLDEN environment variable is set.
+func AssertGolden(t testing.TB, name, got string) {
+	t.Helper()
+	g := Golden{Update: os.Getenv(UpdateEnv) != ""}
+	g.Assert(t, name, got)
+}
+
+// Path returns the path of the golden file for name.
+func (g Golden) Path(name string) string {
+	dir := g.Dir
+	if dir == "" {
+		dir = "testdata"
+	}
+	return filepath.Join(dir, name+".golden")
+}
+
+// Assert compares got with the golden file for name. A mismatch is reported
+// with the first differing line; escape sequences are shown quoted so color
+// differences are visible. With Update set, the golden file is written instead.
+func (g Golden) Assert(t testing.TB, name, got string) {
+	t.Helper()
+	path := g.Path(name)
+
+	if g.Update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("creating golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("golden file %s does not exist; run with %s=1 to create it", path, UpdateEnv)
+	}
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	if diff := Diff(string(want), got); diff != "" {
+		t.Errorf("output does not match %s:\n%s", path, diff)
+	}
+}
+
+// Diff describes the first difference between want and got line by line,
+// or returns an empty string if they are equal.
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i >= len(wantLines) || i >= len(gotLines) || w != g {
+			return fmt.Sprintf("line %d:\n  want: %q\n  got:  %q\n(want %d lines, got %d)",
+				i+1, w, g, len(wantLines), len(gotLines))
+		}
+	}
+	return ""
+}
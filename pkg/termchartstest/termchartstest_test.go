@@ -0,0 +1,111 @@
+package termchartstest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neilpeterson/termcharts/pkg/termcharts"
+)
+
+// recorder is a testing.TB that records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+	r.fatal = true
+}
+
+func TestAssertGolden(t *testing.T) {
+	chart := termcharts.NewBarChart(
+		Deterministic(),
+		termcharts.WithData([]float64{10, 20, 30}),
+		termcharts.WithLabels([]string{"A", "B", "C"}),
+		termcharts.WithWidth(40),
+	)
+	AssertGolden(t, "bar", Render(t, chart))
+}
+
+func TestGolden_Assert(t *testing.T) {
+	g := Golden{Dir: t.TempDir()}
+
+	// Missing golden file
+	r := &recorder{TB: t}
+	g.Assert(r, "chart", "a\nb\n")
+	if !r.fatal || !strings.Contains(r.errors[0], UpdateEnv) {
+		t.Errorf("missing golden file should fail with update hint, got %v", r.errors)
+	}
+
+	// Update writes the file
+	r = &recorder{TB: t}
+	Golden{Dir: g.Dir, Update: true}.Assert(r, "chart", "a\nb\n")
+	if len(r.errors) > 0 {
+		t.Fatalf("update failed: %v", r.errors)
+	}
+	if data, err := os.ReadFile(filepath.Join(g.Dir, "chart.golden")); err != nil || string(data) != "a\nb\n" {
+		t.Fatalf("golden file = %q, %v", data, err)
+	}
+
+	// Matching output passes
+	r = &recorder{TB: t}
+	g.Assert(r, "chart", "a\nb\n")
+	if len(r.errors) > 0 {
+		t.Errorf("matching output failed: %v", r.errors)
+	}
+
+	// Mismatched output reports the differing line
+	r = &recorder{TB: t}
+	g.Assert(r, "chart", "a\nc\n")
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "line 2:") {
+		t.Errorf("mismatch should report line 2, got %v", r.errors)
+	}
+}
+
+func TestRender_Error(t *testing.T) {
+	r := &recorder{TB: t}
+	Render(r, termcharts.NewBarChart())
+	if !r.fatal {
+		t.Error("Render should fail the test for a chart that cannot be drawn")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     string
+		got      string
+		contains string
+	}{
+		{name: "equal", want: "a\nb", got: "a\nb", contains: ""},
+		{name: "changed line", want: "a\nb", got: "a\nx", contains: "line 2:\n  want: \"b\"\n  got:  \"x\""},
+		{name: "extra line", want: "a", got: "a\nb", contains: "(want 1 lines, got 2)"},
+		{name: "color difference", want: "a", got: "\033[31ma\033[0m", contains: `\x1b[31m`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := Diff(tt.want, tt.got)
+			if tt.contains == "" {
+				if diff != "" {
+					t.Errorf("Diff() = %q, want no difference", diff)
+				}
+				return
+			}
+			if !strings.Contains(diff, tt.contains) {
+				t.Errorf("Diff() = %q, want it to contain %q", diff, tt.contains)
+			}
+		})
+	}
+}